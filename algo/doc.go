@@ -14,5 +14,6 @@
  * limitations under the License.
  */
 
-// Package algo contains algorithms such as merging, intersecting sorted lists.
+// Package algo contains algorithms such as merging, intersecting sorted lists, and the graph
+// algorithms run over the edges of a predicate.
 package algo
@@ -0,0 +1,230 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package algo
+
+import (
+	"math"
+	"sort"
+)
+
+// Graph holds the edges of a predicate. The nodes are numbered from 0 in the order of their uids,
+// so that the algorithms can keep their state in slices.
+type Graph struct {
+	// Uids are the uids of the nodes, sorted.
+	Uids []uint64
+	// out and in are the nodes each node has an edge to, and has an edge from.
+	out [][]uint32
+	in  [][]uint32
+}
+
+// NewGraph returns the graph of the edges, given by the uids each uid has an edge to.
+func NewGraph(edges map[uint64][]uint64) *Graph {
+	seen := make(map[uint64]struct{}, len(edges))
+	for src, dsts := range edges {
+		seen[src] = struct{}{}
+		for _, dst := range dsts {
+			seen[dst] = struct{}{}
+		}
+	}
+	g := &Graph{Uids: make([]uint64, 0, len(seen))}
+	for uid := range seen {
+		g.Uids = append(g.Uids, uid)
+	}
+	sort.Slice(g.Uids, func(i, j int) bool { return g.Uids[i] < g.Uids[j] })
+
+	index := make(map[uint64]uint32, len(g.Uids))
+	for i, uid := range g.Uids {
+		index[uid] = uint32(i)
+	}
+	g.out = make([][]uint32, len(g.Uids))
+	g.in = make([][]uint32, len(g.Uids))
+	// The edges are added in the order of the uids, so that the results don't depend on the order
+	// of the map, not even by floating point rounding.
+	for i, src := range g.Uids {
+		for _, dst := range edges[src] {
+			j := index[dst]
+			g.out[i] = append(g.out[i], j)
+			g.in[j] = append(g.in[j], uint32(i))
+		}
+	}
+	return g
+}
+
+// Contains tells if uid is a node of the graph.
+func (g *Graph) Contains(uid uint64) bool {
+	i := sort.Search(len(g.Uids), func(i int) bool { return g.Uids[i] >= uid })
+	return i < len(g.Uids) && g.Uids[i] == uid
+}
+
+// PageRank returns the PageRank of the nodes, which sum to 1. The ranks of the nodes without
+// edges out are shared by all the nodes. It stops after maxIter iterations, or once the ranks
+// change by less than tolerance in total.
+func PageRank(g *Graph, damping float64, maxIter int, tolerance float64) []float64 {
+	n := float64(len(g.Uids))
+	rank := make([]float64, len(g.Uids))
+	next := make([]float64, len(g.Uids))
+	for i := range rank {
+		rank[i] = 1 / n
+	}
+
+	for iter := 0; iter < maxIter; iter++ {
+		var dangling float64
+		for i, out := range g.out {
+			if len(out) == 0 {
+				dangling += rank[i]
+			}
+		}
+		base := (1-damping)/n + damping*dangling/n
+		for i := range next {
+			next[i] = base
+		}
+		for i, out := range g.out {
+			if len(out) == 0 {
+				continue
+			}
+			share := damping * rank[i] / float64(len(out))
+			for _, j := range out {
+				next[j] += share
+			}
+		}
+
+		var diff float64
+		for i := range rank {
+			diff += math.Abs(next[i] - rank[i])
+		}
+		rank, next = next, rank
+		if diff < tolerance {
+			break
+		}
+	}
+	return rank
+}
+
+// ConnectedComponents returns the weakly connected component of each node, i.e. of the graph
+// with its edges undirected. A component is identified by the smallest uid in it.
+func ConnectedComponents(g *Graph) []uint64 {
+	parent := make([]uint32, len(g.Uids))
+	for i := range parent {
+		parent[i] = uint32(i)
+	}
+	find := func(i uint32) uint32 {
+		for parent[i] != i {
+			parent[i] = parent[parent[i]]
+			i = parent[i]
+		}
+		return i
+	}
+
+	for i, out := range g.out {
+		for _, j := range out {
+			ri, rj := find(uint32(i)), find(j)
+			// The root of a component is its smallest node, so its smallest uid.
+			if ri < rj {
+				parent[rj] = ri
+			} else {
+				parent[ri] = rj
+			}
+		}
+	}
+
+	components := make([]uint64, len(g.Uids))
+	for i := range components {
+		components[i] = g.Uids[find(uint32(i))]
+	}
+	return components
+}
+
+// LabelPropagation returns the community of each node found by label propagation, with the
+// edges undirected. Every node starts with its uid as label, and takes the most common label of
+// its neighbors, until no label changes or after maxIter iterations. A node keeps its label if
+// it's among the most common ones. Ties are otherwise broken by a hash of the labels, so that the
+// communities don't depend on the order of the edges, and the labels of the nodes visited first
+// aren't favored the way they would be by picking the smallest one.
+func LabelPropagation(g *Graph, maxIter int) []uint64 {
+	labels := make([]uint64, len(g.Uids))
+	copy(labels, g.Uids)
+
+	counts := make(map[uint64]int)
+	for iter := 0; iter < maxIter; iter++ {
+		changed := false
+		for i := range labels {
+			if len(g.out[i]) == 0 && len(g.in[i]) == 0 {
+				continue
+			}
+			for label := range counts {
+				delete(counts, label)
+			}
+			for _, j := range g.out[i] {
+				counts[labels[j]]++
+			}
+			for _, j := range g.in[i] {
+				counts[labels[j]]++
+			}
+
+			var maxCount int
+			for _, c := range counts {
+				if c > maxCount {
+					maxCount = c
+				}
+			}
+			if counts[labels[i]] == maxCount {
+				continue
+			}
+			var best, bestHash uint64
+			found := false
+			for label, c := range counts {
+				if c != maxCount {
+					continue
+				}
+				h := labelHash(label)
+				if !found || h < bestHash || h == bestHash && label < best {
+					best, bestHash, found = label, h, true
+				}
+			}
+			labels[i] = best
+			changed = true
+		}
+		if !changed {
+			break
+		}
+	}
+	return labels
+}
+
+// labelHash mixes the bits of a label, as the finalizer of SplitMix64 does.
+func labelHash(label uint64) uint64 {
+	label ^= label >> 30
+	label *= 0xbf58476d1ce4e5b9
+	label ^= label >> 27
+	label *= 0x94d049bb133111eb
+	return label ^ label>>31
+}
+
+// DegreeCentrality returns the number of edges each node has in and out, and its degree
+// centrality: its number of edges divided by the number of other nodes.
+func DegreeCentrality(g *Graph) (in, out []int64, centrality []float64) {
+	in = make([]int64, len(g.Uids))
+	out = make([]int64, len(g.Uids))
+	centrality = make([]float64, len(g.Uids))
+	for i := range g.Uids {
+		in[i], out[i] = int64(len(g.in[i])), int64(len(g.out[i]))
+		if len(g.Uids) > 1 {
+			centrality[i] = float64(in[i]+out[i]) / float64(len(g.Uids)-1)
+		}
+	}
+	return in, out, centrality
+}
@@ -0,0 +1,107 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package algo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testGraph has two components: a cycle 1 -> 2 -> 3 -> 1 with 4 pointing to 1, and 10 -> 11.
+func testGraph() *Graph {
+	return NewGraph(map[uint64][]uint64{
+		1:  {2},
+		2:  {3},
+		3:  {1},
+		4:  {1},
+		10: {11},
+	})
+}
+
+func TestNewGraph(t *testing.T) {
+	g := testGraph()
+	require.Equal(t, []uint64{1, 2, 3, 4, 10, 11}, g.Uids)
+	require.Equal(t, [][]uint32{{1}, {2}, {0}, {0}, {5}, nil}, g.out)
+	require.Equal(t, [][]uint32{{2, 3}, {0}, {1}, nil, nil, {4}}, g.in)
+	require.True(t, g.Contains(11))
+	require.False(t, g.Contains(5))
+}
+
+func TestPageRank(t *testing.T) {
+	g := testGraph()
+	rank := PageRank(g, 0.85, 100, 1e-9)
+
+	var sum float64
+	for _, r := range rank {
+		sum += r
+	}
+	require.InDelta(t, 1, sum, 1e-6)
+	// 1 has the most edges in, 4 has none.
+	for i := 1; i < len(rank); i++ {
+		require.Greater(t, rank[0], rank[i])
+	}
+	require.Less(t, rank[3], rank[2])
+	require.Less(t, rank[4], rank[5])
+}
+
+func TestPageRankSymmetric(t *testing.T) {
+	g := NewGraph(map[uint64][]uint64{1: {2}, 2: {3}, 3: {1}})
+	for _, r := range PageRank(g, 0.85, 20, 1e-9) {
+		require.InDelta(t, 1.0/3, r, 1e-9)
+	}
+}
+
+func TestConnectedComponents(t *testing.T) {
+	require.Equal(t, []uint64{1, 1, 1, 1, 10, 10}, ConnectedComponents(testGraph()))
+
+	// The edges join the components in an order where the smallest uid comes last.
+	g := NewGraph(map[uint64][]uint64{5: {6}, 7: {6}, 8: {7}, 9: {1}, 2: {9}, 3: {8, 2}})
+	for _, c := range ConnectedComponents(g) {
+		require.Equal(t, uint64(1), c)
+	}
+}
+
+func TestLabelPropagation(t *testing.T) {
+	// Two cliques of four nodes, joined by the edge 4 -> 5.
+	g := NewGraph(map[uint64][]uint64{
+		1: {2, 3, 4},
+		2: {3, 4},
+		3: {4},
+		4: {5},
+		5: {6, 7, 8},
+		6: {7, 8},
+		7: {8},
+	})
+	labels := LabelPropagation(g, 20)
+	for i := 1; i < 4; i++ {
+		require.Equal(t, labels[0], labels[i])
+		require.Equal(t, labels[4], labels[4+i])
+	}
+	require.NotEqual(t, labels[0], labels[4])
+
+	// The labels don't spread across components.
+	labels = LabelPropagation(testGraph(), 20)
+	require.Equal(t, []uint64{3, 3, 3, 3, 11, 11}, labels)
+}
+
+func TestDegreeCentrality(t *testing.T) {
+	in, out, centrality := DegreeCentrality(testGraph())
+	require.Equal(t, []int64{2, 1, 1, 0, 0, 1}, in)
+	require.Equal(t, []int64{1, 1, 1, 1, 1, 0}, out)
+	require.Equal(t, []float64{0.6, 0.4, 0.4, 0.2, 0.2, 0.2}, centrality)
+}
@@ -70,8 +70,21 @@ const (
 	RdfFormat
 	// JsonFormat is a constant to denote the input to the live/bulk loader is in the JSON format.
 	JsonFormat
+	// CsvFormat is a constant to denote the input to the live/bulk loader is in the CSV format.
+	CsvFormat
+	// TsvFormat is a constant to denote the input to the live/bulk loader is in the TSV format.
+	TsvFormat
+	// ParquetFormat is a constant to denote the input to the live/bulk loader is in the
+	// Parquet format.
+	ParquetFormat
 )
 
+// IsTabular returns true if the rows of the format must be mapped to nodes with a
+// ColumnMapping.
+func (f InputFormat) IsTabular() bool {
+	return f == CsvFormat || f == TsvFormat || f == ParquetFormat
+}
+
 // NewChunker returns a new chunker for the specified format.
 func NewChunker(inputFormat InputFormat, batchSize int) Chunker {
 	switch inputFormat {
@@ -90,6 +103,30 @@ func NewChunker(inputFormat InputFormat, batchSize int) Chunker {
 	}
 }
 
+// NewTabularChunker returns a new chunker for the CSV, TSV or Parquet format, which maps
+// the columns of the input to predicates as described by m.
+func NewTabularChunker(inputFormat InputFormat, batchSize int, m *ColumnMapping) Chunker {
+	switch inputFormat {
+	case CsvFormat, TsvFormat:
+		comma := ','
+		if inputFormat == TsvFormat {
+			comma = '\t'
+		}
+		return &csvChunker{
+			nqs:     NewNQuadBuffer(batchSize),
+			mapping: m,
+			comma:   comma,
+		}
+	case ParquetFormat:
+		return &parquetChunker{
+			nqs:     NewNQuadBuffer(batchSize),
+			mapping: m,
+		}
+	default:
+		return NewChunker(inputFormat, batchSize)
+	}
+}
+
 // Chunk reads the input line by line until one of the following 3 conditions happens
 // 1) the EOF is reached
 // 2) 1e5 lines have been read
@@ -388,6 +425,29 @@ func FileReader(file string, key x.SensitiveByteSlice) (rd *bufio.Reader, cleanu
 	return rd, cleanup
 }
 
+// TabularFileChunker returns a chunker for the tabular file, like NewTabularChunker, along
+// with a function to release its resources. The row groups of a Parquet file are read at
+// their offsets in the file, unless it's compressed or read from stdin, so that it's not held
+// in memory.
+func TabularFileChunker(file string, format InputFormat, batchSize int,
+	m *ColumnMapping) (ck Chunker, cleanup func()) {
+	cleanup = func() {}
+	if format != ParquetFormat || file == "-" || filepath.Ext(file) == ".gz" {
+		return NewTabularChunker(format, batchSize, m), cleanup
+	}
+
+	f, err := os.Open(file)
+	x.Check(err)
+	fi, err := f.Stat()
+	x.Check(err)
+	return &parquetChunker{
+		nqs:     NewNQuadBuffer(batchSize),
+		mapping: m,
+		file:    f,
+		size:    fi.Size(),
+	}, func() { _ = f.Close() }
+}
+
 // IsJSONData returns true if the reader, which should be at the start of the stream, is reading
 // a JSON stream, false otherwise.
 func IsJSONData(r *bufio.Reader) (bool, error) {
@@ -402,8 +462,8 @@ func IsJSONData(r *bufio.Reader) (bool, error) {
 	return err == nil, nil
 }
 
-// DataFormat returns a file's data format (RDF, JSON, or unknown) based on the filename
-// or the user-provided format option. The file extension has precedence.
+// DataFormat returns a file's data format (RDF, JSON, CSV, TSV, Parquet or unknown) based on
+// the filename or the user-provided format option. The file extension has precedence.
 func DataFormat(filename string, format string) InputFormat {
 	format = strings.ToLower(format)
 	filename = strings.TrimSuffix(strings.ToLower(filename), ".gz")
@@ -412,6 +472,12 @@ func DataFormat(filename string, format string) InputFormat {
 		return RdfFormat
 	case strings.HasSuffix(filename, ".json") || format == "json":
 		return JsonFormat
+	case strings.HasSuffix(filename, ".csv") || format == "csv":
+		return CsvFormat
+	case strings.HasSuffix(filename, ".tsv") || format == "tsv":
+		return TsvFormat
+	case strings.HasSuffix(filename, ".parquet") || format == "parquet":
+		return ParquetFormat
 	default:
 		return UnknownFormat
 	}
@@ -297,6 +297,7 @@ type NQuadBuffer struct {
 	nquads    []*api.NQuad
 	nqCh      chan []*api.NQuad
 	predHints map[string]pb.Metadata_HintType
+	numPushed uint64
 }
 
 // NewNQuadBuffer returns a new NQuadBuffer instance with the specified batch size.
@@ -319,6 +320,7 @@ func (buf *NQuadBuffer) Ch() <-chan []*api.NQuad {
 
 // Push can be passed one or more NQuad pointers, which get pushed to the buffer.
 func (buf *NQuadBuffer) Push(nqs ...*api.NQuad) {
+	buf.numPushed += uint64(len(nqs))
 	for _, nq := range nqs {
 		buf.nquads = append(buf.nquads, nq)
 		if buf.batchSize > 0 && len(buf.nquads) >= buf.batchSize {
@@ -328,6 +330,12 @@ func (buf *NQuadBuffer) Push(nqs ...*api.NQuad) {
 	}
 }
 
+// NumPushed returns the number of NQuads pushed to the buffer so far, including the ones that
+// haven't been sent to the channel yet.
+func (buf *NQuadBuffer) NumPushed() uint64 {
+	return buf.numPushed
+}
+
 // Metadata returns the parse metadata that has been aggregated so far..
 func (buf *NQuadBuffer) Metadata() *pb.Metadata {
 	return &pb.Metadata{
@@ -0,0 +1,793 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chunker
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"math"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+)
+
+// This file implements a reader for Parquet files (https://github.com/apache/parquet-format)
+// that is enough to load the flat tables exported by data warehouses. Nested or repeated
+// columns, and the DELTA_* and BYTE_STREAM_SPLIT encodings are not supported. Pages can be
+// uncompressed, or compressed with snappy or gzip.
+
+var parquetMagic = []byte("PAR1")
+
+// Physical types.
+const (
+	parquetBoolean = iota
+	parquetInt32
+	parquetInt64
+	parquetInt96
+	parquetFloat
+	parquetDouble
+	parquetByteArray
+	parquetFixedLenByteArray
+)
+
+// Converted types, kept by writers along with the logical types for compatibility.
+const (
+	convertedUTF8            = 0
+	convertedDecimal         = 5
+	convertedDate            = 6
+	convertedTimestampMillis = 9
+	convertedTimestampMicros = 10
+)
+
+// Page types.
+const (
+	pageData       = 0
+	pageDictionary = 2
+	pageDataV2     = 3
+)
+
+// Encodings.
+const (
+	encodingPlain           = 0
+	encodingPlainDictionary = 2
+	encodingRLE             = 3
+	encodingRLEDictionary   = 8
+)
+
+// Compression codecs.
+const (
+	codecUncompressed = 0
+	codecSnappy       = 1
+	codecGzip         = 2
+)
+
+// maxParquetChunkSize is the largest size of a column chunk, compressed or not, which bounds the
+// memory taken by reading a corrupt file.
+const maxParquetChunkSize = 1 << 30
+
+type parquetColumn struct {
+	name      string
+	typ       int64
+	typeLen   int
+	optional  bool
+	date      bool
+	timeUnit  time.Duration // Set for timestamps.
+	decimal   bool
+	scale     int
+	chunkMeta []tstruct // Metadata of the column chunk in each row group.
+}
+
+// parquetReader reads the row groups of a Parquet file one at a time, at their offsets in
+// the file, so that only the metadata and the row group being read are held in memory.
+type parquetReader struct {
+	r         io.ReaderAt
+	size      int64
+	columns   []*parquetColumn
+	groupRows []int64
+}
+
+// newParquetReader reads the metadata of the Parquet file of the given size read by r.
+func newParquetReader(r io.ReaderAt, size int64) (*parquetReader, error) {
+	if size < 12 {
+		return nil, errors.New("Not a Parquet file")
+	}
+	head := make([]byte, 4)
+	tail := make([]byte, 8)
+	if _, err := r.ReadAt(head, 0); err != nil {
+		return nil, errors.Wrapf(err, "while reading Parquet header")
+	}
+	if _, err := r.ReadAt(tail, size-8); err != nil {
+		return nil, errors.Wrapf(err, "while reading Parquet footer")
+	}
+	if !bytes.Equal(head, parquetMagic) || !bytes.Equal(tail[4:], parquetMagic) {
+		return nil, errors.New("Not a Parquet file")
+	}
+	metaLen := int64(binary.LittleEndian.Uint32(tail))
+	if metaLen > size-12 {
+		return nil, errors.New("Invalid Parquet footer")
+	}
+	footer := make([]byte, metaLen)
+	if _, err := r.ReadAt(footer, size-8-metaLen); err != nil {
+		return nil, errors.Wrapf(err, "while reading Parquet footer")
+	}
+	tr := &thriftReader{b: footer}
+	meta, err := tr.readStruct()
+	if err != nil {
+		return nil, errors.Wrapf(err, "while reading Parquet metadata")
+	}
+
+	pr := &parquetReader{r: r, size: size}
+	schema := meta.list(2)
+	if len(schema) == 0 {
+		return nil, errors.New("Parquet file has no schema")
+	}
+	for _, s := range schema[1:] {
+		el, _ := s.(tstruct)
+		col := &parquetColumn{
+			name:     el.str(4),
+			typ:      el.int(1),
+			typeLen:  int(el.int(2)),
+			optional: el.int(3) == 1,
+			scale:    int(el.int(7)),
+		}
+		switch {
+		case el.int(5) > 0:
+			return nil, errors.Errorf("Nested column %q is not supported", col.name)
+		case el.int(3) == 2:
+			return nil, errors.Errorf("Repeated column %q is not supported", col.name)
+		}
+
+		switch el.int(6) {
+		case convertedDecimal:
+			col.decimal = true
+		case convertedDate:
+			col.date = true
+		case convertedTimestampMillis:
+			col.timeUnit = time.Millisecond
+		case convertedTimestampMicros:
+			col.timeUnit = time.Microsecond
+		}
+		if lt := el.st(10); lt != nil {
+			switch {
+			case lt.st(5) != nil:
+				col.decimal = true
+				col.scale = int(lt.st(5).int(1))
+			case lt.st(6) != nil:
+				col.date = true
+			case lt.st(8) != nil:
+				unit := lt.st(8).st(2)
+				switch {
+				case unit.st(1) != nil:
+					col.timeUnit = time.Millisecond
+				case unit.st(2) != nil:
+					col.timeUnit = time.Microsecond
+				case unit.st(3) != nil:
+					col.timeUnit = time.Nanosecond
+				}
+			}
+		}
+		pr.columns = append(pr.columns, col)
+	}
+
+	for _, g := range meta.list(4) {
+		rg, _ := g.(tstruct)
+		chunks := rg.list(1)
+		if len(chunks) != len(pr.columns) {
+			return nil, errors.Errorf("Row group has %d columns, expected %d",
+				len(chunks), len(pr.columns))
+		}
+		for i, c := range chunks {
+			cc, _ := c.(tstruct)
+			if cc.str(1) != "" {
+				return nil, errors.New("Column chunks in external files are not supported")
+			}
+			pr.columns[i].chunkMeta = append(pr.columns[i].chunkMeta, cc.st(3))
+		}
+		pr.groupRows = append(pr.groupRows, rg.int(3))
+	}
+	return pr, nil
+}
+
+// columnNames returns the names of the columns, in the order of the values of each row.
+func (pr *parquetReader) columnNames() []string {
+	names := make([]string, 0, len(pr.columns))
+	for _, col := range pr.columns {
+		names = append(names, col.name)
+	}
+	return names
+}
+
+// readRowGroup returns the rows of the i-th row group. The values are nil for nulls, or one
+// of bool, int64, float64, string and time.Time.
+func (pr *parquetReader) readRowGroup(i int) ([][]interface{}, error) {
+	numRows := int(pr.groupRows[i])
+	rows := make([][]interface{}, numRows)
+	for r := range rows {
+		rows[r] = make([]interface{}, len(pr.columns))
+	}
+	for c, col := range pr.columns {
+		vals, err := pr.readColumnChunk(col, col.chunkMeta[i])
+		if err != nil {
+			return nil, errors.Wrapf(err, "while reading column %q", col.name)
+		}
+		if len(vals) != numRows {
+			return nil, errors.Errorf("Column %q has %d values, expected %d",
+				col.name, len(vals), numRows)
+		}
+		for r, v := range vals {
+			rows[r][c] = v
+		}
+	}
+	return rows, nil
+}
+
+func (pr *parquetReader) readColumnChunk(col *parquetColumn, meta tstruct) ([]interface{}, error) {
+	codec := meta.int(4)
+	numValues := int(meta.int(5))
+	// The total sizes of the pages of the chunk, including their headers.
+	size, uncompressedSize := meta.int(7), meta.int(6)
+	switch {
+	case numValues < 0:
+		return nil, errors.Errorf("Invalid number of values %d", numValues)
+	case size < 0 || size > maxParquetChunkSize:
+		return nil, errors.Errorf("Invalid column chunk size %d", size)
+	case uncompressedSize < 0 || uncompressedSize > maxParquetChunkSize:
+		return nil, errors.Errorf("Invalid uncompressed column chunk size %d", uncompressedSize)
+	}
+	start := meta.int(9)
+	if dictOffset := meta.int(11); dictOffset > 0 && dictOffset < start {
+		start = dictOffset
+	}
+	end := start + size
+	if start < 4 || end > pr.size || end < start {
+		return nil, errors.New("Invalid column chunk offsets")
+	}
+	data := make([]byte, end-start)
+	if _, err := pr.r.ReadAt(data, start); err != nil {
+		return nil, errors.Wrapf(err, "while reading column chunk")
+	}
+
+	var dict, vals []interface{}
+	// left is what's left of the uncompressed size of the chunk, for the next pages.
+	left := int(uncompressedSize)
+	tr := &thriftReader{b: data}
+	for len(vals) < numValues && tr.pos < len(tr.b) {
+		headerStart := tr.pos
+		header, err := tr.readStruct()
+		if err != nil {
+			return nil, errors.Wrapf(err, "while reading page header")
+		}
+		size := int(header.int(3))
+		if size < 0 || tr.pos+size > len(tr.b) {
+			return nil, errors.Errorf("Invalid page size %d", size)
+		}
+		page := tr.b[tr.pos : tr.pos+size]
+		tr.pos += size
+		uncompressedSize := int(header.int(2))
+		left -= tr.pos - size - headerStart
+		if uncompressedSize < 0 || uncompressedSize > left {
+			return nil, errors.Errorf("Invalid uncompressed page size %d, the column chunk "+
+				"has %d bytes left", uncompressedSize, left)
+		}
+		left -= uncompressedSize
+
+		switch header.int(1) {
+		case pageDictionary:
+			body, err := decompress(codec, page, uncompressedSize)
+			if err != nil {
+				return nil, err
+			}
+			if dict, err = col.decodePlain(body, int(header.st(7).int(1))); err != nil {
+				return nil, errors.Wrapf(err, "while reading dictionary page")
+			}
+		case pageData:
+			dh := header.st(5)
+			body, err := decompress(codec, page, uncompressedSize)
+			if err != nil {
+				return nil, err
+			}
+			n := int(dh.int(1))
+			if n < 0 || n > numValues-len(vals) {
+				return nil, errors.Errorf("Invalid number of values %d in data page", n)
+			}
+			var defs []uint32
+			if col.optional {
+				if dh.int(3) != encodingRLE {
+					return nil, errors.Errorf("Unsupported definition level encoding %d",
+						dh.int(3))
+				}
+				if len(body) < 4 {
+					return nil, errors.New("Invalid data page")
+				}
+				l := int(binary.LittleEndian.Uint32(body))
+				if 4+l > len(body) {
+					return nil, errors.New("Invalid definition levels")
+				}
+				if defs, err = decodeHybrid(body[4:4+l], 1, n); err != nil {
+					return nil, err
+				}
+				body = body[4+l:]
+			}
+			pageVals, err := col.decodePage(dh.int(2), body, n, defs, dict)
+			if err != nil {
+				return nil, err
+			}
+			vals = append(vals, pageVals...)
+		case pageDataV2:
+			dh := header.st(8)
+			n := int(dh.int(1))
+			if n < 0 || n > numValues-len(vals) {
+				return nil, errors.Errorf("Invalid number of values %d in data page", n)
+			}
+			defLen, repLen := int(dh.int(5)), int(dh.int(6))
+			if repLen != 0 {
+				return nil, errors.New("Repetition levels are not supported")
+			}
+			if defLen < 0 || defLen > len(page) || defLen > uncompressedSize {
+				return nil, errors.New("Invalid definition levels")
+			}
+			var defs []uint32
+			if col.optional {
+				if defs, err = decodeHybrid(page[:defLen], 1, n); err != nil {
+					return nil, err
+				}
+			}
+			body := page[defLen:]
+			if compressed, ok := dh[7].(bool); !ok || compressed {
+				if body, err = decompress(codec, body, uncompressedSize-defLen); err != nil {
+					return nil, err
+				}
+			}
+			pageVals, err := col.decodePage(dh.int(4), body, n, defs, dict)
+			if err != nil {
+				return nil, err
+			}
+			vals = append(vals, pageVals...)
+		}
+	}
+	return vals, nil
+}
+
+// decodePage decodes the n values of a data page, defs holds their definition levels if
+// the column is optional.
+func (col *parquetColumn) decodePage(encoding int64, body []byte, n int, defs []uint32,
+	dict []interface{}) ([]interface{}, error) {
+	nonNull := n
+	if defs != nil {
+		nonNull = 0
+		for _, d := range defs {
+			nonNull += int(d)
+		}
+	}
+
+	var vals []interface{}
+	switch encoding {
+	case encodingPlain:
+		var err error
+		if vals, err = col.decodePlain(body, nonNull); err != nil {
+			return nil, err
+		}
+	case encodingPlainDictionary, encodingRLEDictionary:
+		if len(body) == 0 {
+			return nil, errors.New("Invalid dictionary encoded page")
+		}
+		idx, err := decodeHybrid(body[1:], int(body[0]), nonNull)
+		if err != nil {
+			return nil, err
+		}
+		vals = make([]interface{}, nonNull)
+		for i, id := range idx {
+			if int(id) >= len(dict) {
+				return nil, errors.Errorf("Dictionary index %d out of range", id)
+			}
+			vals[i] = dict[id]
+		}
+	default:
+		return nil, errors.Errorf("Unsupported encoding %d", encoding)
+	}
+
+	if defs == nil {
+		return vals, nil
+	}
+	out := make([]interface{}, n)
+	j := 0
+	for i, d := range defs {
+		if d == 1 {
+			out[i] = vals[j]
+			j++
+		}
+	}
+	return out, nil
+}
+
+// decodePlain decodes n values with the PLAIN encoding.
+func (col *parquetColumn) decodePlain(b []byte, n int) ([]interface{}, error) {
+	errShort := errors.New("Page is too short")
+	// Every value takes a bit at least.
+	if n < 0 || n > 8*len(b) {
+		return nil, errShort
+	}
+	vals := make([]interface{}, 0, n)
+	for i := 0; i < n; i++ {
+		var v interface{}
+		switch col.typ {
+		case parquetBoolean:
+			if i/8 >= len(b) {
+				return nil, errShort
+			}
+			v = b[i/8]>>(uint(i)%8)&1 == 1
+		case parquetInt32:
+			if len(b) < 4 {
+				return nil, errShort
+			}
+			v = col.convertInt(int64(int32(binary.LittleEndian.Uint32(b))))
+			b = b[4:]
+		case parquetInt64:
+			if len(b) < 8 {
+				return nil, errShort
+			}
+			v = col.convertInt(int64(binary.LittleEndian.Uint64(b)))
+			b = b[8:]
+		case parquetInt96:
+			if len(b) < 12 {
+				return nil, errShort
+			}
+			// Nanoseconds in the day followed by the julian day.
+			nanos := int64(binary.LittleEndian.Uint64(b))
+			day := int64(binary.LittleEndian.Uint32(b[8:]))
+			v = time.Unix((day-2440588)*86400, nanos).UTC()
+			b = b[12:]
+		case parquetFloat:
+			if len(b) < 4 {
+				return nil, errShort
+			}
+			v = float64(math.Float32frombits(binary.LittleEndian.Uint32(b)))
+			b = b[4:]
+		case parquetDouble:
+			if len(b) < 8 {
+				return nil, errShort
+			}
+			v = math.Float64frombits(binary.LittleEndian.Uint64(b))
+			b = b[8:]
+		case parquetByteArray, parquetFixedLenByteArray:
+			l := col.typeLen
+			if col.typ == parquetByteArray {
+				if len(b) < 4 {
+					return nil, errShort
+				}
+				l = int(binary.LittleEndian.Uint32(b))
+				b = b[4:]
+			}
+			if l < 0 || len(b) < l {
+				return nil, errShort
+			}
+			v = col.convertBytes(b[:l])
+			b = b[l:]
+		default:
+			return nil, errors.Errorf("Unsupported type %d", col.typ)
+		}
+		vals = append(vals, v)
+	}
+	return vals, nil
+}
+
+func (col *parquetColumn) convertInt(v int64) interface{} {
+	switch {
+	case col.date:
+		return time.Unix(v*86400, 0).UTC()
+	case col.timeUnit != 0:
+		return time.Unix(0, 0).Add(time.Duration(v) * col.timeUnit).UTC()
+	case col.decimal:
+		return formatDecimal(big.NewInt(v), col.scale)
+	}
+	return v
+}
+
+func (col *parquetColumn) convertBytes(b []byte) interface{} {
+	if !col.decimal {
+		return string(b)
+	}
+	// Decimals are stored as big endian two's complement integers.
+	v := new(big.Int).SetBytes(b)
+	if len(b) > 0 && b[0]&0x80 != 0 {
+		v.Sub(v, new(big.Int).Lsh(big.NewInt(1), uint(8*len(b))))
+	}
+	return formatDecimal(v, col.scale)
+}
+
+func formatDecimal(v *big.Int, scale int) string {
+	if scale <= 0 {
+		return v.String()
+	}
+	s := new(big.Int).Abs(v).String()
+	if len(s) <= scale {
+		s = strings.Repeat("0", scale-len(s)+1) + s
+	}
+	s = s[:len(s)-scale] + "." + s[len(s)-scale:]
+	if v.Sign() < 0 {
+		s = "-" + s
+	}
+	return s
+}
+
+// decodeHybrid decodes n values written with the RLE/bit-packing hybrid encoding.
+func decodeHybrid(b []byte, bitWidth, n int) ([]uint32, error) {
+	if bitWidth > 32 {
+		return nil, errors.Errorf("Invalid bit width %d", bitWidth)
+	}
+	out := make([]uint32, 0, n)
+	for len(out) < n {
+		header, k := binary.Uvarint(b)
+		if k <= 0 {
+			return nil, errors.New("Invalid RLE run")
+		}
+		b = b[k:]
+		if header&1 == 0 {
+			// A value repeated header/2 times, in the smallest number of bytes.
+			width := (bitWidth + 7) / 8
+			if len(b) < width {
+				return nil, errors.New("Invalid RLE run")
+			}
+			var v uint32
+			for i := 0; i < width; i++ {
+				v |= uint32(b[i]) << (8 * uint(i))
+			}
+			b = b[width:]
+			for i := uint64(0); i < header>>1 && len(out) < n; i++ {
+				out = append(out, v)
+			}
+			continue
+		}
+		// Groups of 8 values packed with bitWidth bits each, least significant bit first.
+		count := int(header>>1) * 8
+		if len(b) < count*bitWidth/8 {
+			return nil, errors.New("Invalid bit-packed run")
+		}
+		for i := 0; i < count && len(out) < n; i++ {
+			var v uint32
+			for j := 0; j < bitWidth; j++ {
+				bit := i*bitWidth + j
+				v |= uint32(b[bit/8]>>(uint(bit)%8)&1) << uint(j)
+			}
+			out = append(out, v)
+		}
+		b = b[count*bitWidth/8:]
+	}
+	return out, nil
+}
+
+// decompress returns the page b compressed with codec, whose uncompressed size is size. It
+// returns an error if the page is larger than that once decompressed.
+func decompress(codec int64, b []byte, size int) ([]byte, error) {
+	errSize := func() error {
+		return errors.Errorf("Page is larger than its uncompressed size %d", size)
+	}
+	switch codec {
+	case codecUncompressed:
+		if len(b) > size {
+			return nil, errSize()
+		}
+		return b, nil
+	case codecSnappy:
+		n, err := snappy.DecodedLen(b)
+		if err != nil {
+			return nil, err
+		}
+		if n > size {
+			return nil, errSize()
+		}
+		return snappy.Decode(make([]byte, n), b)
+	case codecGzip:
+		r, err := gzip.NewReader(bytes.NewReader(b))
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		out, err := ioutil.ReadAll(io.LimitReader(r, int64(size)+1))
+		if err != nil {
+			return nil, err
+		}
+		if len(out) > size {
+			return nil, errSize()
+		}
+		return out, nil
+	}
+	return nil, errors.Errorf("Unsupported compression codec %d, only snappy and gzip are "+
+		"supported", codec)
+}
+
+// tstruct holds the fields of a thrift struct by field id. Values are bool, int64, float64,
+// []byte, []interface{} or tstruct.
+type tstruct map[int16]interface{}
+
+func (s tstruct) int(id int16) int64 {
+	v, _ := s[id].(int64)
+	return v
+}
+
+func (s tstruct) str(id int16) string {
+	v, _ := s[id].([]byte)
+	return string(v)
+}
+
+func (s tstruct) list(id int16) []interface{} {
+	v, _ := s[id].([]interface{})
+	return v
+}
+
+func (s tstruct) st(id int16) tstruct {
+	v, _ := s[id].(tstruct)
+	return v
+}
+
+// thriftReader decodes the thrift compact protocol, used by Parquet for its metadata.
+type thriftReader struct {
+	b   []byte
+	pos int
+}
+
+const (
+	thriftTrue   = 1
+	thriftFalse  = 2
+	thriftByte   = 3
+	thriftI16    = 4
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftDouble = 7
+	thriftBinary = 8
+	thriftList   = 9
+	thriftSet    = 10
+	thriftMap    = 11
+	thriftStruct = 12
+)
+
+var errThriftEOF = errors.New("Unexpected end of thrift data")
+
+func (t *thriftReader) byte() (byte, error) {
+	if t.pos >= len(t.b) {
+		return 0, errThriftEOF
+	}
+	t.pos++
+	return t.b[t.pos-1], nil
+}
+
+func (t *thriftReader) uvarint() (uint64, error) {
+	v, n := binary.Uvarint(t.b[t.pos:])
+	if n <= 0 {
+		return 0, errThriftEOF
+	}
+	t.pos += n
+	return v, nil
+}
+
+func (t *thriftReader) varint() (int64, error) {
+	v, err := t.uvarint()
+	return int64(v>>1) ^ -int64(v&1), err
+}
+
+func (t *thriftReader) readStruct() (tstruct, error) {
+	s := tstruct{}
+	var id int16
+	for {
+		h, err := t.byte()
+		if err != nil {
+			return nil, err
+		}
+		if h == 0 {
+			return s, nil
+		}
+		if delta := int16(h >> 4); delta != 0 {
+			id += delta
+		} else {
+			v, err := t.varint()
+			if err != nil {
+				return nil, err
+			}
+			id = int16(v)
+		}
+		switch typ := h & 0x0f; typ {
+		case thriftTrue, thriftFalse:
+			s[id] = typ == thriftTrue
+		default:
+			if s[id], err = t.readValue(typ); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+func (t *thriftReader) readValue(typ byte) (interface{}, error) {
+	switch typ {
+	case thriftTrue, thriftFalse:
+		// Booleans in lists and maps take a byte.
+		b, err := t.byte()
+		return b == thriftTrue, err
+	case thriftByte:
+		b, err := t.byte()
+		return int64(int8(b)), err
+	case thriftI16, thriftI32, thriftI64:
+		return t.varint()
+	case thriftDouble:
+		if t.pos+8 > len(t.b) {
+			return nil, errThriftEOF
+		}
+		t.pos += 8
+		return math.Float64frombits(binary.LittleEndian.Uint64(t.b[t.pos-8:])), nil
+	case thriftBinary:
+		l, err := t.uvarint()
+		if err != nil {
+			return nil, err
+		}
+		if l > uint64(len(t.b)-t.pos) {
+			return nil, errThriftEOF
+		}
+		t.pos += int(l)
+		return t.b[t.pos-int(l) : t.pos], nil
+	case thriftList, thriftSet:
+		h, err := t.byte()
+		if err != nil {
+			return nil, err
+		}
+		size := uint64(h >> 4)
+		if size == 15 {
+			if size, err = t.uvarint(); err != nil {
+				return nil, err
+			}
+		}
+		if size > uint64(len(t.b)-t.pos) {
+			return nil, errThriftEOF
+		}
+		list := make([]interface{}, 0, size)
+		for i := uint64(0); i < size; i++ {
+			v, err := t.readValue(h & 0x0f)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+		}
+		return list, nil
+	case thriftMap:
+		// Maps only hold key-value metadata, which we skip.
+		size, err := t.uvarint()
+		if err != nil || size == 0 {
+			return nil, err
+		}
+		kv, err := t.byte()
+		if err != nil {
+			return nil, err
+		}
+		for i := uint64(0); i < 2*size; i++ {
+			typ := kv >> 4
+			if i%2 == 1 {
+				typ = kv & 0x0f
+			}
+			if _, err := t.readValue(typ); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	case thriftStruct:
+		return t.readStruct()
+	}
+	return nil, errors.Errorf("Unknown thrift type %d", typ)
+}
@@ -0,0 +1,408 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chunker
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/types"
+)
+
+// tfield is a field of a thrift struct, written by thriftStructTo. The values can be int32,
+// int64, bool, string, []tfield for structs or []interface{} for lists.
+type tfield struct {
+	id int16
+	v  interface{}
+}
+
+func thriftType(v interface{}) byte {
+	switch v := v.(type) {
+	case int32:
+		return thriftI32
+	case int64:
+		return thriftI64
+	case bool:
+		if v {
+			return thriftTrue
+		}
+		return thriftFalse
+	case string:
+		return thriftBinary
+	case []tfield:
+		return thriftStruct
+	case []interface{}:
+		return thriftList
+	}
+	panic("unknown thrift value")
+}
+
+func thriftValue(buf *bytes.Buffer, v interface{}) {
+	var tmp [binary.MaxVarintLen64]byte
+	switch v := v.(type) {
+	case int32:
+		buf.Write(tmp[:binary.PutVarint(tmp[:], int64(v))])
+	case int64:
+		buf.Write(tmp[:binary.PutVarint(tmp[:], v)])
+	case string:
+		buf.Write(tmp[:binary.PutUvarint(tmp[:], uint64(len(v)))])
+		buf.WriteString(v)
+	case []tfield:
+		thriftStructTo(buf, v)
+	case []interface{}:
+		buf.WriteByte(byte(len(v))<<4 | thriftType(v[0]))
+		for _, e := range v {
+			thriftValue(buf, e)
+		}
+	}
+}
+
+func thriftStructTo(buf *bytes.Buffer, fields []tfield) {
+	var last int16
+	for _, f := range fields {
+		buf.WriteByte(byte(f.id-last)<<4 | thriftType(f.v))
+		if _, ok := f.v.(bool); !ok {
+			thriftValue(buf, f.v)
+		}
+		last = f.id
+	}
+	buf.WriteByte(0)
+}
+
+type testPage struct {
+	header []tfield // Page header, without the sizes.
+	body   []byte
+}
+
+type testColumn struct {
+	schema []tfield
+	typ    int32
+	codec  int32
+	pages  []testPage
+}
+
+// writeParquet returns a Parquet file holding a single row group of the given columns.
+func writeParquet(t *testing.T, numRows int64, cols []testColumn) []byte {
+	buf := bytes.NewBuffer(append([]byte{}, parquetMagic...))
+	schema := []interface{}{[]tfield{{4, "schema"}, {5, int32(len(cols))}}}
+	var chunks []interface{}
+	for _, col := range cols {
+		schema = append(schema, col.schema)
+		start := int64(buf.Len())
+		var dataOffset, dictOffset, uncompressed int64
+		for _, p := range col.pages {
+			body := p.body
+			switch col.codec {
+			case codecSnappy:
+				body = snappy.Encode(nil, body)
+			case codecGzip:
+				var b bytes.Buffer
+				w := gzip.NewWriter(&b)
+				_, err := w.Write(body)
+				require.NoError(t, err)
+				require.NoError(t, w.Close())
+				body = b.Bytes()
+			}
+			if p.header[0].v == int32(pageDictionary) {
+				dictOffset = int64(buf.Len())
+			} else if dataOffset == 0 {
+				dataOffset = int64(buf.Len())
+			}
+			header := append([]tfield{p.header[0],
+				{2, int32(len(p.body))}, {3, int32(len(body))}}, p.header[1:]...)
+			headerStart := buf.Len()
+			thriftStructTo(buf, header)
+			uncompressed += int64(buf.Len()-headerStart) + int64(len(p.body))
+			buf.Write(body)
+		}
+		meta := []tfield{{1, col.typ}, {2, []interface{}{int32(0)}},
+			{3, []interface{}{col.schema[2].v}}, {4, col.codec}, {5, numRows},
+			{6, uncompressed}, {7, int64(buf.Len()) - start}, {9, dataOffset}}
+		if dictOffset > 0 {
+			meta = append(meta, tfield{11, dictOffset})
+		}
+		chunks = append(chunks, []tfield{{2, start}, {3, meta}})
+	}
+
+	meta := new(bytes.Buffer)
+	thriftStructTo(meta, []tfield{{1, int32(1)}, {2, schema}, {3, numRows},
+		{4, []interface{}{[]tfield{{1, chunks}, {2, int64(0)}, {3, numRows}}}}})
+	buf.Write(meta.Bytes())
+	require.NoError(t, binary.Write(buf, binary.LittleEndian, uint32(meta.Len())))
+	buf.Write(parquetMagic)
+	return buf.Bytes()
+}
+
+func plainByteArrays(vals ...string) []byte {
+	var buf bytes.Buffer
+	for _, v := range vals {
+		_ = binary.Write(&buf, binary.LittleEndian, uint32(len(v)))
+		buf.WriteString(v)
+	}
+	return buf.Bytes()
+}
+
+func plainValues(vals ...interface{}) []byte {
+	var buf bytes.Buffer
+	for _, v := range vals {
+		_ = binary.Write(&buf, binary.LittleEndian, v)
+	}
+	return buf.Bytes()
+}
+
+func dataPage(numValues int32, encoding int32) []tfield {
+	return []tfield{{1, int32(pageData)},
+		{5, []tfield{{1, numValues}, {2, encoding}, {3, int32(encodingRLE)},
+			{4, int32(encodingRLE)}}}}
+}
+
+func dataPageV2(numValues, numNulls, encoding, defLen int32) []tfield {
+	return []tfield{{1, int32(pageDataV2)},
+		{8, []tfield{{1, numValues}, {2, numNulls}, {3, numValues}, {4, encoding},
+			{5, defLen}, {6, int32(0)}, {7, false}}}}
+}
+
+func testParquetFile(t *testing.T) []byte {
+	// The definition levels 1, 0, 1 bit-packed, after their length.
+	ageDefs := []byte{2, 0, 0, 0, 3, 0x5}
+	return writeParquet(t, 3, []testColumn{
+		{
+			schema: []tfield{{1, int32(parquetByteArray)}, {3, int32(0)}, {4, "id"},
+				{6, int32(0)}},
+			typ:   parquetByteArray,
+			pages: []testPage{{dataPage(3, encodingPlain), plainByteArrays("a", "b", "c")}},
+		},
+		{
+			schema: []tfield{{1, int32(parquetInt32)}, {3, int32(1)}, {4, "age"}},
+			typ:    parquetInt32,
+			codec:  codecSnappy,
+			pages: []testPage{{dataPage(3, encodingPlain),
+				append(ageDefs, plainValues(int32(30), int32(41))...)}},
+		},
+		{
+			schema: []tfield{{1, int32(parquetInt32)}, {3, int32(0)}, {4, "born"},
+				{6, int32(convertedDate)}},
+			typ: parquetInt32,
+			pages: []testPage{
+				{[]tfield{{1, int32(pageDictionary)},
+					{7, []tfield{{1, int32(2)}, {2, int32(encodingPlain)}}}},
+					plainValues(int32(0), int32(18262))},
+				// Bit width 1, then the indexes 1, 1, 0 in two RLE runs.
+				{dataPageV2(3, 0, encodingRLEDictionary, 0), []byte{1, 4, 1, 2, 0}},
+			},
+		},
+		{
+			schema: []tfield{{1, int32(parquetDouble)}, {3, int32(0)}, {4, "score"}},
+			typ:    parquetDouble,
+			codec:  codecGzip,
+			pages: []testPage{{dataPage(3, encodingPlain),
+				plainValues(1.5, float64(-2), 0.25)}},
+		},
+		{
+			schema: []tfield{{1, int32(parquetByteArray)}, {3, int32(1)}, {4, "friend"}},
+			typ:    parquetByteArray,
+			// The definition levels 1, 0, 0 in two RLE runs, then the value.
+			pages: []testPage{{dataPageV2(3, 2, encodingPlain, 4),
+				append([]byte{2, 1, 4, 0}, plainByteArrays("b")...)}},
+		},
+		{
+			schema: []tfield{{1, int32(parquetInt64)}, {3, int32(0)}, {4, "price"},
+				{10, []tfield{{5, []tfield{{1, int32(2)}, {2, int32(10)}}}}}},
+			typ: parquetInt64,
+			pages: []testPage{{dataPage(3, encodingPlain),
+				plainValues(int64(1234), int64(-5), int64(0))}},
+		},
+	})
+}
+
+func parquetReaderFor(data []byte) (*parquetReader, error) {
+	return newParquetReader(bytes.NewReader(data), int64(len(data)))
+}
+
+func TestParquetReader(t *testing.T) {
+	pr, err := parquetReaderFor(testParquetFile(t))
+	require.NoError(t, err)
+	require.Equal(t, []string{"id", "age", "born", "score", "friend", "price"}, pr.columnNames())
+	require.Equal(t, []int64{3}, pr.groupRows)
+
+	rows, err := pr.readRowGroup(0)
+	require.NoError(t, err)
+	date := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.Equal(t, [][]interface{}{
+		{"a", int64(30), date, 1.5, "b", "12.34"},
+		{"b", nil, date, float64(-2), nil, "-0.05"},
+		{"c", int64(41), time.Unix(0, 0).UTC(), 0.25, nil, "0.00"},
+	}, rows)
+}
+
+func TestParquetChunker(t *testing.T) {
+	ck := NewTabularChunker(ParquetFormat, 1000, &ColumnMapping{XidColumn: "id"})
+	chunkBuf, err := ck.Chunk(bufioReader(string(testParquetFile(t))))
+	require.Equal(t, io.EOF, err)
+	require.Equal(t, "1\nid,age,born,score,friend,price\n"+
+		"a,30,2020-01-01T00:00:00Z,1.5,b,12.34\n"+
+		"b,,2020-01-01T00:00:00Z,-2,,-0.05\n"+
+		"c,41,1970-01-01T00:00:00Z,0.25,,0.00\n", chunkBuf.String())
+}
+
+func TestParquetFileChunker(t *testing.T) {
+	f, err := ioutil.TempFile("", "chunker")
+	require.NoError(t, err)
+	defer os.Remove(f.Name())
+	_, err = f.Write(testParquetFile(t))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	ck, cleanup := TabularFileChunker(f.Name(), ParquetFormat, 1000,
+		&ColumnMapping{XidColumn: "id"})
+	defer cleanup()
+	// The file is read at its offsets, not from the reader passed to Chunk.
+	chunkBuf, err := ck.Chunk(nil)
+	require.Equal(t, io.EOF, err)
+	require.NoError(t, ck.Parse(chunkBuf))
+	require.Equal(t, uint64(12), ck.NQuads().NumPushed())
+}
+
+func TestParquetInvalid(t *testing.T) {
+	_, err := parquetReaderFor([]byte("PAR1"))
+	require.Error(t, err)
+	_, err = parquetReaderFor([]byte("not a parquet file at all"))
+	require.Error(t, err)
+
+	data := testParquetFile(t)
+	// Corrupt the footer length.
+	binary.LittleEndian.PutUint32(data[len(data)-8:], math.MaxUint32)
+	_, err = parquetReaderFor(data)
+	require.Error(t, err)
+}
+
+func TestParquetCorruptSizes(t *testing.T) {
+	tests := []struct {
+		// corrupt changes the metadata of the column chunk of age.
+		corrupt func(meta tstruct)
+		err     string
+	}{
+		{func(meta tstruct) { meta[7] = int64(maxParquetChunkSize + 1) },
+			"Invalid column chunk size 1073741825"},
+		{func(meta tstruct) { meta[7] = int64(-1) }, "Invalid column chunk size -1"},
+		{func(meta tstruct) { meta[6] = int64(maxParquetChunkSize + 1) },
+			"Invalid uncompressed column chunk size 1073741825"},
+		{func(meta tstruct) { meta[6] = int64(20) },
+			"Invalid uncompressed page size 14, the column chunk has 3 bytes left"},
+		{func(meta tstruct) { meta[5] = int64(-1) }, "Invalid number of values -1"},
+	}
+	for _, tc := range tests {
+		pr, err := parquetReaderFor(testParquetFile(t))
+		require.NoError(t, err)
+		tc.corrupt(pr.columns[1].chunkMeta[0])
+		_, err = pr.readRowGroup(0)
+		require.EqualError(t, err, `while reading column "age": `+tc.err)
+	}
+
+	// A data page holding more values than its column chunk.
+	data := writeParquet(t, 3, []testColumn{{
+		schema: []tfield{{1, int32(parquetInt32)}, {3, int32(0)}, {4, "age"}},
+		typ:    parquetInt32,
+		pages:  []testPage{{dataPage(1<<30, encodingPlain), plainValues(int32(30))}},
+	}})
+	pr, err := parquetReaderFor(data)
+	require.NoError(t, err)
+	_, err = pr.readRowGroup(0)
+	require.EqualError(t, err,
+		`while reading column "age": Invalid number of values 1073741824 in data page`)
+}
+
+func TestDecompressLargerPage(t *testing.T) {
+	page := bytes.Repeat([]byte{1}, 100)
+	var gz bytes.Buffer
+	w := gzip.NewWriter(&gz)
+	_, err := w.Write(page)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	for codec, b := range map[int64][]byte{
+		codecUncompressed: page,
+		codecSnappy:       snappy.Encode(nil, page),
+		codecGzip:         gz.Bytes(),
+	} {
+		out, err := decompress(codec, b, 100)
+		require.NoError(t, err)
+		require.Equal(t, page, out)
+		_, err = decompress(codec, b, 10)
+		require.EqualError(t, err, "Page is larger than its uncompressed size 10")
+	}
+}
+
+func TestDecodeHybrid(t *testing.T) {
+	// A bit-packed run of 8 values with width 3, then an RLE run of 3 values.
+	vals, err := decodeHybrid([]byte{3, 0x88, 0xc6, 0xfa, 6, 5}, 3, 11)
+	require.NoError(t, err)
+	require.Equal(t, []uint32{0, 1, 2, 3, 4, 5, 6, 7, 5, 5, 5}, vals)
+
+	_, err = decodeHybrid([]byte{3, 0x88}, 3, 8)
+	require.Error(t, err)
+}
+
+func TestParquetWriter(t *testing.T) {
+	var buf bytes.Buffer
+	pw, err := NewParquetWriter(&buf, []ParquetColumn{
+		{"uid", types.StringID}, {"age", types.IntID}, {"score", types.FloatID},
+		{"alive", types.BoolID}, {"born", types.DateTimeID}})
+	require.NoError(t, err)
+
+	var want [][]interface{}
+	born := time.Date(1990, 5, 17, 10, 30, 0, 123456000, time.UTC)
+	for i := 0; i < 10; i++ {
+		row := []interface{}{fmt.Sprintf("0x%x", i+1), int64(20 + i), float64(i) / 4,
+			i%3 == 0, born.AddDate(i, 0, 0)}
+		if i%4 == 1 {
+			row[1], row[3], row[4] = nil, nil, nil
+		}
+		require.NoError(t, pw.Write(row))
+		want = append(want, row)
+	}
+	require.Error(t, pw.Write([]interface{}{"0x1"}))
+	require.NoError(t, pw.Close())
+
+	pr, err := parquetReaderFor(buf.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, []string{"uid", "age", "score", "alive", "born"}, pr.columnNames())
+	rows, err := pr.readRowGroup(0)
+	require.NoError(t, err)
+	require.Equal(t, want, rows)
+
+	// Values must match the type of their column.
+	pw, err = NewParquetWriter(&buf, []ParquetColumn{{"age", types.IntID}})
+	require.NoError(t, err)
+	require.NoError(t, pw.Write([]interface{}{"twenty"}))
+	require.Error(t, pw.Close())
+
+	// Values without a thrift type can't be written.
+	require.Error(t, writeThriftStruct(&buf, []thriftField{{1, 1.5}}))
+	require.Error(t, writeThriftStruct(&buf, []thriftField{{1, []interface{}{uint8(1)}}}))
+}
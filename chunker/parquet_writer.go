@@ -0,0 +1,348 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chunker
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+
+	"github.com/dgraph-io/dgraph/types"
+)
+
+// parquetRowGroupSize is the number of rows buffered by ParquetWriter before they are written
+// as a row group.
+const parquetRowGroupSize = 1e5
+
+// ParquetColumn describes a column written by ParquetWriter. Columns of type int, float, bool
+// and datetime hold int64, float64, bool and time.Time values, the other columns hold strings.
+// All the columns are optional, a nil value is written as a null.
+type ParquetColumn struct {
+	Name string
+	Type types.TypeID
+}
+
+// ParquetWriter writes rows to a Parquet file with a flat schema. The pages are compressed
+// with snappy.
+type ParquetWriter struct {
+	w         io.Writer
+	columns   []ParquetColumn
+	rows      [][]interface{}
+	offset    int64
+	numRows   int64
+	rowGroups []interface{}
+}
+
+// NewParquetWriter returns a writer of rows with the given columns to w.
+func NewParquetWriter(w io.Writer, columns []ParquetColumn) (*ParquetWriter, error) {
+	pw := &ParquetWriter{w: w, columns: columns}
+	if err := pw.write(parquetMagic); err != nil {
+		return nil, err
+	}
+	return pw, nil
+}
+
+func (pw *ParquetWriter) write(b []byte) error {
+	n, err := pw.w.Write(b)
+	pw.offset += int64(n)
+	return err
+}
+
+// Write adds a row holding a value for each column.
+func (pw *ParquetWriter) Write(row []interface{}) error {
+	if len(row) != len(pw.columns) {
+		return errors.Errorf("Row has %d values, expected %d", len(row), len(pw.columns))
+	}
+	pw.rows = append(pw.rows, row)
+	if len(pw.rows) >= parquetRowGroupSize {
+		return pw.flush()
+	}
+	return nil
+}
+
+func parquetPhysicalType(typ types.TypeID) int32 {
+	switch typ {
+	case types.IntID, types.DateTimeID:
+		return parquetInt64
+	case types.FloatID:
+		return parquetDouble
+	case types.BoolID:
+		return parquetBoolean
+	}
+	return parquetByteArray
+}
+
+// flush writes the buffered rows as a row group, with a single page per column.
+func (pw *ParquetWriter) flush() error {
+	if len(pw.rows) == 0 {
+		return nil
+	}
+	numRows := len(pw.rows)
+	var chunks []interface{}
+	var groupSize int64
+	for c, col := range pw.columns {
+		defs := make([]byte, 0, numRows)
+		var vals bytes.Buffer
+		var bits byte
+		var numBits uint
+		for _, row := range pw.rows {
+			v := row[c]
+			if v == nil {
+				defs = append(defs, 0)
+				continue
+			}
+			defs = append(defs, 1)
+			if col.Type != types.BoolID {
+				if err := writePlain(&vals, col, v); err != nil {
+					return err
+				}
+				continue
+			}
+			// Booleans are packed in bits.
+			b, ok := v.(bool)
+			if !ok {
+				return errors.Errorf("Invalid value of type %T for column %q", v, col.Name)
+			}
+			if b {
+				bits |= 1 << numBits
+			}
+			if numBits++; numBits == 8 {
+				vals.WriteByte(bits)
+				bits, numBits = 0, 0
+			}
+		}
+		if numBits > 0 {
+			vals.WriteByte(bits)
+		}
+
+		levels := encodeRLE(defs)
+		page := make([]byte, 4, 4+len(levels)+vals.Len())
+		binary.LittleEndian.PutUint32(page, uint32(len(levels)))
+		page = append(append(page, levels...), vals.Bytes()...)
+		compressed := snappy.Encode(nil, page)
+
+		var header bytes.Buffer
+		err := writeThriftStruct(&header, []thriftField{{1, int32(pageData)},
+			{2, int32(len(page))}, {3, int32(len(compressed))}, {5, []thriftField{
+				{1, int32(numRows)}, {2, int32(encodingPlain)}, {3, int32(encodingRLE)},
+				{4, int32(encodingRLE)}}}})
+		if err != nil {
+			return err
+		}
+		start := pw.offset
+		if err := pw.write(header.Bytes()); err != nil {
+			return err
+		}
+		if err := pw.write(compressed); err != nil {
+			return err
+		}
+		size := pw.offset - start
+		uncompressed := int64(header.Len() + len(page))
+		groupSize += uncompressed
+		chunks = append(chunks, []thriftField{{2, start}, {3, []thriftField{
+			{1, parquetPhysicalType(col.Type)},
+			{2, []interface{}{int32(encodingPlain), int32(encodingRLE)}},
+			{3, []interface{}{col.Name}}, {4, int32(codecSnappy)}, {5, int64(numRows)},
+			{6, uncompressed}, {7, size}, {9, start}}}})
+	}
+	pw.rowGroups = append(pw.rowGroups,
+		[]thriftField{{1, chunks}, {2, groupSize}, {3, int64(numRows)}})
+	pw.numRows += int64(numRows)
+	pw.rows = pw.rows[:0]
+	return nil
+}
+
+func writePlain(buf *bytes.Buffer, col ParquetColumn, v interface{}) error {
+	switch col.Type {
+	case types.IntID:
+		if i, ok := v.(int64); ok {
+			return binary.Write(buf, binary.LittleEndian, i)
+		}
+	case types.DateTimeID:
+		if t, ok := v.(time.Time); ok {
+			micros := t.Unix()*1e6 + int64(t.Nanosecond()/1e3)
+			return binary.Write(buf, binary.LittleEndian, micros)
+		}
+	case types.FloatID:
+		if f, ok := v.(float64); ok {
+			return binary.Write(buf, binary.LittleEndian, f)
+		}
+	default:
+		if s, ok := v.(string); ok {
+			if err := binary.Write(buf, binary.LittleEndian, uint32(len(s))); err != nil {
+				return err
+			}
+			_, err := buf.WriteString(s)
+			return err
+		}
+	}
+	return errors.Errorf("Invalid value of type %T for column %q", v, col.Name)
+}
+
+// Close writes the buffered rows and the metadata of the file. It doesn't close the
+// underlying writer.
+func (pw *ParquetWriter) Close() error {
+	if err := pw.flush(); err != nil {
+		return err
+	}
+	schema := []interface{}{[]thriftField{{4, "schema"}, {5, int32(len(pw.columns))}}}
+	for _, col := range pw.columns {
+		el := []thriftField{{1, parquetPhysicalType(col.Type)}, {3, int32(1)}, {4, col.Name}}
+		switch parquetPhysicalType(col.Type) {
+		case parquetByteArray:
+			el = append(el, thriftField{6, int32(convertedUTF8)})
+		case parquetInt64:
+			if col.Type == types.DateTimeID {
+				el = append(el, thriftField{6, int32(convertedTimestampMicros)})
+			}
+		}
+		schema = append(schema, el)
+	}
+	meta := []thriftField{{1, int32(1)}, {2, schema}, {3, pw.numRows}}
+	if len(pw.rowGroups) > 0 {
+		meta = append(meta, thriftField{4, pw.rowGroups})
+	}
+	meta = append(meta, thriftField{6, "dgraph"})
+
+	var buf bytes.Buffer
+	if err := writeThriftStruct(&buf, meta); err != nil {
+		return err
+	}
+	var size [4]byte
+	binary.LittleEndian.PutUint32(size[:], uint32(buf.Len()))
+	buf.Write(size[:])
+	buf.Write(parquetMagic)
+	return pw.write(buf.Bytes())
+}
+
+// encodeRLE encodes definition levels of width 1 in runs of the RLE/bit-packing hybrid
+// encoding.
+func encodeRLE(levels []byte) []byte {
+	var out []byte
+	var tmp [binary.MaxVarintLen64]byte
+	for i := 0; i < len(levels); {
+		j := i
+		for j < len(levels) && levels[j] == levels[i] {
+			j++
+		}
+		out = append(out, tmp[:binary.PutUvarint(tmp[:], uint64(j-i)<<1)]...)
+		out = append(out, levels[i])
+		i = j
+	}
+	return out
+}
+
+// thriftField is a field of a thrift struct, written by writeThriftStruct. The values can be
+// int32, int64, bool, string, []thriftField for structs or []interface{} for lists.
+type thriftField struct {
+	id int16
+	v  interface{}
+}
+
+func thriftTypeOf(v interface{}) (byte, error) {
+	switch v := v.(type) {
+	case int32:
+		return thriftI32, nil
+	case int64:
+		return thriftI64, nil
+	case bool:
+		if v {
+			return thriftTrue, nil
+		}
+		return thriftFalse, nil
+	case string:
+		return thriftBinary, nil
+	case []thriftField:
+		return thriftStruct, nil
+	case []interface{}:
+		return thriftList, nil
+	}
+	return 0, errors.Errorf("Unknown thrift value of type %T", v)
+}
+
+func writeThriftValue(buf *bytes.Buffer, v interface{}) error {
+	var tmp [binary.MaxVarintLen64]byte
+	switch v := v.(type) {
+	case bool:
+		// Booleans in lists take a byte.
+		typ, _ := thriftTypeOf(v)
+		buf.WriteByte(typ)
+	case int32:
+		buf.Write(tmp[:binary.PutVarint(tmp[:], int64(v))])
+	case int64:
+		buf.Write(tmp[:binary.PutVarint(tmp[:], v)])
+	case string:
+		buf.Write(tmp[:binary.PutUvarint(tmp[:], uint64(len(v)))])
+		buf.WriteString(v)
+	case []thriftField:
+		return writeThriftStruct(buf, v)
+	case []interface{}:
+		typ := byte(thriftStruct)
+		if len(v) > 0 {
+			var err error
+			if typ, err = thriftTypeOf(v[0]); err != nil {
+				return err
+			}
+		}
+		if len(v) < 15 {
+			buf.WriteByte(byte(len(v))<<4 | typ)
+		} else {
+			buf.WriteByte(0xf0 | typ)
+			buf.Write(tmp[:binary.PutUvarint(tmp[:], uint64(len(v)))])
+		}
+		for _, e := range v {
+			if err := writeThriftValue(buf, e); err != nil {
+				return err
+			}
+		}
+	default:
+		_, err := thriftTypeOf(v)
+		return err
+	}
+	return nil
+}
+
+// writeThriftStruct writes the fields, in increasing order of id, with the thrift compact
+// protocol.
+func writeThriftStruct(buf *bytes.Buffer, fields []thriftField) error {
+	var last int16
+	var tmp [binary.MaxVarintLen64]byte
+	for _, f := range fields {
+		typ, err := thriftTypeOf(f.v)
+		if err != nil {
+			return err
+		}
+		if delta := f.id - last; delta > 0 && delta <= 15 {
+			buf.WriteByte(byte(delta)<<4 | typ)
+		} else {
+			buf.WriteByte(typ)
+			buf.Write(tmp[:binary.PutVarint(tmp[:], int64(f.id))])
+		}
+		if _, ok := f.v.(bool); !ok {
+			if err := writeThriftValue(buf, f.v); err != nil {
+				return err
+			}
+		}
+		last = f.id
+	}
+	buf.WriteByte(0)
+	return nil
+}
@@ -0,0 +1,372 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chunker
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dgraph-io/dgo/v200/protos/api"
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/pkg/errors"
+)
+
+// ColumnMapping describes how the columns of a CSV, TSV or Parquet file map to the
+// predicates of the nodes created for each row. For example:
+//
+//  {
+//    "xid": "id",
+//    "type": "Person",
+//    "columns": [
+//      {"column": "name", "predicate": "name"},
+//      {"column": "born", "predicate": "dob", "type": "datetime"},
+//      {"column": "manager_id", "predicate": "manager", "edge": true}
+//    ]
+//  }
+type ColumnMapping struct {
+	// XidColumn holds the external id of the node of each row.
+	XidColumn string `json:"xid"`
+	// XidPrefix is prepended to the external ids, so that the ids of nodes loaded from
+	// different files don't collide.
+	XidPrefix string `json:"xid_prefix"`
+	// Type is set as the dgraph.type of the nodes, if not empty.
+	Type string `json:"type"`
+	// Columns lists the columns to load. If empty, all the columns other than the xid column
+	// are loaded as untyped values of the predicate with the same name.
+	Columns []*ColumnPredicate `json:"columns"`
+}
+
+// ColumnPredicate maps a column to a predicate.
+type ColumnPredicate struct {
+	Column    string `json:"column"`
+	Predicate string `json:"predicate"`
+	// Type is the scalar type the values are converted to, e.g. int or datetime. The values
+	// are untyped if it's empty, like the values of RDF literals without a type.
+	Type string `json:"type"`
+	// Edge is set if the values are external ids of other nodes, which the predicate links to.
+	Edge bool `json:"edge"`
+	// XidPrefix is prepended to the external ids of the linked nodes.
+	XidPrefix string `json:"xid_prefix"`
+
+	typ types.TypeID
+}
+
+// ReadColumnMapping reads and validates the column mapping in the given JSON file.
+func ReadColumnMapping(file string) (*ColumnMapping, error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while reading mapping file %s", file)
+	}
+	return ParseColumnMapping(b)
+}
+
+// ParseColumnMapping parses and validates a JSON column mapping.
+func ParseColumnMapping(b []byte) (*ColumnMapping, error) {
+	var m ColumnMapping
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, errors.Wrapf(err, "while parsing column mapping")
+	}
+	if m.XidColumn == "" {
+		return nil, errors.New("Column mapping must set the xid column")
+	}
+	for _, c := range m.Columns {
+		switch {
+		case c.Column == "":
+			return nil, errors.New("Column mapping has a predicate without column")
+		case c.Predicate == "":
+			c.Predicate = c.Column
+		}
+		if c.Edge {
+			if c.Type != "" && c.Type != "uid" {
+				return nil, errors.Errorf("Edge column %q can't have type %q", c.Column, c.Type)
+			}
+			continue
+		}
+		c.typ = types.DefaultID
+		if c.Type != "" {
+			typ, ok := types.TypeForName(c.Type)
+			if !ok || typ == types.UidID {
+				return nil, errors.Errorf("Invalid type %q for column %q", c.Type, c.Column)
+			}
+			c.typ = typ
+		}
+	}
+	return &m, nil
+}
+
+// tabularRows is the number of rows in each chunk of a tabular file.
+const tabularRows = 1e4
+
+// csvChunker reads CSV and TSV files. Each chunk is a line holding the number of its first row
+// in the file, followed by a CSV document starting with the header of the file, so that it can
+// be parsed independently from the others.
+type csvChunker struct {
+	nqs     *NQuadBuffer
+	mapping *ColumnMapping
+	comma   rune
+	r       *csv.Reader
+	header  []string
+	rows    int64 // Rows read so far, without the header.
+}
+
+func (cc *csvChunker) NQuads() *NQuadBuffer {
+	return cc.nqs
+}
+
+// Chunk reads up to tabularRows rows from the reader.
+func (cc *csvChunker) Chunk(r *bufio.Reader) (*bytes.Buffer, error) {
+	if cc.r == nil {
+		cc.r = csv.NewReader(r)
+		cc.r.Comma = cc.comma
+		cc.r.LazyQuotes = cc.comma == '\t'
+		cc.r.ReuseRecord = true
+		header, err := cc.r.Read()
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "while reading header")
+		}
+		cc.header = append([]string{}, header...)
+	}
+
+	out := new(bytes.Buffer)
+	// The header is the first row of the file.
+	fmt.Fprintf(out, "%d\n", cc.rows+2)
+	w := csv.NewWriter(out)
+	if err := w.Write(cc.header); err != nil {
+		return nil, err
+	}
+	for i := 0; i < tabularRows; i++ {
+		row, err := cc.r.Read()
+		if err == io.EOF {
+			w.Flush()
+			return out, io.EOF
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := w.Write(row); err != nil {
+			return nil, err
+		}
+		cc.rows++
+	}
+	w.Flush()
+	return out, w.Error()
+}
+
+func (cc *csvChunker) Parse(chunkBuf *bytes.Buffer) error {
+	if chunkBuf == nil || chunkBuf.Len() == 0 {
+		return nil
+	}
+	return parseTabular(chunkBuf, cc.mapping, cc.nqs)
+}
+
+// parquetChunker reads Parquet files. Each chunk holds the rows of a row group in CSV, to be
+// parsed like CSV chunks. The row groups are read from file at their offsets if it's set,
+// otherwise the input is read in memory by the first call to Chunk.
+type parquetChunker struct {
+	nqs     *NQuadBuffer
+	mapping *ColumnMapping
+	file    io.ReaderAt
+	size    int64
+	pr      *parquetReader
+	group   int
+	rows    int64 // Rows read so far.
+}
+
+func (pc *parquetChunker) NQuads() *NQuadBuffer {
+	return pc.nqs
+}
+
+func (pc *parquetChunker) Chunk(r *bufio.Reader) (*bytes.Buffer, error) {
+	if pc.pr == nil {
+		file, size := pc.file, pc.size
+		if file == nil {
+			data, err := ioutil.ReadAll(r)
+			if err != nil {
+				return nil, err
+			}
+			file, size = bytes.NewReader(data), int64(len(data))
+		}
+		var err error
+		if pc.pr, err = newParquetReader(file, size); err != nil {
+			return nil, err
+		}
+	}
+	if pc.group >= len(pc.pr.groupRows) {
+		return nil, io.EOF
+	}
+
+	rows, err := pc.pr.readRowGroup(pc.group)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while reading row group %d", pc.group)
+	}
+	pc.group++
+
+	out := new(bytes.Buffer)
+	fmt.Fprintf(out, "%d\n", pc.rows+1)
+	pc.rows += int64(len(rows))
+	w := csv.NewWriter(out)
+	if err := w.Write(pc.pr.columnNames()); err != nil {
+		return nil, err
+	}
+	record := make([]string, len(pc.pr.columns))
+	for _, row := range rows {
+		for i, v := range row {
+			record[i] = formatParquetValue(v)
+		}
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	if pc.group == len(pc.pr.groupRows) {
+		return out, io.EOF
+	}
+	return out, nil
+}
+
+func (pc *parquetChunker) Parse(chunkBuf *bytes.Buffer) error {
+	if chunkBuf == nil || chunkBuf.Len() == 0 {
+		return nil
+	}
+	return parseTabular(chunkBuf, pc.mapping, pc.nqs)
+}
+
+func formatParquetValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case bool:
+		return strconv.FormatBool(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case string:
+		return v
+	}
+	return ""
+}
+
+// parseTabular parses a chunk made of the number of its first row and a CSV document starting
+// with a header, and pushes the N-Quads of each row as described by the mapping. Empty fields
+// are skipped.
+func parseTabular(chunkBuf *bytes.Buffer, m *ColumnMapping, nqs *NQuadBuffer) error {
+	first, err := chunkBuf.ReadString('\n')
+	if err != nil {
+		return errors.Wrapf(err, "while reading the first row of the chunk")
+	}
+	firstRow, err := strconv.ParseInt(strings.TrimSpace(first), 10, 64)
+	if err != nil {
+		return errors.Wrapf(err, "while reading the first row of the chunk")
+	}
+
+	r := csv.NewReader(chunkBuf)
+	r.ReuseRecord = true
+	header, err := r.Read()
+	if err != nil {
+		return errors.Wrapf(err, "while reading header")
+	}
+
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		index[name] = i
+	}
+	xidIdx, ok := index[m.XidColumn]
+	if !ok {
+		return errors.Errorf("Xid column %q not found", m.XidColumn)
+	}
+	columns := m.Columns
+	if len(columns) == 0 {
+		for _, name := range header {
+			if name != m.XidColumn {
+				columns = append(columns, &ColumnPredicate{
+					Column: name, Predicate: name, typ: types.DefaultID})
+			}
+		}
+	}
+	colIdx := make([]int, len(columns))
+	for i, c := range columns {
+		if colIdx[i], ok = index[c.Column]; !ok {
+			return errors.Errorf("Column %q not found", c.Column)
+		}
+	}
+
+	for line := firstRow; ; line++ {
+		row, err := r.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		xid := row[xidIdx]
+		if xid == "" {
+			return errors.Errorf("Empty xid in row %d", line)
+		}
+		subject := "_:" + m.XidPrefix + xid
+		if m.Type != "" {
+			nqs.Push(&api.NQuad{
+				Subject:     subject,
+				Predicate:   "dgraph.type",
+				ObjectValue: &api.Value{Val: &api.Value_DefaultVal{DefaultVal: m.Type}},
+			})
+		}
+
+		for i, c := range columns {
+			val := row[colIdx[i]]
+			if val == "" {
+				continue
+			}
+			nq := &api.NQuad{Subject: subject, Predicate: c.Predicate}
+			if c.Edge {
+				nq.ObjectId = "_:" + c.XidPrefix + val
+				nqs.Push(nq)
+				continue
+			}
+			if c.typ == types.DefaultID {
+				nq.ObjectValue = &api.Value{Val: &api.Value_DefaultVal{DefaultVal: val}}
+				nqs.Push(nq)
+				continue
+			}
+			src := types.Val{Tid: types.StringID, Value: []byte(val)}
+			dst, err := types.Convert(src, c.typ)
+			if err != nil {
+				return errors.Wrapf(err, "while converting column %q in row %d", c.Column, line)
+			}
+			if nq.ObjectValue, err = types.ObjectValue(c.typ, dst.Value); err != nil {
+				return errors.Wrapf(err, "while converting column %q in row %d", c.Column, line)
+			}
+			nqs.Push(nq)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chunker
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/dgraph-io/dgo/v200/protos/api"
+	"github.com/stretchr/testify/require"
+)
+
+const testMapping = `{
+	"xid": "id",
+	"xid_prefix": "person.",
+	"type": "Person",
+	"columns": [
+		{"column": "name"},
+		{"column": "age", "type": "int"},
+		{"column": "boss", "predicate": "manager", "edge": true, "xid_prefix": "person."}
+	]
+}`
+
+func TestParseColumnMapping(t *testing.T) {
+	m, err := ParseColumnMapping([]byte(testMapping))
+	require.NoError(t, err)
+	require.Equal(t, "id", m.XidColumn)
+	require.Len(t, m.Columns, 3)
+	require.Equal(t, "name", m.Columns[0].Predicate)
+	require.Equal(t, "manager", m.Columns[2].Predicate)
+
+	for _, mapping := range []string{
+		`{"columns": [{"column": "name"}]}`,
+		`{"xid": "id", "columns": [{"predicate": "name"}]}`,
+		`{"xid": "id", "columns": [{"column": "age", "type": "integer"}]}`,
+		`{"xid": "id", "columns": [{"column": "boss", "type": "int", "edge": true}]}`,
+		`{"xid": "id", "columns": [{"column": "boss", "type": "uid"}]}`,
+		`{"xid": "id", "columns": {}}`,
+	} {
+		_, err := ParseColumnMapping([]byte(mapping))
+		require.Error(t, err, mapping)
+	}
+}
+
+func parseTabularChunks(t *testing.T, ck Chunker, input string) []*api.NQuad {
+	r := bufioReader(input)
+	for {
+		chunkBuf, err := ck.Chunk(r)
+		if err != io.EOF {
+			require.NoError(t, err)
+		}
+		require.NoError(t, ck.Parse(chunkBuf))
+		if err == io.EOF {
+			break
+		}
+	}
+	ck.NQuads().Flush()
+	var nqs []*api.NQuad
+	for batch := range ck.NQuads().Ch() {
+		nqs = append(nqs, batch...)
+	}
+	return nqs
+}
+
+func TestCSVChunker(t *testing.T) {
+	m, err := ParseColumnMapping([]byte(testMapping))
+	require.NoError(t, err)
+	input := "id,name,age,boss,ignored\n" +
+		"1,\"Doe, Jane\",41,,x\n" +
+		"2,John,,1,y\n"
+	ck := NewTabularChunker(CsvFormat, 1000, m)
+	nqs := parseTabularChunks(t, ck, input)
+	require.Equal(t, uint64(6), ck.NQuads().NumPushed())
+
+	def := func(v string) *api.Value {
+		return &api.Value{Val: &api.Value_DefaultVal{DefaultVal: v}}
+	}
+	require.Equal(t, []*api.NQuad{
+		{Subject: "_:person.1", Predicate: "dgraph.type", ObjectValue: def("Person")},
+		{Subject: "_:person.1", Predicate: "name", ObjectValue: def("Doe, Jane")},
+		{Subject: "_:person.1", Predicate: "age",
+			ObjectValue: &api.Value{Val: &api.Value_IntVal{IntVal: 41}}},
+		{Subject: "_:person.2", Predicate: "dgraph.type", ObjectValue: def("Person")},
+		{Subject: "_:person.2", Predicate: "name", ObjectValue: def("John")},
+		{Subject: "_:person.2", Predicate: "manager", ObjectId: "_:person.1"},
+	}, nqs)
+}
+
+func TestTSVChunkerAllColumns(t *testing.T) {
+	m, err := ParseColumnMapping([]byte(`{"xid": "id"}`))
+	require.NoError(t, err)
+	input := "id\tname\tcity\n" +
+		"a\tAlice \"Al\"\tParis\n"
+	nqs := parseTabularChunks(t, NewTabularChunker(TsvFormat, 1000, m), input)
+
+	require.Equal(t, []*api.NQuad{
+		{Subject: "_:a", Predicate: "name",
+			ObjectValue: &api.Value{Val: &api.Value_DefaultVal{DefaultVal: "Alice \"Al\""}}},
+		{Subject: "_:a", Predicate: "city",
+			ObjectValue: &api.Value{Val: &api.Value_DefaultVal{DefaultVal: "Paris"}}},
+	}, nqs)
+}
+
+func TestCSVChunkerErrors(t *testing.T) {
+	m, err := ParseColumnMapping([]byte(testMapping))
+	require.NoError(t, err)
+
+	for _, input := range []string{
+		// Missing column of the mapping.
+		"id,name,age\n1,Jane,41\n",
+		// Empty xid.
+		"id,name,age,boss\n,Jane,41,\n",
+		// Invalid int.
+		"id,name,age,boss\n1,Jane,old,\n",
+	} {
+		ck := NewTabularChunker(CsvFormat, 1000, m)
+		chunkBuf, err := ck.Chunk(bufioReader(input))
+		require.Equal(t, io.EOF, err)
+		require.Error(t, ck.Parse(chunkBuf), input)
+	}
+
+	// The rows are numbered from the start of the file, not of the chunk.
+	var sb strings.Builder
+	sb.WriteString("id,name,age,boss\n")
+	for i := 1; i <= tabularRows; i++ {
+		fmt.Fprintf(&sb, "%d,Jane,41,\n", i)
+	}
+	sb.WriteString(",John,42,\n")
+	ck := NewTabularChunker(CsvFormat, 1000, m)
+	r := bufioReader(sb.String())
+	_, err = ck.Chunk(r)
+	require.NoError(t, err)
+	chunkBuf, err := ck.Chunk(r)
+	require.Equal(t, io.EOF, err)
+	require.EqualError(t, ck.Parse(chunkBuf), fmt.Sprintf("Empty xid in row %d", int(tabularRows)+2))
+
+	// An empty file has no chunks.
+	ck = NewTabularChunker(CsvFormat, 1000, m)
+	chunkBuf, err = ck.Chunk(bufioReader(""))
+	require.Equal(t, io.EOF, err)
+	require.Nil(t, chunkBuf)
+}
+
+func TestTabularDataFormat(t *testing.T) {
+	require.Equal(t, CsvFormat, DataFormat("people.csv.gz", ""))
+	require.Equal(t, TsvFormat, DataFormat("people.tsv", ""))
+	require.Equal(t, ParquetFormat, DataFormat("people.parquet", ""))
+	require.Equal(t, ParquetFormat, DataFormat("people", "parquet"))
+	require.True(t, ParquetFormat.IsTabular())
+	require.False(t, RdfFormat.IsTabular())
+}
@@ -142,6 +142,7 @@ func exportHandler(w http.ResponseWriter, r *http.Request, adminServer web.IServ
 			response {
 			  code
 			}
+			taskId
 		  }
 		}`,
 		Variables: map[string]interface{}{},
@@ -151,6 +152,10 @@ func exportHandler(w http.ResponseWriter, r *http.Request, adminServer web.IServ
 		x.SetStatus(w, resp.Errors[0].Message, "Export failed.")
 		return
 	}
+	if err := waitForTask(r, resp, "export"); err != nil {
+		x.SetStatus(w, err.Error(), "Export failed.")
+		return
+	}
 	w.Header().Set("Content-Type", "application/json")
 	x.Check2(w.Write([]byte(`{"code": "Success", "message": "Export completed."}`)))
 }
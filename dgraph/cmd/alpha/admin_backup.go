@@ -45,6 +45,7 @@ func backupHandler(w http.ResponseWriter, r *http.Request, adminServer web.IServ
 			response {
 			  code
 			}
+			taskId
 		  }
 		}`,
 		Variables: map[string]interface{}{"input": map[string]interface{}{
@@ -62,6 +63,10 @@ func backupHandler(w http.ResponseWriter, r *http.Request, adminServer web.IServ
 		x.SetStatus(w, resp.Errors.Error(), "Backup failed.")
 		return
 	}
+	if err := waitForTask(r, resp, "backup"); err != nil {
+		x.SetStatus(w, err.Error(), "Backup failed.")
+		return
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	x.Check2(w.Write([]byte(`{"code": "Success", "message": "Backup completed."}`)))
@@ -69,6 +69,7 @@ func keywordHandler(w http.ResponseWriter, r *http.Request) {
 		"anyoftext",
 		"as",
 		"avg",
+		"bbox",
 		"ceil",
 		"cond",
 		"contains",
@@ -83,6 +84,7 @@ func keywordHandler(w http.ResponseWriter, r *http.Request) {
 		"fulltext",
 		"func",
 		"ge",
+		"geo_distance",
 		"gt",
 		"index",
 		"intersects",
@@ -37,6 +37,7 @@ import (
 	"github.com/dgraph-io/dgraph/gql"
 	"github.com/dgraph-io/dgraph/graphql/schema"
 	"github.com/dgraph-io/dgraph/graphql/web"
+	"github.com/dgraph-io/dgraph/posting"
 	"github.com/dgraph-io/dgraph/query"
 	"github.com/dgraph-io/dgraph/worker"
 	"github.com/dgraph-io/dgraph/x"
@@ -158,6 +159,16 @@ func queryHandler(w http.ResponseWriter, r *http.Request) {
 		x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
 		return
 	}
+	isExplain, err := parseBool(r, "explain")
+	if err != nil {
+		x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+		return
+	}
+	isProfile, err := parseBool(r, "profile")
+	if err != nil {
+		x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+		return
+	}
 	queryTimeout, err := parseDuration(r, "timeout")
 	if err != nil {
 		x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
@@ -168,6 +179,19 @@ func queryHandler(w http.ResponseWriter, r *http.Request) {
 		x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
 		return
 	}
+	// If at is set, run this as a read-only query at the given point in time.
+	var readOnlyAt bool
+	if at := r.URL.Query().Get("at"); at != "" {
+		if startTs != 0 {
+			x.SetStatus(w, x.ErrorInvalidRequest, "at and startTs can't be set together")
+			return
+		}
+		if startTs, err = posting.ReadTsAt(at); err != nil {
+			x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+			return
+		}
+		readOnlyAt = true
+	}
 
 	body := readRequest(w, r)
 	if body == nil {
@@ -206,6 +230,9 @@ func queryHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	ctx := context.WithValue(r.Context(), query.DebugKey, isDebugMode)
+	ctx = context.WithValue(ctx, query.ExplainKey, isExplain)
+	ctx = context.WithValue(ctx, query.ProfileKey, isProfile)
+	ctx, plans := query.WithPlans(ctx)
 	ctx = x.AttachAccessJwt(ctx, r)
 	ctx = x.AttachRemoteIP(ctx, r)
 
@@ -216,9 +243,10 @@ func queryHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	req := api.Request{
-		Vars:    params.Variables,
-		Query:   params.Query,
-		StartTs: startTs,
+		Vars:     params.Variables,
+		Query:    params.Query,
+		StartTs:  startTs,
+		ReadOnly: readOnlyAt,
 	}
 
 	if req.StartTs == 0 {
@@ -257,6 +285,7 @@ func queryHandler(w http.ResponseWriter, r *http.Request) {
 		Txn:     resp.Txn,
 		Latency: resp.Latency,
 		Metrics: resp.Metrics,
+		Plan:    plans.Nodes,
 	}
 	js, err := json.Marshal(e)
 	if err != nil {
@@ -646,6 +675,25 @@ func resolveWithAdminServer(gqlReq *schema.Request, r *http.Request,
 	return adminServer.Resolve(ctx, gqlReq)
 }
 
+// waitForTask waits until the task queued by the given admin mutation is over, so that the
+// HTTP endpoints of the admin operations keep returning once the operation is done.
+func waitForTask(r *http.Request, resp *schema.Response, mutation string) error {
+	var data map[string]struct {
+		TaskId string `json:"taskId"`
+	}
+	if err := json.Unmarshal(resp.Data.Bytes(), &data); err != nil {
+		return errors.Wrapf(err, "while reading the response of %s", mutation)
+	}
+	t, err := edgraph.WaitForTask(r.Context(), data[mutation].TaskId)
+	if err != nil {
+		return err
+	}
+	if t.Status == edgraph.TaskFailed {
+		return errors.New(t.Error)
+	}
+	return nil
+}
+
 func writeSuccessResponse(w http.ResponseWriter, r *http.Request) {
 	res := map[string]interface{}{}
 	data := map[string]interface{}{}
@@ -770,6 +770,52 @@ func TestDebugSupport(t *testing.T) {
 	require.Empty(t, resp.Header.Get("Content-Encoding"))
 }
 
+func TestQueryPlan(t *testing.T) {
+	require.NoError(t, dropAll())
+	require.NoError(t, alterSchema(`name: string @index(exact) .
+	age: int .`))
+	require.NoError(t, runMutation(`
+	{
+	  set {
+		_:a <name> "Alice" .
+		_:a <age> "21" .
+		_:b <name> "Bob" .
+	  }
+	}
+	`))
+
+	q := `{ users(func: eq(name, "Alice")) @filter(has(age)) { name } }`
+	plan := func(option string) (string, []*query.PlanNode) {
+		_, body, err := runWithRetries("POST", "application/dql",
+			addr+"/query?"+option+"=true", q)
+		require.NoError(t, err)
+		var r res
+		require.NoError(t, json.Unmarshal(body, &r))
+		require.NotNil(t, r.Extensions)
+		require.Len(t, r.Extensions.Plan, 1)
+		return string(r.Data), r.Extensions.Plan
+	}
+
+	// The query is only planned.
+	data, nodes := plan("explain")
+	require.JSONEq(t, `{}`, data)
+	require.Equal(t, "users", nodes[0].Alias)
+	require.Equal(t, "eq(name, Alice)", nodes[0].Func)
+	require.True(t, nodes[0].UsesIndex)
+	require.NotZero(t, nodes[0].Group)
+	require.Nil(t, nodes[0].Stats)
+	require.Len(t, nodes[0].Filters, 1)
+	require.Equal(t, "has(age)", nodes[0].Filters[0].Func)
+	require.False(t, nodes[0].Filters[0].UsesIndex)
+
+	// The query is run.
+	data, nodes = plan("profile")
+	require.JSONEq(t, `{"users": [{"name": "Alice"}]}`, data)
+	require.Equal(t, 1, nodes[0].Stats.UidsOut)
+	require.NotZero(t, nodes[0].Stats.TaskNs)
+	require.Equal(t, 1, nodes[0].Filters[0].Stats.UidsIn)
+}
+
 func TestHealth(t *testing.T) {
 	url := fmt.Sprintf("%s/health", addr)
 	resp, err := http.Get(url)
@@ -22,11 +22,13 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"strconv"
 
 	"github.com/dgraph-io/dgo/v200/protos/api"
 	"github.com/dgraph-io/dgraph/edgraph"
 	"github.com/dgraph-io/dgraph/x"
 	"github.com/golang/glog"
+	"google.golang.org/grpc/metadata"
 )
 
 func loginHandler(w http.ResponseWriter, r *http.Request) {
@@ -45,6 +47,24 @@ func loginHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// The namespace to log into isn't part of api.LoginRequest. It is passed on in the
+	// metadata, the same way gRPC clients send it.
+	var nsReq struct {
+		Namespace uint64 `json:"namespace"`
+	}
+	if err := json.Unmarshal(body, &nsReq); err != nil {
+		x.SetStatusWithData(w, x.Error, err.Error())
+		return
+	}
+	if nsReq.Namespace != x.GalaxyNamespace {
+		md, ok := metadata.FromIncomingContext(ctx)
+		if !ok {
+			md = metadata.New(nil)
+		}
+		md.Set("namespace", strconv.FormatUint(nsReq.Namespace, 10))
+		ctx = metadata.NewIncomingContext(ctx, md)
+	}
+
 	resp, err := (&edgraph.Server{}).Login(ctx, &loginReq)
 	if err != nil {
 		x.SetStatusWithData(w, x.ErrorInvalidRequest, err.Error())
@@ -38,6 +38,7 @@ import (
 	badgerpb "github.com/dgraph-io/badger/v2/pb"
 	"github.com/dgraph-io/dgo/v200/protos/api"
 	"github.com/dgraph-io/dgraph/edgraph"
+	"github.com/dgraph-io/dgraph/ee/audit"
 	"github.com/dgraph-io/dgraph/ee/enc"
 	"github.com/dgraph-io/dgraph/graphql/admin"
 	"github.com/dgraph-io/dgraph/graphql/web"
@@ -111,6 +112,7 @@ they form a Raft group and provide synchronous replication.
 			"level (if applicable) for the postings directory. none would disable compression,"+
 			" while zstd:1 would set zstd compression at level 1.")
 	enc.RegisterFlags(flag)
+	audit.RegisterFlags(flag)
 
 	// Snapshot and Transactions.
 	flag.Int("snapshot_after", 10000,
@@ -120,6 +122,14 @@ they form a Raft group and provide synchronous replication.
 	flag.String("abort_older_than", "5m",
 		"Abort any pending transactions older than this duration. The liveness of a"+
 			" transaction is determined by its last mutation.")
+	flag.Duration("history_retention", 0,
+		"Keep the old versions of the data for this duration, so that queries can read the"+
+			" state at any point in time within it. Zero only keeps the versions needed by"+
+			" pending transactions.")
+
+	flag.String("cdc_file", "",
+		"If set, every committed mutation is emitted as a JSON change event to this file."+
+			" The commit ts of the last emitted event is checkpointed in the postings directory.")
 
 	flag.StringP("wal", "w", "w", "Directory to store raft write-ahead logs.")
 	flag.String("whitelist", "",
@@ -161,12 +171,32 @@ they form a Raft group and provide synchronous replication.
 	flag.Uint64("query_edge_limit", 1e6,
 		"Limit for the maximum number of edges that can be returned in a query."+
 			" This applies to shortest path and recursive queries.")
+	flag.Duration("query_timeout", 0,
+		"Maximum time a query or a mutation can run for, after which it's cancelled. A request "+
+			"can have a shorter timeout of its own. 0 means no timeout.")
+	flag.Int64("query_memory_limit_mb", 0,
+		"Maximum memory in MB the results of a query can take, while it's processed and "+
+			"encoded. The query fails once it goes over. It's a soft limit, the results of "+
+			"the tasks of the query are counted once they're built. 0 means no limit.")
 	flag.Uint64("normalize_node_limit", 1e4,
 		"Limit for the maximum number of nodes that can be returned in a query that uses the "+
 			"normalize directive.")
 	flag.Uint64("mutations_nquad_limit", 1e6,
 		"Limit for the maximum number of nquads that can be inserted in a mutation request")
 
+	flag.String("slow_query_dir", "",
+		"If set, the queries and mutations slower than --slow_query_threshold are logged as "+
+			"JSON lines in files inside this directory.")
+	flag.Duration("slow_query_threshold", time.Second,
+		"Latency over which a query or a mutation is logged in the slow query log.")
+	flag.Float64("slow_query_sample_rate", 1,
+		"Fraction of the slow queries and mutations that are logged, from 0 to 1.")
+	flag.Int("slow_query_size_mb", 100,
+		"Size in MB after which the slow query log file is rotated.")
+	flag.Int("slow_query_max_files", 10,
+		"Number of slow query log files to retain, older files are deleted. "+
+			"Use 0 to retain all files.")
+
 	//Custom plugins.
 	flag.String("custom_tokenizers", "",
 		"Comma separated list of tokenizer plugins")
@@ -176,13 +206,32 @@ they form a Raft group and provide synchronous replication.
 
 	flag.Bool("graphql_introspection", true, "Set to false for no GraphQL schema introspection")
 	flag.Bool("graphql_debug", false, "Enable debug mode in GraphQL. This returns auth errors to clients. We do not recommend turning it on for production.")
+	flag.Bool("graphql_trusted_documents", false,
+		"Only execute the trusted documents registered through /admin on /graphql, given by "+
+			"their sha256 hash or their query. Other operations are rejected.")
+	flag.Int("graphql_max_depth", 0,
+		"Maximum depth of a GraphQL operation on /graphql. 0 means no limit.")
+	flag.Int("graphql_max_aliases", 0,
+		"Maximum number of aliases in a GraphQL operation on /graphql. 0 means no limit.")
+	flag.Uint64("graphql_max_cost", 0,
+		"Maximum cost of a GraphQL operation on /graphql. Every object field costs 1, or the "+
+			"weight given by @cost, multiplied by the size of the lists it is in. 0 means no limit.")
+	flag.Uint64("graphql_list_size", 100,
+		"Number of items assumed for a list field without a first argument, when calculating "+
+			"the cost of a GraphQL operation.")
+	flag.Int64("graphql_response_cache_mb", 0,
+		"Size in MB of the cache of the responses to the GraphQL queries with a @cacheControl "+
+			"directive. A response is cached for at most the maxAge of the directive, and until "+
+			"a commit changes the predicates it read. 0 disables the cache.")
 
 	// Ludicrous mode
 	flag.Bool("ludicrous_mode", false, "Run Dgraph in ludicrous mode.")
 	flag.Int("ludicrous_concurrency", 2000, "Number of concurrent threads in ludicrous mode")
 
 	flag.Bool("graphql_extensions", true, "Set to false if extensions not required in GraphQL response body")
-	flag.Duration("graphql_poll_interval", time.Second, "polling interval for graphql subscription.")
+	flag.Duration("graphql_poll_interval", time.Second, "polling interval for graphql subscription. "+
+		"A subscription runs its query again at most once per interval, once a commit changes "+
+		"the predicates it read, or on every interval if they can't be tracked.")
 	flag.String("graphql_lambda_url", "",
 		"URL of lambda server that implements custom GraphQL JavaScript resolvers")
 
@@ -379,6 +428,7 @@ func serveGRPC(l net.Listener, tlsCfg *tls.Config, closer *z.Closer) {
 		grpc.MaxSendMsgSize(x.GrpcMaxSize),
 		grpc.MaxConcurrentStreams(1000),
 		grpc.StatsHandler(&ocgrpc.ServerHandler{}),
+		grpc.UnaryInterceptor(audit.AuditRequestGRPC),
 	}
 	if tlsCfg != nil {
 		opt = append(opt, grpc.Creds(credentials.NewTLS(tlsCfg)))
@@ -386,6 +436,7 @@ func serveGRPC(l net.Listener, tlsCfg *tls.Config, closer *z.Closer) {
 
 	s := grpc.NewServer(opt...)
 	api.RegisterDgraphServer(s, &edgraph.Server{})
+	pb.RegisterSubscriptionsServer(s, &subscriptionsServer{})
 	hapi.RegisterHealthServer(s, health.NewServer())
 	worker.RegisterZeroProxyServer(s)
 
@@ -417,12 +468,13 @@ func setupServer(closer *z.Closer) {
 		log.Fatal(err)
 	}
 
-	http.HandleFunc("/query", queryHandler)
-	http.HandleFunc("/query/", queryHandler)
-	http.HandleFunc("/mutate", mutationHandler)
-	http.HandleFunc("/mutate/", mutationHandler)
-	http.HandleFunc("/commit", commitHandler)
-	http.HandleFunc("/alter", alterHandler)
+	http.Handle("/query", audit.AuditRequestHttp(http.HandlerFunc(queryHandler)))
+	http.Handle("/query/", audit.AuditRequestHttp(http.HandlerFunc(queryHandler)))
+	http.Handle("/query/subscribe", audit.AuditRequestHttp(http.HandlerFunc(subscriptionHandler)))
+	http.Handle("/mutate", audit.AuditRequestHttp(http.HandlerFunc(mutationHandler)))
+	http.Handle("/mutate/", audit.AuditRequestHttp(http.HandlerFunc(mutationHandler)))
+	http.Handle("/commit", audit.AuditRequestHttp(http.HandlerFunc(commitHandler)))
+	http.Handle("/alter", audit.AuditRequestHttp(http.HandlerFunc(alterHandler)))
 	http.HandleFunc("/health", healthCheck)
 	http.HandleFunc("/state", stateHandler)
 	http.HandleFunc("/jemalloc", x.JemallocHandler)
@@ -451,7 +503,7 @@ func setupServer(closer *z.Closer) {
 	var gqlHealthStore *admin.GraphQLHealthStore
 	// Do not use := notation here because adminServer is a global variable.
 	mainServer, adminServer, gqlHealthStore = admin.NewServers(introspection, &globalEpoch, closer)
-	http.Handle("/graphql", mainServer.HTTPHandler())
+	http.Handle("/graphql", audit.AuditRequestHttp(mainServer.HTTPHandler()))
 	http.HandleFunc("/probe/graphql", func(w http.ResponseWriter, r *http.Request) {
 		healthStatus := gqlHealthStore.GetHealth()
 		httpStatusCode := http.StatusOK
@@ -463,16 +515,16 @@ func setupServer(closer *z.Closer) {
 		x.Check2(w.Write([]byte(fmt.Sprintf(`{"status":"%s","schemaUpdateCounter":%d}`,
 			healthStatus.StatusMsg, atomic.LoadUint64(&globalEpoch)))))
 	})
-	http.Handle("/admin", allowedMethodsHandler(allowedMethods{
+	http.Handle("/admin", audit.AuditRequestHttp(allowedMethodsHandler(allowedMethods{
 		http.MethodGet:     true,
 		http.MethodPost:    true,
 		http.MethodOptions: true,
-	}, adminAuthHandler(adminServer.HTTPHandler())))
+	}, adminAuthHandler(adminServer.HTTPHandler()))))
 
-	http.Handle("/admin/schema", adminAuthHandler(http.HandlerFunc(func(w http.ResponseWriter,
-		r *http.Request) {
-		adminSchemaHandler(w, r, adminServer)
-	})))
+	http.Handle("/admin/schema", audit.AuditRequestHttp(adminAuthHandler(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			adminSchemaHandler(w, r, adminServer)
+		}))))
 
 	http.Handle("/admin/schema/validate", http.HandlerFunc(func(w http.ResponseWriter,
 		r *http.Request) {
@@ -491,29 +543,32 @@ func setupServer(closer *z.Closer) {
 		x.SetStatusWithErrors(w, x.ErrorInvalidRequest, errs)
 	}))
 
-	http.Handle("/admin/shutdown", allowedMethodsHandler(allowedMethods{http.MethodGet: true},
+	http.Handle("/admin/shutdown", audit.AuditRequestHttp(allowedMethodsHandler(
+		allowedMethods{http.MethodGet: true},
 		adminAuthHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			shutDownHandler(w, r, adminServer)
-		}))))
+		})))))
 
-	http.Handle("/admin/draining", allowedMethodsHandler(allowedMethods{
+	http.Handle("/admin/draining", audit.AuditRequestHttp(allowedMethodsHandler(allowedMethods{
 		http.MethodPut:  true,
 		http.MethodPost: true,
 	}, adminAuthHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		drainingHandler(w, r, adminServer)
-	}))))
+	})))))
 
-	http.Handle("/admin/export", allowedMethodsHandler(allowedMethods{http.MethodGet: true},
+	http.Handle("/admin/export", audit.AuditRequestHttp(allowedMethodsHandler(
+		allowedMethods{http.MethodGet: true},
 		adminAuthHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			exportHandler(w, r, adminServer)
-		}))))
+		})))))
 
-	http.Handle("/admin/config/cache_mb", allowedMethodsHandler(allowedMethods{
-		http.MethodGet: true,
-		http.MethodPut: true,
-	}, adminAuthHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		memoryLimitHandler(w, r, adminServer)
-	}))))
+	http.Handle("/admin/config/cache_mb", audit.AuditRequestHttp(allowedMethodsHandler(
+		allowedMethods{
+			http.MethodGet: true,
+			http.MethodPut: true,
+		}, adminAuthHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			memoryLimitHandler(w, r, adminServer)
+		})))))
 
 	addr := fmt.Sprintf("%s:%d", laddr, httpPort())
 	glog.Infof("Bringing up GraphQL HTTP API at %s/graphql", addr)
@@ -669,15 +724,38 @@ func run() {
 		return
 	}
 
+	x.Check(audit.InitAuditor(audit.GetAuditConf(Alpha.Conf, "alpha",
+		x.WorkerConfig.EncryptionKey)))
+	defer audit.Close()
+
+	if dir := Alpha.Conf.GetString("slow_query_dir"); dir != "" {
+		x.Check(edgraph.InitSlowQueryLog(&edgraph.SlowQueryConf{
+			Dir:        dir,
+			Threshold:  Alpha.Conf.GetDuration("slow_query_threshold"),
+			SampleRate: Alpha.Conf.GetFloat64("slow_query_sample_rate"),
+			MaxSizeMB:  Alpha.Conf.GetInt("slow_query_size_mb"),
+			MaxFiles:   Alpha.Conf.GetInt("slow_query_max_files"),
+		}))
+		defer edgraph.CloseSlowQueryLog()
+	}
+
 	setupCustomTokenizers()
 	x.Init()
 	x.Config.PortOffset = Alpha.Conf.GetInt("port_offset")
 	x.Config.QueryEdgeLimit = cast.ToUint64(Alpha.Conf.GetString("query_edge_limit"))
+	x.Config.QueryTimeout = Alpha.Conf.GetDuration("query_timeout")
+	x.Config.QueryMemoryLimit = Alpha.Conf.GetInt64("query_memory_limit_mb") << 20
 	x.Config.NormalizeNodeLimit = cast.ToInt(Alpha.Conf.GetString("normalize_node_limit"))
 	x.Config.MutationsNQuadLimit = cast.ToInt(Alpha.Conf.GetString("mutations_nquad_limit"))
 	x.Config.PollInterval = Alpha.Conf.GetDuration("graphql_poll_interval")
 	x.Config.GraphqlExtension = Alpha.Conf.GetBool("graphql_extensions")
 	x.Config.GraphqlDebug = Alpha.Conf.GetBool("graphql_debug")
+	x.Config.GraphqlTrustedDocuments = Alpha.Conf.GetBool("graphql_trusted_documents")
+	x.Config.GraphqlMaxDepth = Alpha.Conf.GetInt("graphql_max_depth")
+	x.Config.GraphqlMaxAliases = Alpha.Conf.GetInt("graphql_max_aliases")
+	x.Config.GraphqlMaxCost = Alpha.Conf.GetUint64("graphql_max_cost")
+	x.Config.GraphqlListSize = Alpha.Conf.GetUint64("graphql_list_size")
+	x.Config.GraphqlResponseCacheMb = Alpha.Conf.GetInt64("graphql_response_cache_mb")
 	x.Config.GraphqlLambdaUrl = Alpha.Conf.GetString("graphql_lambda_url")
 	if x.Config.GraphqlLambdaUrl != "" {
 		graphqlLambdaUrl, err := url.Parse(x.Config.GraphqlLambdaUrl)
@@ -715,8 +793,16 @@ func run() {
 	schema.Init(worker.State.Pstore)
 	posting.Init(worker.State.Pstore, postingListCacheSize)
 	defer posting.Cleanup()
+	x.Check(posting.SetRetention(Alpha.Conf.GetDuration("history_retention")))
 	worker.Init(worker.State.Pstore)
 
+	if cdcFile := Alpha.Conf.GetString("cdc_file"); cdcFile != "" {
+		sink, err := worker.NewFileSink(cdcFile)
+		x.Check(err)
+		x.Check(worker.InitCDC(sink, opts.PostingDir))
+		defer worker.CloseCDC()
+	}
+
 	// setup shutdown os signal handler
 	sdCh := make(chan os.Signal, 3)
 
@@ -755,7 +841,7 @@ func run() {
 		}
 	}()
 
-	updaters := z.NewCloser(4)
+	updaters := z.NewCloser(6)
 	go func() {
 		worker.StartRaftNodes(worker.State.WALstore, bindall)
 		atomic.AddUint32(&initDone, 1)
@@ -765,6 +851,10 @@ func run() {
 		edgraph.ResetAcl(updaters)
 		edgraph.RefreshAcls(updaters)
 		edgraph.ResetCors(updaters)
+		// Run the queued exports, backups and restores while this alpha leads group 1.
+		go edgraph.RunTasks(updaters)
+		// Write the usage of the trusted documents executed by this alpha.
+		go edgraph.FlushTrustedDocumentUsage(updaters)
 		// Update the accepted cors origins.
 		for updaters.Ctx().Err() == nil {
 			origins, err := edgraph.GetCorsOrigins(updaters.Ctx())
@@ -80,7 +80,7 @@ func processToFastJSON(q string) string {
 		log.Fatal(err)
 	}
 
-	buf, err := query.ToJson(&l, qr.Subgraphs)
+	buf, err := query.ToJson(ctx, &l, qr.Subgraphs)
 	if err != nil {
 		log.Fatal(err)
 	}
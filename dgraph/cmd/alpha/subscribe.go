@@ -0,0 +1,155 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package alpha
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/dgraph-io/dgo/v200/protos/api"
+	"github.com/dgraph-io/dgraph/graphql/subscription"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/query"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/golang/glog"
+	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
+)
+
+var (
+	dqlPollerOnce sync.Once
+	dqlPoller     *subscription.Poller
+)
+
+// getDQLPoller returns the poller shared by the subscriptions to DQL queries over gRPC and
+// WebSocket, so that the same queries are only polled once.
+func getDQLPoller() *subscription.Poller {
+	dqlPollerOnce.Do(func() {
+		dqlPoller = subscription.NewDQLPoller()
+	})
+	return dqlPoller
+}
+
+// subscribe subscribes to the DQL query of the request, and calls send with every response to
+// it until ctx is done, or the query fails.
+func subscribe(ctx context.Context, req *api.Request, send func(*api.Response) error) error {
+	poller := getDQLPoller()
+	sub, err := poller.AddDQLSubscriber(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer poller.TerminateSubscription(sub.BucketID, sub.SubscriptionID)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case update, ok := <-sub.UpdateCh:
+			if !ok {
+				return errors.New("Subscription was terminated")
+			}
+			switch u := update.(type) {
+			case error:
+				return u
+			case *api.Response:
+				if err := send(u); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+type subscriptionsServer struct{}
+
+// Subscribe implements pb.SubscriptionsServer.
+func (s *subscriptionsServer) Subscribe(req *api.Request,
+	stream pb.Subscriptions_SubscribeServer) error {
+	return subscribe(stream.Context(), req, stream.Send)
+}
+
+var subscriptionUpgrader = websocket.Upgrader{
+	// Like the other HTTP endpoints, the subscriptions are allowed from any origin.
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// subscriptionHandler serves the subscriptions to DQL queries over WebSocket. The client sends
+// the query as the first message, in the same JSON format as the body of /query. The server
+// then sends a message in the format of the response of /query every time the result of the
+// query changes, or a message with the errors before closing the connection.
+func subscriptionHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := x.AttachAccessJwt(r.Context(), r)
+	ctx = x.AttachRemoteIP(ctx, r)
+
+	conn, err := subscriptionUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// The upgrader already replied with the error.
+		glog.Errorf("Unable to upgrade the subscription connection: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	var params struct {
+		Query     string            `json:"query"`
+		Variables map[string]string `json:"variables"`
+	}
+	if err := conn.ReadJSON(&params); err != nil {
+		writeSubscriptionError(conn, x.ErrorInvalidRequest, err)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		// The client doesn't send anything else, so this returns once the connection is closed.
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				cancel()
+				return
+			}
+		}
+	}()
+
+	req := &api.Request{Query: params.Query, Vars: params.Variables}
+	err = subscribe(ctx, req, func(resp *api.Response) error {
+		e := query.Extensions{
+			Latency: resp.Latency,
+			Metrics: resp.Metrics,
+		}
+		return conn.WriteJSON(struct {
+			Data       json.RawMessage  `json:"data"`
+			Extensions query.Extensions `json:"extensions"`
+		}{resp.Json, e})
+	})
+	if err != nil && ctx.Err() == nil {
+		writeSubscriptionError(conn, x.ErrorInvalidRequest, err)
+	}
+}
+
+func writeSubscriptionError(conn *websocket.Conn, code string, err error) {
+	errs := x.GqlErrorList{{
+		Message:    err.Error(),
+		Extensions: map[string]interface{}{"code": code},
+	}}
+	if err := conn.WriteJSON(struct {
+		Errors x.GqlErrorList `json:"errors"`
+	}{errs}); err != nil {
+		glog.Errorf("Unable to write the subscription error: %v", err)
+	}
+}
@@ -0,0 +1,250 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package analytics builds the tool that runs graph algorithms over the edges of a predicate, and
+// writes their results back as new predicates. It submits an analytics task to the /admin
+// endpoint of an Alpha, and waits for the task to finish.
+package analytics
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// Analytics is the sub-command invoked when calling "dgraph analytics".
+var Analytics x.SubCommand
+
+func init() {
+	Analytics.Cmd = &cobra.Command{
+		Use:   "analytics",
+		Short: "Run graph algorithms over the edges of a predicate",
+		Long: `
+Runs graph algorithms over the edges of a uid predicate, and writes their results as new
+predicates of the nodes, which can then be queried and indexed like any other predicate.
+
+The algorithms and the predicates they write, named after --prefix, are:
+  pagerank  PageRank of the node, a float.                         <prefix>pagerank
+  wcc       Weakly connected component of the node, identified by   <prefix>component
+            its smallest uid, an int.
+  lpa       Community of the node found by label propagation,       <prefix>community
+            identified by the uid it started from, an int.
+  degree    Edges in and out of the node, ints, and its degree      <prefix>in_degree
+            centrality, a float.                                    <prefix>out_degree
+                                                                    <prefix>degree_centrality
+
+The algorithms run as a task of the cluster, submitted to the /admin endpoint of the Alpha. The
+task is run by the group serving the predicate, which reads its posting lists directly and
+writes the results through Raft. An index on a result predicate must be dropped before running
+it again, and added back once it's done.
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			x.Check(run(Analytics.Conf))
+		},
+	}
+	Analytics.EnvPrefix = "DGRAPH_ANALYTICS"
+
+	flag := Analytics.Cmd.Flags()
+	flag.String("alpha", "localhost:8080", "HTTP address of Dgraph Alpha.")
+	flag.String("pred", "", "Uid predicate whose edges the algorithms run over.")
+	flag.Uint64("namespace", x.GalaxyNamespace, "Namespace of the predicate.")
+	flag.String("algorithms", "pagerank",
+		"Comma separated list of the algorithms to run: pagerank, wcc, lpa and degree.")
+	flag.String("prefix", "",
+		"Prefix of the predicates the results are written to. Defaults to the predicate "+
+			"followed by a dot, e.g. follows.pagerank.")
+	flag.Int("iterations", 20,
+		"Maximum number of iterations of PageRank and label propagation.")
+	flag.Float64("damping", 0.85, "Damping factor of PageRank.")
+	flag.Float64("tolerance", 1e-6,
+		"PageRank stops once the ranks change by less than this in total.")
+	flag.String("user", "", "Username of a guardian, if login is required.")
+	flag.String("password", "", "Password of the user.")
+	flag.String("auth_token", "",
+		"The auth token passed to the Alpha in the X-Dgraph-AuthToken header.")
+	flag.Bool("wait", true, "Wait for the task to finish.")
+}
+
+// pollInterval is how often the status of the task is read.
+var pollInterval = time.Second
+
+// adminClient sends GraphQL requests to the /admin endpoint of an Alpha.
+type adminClient struct {
+	url    string
+	header http.Header
+}
+
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// do sends the query with the variables, and unmarshals the data of the response into out.
+func (c *adminClient) do(query string, variables map[string]interface{},
+	out interface{}) error {
+
+	b, err := json.Marshal(map[string]interface{}{"query": query, "variables": variables})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, c.url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header = c.header.Clone()
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var gqlResp graphQLResponse
+	if err := json.NewDecoder(resp.Body).Decode(&gqlResp); err != nil {
+		return errors.Wrapf(err, "while reading the response of %s, status %s", c.url,
+			resp.Status)
+	}
+	if len(gqlResp.Errors) > 0 {
+		var msgs []string
+		for _, e := range gqlResp.Errors {
+			msgs = append(msgs, e.Message)
+		}
+		return errors.New(strings.Join(msgs, "; "))
+	}
+	return json.Unmarshal(gqlResp.Data, out)
+}
+
+// login logs the user in, and sends the access JWT with the next requests.
+func (c *adminClient) login(user, password string) error {
+	var data struct {
+		Login struct {
+			Response struct {
+				AccessJWT string `json:"accessJWT"`
+			} `json:"response"`
+		} `json:"login"`
+	}
+	err := c.do(`mutation($user: String, $password: String) {
+		login(userId: $user, password: $password) { response { accessJWT } }
+	}`, map[string]interface{}{"user": user, "password": password}, &data)
+	if err != nil {
+		return errors.Wrapf(err, "while logging in")
+	}
+	c.header.Set("X-Dgraph-AccessToken", data.Login.Response.AccessJWT)
+	return nil
+}
+
+// task is the status of a task, as returned by the task query.
+type task struct {
+	Status   string   `json:"status"`
+	Progress float64  `json:"progress"`
+	Error    string   `json:"error"`
+	Output   []string `json:"output"`
+}
+
+func run(conf *viper.Viper) error {
+	pred := conf.GetString("pred")
+	if pred == "" {
+		return errors.Errorf("--pred must be set")
+	}
+	input := map[string]interface{}{
+		"predicate":  pred,
+		"namespace":  conf.GetUint64("namespace"),
+		"iterations": conf.GetInt("iterations"),
+		"damping":    conf.GetFloat64("damping"),
+		"tolerance":  conf.GetFloat64("tolerance"),
+	}
+	if prefix := conf.GetString("prefix"); prefix != "" {
+		input["prefix"] = prefix
+	}
+	var algorithms []string
+	for _, algo := range strings.Split(conf.GetString("algorithms"), ",") {
+		if algo = strings.TrimSpace(algo); algo != "" {
+			algorithms = append(algorithms, algo)
+		}
+	}
+	input["algorithms"] = algorithms
+
+	c := &adminClient{url: "http://" + conf.GetString("alpha") + "/admin", header: http.Header{}}
+	if token := conf.GetString("auth_token"); token != "" {
+		c.header.Set("X-Dgraph-AuthToken", token)
+	}
+	if user := conf.GetString("user"); user != "" {
+		if err := c.login(user, conf.GetString("password")); err != nil {
+			return err
+		}
+	}
+
+	var data struct {
+		RunAnalytics struct {
+			TaskId string `json:"taskId"`
+		} `json:"runAnalytics"`
+	}
+	err := c.do(`mutation($input: RunAnalyticsInput!) {
+		runAnalytics(input: $input) { taskId }
+	}`, map[string]interface{}{"input": input}, &data)
+	if err != nil {
+		return errors.Wrapf(err, "while submitting the analytics task")
+	}
+	id := data.RunAnalytics.TaskId
+	fmt.Printf("Submitted analytics task %s\n", id)
+	if !conf.GetBool("wait") {
+		return nil
+	}
+
+	t, err := waitForTask(c, id, pollInterval)
+	if err != nil {
+		return err
+	}
+	if t.Status != "Success" {
+		return errors.Errorf("analytics task %s failed: %s", id, t.Error)
+	}
+	fmt.Printf("Wrote %s\n", strings.Join(t.Output, ", "))
+	return nil
+}
+
+// waitForTask polls the status of the task with the given ID until it's over, and returns it.
+func waitForTask(c *adminClient, id string, interval time.Duration) (*task, error) {
+	for {
+		var data struct {
+			Task *task `json:"task"`
+		}
+		err := c.do(`query($id: String!) {
+			task(id: $id) { status progress error output }
+		}`, map[string]interface{}{"id": id}, &data)
+		switch {
+		case err != nil:
+			return nil, errors.Wrapf(err, "while reading the status of task %s", id)
+		case data.Task == nil:
+			return nil, errors.Errorf("task %s not found", id)
+		case data.Task.Status == "Success" || data.Task.Status == "Failed":
+			return data.Task, nil
+		}
+		fmt.Printf("Task %s is %s, %.0f%% done\n", id, strings.ToLower(data.Task.Status),
+			100*data.Task.Progress)
+		time.Sleep(interval)
+	}
+}
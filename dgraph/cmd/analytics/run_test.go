@@ -0,0 +1,118 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package analytics
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAdmin serves the requests of the tool to /admin, reporting the task as running on the
+// first poll and as done with the given status on the next ones.
+type fakeAdmin struct {
+	sync.Mutex
+	status string
+	input  map[string]interface{}
+	token  string
+	polls  int
+}
+
+func (f *fakeAdmin) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.Lock()
+	defer f.Unlock()
+	var req struct {
+		Query     string                 `json:"query"`
+		Variables map[string]interface{} `json:"variables"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	f.token = r.Header.Get("X-Dgraph-AuthToken")
+
+	var data interface{}
+	switch {
+	case strings.Contains(req.Query, "runAnalytics"):
+		f.input, _ = req.Variables["input"].(map[string]interface{})
+		data = map[string]interface{}{"runAnalytics": map[string]string{"taskId": "0x2a"}}
+	case strings.Contains(req.Query, "task(") && req.Variables["id"] == "0x2a":
+		t := map[string]interface{}{"status": "Running", "progress": 0.5}
+		if f.polls++; f.polls > 1 {
+			t = map[string]interface{}{"status": f.status, "progress": 1,
+				"output": []string{"follows.pagerank"}}
+			if f.status == "Failed" {
+				t["error"] = "predicate follows isn't a uid predicate"
+			}
+		}
+		data = map[string]interface{}{"task": t}
+	default:
+		data = map[string]interface{}{"task": nil}
+	}
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+}
+
+func newConf(alpha string) *viper.Viper {
+	conf := viper.New()
+	conf.Set("alpha", alpha)
+	conf.Set("pred", "follows")
+	conf.Set("algorithms", "pagerank, wcc")
+	conf.Set("iterations", 20)
+	conf.Set("damping", 0.85)
+	conf.Set("tolerance", 1e-6)
+	conf.Set("auth_token", "secret")
+	conf.Set("wait", true)
+	return conf
+}
+
+func TestRun(t *testing.T) {
+	pollInterval = 0
+	admin := &fakeAdmin{status: "Success"}
+	srv := httptest.NewServer(admin)
+	defer srv.Close()
+
+	require.NoError(t, run(newConf(strings.TrimPrefix(srv.URL, "http://"))))
+	require.Equal(t, map[string]interface{}{
+		"predicate":  "follows",
+		"namespace":  float64(0),
+		"algorithms": []interface{}{"pagerank", "wcc"},
+		"iterations": float64(20),
+		"damping":    0.85,
+		"tolerance":  1e-6,
+	}, admin.input)
+	require.Equal(t, "secret", admin.token)
+	require.Equal(t, 2, admin.polls)
+
+	admin.status, admin.polls = "Failed", 0
+	require.EqualError(t, run(newConf(strings.TrimPrefix(srv.URL, "http://"))),
+		"analytics task 0x2a failed: predicate follows isn't a uid predicate")
+}
+
+func TestWaitForMissingTask(t *testing.T) {
+	srv := httptest.NewServer(&fakeAdmin{})
+	defer srv.Close()
+
+	c := &adminClient{url: srv.URL + "/admin", header: http.Header{}}
+	_, err := waitForTask(c, "0x1", 0)
+	require.EqualError(t, err, "task 0x1 not found")
+}
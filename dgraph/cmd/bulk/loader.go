@@ -50,6 +50,7 @@ import (
 type options struct {
 	DataFiles        string
 	DataFormat       string
+	MappingFile      string
 	SchemaFile       string
 	GqlSchemaFile    string
 	OutDir           string
@@ -76,6 +77,7 @@ type options struct {
 	ReduceShards int
 
 	shardOutputDirs []string
+	columnMapping   *chunker.ColumnMapping
 
 	// ........... Badger options ..........
 	// EncryptionKey is the key used for encryption. Enterprise only feature.
@@ -199,21 +201,33 @@ func (ld *loader) mapStage() {
 	}
 	ld.xids = xidmap.New(ld.zero, db, filepath.Join(ld.opt.TmpDir, bufferDir))
 
-	files := x.FindDataFiles(ld.opt.DataFiles, []string{".rdf", ".rdf.gz", ".json", ".json.gz"})
+	files := x.FindDataFiles(ld.opt.DataFiles, []string{".rdf", ".rdf.gz", ".json", ".json.gz",
+		".csv", ".csv.gz", ".tsv", ".tsv.gz", ".parquet"})
 	if len(files) == 0 {
 		fmt.Printf("No data files found in %s.\n", ld.opt.DataFiles)
 		os.Exit(1)
 	}
 
 	// Because mappers must handle chunks that may be from different input files, they must all
-	// assume the same data format, either RDF or JSON. Use the one specified by the user or by
-	// the first load file.
+	// assume the same data format, either RDF, JSON, CSV, TSV or Parquet. Use the one specified
+	// by the user or by the first load file.
 	loadType := chunker.DataFormat(files[0], ld.opt.DataFormat)
 	if loadType == chunker.UnknownFormat {
 		// Dont't try to detect JSON input in bulk loader.
 		fmt.Printf("Need --format=rdf or --format=json to load %s", files[0])
 		os.Exit(1)
 	}
+	if loadType.IsTabular() {
+		if ld.opt.columnMapping == nil {
+			fmt.Printf("Need --mapping to load %s\n", files[0])
+			os.Exit(1)
+		}
+		if ld.opt.GqlSchemaFile != "" {
+			// The mappers parse all the chunks with the same format.
+			fmt.Printf("Can't load the GraphQL schema along with CSV, TSV or Parquet files\n")
+			os.Exit(1)
+		}
+	}
 
 	var mapperWg sync.WaitGroup
 	mapperWg.Add(len(ld.mappers))
@@ -240,7 +254,9 @@ func (ld *loader) mapStage() {
 			r, cleanup := chunker.FileReader(file, key)
 			defer cleanup()
 
-			chunk := chunker.NewChunker(loadType, 1000)
+			chunk, closeChunker := chunker.TabularFileChunker(file, loadType, 1000,
+				ld.opt.columnMapping)
+			defer closeChunker()
 			for {
 				chunkBuf, err := chunk.Chunk(r)
 				if chunkBuf != nil && chunkBuf.Len() > 0 {
@@ -220,7 +220,7 @@ func (m *mapper) writeMapEntriesToFile(cbuf *z.Buffer, shardIdx int) {
 }
 
 func (m *mapper) run(inputFormat chunker.InputFormat) {
-	chunk := chunker.NewChunker(inputFormat, 1000)
+	chunk := chunker.NewTabularChunker(inputFormat, 1000, m.opt.columnMapping)
 	nquads := chunk.NQuads()
 	go func() {
 		for chunkBuf := range m.readerChunkCh {
@@ -31,6 +31,7 @@ import (
 
 	"github.com/dgraph-io/dgraph/worker"
 
+	"github.com/dgraph-io/dgraph/chunker"
 	"github.com/dgraph-io/dgraph/ee/enc"
 	"github.com/dgraph-io/dgraph/tok"
 	"github.com/dgraph-io/dgraph/x"
@@ -55,12 +56,17 @@ func init() {
 
 	flag := Bulk.Cmd.Flags()
 	flag.StringP("files", "f", "",
-		"Location of *.rdf(.gz) or *.json(.gz) file(s) to load.")
+		"Location of *.rdf(.gz), *.json(.gz), *.csv(.gz), *.tsv(.gz) or *.parquet file(s) "+
+			"to load.")
 	flag.StringP("schema", "s", "",
 		"Location of schema file.")
 	flag.StringP("graphql_schema", "g", "", "Location of the GraphQL schema file.")
 	flag.String("format", "",
-		"Specify file format (rdf or json) instead of getting it from filename.")
+		"Specify file format (rdf, json, csv, tsv or parquet) instead of getting it from "+
+			"filename.")
+	flag.String("mapping", "",
+		"Location of the JSON file mapping the columns of CSV, TSV or Parquet files to "+
+			"predicates.")
 	flag.Bool("encrypted", false,
 		"Flag to indicate whether schema and data files are encrypted. "+
 			"Must be specified with --encryption_key_file or vault option(s).")
@@ -128,6 +134,7 @@ func run() {
 	opt := options{
 		DataFiles:        Bulk.Conf.GetString("files"),
 		DataFormat:       Bulk.Conf.GetString("format"),
+		MappingFile:      Bulk.Conf.GetString("mapping"),
 		SchemaFile:       Bulk.Conf.GetString("schema"),
 		GqlSchemaFile:    Bulk.Conf.GetString("graphql_schema"),
 		Encrypted:        Bulk.Conf.GetBool("encrypted"),
@@ -212,7 +219,7 @@ func run() {
 		os.Exit(1)
 	}
 	if opt.DataFiles == "" {
-		fmt.Fprint(os.Stderr, "RDF or JSON file(s) location must be specified.\n")
+		fmt.Fprint(os.Stderr, "Data file(s) location must be specified.\n")
 		os.Exit(1)
 	} else {
 		fileList := strings.Split(opt.DataFiles, ",")
@@ -224,6 +231,13 @@ func run() {
 		}
 	}
 
+	if opt.MappingFile != "" {
+		if opt.columnMapping, err = chunker.ReadColumnMapping(opt.MappingFile); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+	}
+
 	if opt.ReduceShards > opt.MapShards {
 		fmt.Fprintf(os.Stderr, "Invalid flags: reduce_shards(%d) should be <= map_shards(%d)\n",
 			opt.ReduceShards, opt.MapShards)
@@ -202,8 +202,12 @@ EOF
       1 dgraph.acl.rule
       1 dgraph.cors
       1 dgraph.drop.op
+      1 dgraph.graphql.p_client
+      1 dgraph.graphql.p_name
       1 dgraph.graphql.p_query
       1 dgraph.graphql.p_sha256hash
+      1 dgraph.graphql.p_usage
+      1 dgraph.graphql.p_version
       1 dgraph.graphql.schema
       1 dgraph.graphql.schema_created_at
       1 dgraph.graphql.schema_history
@@ -211,6 +215,7 @@ EOF
       1 dgraph.password
       1 dgraph.rule.permission
       1 dgraph.rule.predicate
+      1 dgraph.task
       1 dgraph.type
       1 dgraph.user.group
       1 dgraph.xid
@@ -43,6 +43,7 @@ import (
 	"github.com/dgraph-io/dgraph/xidmap"
 	"github.com/dgryski/go-farm"
 	"github.com/dustin/go-humanize/english"
+	"github.com/golang/glog"
 )
 
 // batchMutationOptions sets the clients batch mode to Pending number of buffers each of Size.
@@ -91,6 +92,11 @@ type loader struct {
 	schema   *schema
 
 	upsertLock sync.RWMutex
+
+	// checkpoints records the progress of the load, if --xidmap is set.
+	checkpoints *checkpoints
+	// rejects holds the mutations that failed permanently, if --rejects is set.
+	rejects *rejectsWriter
 }
 
 // Counter keeps a track of various parameters about a batch mutation. Running totals are printed
@@ -104,6 +110,8 @@ type Counter struct {
 	Aborts uint64
 	// Time elapsed since the batch started.
 	Elapsed time.Duration
+	// Number of N-Quads rejected.
+	Rejected uint64
 }
 
 // handleError inspects errors and terminates if the errors are non-recoverable.
@@ -150,6 +158,11 @@ func (l *loader) infinitelyRetry(req *request) {
 			}
 			atomic.AddUint64(&l.nquads, uint64(len(req.Set)))
 			atomic.AddUint64(&l.txns, 1)
+			l.done(req)
+			return
+		}
+		if l.rejects != nil && isPermanent(err) {
+			l.reject(req, err)
 			return
 		}
 		nretries++
@@ -180,6 +193,12 @@ func (l *loader) request(req *request) {
 		atomic.AddUint64(&l.nquads, uint64(len(req.Set)))
 		atomic.AddUint64(&l.txns, 1)
 		l.deregister(req)
+		l.done(req)
+		return
+	}
+	if l.rejects != nil && isPermanent(err) {
+		l.deregister(req)
+		l.reject(req, err)
 		return
 	}
 	handleError(err, false)
@@ -188,6 +207,44 @@ func (l *loader) request(req *request) {
 	go l.infinitelyRetry(req)
 }
 
+// done records that the request is over, either committed or rejected.
+func (l *loader) done(req *request) {
+	if req.progress != nil {
+		req.progress.requestDone(req.drain)
+	}
+}
+
+// reject writes the N-Quads of a request that failed permanently to the rejects file, instead
+// of retrying it.
+func (l *loader) reject(req *request, cause error) {
+	if opt.verbose {
+		fmt.Printf("Rejecting %d N-Quads: %v\n", len(req.Set), cause)
+	}
+	x.Checkf(l.rejects.reject(req.Mutation, cause), "Error while writing rejected N-Quads")
+	l.done(req)
+}
+
+// saveCheckpoints saves the progress of the load periodically, until stop is closed.
+func (l *loader) saveCheckpoints(stop <-chan struct{}) {
+	ticker := time.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+		l.checkpoints.snapshot()
+		if err := l.alloc.Sync(); err != nil {
+			glog.Errorf("Error while writing the xidmap: %v", err)
+			continue
+		}
+		if err := l.checkpoints.save(); err != nil {
+			glog.Errorf("Error while saving the checkpoints: %v", err)
+		}
+	}
+}
+
 func getTypeVal(val *api.Value) (types.Val, error) {
 	p := gql.TypeValFrom(val)
 	//Convert value to bytes
@@ -439,5 +496,6 @@ func (l *loader) Counter() Counter {
 		TxnsDone: atomic.LoadUint64(&l.txns),
 		Elapsed:  time.Since(l.start),
 		Aborts:   atomic.LoadUint64(&l.aborts),
+		Rejected: l.rejects.numRejected(),
 	}
 }
@@ -0,0 +1,231 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package live
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// checkpointFile is the name of the file, in the --xidmap directory, that holds the progress of
+// the load of each data file. With --resume, the chunks of the files that were committed are
+// skipped, so that an interrupted load can carry on where it stopped.
+const checkpointFile = "live_checkpoints.json"
+
+// fileCheckpoint is the progress of the load of a data file. The first Chunks chunks of the file,
+// which end at byte Offset of the uncompressed data, have been committed.
+type fileCheckpoint struct {
+	Chunks int64 `json:"chunks"`
+	Offset int64 `json:"offset"`
+	Done   bool  `json:"done"`
+}
+
+type checkpoints struct {
+	sync.Mutex
+	path  string
+	Files map[string]fileCheckpoint `json:"files"`
+	// progress holds the files being loaded.
+	progress map[string]*fileProgress
+}
+
+// newCheckpoints returns empty checkpoints, stored in dir.
+func newCheckpoints(dir string) *checkpoints {
+	return &checkpoints{
+		path:     filepath.Join(dir, checkpointFile),
+		Files:    make(map[string]fileCheckpoint),
+		progress: make(map[string]*fileProgress),
+	}
+}
+
+// readCheckpoints reads the checkpoints stored in dir, if any.
+func readCheckpoints(dir string) (*checkpoints, error) {
+	c := newCheckpoints(dir)
+	b, err := ioutil.ReadFile(c.path)
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		return nil, errors.Wrapf(err, "while reading checkpoints")
+	default:
+		if err := json.Unmarshal(b, c); err != nil {
+			return nil, errors.Wrapf(err, "while parsing checkpoints in %s", c.path)
+		}
+	}
+	if c.Files == nil {
+		c.Files = make(map[string]fileCheckpoint)
+	}
+	return c, nil
+}
+
+// track records the progress of the load of file in the checkpoints taken from now on.
+func (c *checkpoints) track(file string, p *fileProgress) {
+	c.Lock()
+	defer c.Unlock()
+	c.progress[file] = p
+}
+
+// snapshot takes the committed progress of the files being loaded. The blank nodes of the
+// committed N-Quads must be written to the xidmap before the checkpoints are saved, or they would
+// be assigned new uids when the load resumes.
+func (c *checkpoints) snapshot() {
+	c.Lock()
+	defer c.Unlock()
+	for file, p := range c.progress {
+		c.Files[file] = p.checkpoint()
+	}
+}
+
+func (c *checkpoints) get(file string) fileCheckpoint {
+	c.Lock()
+	defer c.Unlock()
+	return c.Files[file]
+}
+
+// save writes the checkpoints to a temporary file first, so that they are never left half
+// written.
+func (c *checkpoints) save() error {
+	c.Lock()
+	b, err := json.MarshalIndent(c, "", "  ")
+	c.Unlock()
+	if err != nil {
+		return err
+	}
+	tmp := c.path + ".tmp"
+	if err := ioutil.WriteFile(tmp, b, 0600); err != nil {
+		return errors.Wrapf(err, "while writing checkpoints")
+	}
+	return os.Rename(tmp, c.path)
+}
+
+// countingReader counts the bytes read from r.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.n += int64(n)
+	return n, err
+}
+
+// chunkEnd records where a chunk of a file ends, both in bytes and in N-Quads.
+type chunkEnd struct {
+	chunks int64
+	offset int64
+	nquads uint64
+}
+
+// drainBatch is a set of requests sent together, which hold the N-Quads of a file up to end.
+type drainBatch struct {
+	end     uint64
+	pending int
+}
+
+// fileProgress tracks which chunks of a data file have been committed. The N-Quads of the file
+// are numbered in the order they are parsed. The requests sent by a drain hold all the N-Quads
+// up to a number, in any order. Once the requests of a drain and of all the drains before it are
+// done, the file is committed up to the last chunk whose N-Quads are all below that number.
+type fileProgress struct {
+	sync.Mutex
+	// rd reads the data of the file through cr, so the offset of the next chunk is the number
+	// of bytes read minus the ones buffered.
+	cr *countingReader
+	rd *bufio.Reader
+
+	chunks []chunkEnd
+	drains []*drainBatch
+	parsed bool
+	// nquads is the number of N-Quads committed so far.
+	nquads    uint64
+	committed fileCheckpoint
+}
+
+func newFileProgress(r io.Reader, start fileCheckpoint) *fileProgress {
+	cr := &countingReader{r: r}
+	return &fileProgress{cr: cr, rd: bufio.NewReader(cr), committed: start}
+}
+
+func (p *fileProgress) offset() int64 {
+	return p.cr.n - int64(p.rd.Buffered())
+}
+
+// chunkParsed records that chunks chunks of the file have been parsed into nquads N-Quads.
+func (p *fileProgress) chunkParsed(chunks int64, nquads uint64) {
+	p.Lock()
+	defer p.Unlock()
+	p.chunks = append(p.chunks, chunkEnd{chunks: chunks, offset: p.offset(), nquads: nquads})
+	p.advance()
+}
+
+// addDrain records that the N-Quads up to end are sent in numReqs requests.
+func (p *fileProgress) addDrain(end uint64, numReqs int) *drainBatch {
+	p.Lock()
+	defer p.Unlock()
+	d := &drainBatch{end: end, pending: numReqs}
+	p.drains = append(p.drains, d)
+	return d
+}
+
+// requestDone records that a request of the drain was committed or rejected.
+func (p *fileProgress) requestDone(d *drainBatch) {
+	p.Lock()
+	defer p.Unlock()
+	d.pending--
+	p.advance()
+}
+
+// finishParsing records that all the chunks of the file have been parsed and sent.
+func (p *fileProgress) finishParsing() {
+	p.Lock()
+	defer p.Unlock()
+	p.parsed = true
+	p.advance()
+}
+
+// advance moves the committed checkpoint forward. It must be called with the lock held.
+func (p *fileProgress) advance() {
+	for len(p.drains) > 0 && p.drains[0].pending == 0 {
+		p.nquads = p.drains[0].end
+		p.drains = p.drains[1:]
+	}
+	if p.parsed && len(p.drains) == 0 {
+		if len(p.chunks) > 0 {
+			last := p.chunks[len(p.chunks)-1]
+			p.committed.Chunks, p.committed.Offset = last.chunks, last.offset
+		}
+		p.chunks = nil
+		p.committed.Done = true
+		return
+	}
+	for len(p.chunks) > 0 && p.chunks[0].nquads <= p.nquads {
+		p.committed.Chunks, p.committed.Offset = p.chunks[0].chunks, p.chunks[0].offset
+		p.chunks = p.chunks[1:]
+	}
+}
+
+func (p *fileProgress) checkpoint() fileCheckpoint {
+	p.Lock()
+	defer p.Unlock()
+	return p.committed
+}
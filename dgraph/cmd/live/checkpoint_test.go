@@ -0,0 +1,87 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package live
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileProgress(t *testing.T) {
+	p := newFileProgress(strings.NewReader("line 1\nline 2\nline 3\n"), fileCheckpoint{})
+	readLine := func() {
+		_, err := p.rd.ReadString('\n')
+		require.NoError(t, err)
+	}
+
+	// Chunks 1 and 2 hold N-Quads 1 to 3 and 4 to 5, sent in two drains.
+	readLine()
+	p.chunkParsed(1, 3)
+	readLine()
+	p.chunkParsed(2, 5)
+	d1 := p.addDrain(4, 2)
+	d2 := p.addDrain(5, 1)
+
+	// The second drain is done first, but the first one isn't.
+	p.requestDone(d2)
+	p.requestDone(d1)
+	require.Equal(t, fileCheckpoint{}, p.checkpoint())
+	p.requestDone(d1)
+	require.Equal(t, fileCheckpoint{Chunks: 2, Offset: 14}, p.checkpoint())
+
+	// The last chunk has no N-Quads.
+	readLine()
+	p.chunkParsed(3, 5)
+	p.finishParsing()
+	require.Equal(t, fileCheckpoint{Chunks: 3, Offset: 21, Done: true}, p.checkpoint())
+}
+
+func TestFileProgressPartial(t *testing.T) {
+	p := newFileProgress(strings.NewReader(""), fileCheckpoint{Chunks: 4, Offset: 100})
+	p.chunkParsed(5, 10)
+	p.chunkParsed(6, 20)
+	d := p.addDrain(15, 1)
+	p.requestDone(d)
+	// Only the first chunk is fully committed.
+	require.Equal(t, fileCheckpoint{Chunks: 5, Offset: 0}, p.checkpoint())
+}
+
+func TestCheckpoints(t *testing.T) {
+	dir, err := ioutil.TempDir("", "checkpoints")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	c, err := readCheckpoints(dir)
+	require.NoError(t, err)
+	require.Equal(t, fileCheckpoint{}, c.get("a.rdf"))
+
+	p := newFileProgress(strings.NewReader(""), fileCheckpoint{Chunks: 2, Offset: 30})
+	c.track("a.rdf", p)
+	c.snapshot()
+	require.NoError(t, c.save())
+
+	c, err = readCheckpoints(dir)
+	require.NoError(t, err)
+	require.Equal(t, fileCheckpoint{Chunks: 2, Offset: 30}, c.get("a.rdf"))
+
+	// Without --resume, the previous checkpoints are ignored.
+	require.Equal(t, fileCheckpoint{}, newCheckpoints(dir).get("a.rdf"))
+}
@@ -0,0 +1,187 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package live
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/dgraph-io/dgo/v200"
+	"github.com/dgraph-io/dgo/v200/protos/api"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dgraph-io/dgraph/dgraph/cmd/zero"
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/types/facets"
+)
+
+// rdfTypes are the RDF types written for the values of the rejected N-Quads. Values of other
+// types are written without type.
+var rdfTypes = map[types.TypeID]string{
+	types.StringID:   "xs:string",
+	types.DateTimeID: "xs:dateTime",
+	types.IntID:      "xs:int",
+	types.FloatID:    "xs:float",
+	types.BoolID:     "xs:boolean",
+	types.GeoID:      "geo:geojson",
+	types.BinaryID:   "xs:base64Binary",
+	types.PasswordID: "xs:password",
+}
+
+// isPermanent tells if a request that failed with err would fail again if retried, e.g. because
+// a value doesn't match the type of its predicate.
+func isPermanent(err error) bool {
+	if err == zero.ErrConflict || err == dgo.ErrAborted {
+		return false
+	}
+	s := status.Convert(err)
+	switch s.Code() {
+	case codes.Internal, codes.Unavailable, codes.Aborted, codes.DeadlineExceeded,
+		codes.ResourceExhausted:
+		return false
+	}
+	return !strings.Contains(s.Message(), "Server overloaded.")
+}
+
+// rejectsWriter writes the N-Quads of the requests that failed permanently to a file in RDF,
+// so that they can be loaded again once the cause of the failure is fixed. The uids assigned to
+// the blank nodes are written instead of the blank nodes.
+type rejectsWriter struct {
+	sync.Mutex
+	f      *os.File
+	w      *bufio.Writer
+	nquads uint64
+}
+
+func newRejectsWriter(path string, appendTo bool) (*rejectsWriter, error) {
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if appendTo {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_APPEND
+	}
+	f, err := os.OpenFile(path, flags, 0600)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while opening rejects file")
+	}
+	return &rejectsWriter{f: f, w: bufio.NewWriter(f)}, nil
+}
+
+// reject writes the N-Quads of the mutation, after a comment holding the error.
+func (rw *rejectsWriter) reject(mu *api.Mutation, cause error) error {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %s\n", strings.Replace(cause.Error(), "\n", " ", -1))
+	for _, nq := range mu.Set {
+		line, err := rdfLine(nq)
+		if err != nil {
+			return err
+		}
+		sb.WriteString(line)
+	}
+
+	rw.Lock()
+	defer rw.Unlock()
+	rw.nquads += uint64(len(mu.Set))
+	if _, err := rw.w.WriteString(sb.String()); err != nil {
+		return err
+	}
+	// Flush right away, so that the rejected N-Quads aren't lost if the load is interrupted.
+	return rw.w.Flush()
+}
+
+// numRejected returns the number of N-Quads rejected so far. It's safe to call on a nil writer.
+func (rw *rejectsWriter) numRejected() uint64 {
+	if rw == nil {
+		return 0
+	}
+	rw.Lock()
+	defer rw.Unlock()
+	return rw.nquads
+}
+
+func (rw *rejectsWriter) Close() error {
+	rw.Lock()
+	defer rw.Unlock()
+	if err := rw.w.Flush(); err != nil {
+		return err
+	}
+	return rw.f.Close()
+}
+
+func escapedString(str string) string {
+	b, err := json.Marshal(str)
+	if err != nil {
+		// All strings can be marshalled.
+		panic(err)
+	}
+	return string(b)
+}
+
+// rdfLine returns the N-Quad in RDF. The subject and the object ids must be uids.
+func rdfLine(nq *api.NQuad) (string, error) {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "<%s> <%s> ", nq.Subject, nq.Predicate)
+	if nq.ObjectValue == nil {
+		fmt.Fprintf(&sb, "<%s>", nq.ObjectId)
+	} else {
+		val, err := getTypeVal(nq.ObjectValue)
+		if err != nil {
+			return "", err
+		}
+		str, err := types.Convert(val, types.StringID)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(escapedString(str.Value.(string)))
+		if nq.Lang != "" {
+			sb.WriteString("@" + nq.Lang)
+		} else if typ, ok := rdfTypes[val.Tid]; ok {
+			sb.WriteString("^^<" + typ + ">")
+		}
+	}
+
+	if len(nq.Facets) > 0 {
+		sb.WriteString(" (")
+		for i, f := range nq.Facets {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			val, err := facets.ValFor(f)
+			if err != nil {
+				return "", err
+			}
+			str := types.ValueForType(types.StringID)
+			if err := types.Marshal(val, &str); err != nil {
+				return "", err
+			}
+			// Values of other types are written unquoted, so that they are parsed back
+			// with the same type.
+			s := str.Value.(string)
+			if val.Tid == types.StringID {
+				s = escapedString(s)
+			}
+			sb.WriteString(f.Key + "=" + s)
+		}
+		sb.WriteString(")")
+	}
+	sb.WriteString(" .\n")
+	return sb.String(), nil
+}
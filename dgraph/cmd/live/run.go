@@ -17,7 +17,6 @@
 package live
 
 import (
-	"bufio"
 	"compress/gzip"
 	"context"
 	"crypto/tls"
@@ -45,6 +44,7 @@ import (
 	"github.com/dgraph-io/dgo/v200/protos/api"
 	"github.com/dgraph-io/ristretto/z"
 	"github.com/dgryski/go-farm"
+	"github.com/dustin/go-humanize"
 
 	"github.com/dgraph-io/dgraph/chunker"
 	"github.com/dgraph-io/dgraph/ee/enc"
@@ -61,6 +61,8 @@ import (
 type options struct {
 	dataFiles       string
 	dataFormat      string
+	mappingFile     string
+	mapping         *chunker.ColumnMapping
 	schemaFile      string
 	zero            string
 	concurrent      int
@@ -75,6 +77,8 @@ type options struct {
 	ludicrousMode   bool
 	upsertPredicate string
 	tmpDir          string
+	resume          bool
+	rejectsFile     string
 	key             x.SensitiveByteSlice
 }
 
@@ -100,6 +104,11 @@ type schema struct {
 type request struct {
 	*api.Mutation
 	conflicts []uint64
+
+	// progress tracks the file the N-Quads come from, and drain the batch of requests this
+	// one was sent with.
+	progress *fileProgress
+	drain    *drainBatch
 }
 
 func (l *schema) init() {
@@ -133,10 +142,13 @@ func init() {
 	Live.EnvPrefix = "DGRAPH_LIVE"
 
 	flag := Live.Cmd.Flags()
-	flag.StringP("files", "f", "", "Location of *.rdf(.gz) or *.json(.gz) file(s) to load")
+	flag.StringP("files", "f", "", "Location of *.rdf(.gz), *.json(.gz), *.csv(.gz), "+
+		"*.tsv(.gz) or *.parquet file(s) to load")
 	flag.StringP("schema", "s", "", "Location of schema file")
-	flag.String("format", "", "Specify file format (rdf or json) instead of getting it "+
-		"from filename")
+	flag.String("format", "", "Specify file format (rdf, json, csv, tsv or parquet) instead "+
+		"of getting it from filename")
+	flag.String("mapping", "", "Location of the JSON file mapping the columns of CSV, TSV "+
+		"or Parquet files to predicates")
 	flag.StringP("alpha", "a", "127.0.0.1:9080",
 		"Comma-separated list of Dgraph alpha gRPC server addresses")
 	flag.StringP("zero", "z", "127.0.0.1:5080", "Dgraph zero gRPC server address")
@@ -164,6 +176,11 @@ func init() {
 	flag.StringP("upsertPredicate", "U", "", "run in upsertPredicate mode. the value would "+
 		"be used to store blank nodes as an xid")
 	flag.String("tmp", "t", "Directory to store temporary buffers.")
+	flag.Bool("resume", false, "Resume an interrupted load, skipping the chunks of the data "+
+		"files that were already committed. Requires --xidmap, where the progress is recorded.")
+	flag.String("rejects", "", "File to write the N-Quads of the mutations that fail with "+
+		"non-retryable errors to, in RDF, instead of retrying them. The whole mutation is "+
+		"written, including the N-Quads that are valid.")
 
 	// Encryption and Vault options
 	enc.RegisterFlags(flag)
@@ -404,6 +421,14 @@ func (l *loader) allocateUids(nqs []*api.NQuad) {
 
 // processFile forwards a file to the RDF or JSON processor as appropriate
 func (l *loader) processFile(ctx context.Context, filename string, key x.SensitiveByteSlice) error {
+	var start fileCheckpoint
+	if l.checkpoints != nil {
+		start = l.checkpoints.get(filename)
+	}
+	if start.Done {
+		fmt.Printf("Skipping data file %q, it was already loaded\n", filename)
+		return nil
+	}
 	fmt.Printf("Processing data file %q\n", filename)
 
 	rd, cleanup := chunker.FileReader(filename, key)
@@ -419,11 +444,58 @@ func (l *loader) processFile(ctx context.Context, filename string, key x.Sensiti
 			}
 		}
 	}
+	if loadType.IsTabular() && opt.mapping == nil {
+		return errors.Errorf("need --mapping to load %s", filename)
+	}
 
-	return l.processLoadFile(ctx, rd, chunker.NewChunker(loadType, opt.batchSize))
+	ck, closeChunker := chunker.TabularFileChunker(filename, loadType, opt.batchSize, opt.mapping)
+	defer closeChunker()
+	p := newFileProgress(rd, start)
+	if l.checkpoints != nil {
+		l.checkpoints.track(filename, p)
+	}
+	return l.processLoadFile(ctx, p, ck)
 }
 
-func (l *loader) processLoadFile(ctx context.Context, rd *bufio.Reader, ck chunker.Chunker) error {
+// skipChunks skips the chunks of the file that were committed by a previous load, and tells if
+// they were the last ones. The chunks are read, so that the chunker keeps its state, e.g. the
+// header of a CSV file, but not parsed.
+func skipChunks(p *fileProgress, ck chunker.Chunker) (bool, error) {
+	start := p.checkpoint()
+	var eof bool
+	for i := int64(0); i < start.Chunks; i++ {
+		if eof {
+			return false, errors.Errorf("file has fewer chunks than the %d already loaded",
+				start.Chunks)
+		}
+		_, err := ck.Chunk(p.rd)
+		switch {
+		case err == io.EOF:
+			eof = true
+		case err != nil:
+			return false, err
+		}
+	}
+	if p.offset() != start.Offset {
+		return false, errors.Errorf("file has changed since it was partially loaded, the loaded"+
+			" chunks ended at byte %d instead of %d", p.offset(), start.Offset)
+	}
+	if start.Chunks > 0 {
+		fmt.Printf("Skipped %d chunks already loaded (%s)\n", start.Chunks,
+			humanize.IBytes(uint64(start.Offset)))
+	}
+	return eof, nil
+}
+
+func (l *loader) processLoadFile(ctx context.Context, p *fileProgress, ck chunker.Chunker) error {
+	if eof, err := skipChunks(p, ck); err != nil {
+		return err
+	} else if eof {
+		p.finishParsing()
+		return nil
+	}
+	chunks := p.checkpoint().Chunks
+
 	var wg sync.WaitGroup
 	wg.Add(1)
 	nqbuf := ck.NQuads()
@@ -431,8 +503,13 @@ func (l *loader) processLoadFile(ctx context.Context, rd *bufio.Reader, ck chunk
 	go func() {
 		defer wg.Done()
 		buffer := make([]*api.NQuad, 0, opt.bufferSize*opt.batchSize)
+		// The number of N-Quads received so far.
+		var received uint64
 
 		drain := func() {
+			if len(buffer) == 0 {
+				return
+			}
 			// We collect opt.bufferSize requests and preprocess them. For the requests
 			// to not confict between themself, we sort them on the basis of their predicates.
 			// Predicates with count index will conflict among themselves, so we keep them at
@@ -454,12 +531,14 @@ func (l *loader) processLoadFile(ctx context.Context, rd *bufio.Reader, ck chunk
 				}
 				return buffer[i].Predicate < buffer[j].Predicate
 			})
+			numReqs := (len(buffer) + opt.batchSize - 1) / opt.batchSize
+			d := p.addDrain(received, numReqs)
 			for len(buffer) > 0 {
 				sz := opt.batchSize
 				if len(buffer) < opt.batchSize {
 					sz = len(buffer)
 				}
-				mu := &request{Mutation: &api.Mutation{Set: buffer[:sz]}}
+				mu := &request{Mutation: &api.Mutation{Set: buffer[:sz]}, progress: p, drain: d}
 				l.reqs <- mu
 				buffer = buffer[sz:]
 			}
@@ -469,6 +548,7 @@ func (l *loader) processLoadFile(ctx context.Context, rd *bufio.Reader, ck chunk
 			if len(nqs) == 0 {
 				continue
 			}
+			received += uint64(len(nqs))
 
 			if opt.upsertPredicate == "" {
 				l.allocateUids(nqs)
@@ -500,13 +580,15 @@ func (l *loader) processLoadFile(ctx context.Context, rd *bufio.Reader, ck chunk
 		default:
 		}
 
-		chunkBuf, err := ck.Chunk(rd)
+		chunkBuf, err := ck.Chunk(p.rd)
 		// Parses the rdf entries from the chunk, groups them into batches (each one
 		// containing opt.batchSize entries) and sends the batches to the loader.reqs channel (see
 		// above).
 		if oerr := ck.Parse(chunkBuf); oerr != nil {
 			return errors.Wrap(oerr, "During parsing chunk in processLoadFile")
 		}
+		chunks++
+		p.chunkParsed(chunks, nqbuf.NumPushed())
 		if err == io.EOF {
 			break
 		} else {
@@ -515,6 +597,7 @@ func (l *loader) processLoadFile(ctx context.Context, rd *bufio.Reader, ck chunk
 	}
 	nqbuf.Flush()
 	wg.Wait()
+	p.finishParsing()
 
 	return nil
 }
@@ -589,6 +672,7 @@ func run() error {
 	opt = options{
 		dataFiles:       Live.Conf.GetString("files"),
 		dataFormat:      Live.Conf.GetString("format"),
+		mappingFile:     Live.Conf.GetString("mapping"),
 		schemaFile:      Live.Conf.GetString("schema"),
 		zero:            zero,
 		concurrent:      Live.Conf.GetInt("conc"),
@@ -603,10 +687,23 @@ func run() error {
 		ludicrousMode:   Live.Conf.GetBool("ludicrous_mode"),
 		upsertPredicate: Live.Conf.GetString("upsertPredicate"),
 		tmpDir:          Live.Conf.GetString("tmp"),
+		resume:          Live.Conf.GetBool("resume"),
+		rejectsFile:     Live.Conf.GetString("rejects"),
 	}
 
 	z.SetTmpDir(opt.tmpDir)
 
+	if opt.mappingFile != "" {
+		if opt.mapping, err = chunker.ReadColumnMapping(opt.mappingFile); err != nil {
+			fmt.Printf("Error while reading mapping file %q: %s\n", opt.mappingFile, err)
+			return err
+		}
+	}
+
+	if opt.resume && opt.clientDir == "" {
+		return errors.New("--resume requires --xidmap, where the progress of the load is stored")
+	}
+
 	if opt.key, err = enc.ReadKey(Live.Conf); err != nil {
 		fmt.Printf("unable to read key %v", err)
 		return err
@@ -635,6 +732,25 @@ func run() error {
 	l := setup(bmOpts, dg, Live.Conf)
 	defer l.zeroconn.Close()
 
+	if len(opt.clientDir) > 0 {
+		if opt.resume {
+			l.checkpoints, err = readCheckpoints(opt.clientDir)
+		} else {
+			l.checkpoints = newCheckpoints(opt.clientDir)
+		}
+		if err != nil {
+			fmt.Printf("Error while reading the checkpoints: %s\n", err)
+			return err
+		}
+	}
+	if len(opt.rejectsFile) > 0 {
+		if l.rejects, err = newRejectsWriter(opt.rejectsFile, opt.resume); err != nil {
+			fmt.Printf("Error while opening the rejects file: %s\n", err)
+			return err
+		}
+		defer l.rejects.Close()
+	}
+
 	if len(opt.schemaFile) > 0 {
 		err := processSchemaFile(ctx, opt.schemaFile, opt.key, dg)
 		if err != nil {
@@ -655,10 +771,11 @@ func run() error {
 	}
 
 	if opt.dataFiles == "" {
-		return errors.New("RDF or JSON file(s) location must be specified")
+		return errors.New("Data file(s) location must be specified")
 	}
 
-	filesList := x.FindDataFiles(opt.dataFiles, []string{".rdf", ".rdf.gz", ".json", ".json.gz"})
+	filesList := x.FindDataFiles(opt.dataFiles, []string{".rdf", ".rdf.gz", ".json", ".json.gz",
+		".csv", ".csv.gz", ".tsv", ".tsv.gz", ".parquet"})
 	totalFiles := len(filesList)
 	if totalFiles == 0 {
 		return errors.Errorf("No data files found in %s", opt.dataFiles)
@@ -678,6 +795,10 @@ func run() error {
 	if bmOpts.PrintCounters {
 		go l.printCounters()
 	}
+	stopCheckpoints := make(chan struct{})
+	if l.checkpoints != nil {
+		go l.saveCheckpoints(stopCheckpoints)
+	}
 
 	for i := 0; i < totalFiles; i++ {
 		if err := <-errCh; err != nil {
@@ -692,6 +813,7 @@ func run() error {
 	// be sure that all retry requests have been added to the waitgroup.
 	l.requestsWg.Wait()
 	l.retryRequestsWg.Wait()
+	close(stopCheckpoints)
 	c := l.Counter()
 	var rate uint64
 	if c.Elapsed.Seconds() < 1 {
@@ -706,10 +828,21 @@ func run() error {
 	fmt.Printf("Number of N-Quads processed  : %d\n", c.Nquads)
 	fmt.Printf("Time spent                   : %v\n", c.Elapsed)
 	fmt.Printf("N-Quads processed per second : %d\n", rate)
+	if l.rejects != nil {
+		fmt.Printf("Number of N-Quads rejected   : %d (written to %s)\n", c.Rejected,
+			opt.rejectsFile)
+	}
 
 	if err := l.alloc.Flush(); err != nil {
 		return err
 	}
+	// The mappings are flushed, so the checkpoints can cover all the loaded data.
+	if l.checkpoints != nil {
+		l.checkpoints.snapshot()
+		if err := l.checkpoints.save(); err != nil {
+			return err
+		}
+	}
 	if l.db != nil {
 		if err := l.db.Close(); err != nil {
 			return err
@@ -23,6 +23,7 @@ const (
 	floatType
 	doubleType
 	datetimeType
+	boolType
 	uidType // foreign key reference, which would corrspond to uid type in Dgraph
 )
 
@@ -40,6 +41,7 @@ func initDataTypes() {
 	typeToString[floatType] = "float"
 	typeToString[doubleType] = "double"
 	typeToString[datetimeType] = "datetime"
+	typeToString[boolType] = "bool"
 	typeToString[uidType] = "uid"
 
 	sqlTypeToInternal = make(map[string]dataType)
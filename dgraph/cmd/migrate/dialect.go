@@ -0,0 +1,114 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package migrate
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	_ "github.com/go-sql-driver/mysql" // MySQL driver
+	"github.com/pkg/errors"
+)
+
+// A dialect captures what differs between the SQL databases that can be migrated: how to
+// connect, how to read the tables, columns, indices and foreign keys from the catalog, and how
+// the column types map to the Dgraph types. The rest of the migration, from the table guides to
+// the conversion of foreign keys to edges, is shared.
+type dialect interface {
+	// open returns a pool of connections to the database.
+	open(host, port, user, password, db string) (*sql.DB, error)
+	// defaultPort is the port the database server listens to by default.
+	defaultPort() string
+
+	// tablesQuery returns the query listing the names of the tables in the database.
+	tablesQuery(db string) (string, []interface{})
+	// columnsQuery returns the query listing the name and the type of each column of a
+	// table, ordered by name.
+	columnsQuery(db, table string) (string, []interface{})
+	// indicesQuery returns the query listing the index name and the column name of each
+	// column covered by an index of a table. The index name of the primary key is PRIMARY.
+	indicesQuery(db, table string) (string, []interface{})
+	// foreignKeysQuery returns the query listing the column name, the constraint name, the
+	// referenced table and the referenced column of each column of a foreign key of a table.
+	foreignKeysQuery(db, table string) (string, []interface{})
+
+	// columnType returns the Dgraph type of the values of a column of the given type, and
+	// whether the column holds lists of such values.
+	columnType(dbType string) (dataType, bool)
+	// splitList returns the values in a list column, as they are written in RDF.
+	splitList(value []byte, typ dataType) ([]string, error)
+	// quote quotes an identifier, e.g. the name of a table.
+	quote(ident string) string
+}
+
+func getDialect(name string, sslMode string) (dialect, error) {
+	switch strings.ToLower(name) {
+	case "mysql":
+		return mysqlDialect{}, nil
+	case "postgres", "postgresql":
+		return postgresDialect{sslMode: sslMode}, nil
+	default:
+		return nil, errors.Errorf("unsupported dialect %q, it must be mysql or postgres", name)
+	}
+}
+
+type mysqlDialect struct{}
+
+func (mysqlDialect) open(host, port, user, password, db string) (*sql.DB, error) {
+	return sql.Open("mysql",
+		fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true", user, password, host, port, db))
+}
+
+func (mysqlDialect) defaultPort() string {
+	return "3306"
+}
+
+func (mysqlDialect) tablesQuery(db string) (string, []interface{}) {
+	return "show tables", nil
+}
+
+func (mysqlDialect) columnsQuery(db, table string) (string, []interface{}) {
+	return `select COLUMN_NAME,DATA_TYPE from INFORMATION_SCHEMA.COLUMNS
+		where TABLE_NAME = ? AND TABLE_SCHEMA = ? ORDER BY COLUMN_NAME`,
+		[]interface{}{table, db}
+}
+
+func (mysqlDialect) indicesQuery(db, table string) (string, []interface{}) {
+	return `select INDEX_NAME,COLUMN_NAME from INFORMATION_SCHEMA.STATISTICS
+		where TABLE_NAME = ? AND INDEX_SCHEMA = ?`,
+		[]interface{}{table, db}
+}
+
+func (mysqlDialect) foreignKeysQuery(db, table string) (string, []interface{}) {
+	return `select COLUMN_NAME,CONSTRAINT_NAME,REFERENCED_TABLE_NAME,REFERENCED_COLUMN_NAME
+		from INFORMATION_SCHEMA.KEY_COLUMN_USAGE where TABLE_NAME = ?
+		AND CONSTRAINT_SCHEMA = ? AND REFERENCED_TABLE_NAME IS NOT NULL`,
+		[]interface{}{table, db}
+}
+
+func (mysqlDialect) columnType(dbType string) (dataType, bool) {
+	return getDataType(dbType), false
+}
+
+func (mysqlDialect) splitList(value []byte, typ dataType) ([]string, error) {
+	return nil, errors.Errorf("MySQL has no list columns")
+}
+
+func (mysqlDialect) quote(ident string) string {
+	return "`" + strings.Replace(ident, "`", "``", -1) + "`"
+}
@@ -30,13 +30,14 @@ import (
 // all the tables' generation guide,
 // the writer to output the generated RDF entries,
 // the writer to output the Dgraph schema,
-// and a sqlPool to read information from MySQL
+// and a sqlPool, with its dialect, to read information from the SQL database
 type dumpMeta struct {
 	tableInfos   map[string]*sqlTable
 	tableGuides  map[string]*tableGuide
 	dataWriter   *bufio.Writer
 	schemaWriter *bufio.Writer
 	sqlPool      *sql.DB
+	dialect      dialect
 
 	buf strings.Builder // reusable buf for building strings, call buf.Reset before use
 }
@@ -91,8 +92,7 @@ func (m *dumpMeta) dumpTable(table string) error {
 	tableGuide := m.tableGuides[table]
 	tableInfo := m.tableInfos[table]
 
-	query := fmt.Sprintf(`select %s from %s`, strings.Join(tableInfo.columnNames, ","), table)
-	rows, err := m.sqlPool.Query(query)
+	rows, err := m.sqlPool.Query(m.selectQuery(tableInfo))
 	if err != nil {
 		return err
 	}
@@ -110,7 +110,7 @@ func (m *dumpMeta) dumpTable(table string) error {
 
 	for rows.Next() {
 		// step 1: read the row's column values
-		colValues, err := getColumnValues(tableInfo.columnNames, tableInfo.columnDataTypes, rows)
+		colValues, err := getColumnValues(tableInfo, rows)
 		if err != nil {
 			return err
 		}
@@ -128,6 +128,16 @@ func (m *dumpMeta) dumpTable(table string) error {
 	return nil
 }
 
+// selectQuery returns the query reading all the columns of a table.
+func (m *dumpMeta) selectQuery(info *sqlTable) string {
+	columns := make([]string, 0, len(info.columnNames))
+	for _, column := range info.columnNames {
+		columns = append(columns, m.dialect.quote(column))
+	}
+	return fmt.Sprintf(`select %s from %s`, strings.Join(columns, ","),
+		m.dialect.quote(info.tableName))
+}
+
 // dumpTableConstraints reads data from a table, and then generate RDF entries
 // from a row to another row in a foreign table by following columns with foreign key constraints.
 // It then sends the generated RDF entries to the m.dataWriter
@@ -135,8 +145,7 @@ func (m *dumpMeta) dumpTableConstraints(table string) error {
 	tableGuide := m.tableGuides[table]
 	tableInfo := m.tableInfos[table]
 
-	query := fmt.Sprintf(`select %s from %s`, strings.Join(tableInfo.columnNames, ","), table)
-	rows, err := m.sqlPool.Query(query)
+	rows, err := m.sqlPool.Query(m.selectQuery(tableInfo))
 	if err != nil {
 		return err
 	}
@@ -147,7 +156,7 @@ func (m *dumpMeta) dumpTableConstraints(table string) error {
 	}
 	for rows.Next() {
 		// step 1: read the row's column values
-		colValues, err := getColumnValues(tableInfo.columnNames, tableInfo.columnDataTypes, rows)
+		colValues, err := getColumnValues(tableInfo, rows)
 		if err != nil {
 			return err
 		}
@@ -192,9 +201,39 @@ func (m *dumpMeta) dumpTableConstraints(table string) error {
 func (m *dumpMeta) outputRow(row *sqlRow, tableInfo *sqlTable) {
 	for i, colValue := range row.values {
 		colName := tableInfo.columnNames[i]
-		if !tableInfo.isForeignKey[colName] {
-			predicate := tableInfo.predNames[i]
-			m.outputPlainCell(row.blankNodeLabel, predicate, tableInfo.columnDataTypes[i], colValue)
+		if tableInfo.isForeignKey[colName] {
+			continue
+		}
+		predicate := tableInfo.predNames[i]
+		if tableInfo.columns[colName].isList {
+			m.outputListCell(row.blankNodeLabel, predicate, tableInfo.columnDataTypes[i], colValue)
+			continue
+		}
+		m.outputPlainCell(row.blankNodeLabel, predicate, tableInfo.columnDataTypes[i], colValue)
+	}
+}
+
+// outputListCell sends to the writer one RDF per value in the list colValue, e.g. an array in
+// PostgreSQL. The predicate of a list column is a list in the Dgraph schema.
+func (m *dumpMeta) outputListCell(blankNode string, predName string, dataType dataType,
+	colValue interface{}) {
+	list, ok := colValue.([]byte)
+	if !ok || list == nil {
+		// NULL lists have no values.
+		return
+	}
+	values, err := m.dialect.splitList(list, dataType)
+	if err != nil {
+		if !quiet {
+			logger.Printf("ignoring list %s because of error when splitting it: %v", list, err)
+		}
+		return
+	}
+	for _, value := range values {
+		if dataType == stringType {
+			fmt.Fprintf(m.dataWriter, "%s <%s> %q .\n", blankNode, predName, value)
+		} else {
+			fmt.Fprintf(m.dataWriter, "%s <%s> \"%s\" .\n", blankNode, predName, value)
 		}
 	}
 }
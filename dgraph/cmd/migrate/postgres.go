@@ -0,0 +1,219 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package migrate
+
+import (
+	"database/sql"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	_ "github.com/lib/pq" // PostgreSQL driver
+	"github.com/pkg/errors"
+)
+
+// postgresTypes maps the PostgreSQL types, as named in pg_type, to the Dgraph types. The json and
+// jsonb values are migrated as strings holding the JSON documents.
+var postgresTypes = map[string]dataType{
+	"int2":        intType,
+	"int4":        intType,
+	"int8":        intType,
+	"float4":      floatType,
+	"float8":      floatType,
+	"numeric":     floatType,
+	"bool":        boolType,
+	"varchar":     stringType,
+	"bpchar":      stringType,
+	"text":        stringType,
+	"citext":      stringType,
+	"uuid":        stringType,
+	"json":        stringType,
+	"jsonb":       stringType,
+	"time":        stringType,
+	"timetz":      stringType,
+	"date":        datetimeType,
+	"timestamp":   datetimeType,
+	"timestamptz": datetimeType,
+}
+
+// postgresTimeLayouts are the layouts of the dates and timestamps in the PostgreSQL arrays, with
+// the default ISO date style. The fractional seconds are parsed even though they are left out.
+var postgresTimeLayouts = []string{
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05Z07",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// postgresDialect reads the tables in the current schema of the database, which is the first
+// schema of the search path of the user, usually public.
+type postgresDialect struct {
+	sslMode string
+}
+
+func (d postgresDialect) open(host, port, user, password, db string) (*sql.DB, error) {
+	u := url.URL{
+		Scheme: "postgres",
+		User:   url.UserPassword(user, password),
+		Host:   net.JoinHostPort(host, port),
+		Path:   db,
+	}
+	if len(d.sslMode) > 0 {
+		u.RawQuery = url.Values{"sslmode": []string{d.sslMode}}.Encode()
+	}
+	return sql.Open("postgres", u.String())
+}
+
+func (postgresDialect) defaultPort() string {
+	return "5432"
+}
+
+func (postgresDialect) tablesQuery(db string) (string, []interface{}) {
+	return `select table_name from information_schema.tables
+		where table_schema = current_schema() and table_type = 'BASE TABLE'
+		order by table_name`, nil
+}
+
+// The udt_name of a column is the name of its type in pg_type, which is also set for arrays,
+// e.g. _int4 for integer[], unlike the data_type that is just ARRAY.
+func (postgresDialect) columnsQuery(db, table string) (string, []interface{}) {
+	return `select column_name, udt_name from information_schema.columns
+		where table_schema = current_schema() and table_name = $1
+		order by column_name`, []interface{}{table}
+}
+
+func (postgresDialect) indicesQuery(db, table string) (string, []interface{}) {
+	return `select case when tc.constraint_type = 'PRIMARY KEY' then 'PRIMARY'
+			else tc.constraint_name end, kcu.column_name
+		from information_schema.table_constraints tc
+		join information_schema.key_column_usage kcu
+			on kcu.constraint_schema = tc.constraint_schema
+			and kcu.constraint_name = tc.constraint_name
+		where tc.table_schema = current_schema() and tc.table_name = $1
+			and tc.constraint_type in ('PRIMARY KEY', 'UNIQUE')`, []interface{}{table}
+}
+
+// The columns of a foreign key are matched with the columns they reference through their
+// position in the referenced unique constraint.
+func (postgresDialect) foreignKeysQuery(db, table string) (string, []interface{}) {
+	return `select kcu.column_name, kcu.constraint_name, ukcu.table_name, ukcu.column_name
+		from information_schema.referential_constraints rc
+		join information_schema.key_column_usage kcu
+			on kcu.constraint_schema = rc.constraint_schema
+			and kcu.constraint_name = rc.constraint_name
+		join information_schema.key_column_usage ukcu
+			on ukcu.constraint_schema = rc.unique_constraint_schema
+			and ukcu.constraint_name = rc.unique_constraint_name
+			and ukcu.ordinal_position = kcu.position_in_unique_constraint
+		where kcu.table_schema = current_schema() and kcu.table_name = $1`,
+		[]interface{}{table}
+}
+
+func (postgresDialect) columnType(dbType string) (dataType, bool) {
+	if strings.HasPrefix(dbType, "_") {
+		typ, ok := postgresTypes[dbType[1:]]
+		if !ok {
+			return unknownType, false
+		}
+		return typ, true
+	}
+	return postgresTypes[dbType], false
+}
+
+func (postgresDialect) splitList(value []byte, typ dataType) ([]string, error) {
+	elems, err := parsePostgresArray(string(value))
+	if err != nil {
+		return nil, err
+	}
+	for i, elem := range elems {
+		switch typ {
+		case boolType:
+			elems[i] = "false"
+			if elem == "t" {
+				elems[i] = "true"
+			}
+		case datetimeType:
+			if elems[i], err = postgresTime(elem); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return elems, nil
+}
+
+func (postgresDialect) quote(ident string) string {
+	return `"` + strings.Replace(ident, `"`, `""`, -1) + `"`
+}
+
+func postgresTime(val string) (string, error) {
+	for _, layout := range postgresTimeLayouts {
+		if t, err := time.Parse(layout, val); err == nil {
+			return t.Format(time.RFC3339Nano), nil
+		}
+	}
+	return "", errors.Errorf("invalid timestamp %q", val)
+}
+
+// parsePostgresArray returns the elements of a one-dimensional array in the PostgreSQL text
+// format, e.g. {1,NULL,"a \"b\""}. The NULL elements are left out.
+func parsePostgresArray(s string) ([]string, error) {
+	if len(s) < 2 || s[0] != '{' || s[len(s)-1] != '}' {
+		return nil, errors.Errorf("invalid array %q", s)
+	}
+	body := s[1 : len(s)-1]
+	if len(body) == 0 {
+		return nil, nil
+	}
+
+	var elems []string
+	for i := 0; ; {
+		var elem strings.Builder
+		quoted := i < len(body) && body[i] == '"'
+		if quoted {
+			for i++; i < len(body) && body[i] != '"'; i++ {
+				if body[i] == '\\' && i+1 < len(body) {
+					i++
+				}
+				elem.WriteByte(body[i])
+			}
+			if i == len(body) {
+				return nil, errors.Errorf("unterminated element in array %q", s)
+			}
+			i++
+		} else {
+			for ; i < len(body) && body[i] != ','; i++ {
+				if body[i] == '{' || body[i] == '"' {
+					return nil, errors.Errorf("only one-dimensional arrays are supported,"+
+						" got %q", s)
+				}
+				elem.WriteByte(body[i])
+			}
+		}
+		if quoted || elem.String() != "NULL" {
+			elems = append(elems, elem.String())
+		}
+
+		if i == len(body) {
+			return elems, nil
+		}
+		if body[i] != ',' {
+			return nil, errors.Errorf("invalid array %q", s)
+		}
+		i++
+	}
+}
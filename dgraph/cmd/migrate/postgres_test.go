@@ -0,0 +1,242 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package migrate
+
+import (
+	"bufio"
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePostgresArray(t *testing.T) {
+	for in, want := range map[string][]string{
+		"{}":                       nil,
+		"{1,2,3}":                  {"1", "2", "3"},
+		`{a,"b c","d,e","f\"g\\"}`: {"a", "b c", "d,e", `f"g\`},
+		`{NULL,"NULL",x}`:          {"NULL", "x"},
+	} {
+		got, err := parsePostgresArray(in)
+		require.NoError(t, err, in)
+		require.Equal(t, want, got, in)
+	}
+
+	for _, in := range []string{"", "1,2", "{{1,2},{3,4}}", `{"a}`, `{"a"b}`} {
+		_, err := parsePostgresArray(in)
+		require.Error(t, err, in)
+	}
+}
+
+func TestPostgresColumnType(t *testing.T) {
+	d := postgresDialect{}
+	for dbType, want := range map[string]dataType{
+		"int4": intType, "numeric": floatType, "jsonb": stringType, "bool": boolType,
+		"timestamptz": datetimeType, "tsvector": unknownType,
+	} {
+		typ, isList := d.columnType(dbType)
+		require.Equal(t, want, typ, dbType)
+		require.False(t, isList)
+	}
+	typ, isList := d.columnType("_varchar")
+	require.Equal(t, stringType, typ)
+	require.True(t, isList)
+
+	vals, err := d.splitList([]byte(`{t,f}`), boolType)
+	require.NoError(t, err)
+	require.Equal(t, []string{"true", "false"}, vals)
+	vals, err = d.splitList([]byte(`{"2020-01-02 03:04:05.5+01","2020-01-02"}`), datetimeType)
+	require.NoError(t, err)
+	require.Equal(t, []string{"2020-01-02T03:04:05.5+01:00", "2020-01-02T00:00:00Z"}, vals)
+}
+
+// fakeDriver is a database/sql driver answering the queries with canned results.
+type fakeDriver struct {
+	query func(query string, args []driver.Value) (*fakeRows, error)
+}
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) {
+	return &fakeConn{d: d}, nil
+}
+
+type fakeConn struct {
+	d *fakeDriver
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{c: c, query: query}, nil
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions are not supported")
+}
+
+type fakeStmt struct {
+	c     *fakeConn
+	query string
+}
+
+func (s *fakeStmt) Close() error {
+	return nil
+}
+
+func (s *fakeStmt) NumInput() int {
+	return -1
+}
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return nil, errors.New("exec is not supported")
+}
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.c.d.query(s.query, args)
+}
+
+type fakeRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string {
+	return r.columns
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+// fakePostgres answers the catalog queries of the PostgreSQL dialect for a person table with
+// a list column, and a role table referencing it.
+func fakePostgres(query string, args []driver.Value) (*fakeRows, error) {
+	born := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	tables := map[string]map[string]*fakeRows{
+		"person": {
+			"information_schema.columns": {[]string{"column_name", "udt_name"}, [][]driver.Value{
+				{"active", "bool"}, {"born", "timestamptz"}, {"id", "int4"}, {"name", "text"},
+				{"tags", "_text"}}},
+			"table_constraints": {[]string{"name", "column_name"}, [][]driver.Value{
+				{"PRIMARY", "id"}}},
+			"referential_constraints": {[]string{"a", "b", "c", "d"}, nil},
+			`select "active","born","id","name","tags" from "person"`: {
+				[]string{"active", "born", "id", "name", "tags"}, [][]driver.Value{
+					{true, born, int64(1), []byte("Ann"), []byte(`{a,"b c"}`)},
+					{nil, nil, int64(2), nil, nil}}},
+		},
+		"role": {
+			"information_schema.columns": {[]string{"column_name", "udt_name"}, [][]driver.Value{
+				{"id", "int4"}, {"person_id", "int4"}, {"title", "varchar"}}},
+			"table_constraints": {[]string{"name", "column_name"}, [][]driver.Value{
+				{"PRIMARY", "id"}}},
+			"referential_constraints": {[]string{"a", "b", "c", "d"}, [][]driver.Value{
+				{"person_id", "role_person_fk", "person", "id"}}},
+			`select "id","person_id","title" from "role"`: {
+				[]string{"id", "person_id", "title"}, [][]driver.Value{
+					{int64(10), int64(1), []byte("Engineer")}}},
+		},
+	}
+
+	if strings.Contains(query, "information_schema.tables") {
+		return &fakeRows{[]string{"table_name"}, [][]driver.Value{{"person"}, {"role"}}}, nil
+	}
+	for table, results := range tables {
+		for q, rows := range results {
+			if strings.Contains(query, q) && (len(args) == 0 || args[0] == table) {
+				return rows, nil
+			}
+		}
+	}
+	return nil, errors.Errorf("unexpected query %q", query)
+}
+
+func TestPostgresMigration(t *testing.T) {
+	sql.Register("fakepostgres", &fakeDriver{query: fakePostgres})
+	pool, err := sql.Open("fakepostgres", "")
+	require.NoError(t, err)
+	defer pool.Close()
+
+	initDataTypes()
+	d := postgresDialect{}
+	tables, err := showTables(pool, d, "db", "")
+	require.NoError(t, err)
+	require.Equal(t, []string{"person", "role"}, tables)
+
+	tableInfos := make(map[string]*sqlTable)
+	for _, table := range tables {
+		info, err := parseTables(pool, d, table, "db")
+		require.NoError(t, err)
+		tableInfos[table] = info
+	}
+	populateReferencedByColumns(tableInfos)
+
+	var schema, data bytes.Buffer
+	m := &dumpMeta{
+		tableInfos:   tableInfos,
+		tableGuides:  getTableGuides(tableInfos),
+		dataWriter:   bufio.NewWriter(&data),
+		schemaWriter: bufio.NewWriter(&schema),
+		sqlPool:      pool,
+		dialect:      d,
+	}
+	require.NoError(t, m.dumpSchema())
+	require.NoError(t, m.dumpTables())
+
+	schemaLines := strings.Split(strings.TrimSpace(schema.String()), "\n")
+	require.ElementsMatch(t, []string{
+		"person.active: bool .",
+		"person.born: datetime .",
+		"person.id: int .",
+		"person.name: string .",
+		"person.tags: [string] .",
+		"role.id: int .",
+		"role.title: string .",
+		"role.person_id: [uid] .",
+	}, schemaLines)
+
+	dataLines := strings.Split(strings.TrimSpace(data.String()), "\n")
+	require.ElementsMatch(t, []string{
+		`_:person.1 <person.active> "true" .`,
+		`_:person.1 <person.born> "2020-01-02T03:04:05Z" .`,
+		`_:person.1 <person.id> "1" .`,
+		`_:person.1 <person.name> "Ann" .`,
+		`_:person.1 <person.tags> "a" .`,
+		`_:person.1 <person.tags> "b c" .`,
+		`_:person.2 <person.id> "2" .`,
+		`_:person.2 <person.name> "" .`,
+		`_:role.10 <role.id> "10" .`,
+		`_:role.10 <role.title> "Engineer" .`,
+		`_:role.10 <role.person_id> _:person.1 .`,
+	}, dataLines)
+}
@@ -59,7 +59,11 @@ func init() {
 	flag.StringP("separator", "p", ".", "The separator for constructing predicate names")
 	flag.BoolP("quiet", "q", false, "Enable quiet mode to suppress the warning logs")
 	flag.StringP("host", "", "localhost", "The hostname or IP address of the database server.")
-	flag.StringP("port", "", "3306", "The port of the database server.")
+	flag.StringP("port", "", "", "The port of the database server. Defaults to 3306 for MySQL "+
+		"and 5432 for PostgreSQL.")
+	flag.String("dialect", "mysql", "The SQL database to migrate from, mysql or postgres.")
+	flag.String("sslmode", "", "The SSL mode of the connection to PostgreSQL: disable, "+
+		"require, verify-ca or verify-full. The driver requires SSL by default.")
 }
 
 func run(conf *viper.Viper) error {
@@ -74,6 +78,14 @@ func run(conf *viper.Viper) error {
 	quiet = conf.GetBool("quiet")
 	separator = conf.GetString("separator")
 
+	d, err := getDialect(conf.GetString("dialect"), conf.GetString("sslmode"))
+	if err != nil {
+		return err
+	}
+	if len(port) == 0 {
+		port = d.defaultPort()
+	}
+
 	switch {
 	case len(user) == 0:
 		logger.Fatalf("The user property should not be empty.")
@@ -97,20 +109,20 @@ func run(conf *viper.Viper) error {
 
 	initDataTypes()
 
-	pool, err := getPool(host, port, user, password, db)
+	pool, err := d.open(host, port, user, password, db)
 	if err != nil {
 		return err
 	}
 	defer pool.Close()
 
-	tablesToRead, err := showTables(pool, tables)
+	tablesToRead, err := showTables(pool, d, db, tables)
 	if err != nil {
 		return err
 	}
 
 	tableInfos := make(map[string]*sqlTable)
 	for _, table := range tablesToRead {
-		tableInfo, err := parseTables(pool, table, db)
+		tableInfo, err := parseTables(pool, d, table, db)
 		if err != nil {
 			return err
 		}
@@ -124,6 +136,7 @@ func run(conf *viper.Viper) error {
 		tableInfos:  tableInfos,
 		tableGuides: tableGuides,
 		sqlPool:     pool,
+		dialect:     d,
 	}, schemaOutput, dataOutput)
 }
 
@@ -20,8 +20,8 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
-	"github.com/go-sql-driver/mysql"
 	"github.com/pkg/errors"
 )
 
@@ -174,11 +174,16 @@ func getValue(dataType dataType, value interface{}) (string, error) {
 		intVal, _ := value.(sql.NullInt64).Value()
 		return fmt.Sprintf("%v", intVal), nil
 	case datetimeType:
-		if !value.(mysql.NullTime).Valid {
+		if !value.(sql.NullTime).Valid {
 			return "", errors.Errorf("found invalid nulltime")
 		}
-		dateVal, _ := value.(mysql.NullTime).Value()
-		return fmt.Sprintf("%v", dateVal), nil
+		return value.(sql.NullTime).Time.Format(time.RFC3339Nano), nil
+	case boolType:
+		if !value.(sql.NullBool).Valid {
+			return "", errors.Errorf("found invalid nullbool")
+		}
+		boolVal, _ := value.(sql.NullBool).Value()
+		return fmt.Sprintf("%v", boolVal), nil
 	case floatType:
 		if !value.(sql.NullFloat64).Valid {
 			return "", errors.Errorf("found invalid nullfloat")
@@ -225,6 +230,11 @@ func createDgraphSchema(info *sqlTable) []string {
 		predicate := fmt.Sprintf("%s%s%s", info.tableName, separator, column)
 
 		dataType := info.columns[column].dataType
+		if info.columns[column].isList {
+			dgraphIndices = append(dgraphIndices, fmt.Sprintf("%s: [%s] .\n",
+				predicate, dataType))
+			continue
+		}
 
 		dgraphIndices = append(dgraphIndices, fmt.Sprintf("%s: %s .\n",
 			predicate, dataType))
@@ -18,7 +18,6 @@ package migrate
 
 import (
 	"database/sql"
-	"fmt"
 	"strings"
 
 	"github.com/dgraph-io/dgraph/x"
@@ -39,6 +38,8 @@ type columnInfo struct {
 	name     string
 	keyType  keyType
 	dataType dataType
+	// isList is set if the column holds lists of values of dataType, e.g. PostgreSQL arrays.
+	isList bool
 }
 
 // fkConstraint represents a foreign key constraint
@@ -90,17 +91,16 @@ func getDataType(dbType string) dataType {
 	return unknownType
 }
 
-func getColumnInfo(fieldName string, dbType string) *columnInfo {
+func getColumnInfo(d dialect, fieldName string, dbType string) *columnInfo {
 	columnInfo := columnInfo{}
 	columnInfo.name = fieldName
-	columnInfo.dataType = getDataType(dbType)
+	columnInfo.dataType, columnInfo.isList = d.columnType(dbType)
 	return &columnInfo
 }
 
-func parseTables(pool *sql.DB, tableName string, database string) (*sqlTable, error) {
-	query := fmt.Sprintf(`select COLUMN_NAME,DATA_TYPE from INFORMATION_SCHEMA.
-COLUMNS where TABLE_NAME = "%s" AND TABLE_SCHEMA="%s" ORDER BY COLUMN_NAME`, tableName, database)
-	columns, err := pool.Query(query)
+func parseTables(pool *sql.DB, d dialect, tableName string, database string) (*sqlTable, error) {
+	query, args := d.columnsQuery(database, tableName)
+	columns, err := pool.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -138,15 +138,15 @@ COLUMNS where TABLE_NAME = "%s" AND TABLE_SCHEMA="%s" ORDER BY COLUMN_NAME`, tab
 
 		// TODO, should store the column data types into the table info as an array
 		// and the RMI should simply get the data types from the table info
-		table.columns[fieldName] = getColumnInfo(fieldName, dbType)
+		info := getColumnInfo(d, fieldName, dbType)
+		table.columns[fieldName] = info
 		table.columnNames = append(table.columnNames, fieldName)
-		table.columnDataTypes = append(table.columnDataTypes, getDataType(dbType))
+		table.columnDataTypes = append(table.columnDataTypes, info.dataType)
 	}
 
 	// query indices
-	indexQuery := fmt.Sprintf(`select INDEX_NAME,COLUMN_NAME from INFORMATION_SCHEMA.`+
-		`STATISTICS where TABLE_NAME = "%s" AND index_schema="%s"`, tableName, database)
-	indices, err := pool.Query(indexQuery)
+	indexQuery, args := d.indicesQuery(database, tableName)
+	indices, err := pool.Query(indexQuery, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -166,10 +166,8 @@ COLUMNS where TABLE_NAME = "%s" AND TABLE_SCHEMA="%s" ORDER BY COLUMN_NAME`, tab
 
 	}
 
-	foreignKeysQuery := fmt.Sprintf(`select COLUMN_NAME,CONSTRAINT_NAME,REFERENCED_TABLE_NAME,
-		REFERENCED_COLUMN_NAME from INFORMATION_SCHEMA.KEY_COLUMN_USAGE where TABLE_NAME = "%s"
-        AND CONSTRAINT_SCHEMA="%s" AND REFERENCED_TABLE_NAME IS NOT NULL`, tableName, database)
-	fkeys, err := pool.Query(foreignKeysQuery)
+	foreignKeysQuery, args := d.foreignKeysQuery(database, tableName)
+	fkeys, err := pool.Query(foreignKeysQuery, args...)
 	if err != nil {
 		return nil, err
 	}
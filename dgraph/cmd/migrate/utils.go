@@ -19,33 +19,25 @@ package migrate
 import (
 	"bufio"
 	"database/sql"
-	"fmt"
 	"os"
 	"reflect"
 	"strings"
 
 	"github.com/dgraph-io/dgraph/x"
-	"github.com/go-sql-driver/mysql"
 	"github.com/pkg/errors"
 )
 
-func getPool(host, port, user, password, db string) (*sql.DB,
-	error) {
-	return sql.Open("mysql",
-		fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true", user, password, host, port, db))
-}
-
 // showTables will return a slice of table names using one of the following logic
 // 1) if the parameter tables is not empty, this function will return a slice of table names
 // by splitting the parameter with the separate comma
 // 2) if the parameter is empty, this function will read all the tables under the given
 // database and then return the result
-func showTables(pool *sql.DB, tableNames string) ([]string, error) {
+func showTables(pool *sql.DB, d dialect, db string, tableNames string) ([]string, error) {
 	if len(tableNames) > 0 {
 		return strings.Split(tableNames, ","), nil
 	}
-	query := "show tables"
-	rows, err := pool.Query(query)
+	query, args := d.tablesQuery(db)
+	rows, err := pool.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -94,8 +86,7 @@ func getFileWriter(filename string) (*bufio.Writer, func(), error) {
 	return bufio.NewWriter(output), func() { _ = output.Close() }, nil
 }
 
-func getColumnValues(columns []string, dataTypes []dataType,
-	rows *sql.Rows) ([]interface{}, error) {
+func getColumnValues(info *sqlTable, rows *sql.Rows) ([]interface{}, error) {
 	// ptrToValues takes a slice of pointers, deference them, and return the values referenced
 	// by these pointers
 	ptrToValues := func(ptrs []interface{}) []interface{} {
@@ -108,8 +99,14 @@ func getColumnValues(columns []string, dataTypes []dataType,
 		return values
 	}
 
+	columns, dataTypes := info.columnNames, info.columnDataTypes
 	valuePtrs := make([]interface{}, 0, len(columns))
 	for i := 0; i < len(columns); i++ {
+		if info.columns[columns[i]].isList {
+			// The lists are read in the text format of the database, and split when written.
+			valuePtrs = append(valuePtrs, new([]byte))
+			continue
+		}
 		switch dataTypes[i] {
 		case stringType:
 			valuePtrs = append(valuePtrs, new([]byte)) // the value can be nil
@@ -118,7 +115,9 @@ func getColumnValues(columns []string, dataTypes []dataType,
 		case floatType:
 			valuePtrs = append(valuePtrs, new(sql.NullFloat64))
 		case datetimeType:
-			valuePtrs = append(valuePtrs, new(mysql.NullTime))
+			valuePtrs = append(valuePtrs, new(sql.NullTime))
+		case boolType:
+			valuePtrs = append(valuePtrs, new(sql.NullBool))
 		default:
 			x.Panic(errors.Errorf("detected unsupported type %s on column %s",
 				dataTypes[i], columns[i]))
@@ -22,6 +22,7 @@ import (
 	"strings"
 
 	"github.com/dgraph-io/dgraph/dgraph/cmd/alpha"
+	"github.com/dgraph-io/dgraph/dgraph/cmd/analytics"
 	"github.com/dgraph-io/dgraph/dgraph/cmd/bulk"
 	"github.com/dgraph-io/dgraph/dgraph/cmd/cert"
 	"github.com/dgraph-io/dgraph/dgraph/cmd/conv"
@@ -77,7 +78,7 @@ var rootConf = viper.New()
 var subcommands = []*x.SubCommand{
 	&bulk.Bulk, &cert.Cert, &conv.Conv, &live.Live, &alpha.Alpha, &zero.Zero, &version.Version,
 	&debug.Debug, &increment.Increment, &migrate.Migrate, &debuginfo.DebugInfo, &upgrade.Upgrade,
-	&raftmigrate.RaftMigrate,
+	&raftmigrate.RaftMigrate, &analytics.Analytics,
 }
 
 func initCmds() {
@@ -36,6 +36,7 @@ import (
 	"google.golang.org/grpc/credentials"
 
 	"github.com/dgraph-io/dgraph/conn"
+	"github.com/dgraph-io/dgraph/ee/audit"
 	"github.com/dgraph-io/dgraph/ee/enc"
 	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/raftwal"
@@ -89,6 +90,8 @@ instances to achieve high-availability.
 	flag.StringP("wal", "w", "zw", "Directory storing WAL.")
 	flag.Duration("rebalance_interval", 8*time.Minute, "Interval for trying a predicate move.")
 	flag.String("enterprise_license", "", "Path to the enterprise license file.")
+	enc.RegisterFlags(flag)
+	audit.RegisterFlags(flag)
 	// TLS configurations
 	x.RegisterServerTLSFlags(flag)
 }
@@ -210,6 +213,11 @@ func run() {
 			opts.rebalanceInterval)
 	}
 
+	key, err := enc.ReadKey(Zero.Conf)
+	x.Checkf(err, "Unable to read encryption key")
+	x.Check(audit.InitAuditor(audit.GetAuditConf(Zero.Conf, "zero", key)))
+	defer audit.Close()
+
 	grpc.EnableTracing = false
 	otrace.ApplyConfig(otrace.Config{
 		DefaultSampler: otrace.ProbabilitySampler(Zero.Conf.GetFloat64("trace"))})
@@ -243,10 +251,11 @@ func run() {
 
 	http.HandleFunc("/health", st.pingResponse)
 	http.HandleFunc("/state", st.getState)
-	http.HandleFunc("/removeNode", st.removeNode)
-	http.HandleFunc("/moveTablet", st.moveTablet)
-	http.HandleFunc("/assign", st.assign)
-	http.HandleFunc("/enterpriseLicense", st.applyEnterpriseLicense)
+	http.Handle("/removeNode", audit.AuditRequestHttp(http.HandlerFunc(st.removeNode)))
+	http.Handle("/moveTablet", audit.AuditRequestHttp(http.HandlerFunc(st.moveTablet)))
+	http.Handle("/assign", audit.AuditRequestHttp(http.HandlerFunc(st.assign)))
+	http.Handle("/enterpriseLicense",
+		audit.AuditRequestHttp(http.HandlerFunc(st.applyEnterpriseLicense)))
 	http.HandleFunc("/jemalloc", x.JemallocHandler)
 	zpages.Handle(http.DefaultServeMux, "/z")
 
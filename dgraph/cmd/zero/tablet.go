@@ -146,6 +146,12 @@ func (s *Server) movePredicate(predicate string, srcGroup, dstGroup uint32) erro
 		UncompressedBytes: tab.UncompressedBytes,
 		Force:             true,
 		MoveTs:            in.TxnTs,
+		// The statistics are still valid for the moved data.
+		NumUids:        tab.NumUids,
+		NumTokens:      tab.NumTokens,
+		NumIndexUids:   tab.NumIndexUids,
+		TopTokenHashes: tab.TopTokenHashes,
+		TopTokenCounts: tab.TopTokenCounts,
 	}
 	msg = fmt.Sprintf("Move at Alpha done. Now proposing: %+v", p)
 	span.Annotate(nil, msg)
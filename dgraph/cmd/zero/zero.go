@@ -388,9 +388,16 @@ func (s *Server) createProposals(dst *pb.Group) ([]*pb.ZeroProposal, error) {
 			continue
 		}
 
-		s := float64(srcTablet.OnDiskBytes)
-		d := float64(dstTablet.OnDiskBytes)
-		if dstTablet.Remove || (s == 0 && d > 0) || (s > 0 && math.Abs(d/s-1) > 0.1) {
+		if dstTablet.Remove || changedMuch(srcTablet.OnDiskBytes, dstTablet.OnDiskBytes) ||
+			statsChanged(srcTablet, dstTablet) {
+			if dstTablet.NumUids == 0 && dstTablet.NumTokens == 0 {
+				// Keep the statistics until the Alpha computes them again.
+				dstTablet.NumUids = srcTablet.NumUids
+				dstTablet.NumTokens = srcTablet.NumTokens
+				dstTablet.NumIndexUids = srcTablet.NumIndexUids
+				dstTablet.TopTokenHashes = srcTablet.TopTokenHashes
+				dstTablet.TopTokenCounts = srcTablet.TopTokenCounts
+			}
 			dstTablet.Force = false
 			proposal := &pb.ZeroProposal{
 				Tablet: dstTablet,
@@ -401,6 +408,23 @@ func (s *Server) createProposals(dst *pb.Group) ([]*pb.ZeroProposal, error) {
 	return res, nil
 }
 
+// changedMuch tells if a size or a count of a tablet changed by more than 10%.
+func changedMuch(src, dst int64) bool {
+	s, d := float64(src), float64(dst)
+	return (s == 0 && d > 0) || (s > 0 && math.Abs(d/s-1) > 0.1)
+}
+
+// statsChanged tells if the cardinality statistics of a tablet changed enough to be proposed.
+func statsChanged(src, dst *pb.Tablet) bool {
+	if dst.NumUids == 0 && dst.NumTokens == 0 {
+		// The Alpha didn't compute the statistics of the tablet.
+		return false
+	}
+	return changedMuch(int64(src.NumUids), int64(dst.NumUids)) ||
+		changedMuch(int64(src.NumTokens), int64(dst.NumTokens)) ||
+		changedMuch(int64(src.NumIndexUids), int64(dst.NumIndexUids))
+}
+
 // removeNode removes the given node from the given group.
 // It's the user's responsibility to ensure that node doesn't come back again
 // before calling the api.
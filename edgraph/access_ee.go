@@ -39,6 +39,7 @@ import (
 	"github.com/golang/glog"
 	otrace "go.opencensus.io/trace"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
@@ -74,22 +75,22 @@ func (s *Server) Login(ctx context.Context,
 		}, "client ip for login")
 	}
 
-	user, err := s.authenticateLogin(ctx, request)
+	user, ns, err := s.authenticateLogin(ctx, request)
 	if err != nil {
 		glog.Errorf("Authentication from address %s failed: %v", addr, err)
 		return nil, x.ErrorInvalidLogin
 	}
-	glog.Infof("%s logged in successfully", user.UserID)
+	glog.Infof("%s logged in successfully to namespace %d", user.UserID, ns)
 
 	resp := &api.Response{}
-	accessJwt, err := getAccessJwt(user.UserID, user.Groups)
+	accessJwt, err := getAccessJwt(user.UserID, user.Groups, ns)
 	if err != nil {
 		errMsg := fmt.Sprintf("unable to get access jwt (userid=%s,addr=%s):%v",
 			user.UserID, addr, err)
 		glog.Errorf(errMsg)
 		return nil, errors.Errorf(errMsg)
 	}
-	refreshJwt, err := getRefreshJwt(user.UserID)
+	refreshJwt, err := getRefreshJwt(user.UserID, ns)
 	if err != nil {
 		errMsg := fmt.Sprintf("unable to get refresh jwt (userid=%s,addr=%s):%v",
 			user.UserID, addr, err)
@@ -115,58 +116,100 @@ func (s *Server) Login(ctx context.Context,
 
 // authenticateLogin authenticates the login request using either the refresh token if present, or
 // the <userId, password> pair. If authentication passes, it queries the user's uid and associated
-// groups from DB and returns the user object
+// groups from DB and returns the user object along with the namespace the user logged into.
 func (s *Server) authenticateLogin(ctx context.Context, request *api.LoginRequest) (*acl.User,
-	error) {
+	uint64, error) {
 	if err := validateLoginRequest(request); err != nil {
-		return nil, errors.Wrapf(err, "invalid login request")
+		return nil, 0, errors.Wrapf(err, "invalid login request")
 	}
 
 	var user *acl.User
 	if len(request.RefreshToken) > 0 {
-		userData, err := validateToken(request.RefreshToken)
+		claims, err := parseJwt(request.RefreshToken)
 		if err != nil {
-			return nil, errors.Wrapf(err, "unable to authenticate the refresh token %v",
+			return nil, 0, errors.Wrapf(err, "unable to authenticate the refresh token %v",
+				request.RefreshToken)
+		}
+		userData, err := userAndGroupsFromClaims(claims)
+		if err != nil {
+			return nil, 0, errors.Wrapf(err, "unable to authenticate the refresh token %v",
+				request.RefreshToken)
+		}
+		ns, err := namespaceFromClaims(claims)
+		if err != nil {
+			return nil, 0, errors.Wrapf(err, "unable to authenticate the refresh token %v",
 				request.RefreshToken)
 		}
 
 		userId := userData[0]
-		user, err = authorizeUser(ctx, userId, "")
+		user, err = authorizeUser(x.AttachNamespace(ctx, ns), userId, "")
 		if err != nil {
-			return nil, errors.Wrapf(err, "while querying user with id %v", userId)
+			return nil, 0, errors.Wrapf(err, "while querying user with id %v", userId)
 		}
 
 		if user == nil {
-			return nil, errors.Errorf("unable to authenticate: "+
+			return nil, 0, errors.Errorf("unable to authenticate: "+
 				"invalid username or password")
 		}
 
 		glog.Infof("Authenticated user %s through refresh token", userId)
-		return user, nil
+		return user, ns, nil
+	}
+
+	ns, err := loginNamespace(ctx)
+	if err != nil {
+		return nil, 0, err
 	}
 
 	// authorize the user using password
-	var err error
-	user, err = authorizeUser(ctx, request.Userid, request.Password)
+	user, err = authorizeUser(x.AttachNamespace(ctx, ns), request.Userid, request.Password)
 	if err != nil {
-		return nil, errors.Wrapf(err, "while querying user with id %v",
+		return nil, 0, errors.Wrapf(err, "while querying user with id %v",
 			request.Userid)
 	}
 
 	if user == nil {
-		return nil, errors.Errorf("unable to authenticate: "+
+		return nil, 0, errors.Errorf("unable to authenticate: "+
 			"invalid username or password")
 	}
 	if !user.PasswordMatch {
-		return nil, x.ErrorInvalidLogin
+		return nil, 0, x.ErrorInvalidLogin
 	}
-	return user, nil
+	return user, ns, nil
+}
+
+// loginNamespace returns the namespace a client asked to log into. The LoginRequest has no
+// field for it, so clients send it in the "namespace" metadata of the request. Clients that
+// don't send it log into the galaxy namespace.
+func loginNamespace(ctx context.Context) (uint64, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return x.GalaxyNamespace, nil
+	}
+	vals := md.Get("namespace")
+	if len(vals) == 0 {
+		return x.GalaxyNamespace, nil
+	}
+	ns, err := strconv.ParseUint(vals[0], 0, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid namespace %q", vals[0])
+	}
+	return ns, nil
 }
 
 // validateToken verifies the signature and expiration of the jwt, and if validation passes,
 // returns a slice of strings, where the first element is the extracted userId
 // and the rest are groupIds encoded in the jwt.
 func validateToken(jwtStr string) ([]string, error) {
+	claims, err := parseJwt(jwtStr)
+	if err != nil {
+		return nil, err
+	}
+	return userAndGroupsFromClaims(claims)
+}
+
+// parseJwt verifies the signature and expiration of the jwt and returns its claims.
+func parseJwt(jwtStr string) (jwt.MapClaims, error) {
 	token, err := jwt.Parse(jwtStr, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, errors.Errorf("unexpected signing method: %v", token.Header["alg"])
@@ -190,7 +233,12 @@ func validateToken(jwtStr string) ([]string, error) {
 	if !claims.VerifyExpiresAt(now, true) {
 		return nil, errors.Errorf("Token is expired") // the same error msg that's used inside jwt-go
 	}
+	return claims, nil
+}
 
+// userAndGroupsFromClaims returns a slice of strings, where the first element is the userId
+// and the rest are the groupIds in the claims of a jwt.
+func userAndGroupsFromClaims(claims jwt.MapClaims) ([]string, error) {
 	userId, ok := claims["userid"].(string)
 	if !ok {
 		return nil, errors.Errorf("userid in claims is not a string:%v", userId)
@@ -213,6 +261,52 @@ func validateToken(jwtStr string) ([]string, error) {
 	return append([]string{userId}, groupIds...), nil
 }
 
+// namespaceFromClaims returns the namespace the jwt was issued for. Tokens issued before
+// namespaces existed belong to the galaxy namespace.
+func namespaceFromClaims(claims jwt.MapClaims) (uint64, error) {
+	val, ok := claims["namespace"]
+	if !ok {
+		return x.GalaxyNamespace, nil
+	}
+	// Numbers in the claims are decoded as float64.
+	ns, ok := val.(float64)
+	if !ok {
+		return 0, errors.Errorf("namespace in claims is not a number:%v", val)
+	}
+	return uint64(ns), nil
+}
+
+// extractNamespace returns the namespace of the access jwt in the context.
+func extractNamespace(ctx context.Context) (uint64, error) {
+	accessJwt, err := x.ExtractJwt(ctx)
+	if err != nil {
+		return 0, err
+	}
+	claims, err := parseJwt(accessJwt[0])
+	if err != nil {
+		return 0, err
+	}
+	return namespaceFromClaims(claims)
+}
+
+// attachNamespace attaches the namespace of the user that sent the request to the context.
+// The namespace is taken from the signed access jwt, so namespaces are only honoured with the
+// acl feature turned on. Requests without a jwt stay in the galaxy namespace, the acl checks
+// reject them later on.
+func attachNamespace(ctx context.Context) (context.Context, error) {
+	if len(worker.Config.HmacSecret) == 0 {
+		return ctx, nil
+	}
+	ns, err := extractNamespace(ctx)
+	switch {
+	case err == x.ErrNoJwt:
+		return ctx, nil
+	case err != nil:
+		return ctx, status.Error(codes.Unauthenticated, err.Error())
+	}
+	return x.AttachNamespace(ctx, ns), nil
+}
+
 // validateLoginRequest validates that the login request has either the refresh token or the
 // <user id, password> pair
 func validateLoginRequest(request *api.LoginRequest) error {
@@ -234,12 +328,13 @@ func validateLoginRequest(request *api.LoginRequest) error {
 	return nil
 }
 
-// getAccessJwt constructs an access jwt with the given user id, groupIds,
+// getAccessJwt constructs an access jwt with the given user id, groupIds, namespace
 // and expiration TTL specified by worker.Config.AccessJwtTtl
-func getAccessJwt(userId string, groups []acl.Group) (string, error) {
+func getAccessJwt(userId string, groups []acl.Group, ns uint64) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"userid": userId,
-		"groups": acl.GetGroupIDs(groups),
+		"userid":    userId,
+		"groups":    acl.GetGroupIDs(groups),
+		"namespace": ns,
 		// set the jwt exp according to the ttl
 		"exp": time.Now().Add(worker.Config.AccessJwtTtl).Unix(),
 	})
@@ -251,12 +346,13 @@ func getAccessJwt(userId string, groups []acl.Group) (string, error) {
 	return jwtString, nil
 }
 
-// getRefreshJwt constructs a refresh jwt with the given user id, and expiration ttl specified by
-// worker.Config.RefreshJwtTtl
-func getRefreshJwt(userId string) (string, error) {
+// getRefreshJwt constructs a refresh jwt with the given user id, namespace, and expiration ttl
+// specified by worker.Config.RefreshJwtTtl
+func getRefreshJwt(userId string, ns uint64) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"userid": userId,
-		"exp":    time.Now().Add(worker.Config.RefreshJwtTtl).Unix(),
+		"userid":    userId,
+		"namespace": ns,
+		"exp":       time.Now().Add(worker.Config.RefreshJwtTtl).Unix(),
 	})
 
 	jwtString, err := token.SignedString([]byte(worker.Config.HmacSecret))
@@ -305,6 +401,11 @@ func authorizeUser(ctx context.Context, userid string, password string) (
 	return user, nil
 }
 
+func init() {
+	nsAclCaches.load = retrieveAcls
+	nsAclCaches.subscribe = subscribeForAcls
+}
+
 // RefreshAcls queries for the ACL triples and refreshes the ACLs accordingly.
 func RefreshAcls(closer *z.Closer) {
 	defer func() {
@@ -316,47 +417,128 @@ func RefreshAcls(closer *z.Closer) {
 		return
 	}
 
-	// retrieve the full data set of ACLs from the corresponding alpha server, and update the
-	// aclCachePtr
-	var maxRefreshTs uint64
-	retrieveAcls := func(refreshTs uint64) error {
-		if refreshTs <= maxRefreshTs {
-			return nil
-		}
-		maxRefreshTs = refreshTs
+	nsAclCaches.Lock()
+	nsAclCaches.enabled = true
+	nsAclCaches.Unlock()
 
-		glog.V(3).Infof("Refreshing ACLs")
-		queryRequest := api.Request{
-			Query:    queryAcls,
-			ReadOnly: true,
-			StartTs:  refreshTs,
-		}
-
-		queryResp, err := (&Server{}).doQuery(closer.Ctx(), &queryRequest, NoAuthorize)
-		if err != nil {
-			return errors.Errorf("unable to retrieve acls: %v", err)
-		}
-		groups, err := acl.UnmarshalGroups(queryResp.GetJson(), "allAcls")
-		if err != nil {
-			return err
-		}
+	closer.AddRunning(1)
+	go subscribeForAcls(x.GalaxyNamespace, aclCachePtr, closer)
 
-		aclCachePtr.update(groups)
-		glog.V(3).Infof("Updated the ACL cache")
-		return nil
+	<-closer.HasBeenClosed()
+	// Stop the subscriptions for the ACLs of the other namespaces.
+	nsAclCaches.Lock()
+	caches := nsAclCaches.m
+	nsAclCaches.m = make(map[uint64]*nsAclCache)
+	nsAclCaches.enabled = false
+	nsAclCaches.Unlock()
+	for _, c := range caches {
+		c.stop()
 	}
+}
 
-	closer.AddRunning(1)
-	go worker.SubscribeForUpdates(aclPrefixes, func(kvs *bpb.KVList) {
+// subscribeForAcls refreshes the cache with the ACLs of namespace ns every time they change,
+// until the closer is closed.
+func subscribeForAcls(ns uint64, cache *aclCache, closer *z.Closer) {
+	var maxRefreshTs uint64
+	worker.SubscribeForUpdates(aclPrefixes(ns), func(kvs *bpb.KVList) {
 		if kvs == nil || len(kvs.Kv) == 0 {
 			return
 		}
-		if err := retrieveAcls(kvs.Kv[0].Version); err != nil {
+		refreshTs := kvs.Kv[0].Version
+		if refreshTs <= maxRefreshTs {
+			return
+		}
+		maxRefreshTs = refreshTs
+
+		if err := retrieveAcls(closer.Ctx(), ns, cache, refreshTs); err != nil {
 			glog.Errorf("Error while retrieving acls: %v", err)
+			return
+		}
+		if ns != x.GalaxyNamespace && cache.empty() {
+			// A namespace always has the guardians group, unless it was deleted, possibly
+			// through another Alpha. The ACLs are loaded again if it's used after all.
+			go dropAclCache(ns)
 		}
 	}, 1, closer)
+}
 
-	<-closer.HasBeenClosed()
+// retrieveAcls retrieves the full data set of ACLs of namespace ns from the corresponding
+// alpha server, and updates the cache.
+func retrieveAcls(ctx context.Context, ns uint64, cache *aclCache, refreshTs uint64) error {
+	glog.V(3).Infof("Refreshing ACLs of namespace %d", ns)
+	queryRequest := api.Request{
+		Query:    queryAcls,
+		ReadOnly: true,
+		StartTs:  refreshTs,
+	}
+
+	queryResp, err := (&Server{}).doQuery(x.AttachNamespace(ctx, ns), &queryRequest, NoAuthorize)
+	if err != nil {
+		return errors.Errorf("unable to retrieve acls: %v", err)
+	}
+	groups, err := acl.UnmarshalGroups(queryResp.GetJson(), "allAcls")
+	if err != nil {
+		return err
+	}
+
+	cache.update(groups)
+	glog.V(3).Infof("Updated the ACL cache of namespace %d", ns)
+	return nil
+}
+
+// aclCacheFor returns the ACL cache of the namespace of the request. The ACLs of a namespace
+// other than the galaxy are loaded, and kept up to date, from the first request onwards. Each
+// namespace is loaded under its own lock, so a slow namespace doesn't hold up the others.
+func aclCacheFor(ctx context.Context) *aclCache {
+	ns := x.ExtractNamespace(ctx)
+	if ns == x.GalaxyNamespace {
+		return aclCachePtr
+	}
+
+	nsAclCaches.Lock()
+	if !nsAclCaches.enabled {
+		nsAclCaches.Unlock()
+		return newAclCache()
+	}
+	c, ok := nsAclCaches.m[ns]
+	if !ok {
+		c = &nsAclCache{cache: newAclCache()}
+		nsAclCaches.m[ns] = c
+	}
+	nsAclCaches.Unlock()
+
+	c.Lock()
+	defer c.Unlock()
+	if c.closer != nil {
+		return c.cache
+	}
+	if err := nsAclCaches.load(ctx, ns, c.cache, 0); err != nil {
+		// An empty cache blocks every predicate. Don't subscribe, so that the next request
+		// tries again.
+		glog.Errorf("Error while retrieving acls of namespace %d: %v", ns, err)
+		return c.cache
+	}
+
+	nsAclCaches.Lock()
+	defer nsAclCaches.Unlock()
+	if nsAclCaches.m[ns] != c {
+		// The namespace was deleted, or the ACLs stopped being refreshed, in the meantime.
+		return c.cache
+	}
+	c.closer = z.NewCloser(1)
+	go nsAclCaches.subscribe(ns, c.cache, c.closer)
+	return c.cache
+}
+
+// dropAclCache forgets about the ACLs of namespace ns, and stops refreshing them.
+func dropAclCache(ns uint64) {
+	nsAclCaches.Lock()
+	c, ok := nsAclCaches.m[ns]
+	delete(nsAclCaches.m, ns)
+	nsAclCaches.Unlock()
+	if ok {
+		c.stop()
+	}
 }
 
 const queryAcls = `
@@ -374,13 +556,16 @@ const queryAcls = `
 }
 `
 
-var aclPrefixes = [][]byte{
-	x.PredicatePrefix("dgraph.acl.permission"),
-	x.PredicatePrefix("dgraph.acl.predicate"),
-	x.PredicatePrefix("dgraph.acl.rule"),
-	x.PredicatePrefix("dgraph.user.group"),
-	x.PredicatePrefix("dgraph.type.Group"),
-	x.PredicatePrefix("dgraph.xid"),
+// aclPrefixes returns the prefixes of the keys that store the ACLs of namespace ns.
+func aclPrefixes(ns uint64) [][]byte {
+	return [][]byte{
+		x.PredicatePrefix(x.NamespaceAttr(ns, "dgraph.acl.permission")),
+		x.PredicatePrefix(x.NamespaceAttr(ns, "dgraph.acl.predicate")),
+		x.PredicatePrefix(x.NamespaceAttr(ns, "dgraph.acl.rule")),
+		x.PredicatePrefix(x.NamespaceAttr(ns, "dgraph.user.group")),
+		x.PredicatePrefix(x.NamespaceAttr(ns, "dgraph.type.Group")),
+		x.PredicatePrefix(x.NamespaceAttr(ns, "dgraph.xid")),
+	}
 }
 
 // clears the aclCachePtr and upserts the Groot account.
@@ -395,71 +580,101 @@ func ResetAcl(closer *z.Closer) {
 		return
 	}
 
-	// guardians is the group of users who have complete access over all predicates.
-	upsertGuardians := func(ctx context.Context) error {
-		query := fmt.Sprintf(`
+	for closer.Ctx().Err() == nil {
+		ctx, cancel := context.WithTimeout(closer.Ctx(), time.Minute)
+		defer cancel()
+		guardiansUid, err := upsertGuardians(ctx)
+		if err != nil {
+			glog.Infof("Unable to upsert the guardian group. Error: %v", err)
+			time.Sleep(100 * time.Millisecond)
+			continue
+		}
+		atomic.StoreUint64(&x.GuardiansGroupUid, guardiansUid)
+		break
+	}
+
+	for closer.Ctx().Err() == nil {
+		ctx, cancel := context.WithTimeout(closer.Ctx(), time.Minute)
+		defer cancel()
+		grootUid, err := upsertGroot(ctx, "password")
+		if err != nil {
+			glog.Infof("Unable to upsert the groot account. Error: %v", err)
+			time.Sleep(100 * time.Millisecond)
+			continue
+		}
+		atomic.StoreUint64(&x.GrootUserUid, grootUid)
+		break
+	}
+}
+
+// upsertGuardians creates the guardians group in the namespace of the context, if it doesn't
+// exist yet, and returns its uid. guardians is the group of users who have complete access over
+// all predicates.
+func upsertGuardians(ctx context.Context) (uint64, error) {
+	query := fmt.Sprintf(`
 			{
 				guid as guardians(func: eq(dgraph.xid, "%s")){
 					uid
 				}
 			}
 		`, x.GuardiansId)
-		groupNQuads := acl.CreateGroupNQuads(x.GuardiansId)
-		req := &api.Request{
-			CommitNow: true,
-			Query:     query,
-			Mutations: []*api.Mutation{
-				{
-					Set:  groupNQuads,
-					Cond: "@if(eq(len(guid), 0))",
-				},
+	groupNQuads := acl.CreateGroupNQuads(x.GuardiansId)
+	req := &api.Request{
+		CommitNow: true,
+		Query:     query,
+		Mutations: []*api.Mutation{
+			{
+				Set:  groupNQuads,
+				Cond: "@if(eq(len(guid), 0))",
 			},
-		}
-
-		resp, err := (&Server{}).doQuery(ctx, req, NoAuthorize)
+		},
+	}
 
-		// Structs to parse guardians group uid from query response
-		type groupNode struct {
-			Uid string `json:"uid"`
-		}
+	resp, err := (&Server{}).doQuery(ctx, req, NoAuthorize)
 
-		type groupQryResp struct {
-			GuardiansGroup []groupNode `json:"guardians"`
-		}
+	// Structs to parse guardians group uid from query response
+	type groupNode struct {
+		Uid string `json:"uid"`
+	}
 
-		if err != nil {
-			return errors.Wrapf(err, "while upserting group with id %s", x.GuardiansId)
-		}
-		var groupResp groupQryResp
-		var guardiansGroupUid string
-		if err := json.Unmarshal(resp.GetJson(), &groupResp); err != nil {
-			return errors.Wrap(err, "Couldn't unmarshal response from guardians group query")
-		}
-		if len(groupResp.GuardiansGroup) == 0 {
-			// no guardians group found
-			// Extract guardians group uid from mutation
-			newGroupUidMap := resp.GetUids()
-			guardiansGroupUid = newGroupUidMap["newgroup"]
-		} else if len(groupResp.GuardiansGroup) == 1 {
-			// we found a guardians group
-			guardiansGroupUid = groupResp.GuardiansGroup[0].Uid
-		} else {
-			return errors.Wrap(err, "Multiple guardians group found")
-		}
+	type groupQryResp struct {
+		GuardiansGroup []groupNode `json:"guardians"`
+	}
 
-		guardiansGroupUidUint, err := strconv.ParseUint(guardiansGroupUid, 0, 64)
-		if err != nil {
-			return errors.Wrapf(err, "Error while parsing Uid: %s of guardians Group", guardiansGroupUid)
-		}
-		atomic.StoreUint64(&x.GuardiansGroupUid, guardiansGroupUidUint)
+	if err != nil {
+		return 0, errors.Wrapf(err, "while upserting group with id %s", x.GuardiansId)
+	}
+	var groupResp groupQryResp
+	var guardiansGroupUid string
+	if err := json.Unmarshal(resp.GetJson(), &groupResp); err != nil {
+		return 0, errors.Wrap(err, "Couldn't unmarshal response from guardians group query")
+	}
+	if len(groupResp.GuardiansGroup) == 0 {
+		// no guardians group found
+		// Extract guardians group uid from mutation
+		newGroupUidMap := resp.GetUids()
+		guardiansGroupUid = newGroupUidMap["newgroup"]
+	} else if len(groupResp.GuardiansGroup) == 1 {
+		// we found a guardians group
+		guardiansGroupUid = groupResp.GuardiansGroup[0].Uid
+	} else {
+		return 0, errors.Wrap(err, "Multiple guardians group found")
+	}
 
-		glog.Infof("Successfully upserted the guardians group")
-		return nil
+	guardiansGroupUidUint, err := strconv.ParseUint(guardiansGroupUid, 0, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "Error while parsing Uid: %s of guardians Group",
+			guardiansGroupUid)
 	}
 
-	// groot is the default user of guardians group.
-	upsertGroot := func(ctx context.Context) error {
-		query := fmt.Sprintf(`
+	glog.Infof("Successfully upserted the guardians group")
+	return guardiansGroupUidUint, nil
+}
+
+// upsertGroot creates the groot user with the given password in the namespace of the context,
+// if it doesn't exist yet, and returns its uid. groot is the default user of guardians group.
+func upsertGroot(ctx context.Context, password string) (uint64, error) {
+	query := fmt.Sprintf(`
 			{
 				grootid as grootUser(func: eq(dgraph.xid, "%s")){
 					uid
@@ -467,86 +682,71 @@ func ResetAcl(closer *z.Closer) {
 				guid as var(func: eq(dgraph.xid, "%s"))
 			}
 		`, x.GrootId, x.GuardiansId)
-		userNQuads := acl.CreateUserNQuads(x.GrootId, "password")
-		userNQuads = append(userNQuads, &api.NQuad{
-			Subject:   "_:newuser",
-			Predicate: "dgraph.user.group",
-			ObjectId:  "uid(guid)",
-		})
-		req := &api.Request{
-			CommitNow: true,
-			Query:     query,
-			Mutations: []*api.Mutation{
-				{
-					Set: userNQuads,
-					// Assuming that if groot exists, it is in guardian group
-					Cond: "@if(eq(len(grootid), 0) and gt(len(guid), 0))",
-				},
+	userNQuads := acl.CreateUserNQuads(x.GrootId, password)
+	userNQuads = append(userNQuads, &api.NQuad{
+		Subject:   "_:newuser",
+		Predicate: "dgraph.user.group",
+		ObjectId:  "uid(guid)",
+	})
+	req := &api.Request{
+		CommitNow: true,
+		Query:     query,
+		Mutations: []*api.Mutation{
+			{
+				Set: userNQuads,
+				// Assuming that if groot exists, it is in guardian group
+				Cond: "@if(eq(len(grootid), 0) and gt(len(guid), 0))",
 			},
-		}
-
-		resp, err := (&Server{}).doQuery(ctx, req, NoAuthorize)
-		if err != nil {
-			return errors.Wrapf(err, "while upserting user with id %s", x.GrootId)
-		}
-
-		// Structs to parse groot user uid from query response
-		type userNode struct {
-			Uid string `json:"uid"`
-		}
+		},
+	}
 
-		type userQryResp struct {
-			GrootUser []userNode `json:"grootUser"`
-		}
+	resp, err := (&Server{}).doQuery(ctx, req, NoAuthorize)
+	if err != nil {
+		return 0, errors.Wrapf(err, "while upserting user with id %s", x.GrootId)
+	}
 
-		var grootUserUid string
-		var userResp userQryResp
-		if err := json.Unmarshal(resp.GetJson(), &userResp); err != nil {
-			return errors.Wrap(err, "Couldn't unmarshal response from groot user query")
-		}
-		if len(userResp.GrootUser) == 0 {
-			// no groot user found from query
-			// Extract uid of created groot user from mutation
-			newUserUidMap := resp.GetUids()
-			grootUserUid = newUserUidMap["newuser"]
-		} else if len(userResp.GrootUser) == 1 {
-			// we found a groot user
-			grootUserUid = userResp.GrootUser[0].Uid
-		} else {
-			return errors.Wrap(err, "Multiple groot users found")
-		}
+	// Structs to parse groot user uid from query response
+	type userNode struct {
+		Uid string `json:"uid"`
+	}
 
-		grootUserUidUint, err := strconv.ParseUint(grootUserUid, 0, 64)
-		if err != nil {
-			return errors.Wrapf(err, "Error while parsing Uid: %s of groot user", grootUserUid)
-		}
-		atomic.StoreUint64(&x.GrootUserUid, grootUserUidUint)
+	type userQryResp struct {
+		GrootUser []userNode `json:"grootUser"`
+	}
 
-		glog.Infof("Successfully upserted groot account")
-		return nil
+	var grootUserUid string
+	var userResp userQryResp
+	if err := json.Unmarshal(resp.GetJson(), &userResp); err != nil {
+		return 0, errors.Wrap(err, "Couldn't unmarshal response from groot user query")
+	}
+	if len(userResp.GrootUser) == 0 {
+		// no groot user found from query
+		// Extract uid of created groot user from mutation
+		newUserUidMap := resp.GetUids()
+		grootUserUid = newUserUidMap["newuser"]
+	} else if len(userResp.GrootUser) == 1 {
+		// we found a groot user
+		grootUserUid = userResp.GrootUser[0].Uid
+	} else {
+		return 0, errors.Wrap(err, "Multiple groot users found")
 	}
 
-	for closer.Ctx().Err() == nil {
-		ctx, cancel := context.WithTimeout(closer.Ctx(), time.Minute)
-		defer cancel()
-		if err := upsertGuardians(ctx); err != nil {
-			glog.Infof("Unable to upsert the guardian group. Error: %v", err)
-			time.Sleep(100 * time.Millisecond)
-			continue
-		}
-		break
+	grootUserUidUint, err := strconv.ParseUint(grootUserUid, 0, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "Error while parsing Uid: %s of groot user", grootUserUid)
 	}
 
-	for closer.Ctx().Err() == nil {
-		ctx, cancel := context.WithTimeout(closer.Ctx(), time.Minute)
-		defer cancel()
-		if err := upsertGroot(ctx); err != nil {
-			glog.Infof("Unable to upsert the groot account. Error: %v", err)
-			time.Sleep(100 * time.Millisecond)
-			continue
-		}
-		break
+	glog.Infof("Successfully upserted groot account")
+	return grootUserUidUint, nil
+}
+
+// requestUser returns the ID of the user sending the request, or "" if it can't be told.
+func requestUser(ctx context.Context) string {
+	userData, err := extractUserAndGroups(ctx)
+	if err != nil || len(userData) == 0 {
+		return ""
 	}
+	return userData[0]
 }
 
 // extract the userId, groupIds from the accessJwt in the context
@@ -558,12 +758,13 @@ func extractUserAndGroups(ctx context.Context) ([]string, error) {
 	return validateToken(accessJwt[0])
 }
 
-func authorizePreds(userId string, groupIds, preds []string,
+func authorizePreds(ctx context.Context, userId string, groupIds, preds []string,
 	aclOp *acl.Operation) (map[string]struct{}, []string) {
 
+	cache := aclCacheFor(ctx)
 	blockedPreds := make(map[string]struct{})
 	for _, pred := range preds {
-		if err := aclCachePtr.authorizePredicate(groupIds, pred, aclOp); err != nil {
+		if err := cache.authorizePredicate(groupIds, pred, aclOp); err != nil {
 			logAccess(&accessEntry{
 				userId:    userId,
 				groups:    groupIds,
@@ -575,16 +776,16 @@ func authorizePreds(userId string, groupIds, preds []string,
 			blockedPreds[pred] = struct{}{}
 		}
 	}
-	aclCachePtr.RLock()
-	allowedPreds := make([]string, len(aclCachePtr.userPredPerms[userId]))
+	cache.RLock()
+	allowedPreds := make([]string, len(cache.userPredPerms[userId]))
 	// User can have multiple permission for same predicate, add predicate
 	// only if the acl.Op is covered in the set of permissions for the user
-	for predicate, perm := range aclCachePtr.userPredPerms[userId] {
+	for predicate, perm := range cache.userPredPerms[userId] {
 		if (perm & aclOp.Code) > 0 {
 			allowedPreds = append(allowedPreds, predicate)
 		}
 	}
-	aclCachePtr.RUnlock()
+	cache.RUnlock()
 	return blockedPreds, allowedPreds
 }
 
@@ -641,7 +842,7 @@ func authorizeAlter(ctx context.Context, op *api.Operation) error {
 				"only guardians are allowed to drop all data, but the current user is %s", userId)
 		}
 
-		blockedPreds, _ := authorizePreds(userId, groupIds, preds, acl.Modify)
+		blockedPreds, _ := authorizePreds(ctx, userId, groupIds, preds, acl.Modify)
 		if len(blockedPreds) > 0 {
 			var msg strings.Builder
 			for key := range blockedPreds {
@@ -752,7 +953,7 @@ func authorizeMutation(ctx context.Context, gmu *gql.Mutation) error {
 			return nil
 		}
 
-		blockedPreds, allowedPreds := authorizePreds(userId, groupIds, preds, acl.Write)
+		blockedPreds, allowedPreds := authorizePreds(ctx, userId, groupIds, preds, acl.Write)
 		if len(blockedPreds) > 0 {
 			var msg strings.Builder
 			for key := range blockedPreds {
@@ -895,7 +1096,7 @@ func authorizeQuery(ctx context.Context, parsedReq *gql.Result, graphql bool) er
 			return nil, nil, nil
 		}
 
-		blockedPreds, allowedPreds := authorizePreds(userId, groupIds, preds, acl.Read)
+		blockedPreds, allowedPreds := authorizePreds(ctx, userId, groupIds, preds, acl.Read)
 		return blockedPreds, allowedPreds, nil
 	}
 
@@ -984,7 +1185,7 @@ func authorizeSchemaQuery(ctx context.Context, er *query.ExecutionResult) error
 			// Members of guardian groups are allowed to query anything.
 			return nil, nil
 		}
-		blockedPreds, _ := authorizePreds(userId, groupIds, preds, acl.Read)
+		blockedPreds, _ := authorizePreds(ctx, userId, groupIds, preds, acl.Read)
 
 		return blockedPreds, nil
 	}
@@ -1041,6 +1242,18 @@ func AuthorizeGuardians(ctx context.Context) error {
 			return status.Error(codes.PermissionDenied, fmt.Sprintf("Only guardians are "+
 				"allowed access. User '%v' is not a member of guardians group.", userId))
 		}
+
+		// The guardians of a namespace only administer the data of their namespace, the
+		// cluster is administered by the guardians of the galaxy.
+		ns, err := extractNamespace(ctx)
+		if err != nil {
+			return status.Error(codes.Unauthenticated, err.Error())
+		}
+		if ns != x.GalaxyNamespace {
+			return status.Error(codes.PermissionDenied, fmt.Sprintf("Only guardians of the "+
+				"galaxy namespace are allowed access. User '%v' belongs to namespace %d.",
+				userId, ns))
+		}
 	}
 
 	return nil
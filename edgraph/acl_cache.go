@@ -13,10 +13,12 @@
 package edgraph
 
 import (
+	"context"
 	"sync"
 
 	"github.com/dgraph-io/dgraph/ee/acl"
 	"github.com/dgraph-io/dgraph/x"
+	"github.com/dgraph-io/ristretto/z"
 	"github.com/pkg/errors"
 )
 
@@ -27,9 +29,46 @@ type aclCache struct {
 	userPredPerms map[string]map[string]int32
 }
 
-var aclCachePtr = &aclCache{
-	predPerms:     make(map[string]map[string]int32),
-	userPredPerms: make(map[string]map[string]int32),
+// aclCachePtr holds the ACLs of the galaxy namespace.
+var aclCachePtr = newAclCache()
+
+// nsAclCaches holds the ACLs of the other namespaces. They are loaded the first time a user
+// of the namespace is authorized, once the ACLs are refreshed by RefreshAcls.
+var nsAclCaches = struct {
+	sync.Mutex
+	enabled bool
+	m       map[uint64]*nsAclCache
+
+	// load and subscribe load the ACLs of a namespace, and keep them up to date. They're
+	// retrieveAcls and subscribeForAcls, replaced in tests.
+	load      func(ctx context.Context, ns uint64, cache *aclCache, readTs uint64) error
+	subscribe func(ns uint64, cache *aclCache, closer *z.Closer)
+}{m: make(map[uint64]*nsAclCache)}
+
+// nsAclCache holds the ACLs of a namespace other than the galaxy.
+type nsAclCache struct {
+	// The lock is held while the ACLs are loaded.
+	sync.Mutex
+	cache *aclCache
+	// closer stops the subscription refreshing the ACLs. It's set once they're loaded.
+	closer *z.Closer
+}
+
+// stop stops refreshing the ACLs of the namespace.
+func (c *nsAclCache) stop() {
+	c.Lock()
+	closer := c.closer
+	c.Unlock()
+	if closer != nil {
+		closer.SignalAndWait()
+	}
+}
+
+func newAclCache() *aclCache {
+	return &aclCache{
+		predPerms:     make(map[string]map[string]int32),
+		userPredPerms: make(map[string]map[string]int32),
+	}
 }
 
 func (cache *aclCache) update(groups []acl.Group) {
@@ -99,10 +138,17 @@ func (cache *aclCache) update(groups []acl.Group) {
 		}
 	}
 
-	aclCachePtr.Lock()
-	defer aclCachePtr.Unlock()
-	aclCachePtr.predPerms = predPerms
-	aclCachePtr.userPredPerms = userPredPerms
+	cache.Lock()
+	defer cache.Unlock()
+	cache.predPerms = predPerms
+	cache.userPredPerms = userPredPerms
+}
+
+// empty tells if the cache holds no user at all.
+func (cache *aclCache) empty() bool {
+	cache.RLock()
+	defer cache.RUnlock()
+	return len(cache.userPredPerms) == 0
 }
 
 func (cache *aclCache) authorizePredicate(groups []string, predicate string,
@@ -111,9 +157,9 @@ func (cache *aclCache) authorizePredicate(groups []string, predicate string,
 		return errors.Errorf("only groot is allowed to access the ACL predicate: %s", predicate)
 	}
 
-	aclCachePtr.RLock()
-	predPerms := aclCachePtr.predPerms
-	aclCachePtr.RUnlock()
+	cache.RLock()
+	predPerms := cache.predPerms
+	cache.RUnlock()
 
 	if groupPerms, found := predPerms[predicate]; found {
 		if hasRequiredAccess(groupPerms, groups, operation) {
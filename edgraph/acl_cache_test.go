@@ -13,9 +13,13 @@
 package edgraph
 
 import (
+	"context"
 	"testing"
 
 	"github.com/dgraph-io/dgraph/ee/acl"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/dgraph-io/ristretto/z"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 )
 
@@ -55,3 +59,121 @@ func TestAclCache(t *testing.T) {
 	require.Error(t, aclCachePtr.authorizePredicate(emptyGroups, predicate, acl.Read),
 		"the anonymous user should not have access when the acl cache is empty")
 }
+
+// withNsAclCaches replaces the loading of the ACLs of the namespaces by the given groups, until
+// the returned function is called.
+func withNsAclCaches(load func(ns uint64) ([]acl.Group, error)) func() {
+	nsAclCaches.Lock()
+	nsAclCaches.enabled = true
+	nsAclCaches.m = make(map[uint64]*nsAclCache)
+	nsAclCaches.load = func(_ context.Context, ns uint64, cache *aclCache, _ uint64) error {
+		groups, err := load(ns)
+		if err != nil {
+			return err
+		}
+		cache.update(groups)
+		return nil
+	}
+	nsAclCaches.subscribe = func(_ uint64, _ *aclCache, closer *z.Closer) {
+		<-closer.HasBeenClosed()
+		closer.Done()
+	}
+	nsAclCaches.Unlock()
+
+	return func() {
+		nsAclCaches.Lock()
+		caches := nsAclCaches.m
+		nsAclCaches.m = make(map[uint64]*nsAclCache)
+		nsAclCaches.enabled = false
+		nsAclCaches.load = retrieveAcls
+		nsAclCaches.subscribe = subscribeForAcls
+		nsAclCaches.Unlock()
+		for _, c := range caches {
+			c.stop()
+		}
+	}
+}
+
+func devGroup(user, predicate string) []acl.Group {
+	return []acl.Group{{
+		GroupID: "dev",
+		Users:   []acl.User{{UserID: user}},
+		Rules:   []acl.Acl{{Predicate: predicate, Perm: 4}},
+	}}
+}
+
+func TestAclCacheNamespaces(t *testing.T) {
+	defer withNsAclCaches(func(ns uint64) ([]acl.Group, error) {
+		switch ns {
+		case 1:
+			return devGroup("alice", "name"), nil
+		case 2:
+			return devGroup("bob", "age"), nil
+		}
+		return nil, errors.Errorf("namespace %d not found", ns)
+	})()
+
+	ns1 := aclCacheFor(x.AttachNamespace(context.Background(), 1))
+	ns2 := aclCacheFor(x.AttachNamespace(context.Background(), 2))
+	require.NotEqual(t, ns1, ns2)
+	require.Equal(t, ns1, aclCacheFor(x.AttachNamespace(context.Background(), 1)))
+
+	// The same group has different rules in each namespace.
+	require.NoError(t, ns1.authorizePredicate([]string{"dev"}, "name", acl.Read))
+	require.Error(t, ns1.authorizePredicate([]string{"dev"}, "age", acl.Read))
+	require.NoError(t, ns2.authorizePredicate([]string{"dev"}, "age", acl.Read))
+	require.Error(t, ns2.authorizePredicate([]string{"dev"}, "name", acl.Read))
+	require.Contains(t, ns1.userPredPerms, "alice")
+	require.NotContains(t, ns1.userPredPerms, "bob")
+
+	// A namespace that couldn't be loaded blocks everything, and is loaded again next time.
+	ns3 := aclCacheFor(x.AttachNamespace(context.Background(), 3))
+	require.Error(t, ns3.authorizePredicate([]string{"dev"}, "name", acl.Read))
+	nsAclCaches.Lock()
+	require.Nil(t, nsAclCaches.m[3].closer)
+	nsAclCaches.Unlock()
+}
+
+func TestAclCacheSlowNamespace(t *testing.T) {
+	release := make(chan struct{})
+	defer withNsAclCaches(func(ns uint64) ([]acl.Group, error) {
+		if ns == 1 {
+			<-release
+		}
+		return devGroup("alice", "name"), nil
+	})()
+
+	loaded := make(chan *aclCache)
+	go func() {
+		loaded <- aclCacheFor(x.AttachNamespace(context.Background(), 1))
+	}()
+	// Namespace 2 is loaded while namespace 1 is still loading.
+	ns2 := aclCacheFor(x.AttachNamespace(context.Background(), 2))
+	require.NoError(t, ns2.authorizePredicate([]string{"dev"}, "name", acl.Read))
+	close(release)
+	ns1 := <-loaded
+	require.NoError(t, ns1.authorizePredicate([]string{"dev"}, "name", acl.Read))
+}
+
+func TestDropAclCache(t *testing.T) {
+	defer withNsAclCaches(func(ns uint64) ([]acl.Group, error) {
+		return devGroup("alice", "name"), nil
+	})()
+
+	aclCacheFor(x.AttachNamespace(context.Background(), 1))
+	nsAclCaches.Lock()
+	c := nsAclCaches.m[1]
+	nsAclCaches.Unlock()
+	require.NotNil(t, c.closer)
+
+	dropAclCache(1)
+	nsAclCaches.Lock()
+	require.NotContains(t, nsAclCaches.m, uint64(1))
+	nsAclCaches.Unlock()
+	// The subscription refreshing the ACLs is stopped.
+	select {
+	case <-c.closer.HasBeenClosed():
+	default:
+		t.Fatal("the subscription of the deleted namespace wasn't stopped")
+	}
+}
@@ -0,0 +1,238 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/dgraph-io/dgo/v200/protos/api"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+
+	"github.com/dgraph-io/dgraph/algo"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// Analytics tasks run graph algorithms over the edges of a uid predicate, and write their results
+// as new predicates of the nodes, which can then be queried and indexed like any other predicate.
+// A task is run by the leader of the group serving the predicate, which reads its posting lists
+// directly, at a single timestamp. The results are written to predicates served by the same
+// group, as posting lists proposed through Raft, see worker.WriteValues. Those predicates are
+// created through the Alter path, and must not have an index while the results are written.
+
+// AnalyticsRequest holds the parameters of an analytics task.
+type AnalyticsRequest struct {
+	// Predicate is the uid predicate whose edges the algorithms run over.
+	Predicate string `json:"predicate"`
+	// Namespace is the namespace of the predicate, where the results are written too.
+	Namespace uint64 `json:"namespace"`
+	// Algorithms are the algorithms to run: pagerank, wcc, lpa and degree.
+	Algorithms []string `json:"algorithms"`
+	// Prefix is the prefix of the predicates the results are written to.
+	Prefix string `json:"prefix"`
+	// Iterations is the maximum number of iterations of PageRank and label propagation.
+	Iterations int `json:"iterations"`
+	// Damping is the damping factor of PageRank.
+	Damping float64 `json:"damping"`
+	// Tolerance stops PageRank once the ranks change by less than it in total.
+	Tolerance float64 `json:"tolerance"`
+}
+
+func init() {
+	RegisterTask("analytics", func(ctx context.Context, b json.RawMessage,
+		_ *worker.Credentials) ([]string, error) {
+		var req AnalyticsRequest
+		if err := json.Unmarshal(b, &req); err != nil {
+			return nil, err
+		}
+		return runAnalytics(x.AttachNamespace(ctx, req.Namespace), &req)
+	})
+}
+
+// SubmitAnalytics checks the parameters of an analytics task, sets the defaults of the ones that
+// aren't set, and submits the task, to be run by the leader of the group serving the predicate.
+func SubmitAnalytics(ctx context.Context, req *AnalyticsRequest) (*Task, error) {
+	if err := req.normalize(); err != nil {
+		return nil, err
+	}
+	gid, err := worker.ServingGroup(x.AttachNamespace(ctx, req.Namespace), req.Predicate)
+	switch {
+	case err != nil:
+		return nil, err
+	case gid == 0:
+		return nil, errors.Errorf("predicate %s not found", req.Predicate)
+	}
+	return submitTask(ctx, "analytics", req, nil, gid)
+}
+
+// isIRIName tells if name can be written between < and > in a schema.
+func isIRIName(name string) bool {
+	return name != "" && !strings.ContainsAny(name, "<>\"{}|^`\\ \t\r\n")
+}
+
+// isNamespaced tells if name holds the separator of the namespace of a predicate, see
+// x.NamespaceAttr. The namespace of a task is set by its request instead.
+func isNamespaced(name string) bool {
+	return strings.IndexByte(name, 0) >= 0
+}
+
+func (req *AnalyticsRequest) normalize() error {
+	if !isIRIName(req.Predicate) || isNamespaced(req.Predicate) {
+		return errors.Errorf("invalid predicate %q", req.Predicate)
+	}
+	if len(req.Algorithms) == 0 {
+		req.Algorithms = []string{"pagerank"}
+	}
+	for _, a := range req.Algorithms {
+		switch a {
+		case "pagerank", "wcc", "lpa", "degree":
+		default:
+			return errors.Errorf("unknown algorithm %q, it must be pagerank, wcc, lpa or degree", a)
+		}
+	}
+	if req.Prefix == "" {
+		req.Prefix = req.Predicate + "."
+	}
+	switch {
+	case !isIRIName(req.Prefix):
+		return errors.Errorf("invalid prefix %q", req.Prefix)
+	case isNamespaced(req.Prefix):
+		return errors.Errorf("invalid prefix %q, the results are written in the namespace "+
+			"of the request", req.Prefix)
+	case x.IsReservedPredicate(req.Prefix):
+		return errors.Errorf("invalid prefix %q, the predicates prefixed with dgraph. are "+
+			"reserved", req.Prefix)
+	}
+	if req.Iterations <= 0 {
+		req.Iterations = 20
+	}
+	if req.Damping == 0 {
+		req.Damping = 0.85
+	}
+	if req.Damping < 0 || req.Damping >= 1 {
+		return errors.Errorf("the damping factor must be between 0 and 1")
+	}
+	if req.Tolerance <= 0 {
+		req.Tolerance = 1e-6
+	}
+	return nil
+}
+
+// analyticsResult holds the values of a predicate written by an algorithm, for the nodes of the
+// graph.
+type analyticsResult struct {
+	pred  string
+	tid   types.TypeID
+	value func(i int) interface{}
+}
+
+// runAnalytics runs the algorithms of req, and returns the predicates it wrote.
+func runAnalytics(ctx context.Context, req *AnalyticsRequest) ([]string, error) {
+	edges, err := worker.ReadEdges(ctx, req.Predicate, worker.State.GetTimestamp(true))
+	if err != nil {
+		return nil, errors.Wrapf(err, "while reading the edges of %s", req.Predicate)
+	}
+	g := algo.NewGraph(edges)
+	glog.Infof("Read %d nodes with edges for %s", len(g.Uids), req.Predicate)
+
+	var results []analyticsResult
+	for _, a := range req.Algorithms {
+		switch a {
+		case "pagerank":
+			rank := algo.PageRank(g, req.Damping, req.Iterations, req.Tolerance)
+			results = append(results, analyticsResult{"pagerank", types.FloatID,
+				func(i int) interface{} { return rank[i] }})
+		case "wcc":
+			components := algo.ConnectedComponents(g)
+			results = append(results, analyticsResult{"component", types.IntID,
+				func(i int) interface{} { return int64(components[i]) }})
+		case "lpa":
+			labels := algo.LabelPropagation(g, req.Iterations)
+			results = append(results, analyticsResult{"community", types.IntID,
+				func(i int) interface{} { return int64(labels[i]) }})
+		case "degree":
+			in, out, centrality := algo.DegreeCentrality(g)
+			results = append(results,
+				analyticsResult{"in_degree", types.IntID,
+					func(i int) interface{} { return in[i] }},
+				analyticsResult{"out_degree", types.IntID,
+					func(i int) interface{} { return out[i] }},
+				analyticsResult{"degree_centrality", types.FloatID,
+					func(i int) interface{} { return centrality[i] }})
+		}
+	}
+
+	var output []string
+	for _, r := range results {
+		pred := req.Prefix + r.pred
+		if err := writeAnalyticsResult(ctx, g, pred, r); err != nil {
+			return nil, errors.Wrapf(err, "while writing %s", pred)
+		}
+		glog.Infof("Wrote %s for %d nodes", pred, len(g.Uids))
+		output = append(output, pred)
+	}
+	return output, nil
+}
+
+// predicateSchema returns the schema of the predicate, or nil if it has none.
+func predicateSchema(ctx context.Context, pred string) (*pb.SchemaNode, error) {
+	nodes, err := worker.GetSchemaOverNetwork(ctx, &pb.SchemaRequest{Predicates: []string{pred}})
+	if err != nil {
+		return nil, err
+	}
+	for _, node := range nodes {
+		if node.Predicate == pred {
+			return node, nil
+		}
+	}
+	return nil, nil
+}
+
+// writeAnalyticsResult writes the values of the result r as the predicate pred of the nodes of
+// the graph, creating the predicate if it doesn't exist. The values written by a previous run
+// for the nodes that aren't in the graph anymore are deleted.
+func writeAnalyticsResult(ctx context.Context, g *algo.Graph, pred string,
+	r analyticsResult) error {
+
+	sch, err := predicateSchema(ctx, pred)
+	switch {
+	case err != nil:
+		return err
+	case sch == nil:
+		// The group of this Alpha is asked to serve the new predicate.
+		op := &api.Operation{Schema: fmt.Sprintf("<%s>: %s .", pred, r.tid.Name())}
+		if _, err := doAlter(ctx, op); err != nil {
+			return err
+		}
+	case sch.Type != r.tid.Name() || sch.List:
+		return errors.Errorf("the predicate already exists with another type, drop it first " +
+			"or pick another prefix")
+	}
+
+	vals := make([]types.Val, len(g.Uids))
+	for i := range g.Uids {
+		vals[i] = types.Val{Tid: r.tid, Value: r.value(i)}
+	}
+	readTs := worker.State.GetTimestamp(true)
+	return worker.WriteValues(ctx, pred, g.Uids, vals, readTs, worker.State.GetTimestamp(false))
+}
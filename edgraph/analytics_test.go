@@ -0,0 +1,66 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/x"
+)
+
+func TestAnalyticsRequestNormalize(t *testing.T) {
+	req := &AnalyticsRequest{Predicate: "follows"}
+	require.NoError(t, req.normalize())
+	require.Equal(t, &AnalyticsRequest{
+		Predicate:  "follows",
+		Algorithms: []string{"pagerank"},
+		Prefix:     "follows.",
+		Iterations: 20,
+		Damping:    0.85,
+		Tolerance:  1e-6,
+	}, req)
+
+	tests := []struct {
+		req *AnalyticsRequest
+		err string
+	}{
+		{&AnalyticsRequest{}, `invalid predicate ""`},
+		{&AnalyticsRequest{Predicate: "a> { b"}, `invalid predicate "a> { b"`},
+		{&AnalyticsRequest{Predicate: "follows", Algorithms: []string{"wcc", "bfs"}},
+			`unknown algorithm "bfs", it must be pagerank, wcc, lpa or degree`},
+		{&AnalyticsRequest{Predicate: "follows", Prefix: "follows "},
+			`invalid prefix "follows "`},
+		{&AnalyticsRequest{Predicate: "follows", Prefix: "dgraph.follows."},
+			`invalid prefix "dgraph.follows.", the predicates prefixed with dgraph. are reserved`},
+		{&AnalyticsRequest{Predicate: "dgraph.user.group"},
+			`invalid prefix "dgraph.user.group.", the predicates prefixed with dgraph. are ` +
+				`reserved`},
+		{&AnalyticsRequest{Predicate: x.NamespaceAttr(2, "follows")},
+			fmt.Sprintf("invalid predicate %q", x.NamespaceAttr(2, "follows"))},
+		{&AnalyticsRequest{Predicate: "follows", Prefix: x.NamespaceAttr(2, "follows.")},
+			fmt.Sprintf("invalid prefix %q, the results are written in the namespace of the "+
+				"request", x.NamespaceAttr(2, "follows."))},
+		{&AnalyticsRequest{Predicate: "follows", Damping: 1.5},
+			"the damping factor must be between 0 and 1"},
+	}
+	for _, tc := range tests {
+		require.EqualError(t, tc.req.normalize(), tc.err)
+	}
+}
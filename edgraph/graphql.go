@@ -264,11 +264,11 @@ func ProcessPersistedQuery(ctx context.Context, gqlReq *schema.Request) error {
 }
 
 func hashMatches(query, sha256Hash string) (bool, error) {
-	hasher := sha256.New()
-	_, err := hasher.Write([]byte(query))
-	if err != nil {
-		return false, err
-	}
-	hashGenerated := hex.EncodeToString(hasher.Sum(nil))
-	return hashGenerated == sha256Hash, nil
+	return queryHash(query) == sha256Hash, nil
+}
+
+// queryHash returns the hex encoded sha256 hash of the query, as sent by clients.
+func queryHash(query string) string {
+	hash := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(hash[:])
 }
@@ -0,0 +1,117 @@
+// +build !oss
+
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Dgraph Community License (the "License"); you
+ * may not use this file except in compliance with the License. You
+ * may obtain a copy of the License at
+ *
+ *     https://github.com/dgraph-io/dgraph/blob/master/licenses/DCL.txt
+ */
+
+package edgraph
+
+import (
+	"context"
+
+	"github.com/dgraph-io/dgo/v200/protos/api"
+	"github.com/dgraph-io/dgraph/gql"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/query"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+)
+
+// CreateNamespace creates a new namespace with the initial schema, a guardians group and a
+// groot user with the given password. It returns the id of the namespace.
+func CreateNamespace(ctx context.Context, password string) (uint64, error) {
+	if len(worker.Config.HmacSecret) == 0 {
+		return 0, errors.New("Namespaces can only be used with the acl feature turned on")
+	}
+	if len(password) == 0 {
+		return 0, errors.New("The password of the groot user should not be empty")
+	}
+
+	// Namespace ids are leased like uids, so that they are unique across the cluster.
+	ids, err := worker.AssignUidsOverNetwork(ctx, &pb.Num{Val: 1})
+	if err != nil {
+		return 0, errors.Wrapf(err, "while allocating the namespace id")
+	}
+	ns := ids.StartId
+	ctx = x.AttachNamespace(ctx, ns)
+
+	m := &pb.Mutations{
+		StartTs: worker.State.GetTimestamp(false),
+		Schema:  schema.InitialSchema(),
+		Types:   schema.InitialTypes(),
+	}
+	if _, err := query.ApplyMutations(ctx, m); err != nil {
+		return 0, errors.Wrapf(err, "while applying the initial schema of namespace %d", ns)
+	}
+	if err := worker.WaitForIndexingOrCtxError(ctx, true); err != nil {
+		return 0, err
+	}
+
+	if _, err := upsertGuardians(ctx); err != nil {
+		return 0, err
+	}
+	if _, err := upsertGroot(ctx, password); err != nil {
+		return 0, err
+	}
+	glog.Infof("Created namespace %d", ns)
+	return ns, nil
+}
+
+// DeleteNamespace drops all the predicates and types of the given namespace.
+func DeleteNamespace(ctx context.Context, ns uint64) error {
+	if ns == x.GalaxyNamespace {
+		return errors.New("The galaxy namespace can't be deleted")
+	}
+	ctx = x.AttachNamespace(ctx, ns)
+
+	nodes, err := worker.GetSchemaOverNetwork(ctx, &pb.SchemaRequest{})
+	if err != nil {
+		return err
+	}
+	for _, node := range nodes {
+		nq := &gql.NQuad{NQuad: &api.NQuad{
+			Subject:     x.Star,
+			Predicate:   node.Predicate,
+			ObjectValue: &api.Value{Val: &api.Value_StrVal{StrVal: x.Star}},
+		}}
+		edge, err := nq.ToDeletePredEdge()
+		if err != nil {
+			return err
+		}
+		m := &pb.Mutations{
+			StartTs: worker.State.GetTimestamp(false),
+			Edges:   []*pb.DirectedEdge{edge},
+		}
+		if _, err := query.ApplyMutations(ctx, m); err != nil {
+			return errors.Wrapf(err, "while dropping predicate %s of namespace %d",
+				node.Predicate, ns)
+		}
+	}
+
+	types, err := worker.GetTypes(ctx, &pb.SchemaRequest{})
+	if err != nil {
+		return err
+	}
+	for _, typ := range types {
+		m := &pb.Mutations{
+			StartTs:   worker.State.GetTimestamp(false),
+			DropOp:    pb.Mutations_TYPE,
+			DropValue: typ.TypeName,
+		}
+		if _, err := query.ApplyMutations(ctx, m); err != nil {
+			return errors.Wrapf(err, "while dropping type %s of namespace %d", typ.TypeName, ns)
+		}
+	}
+	dropAclCache(ns)
+	glog.Infof("Deleted namespace %d", ns)
+	return nil
+}
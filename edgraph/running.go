@@ -0,0 +1,172 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dgraph-io/dgo/v200/protos/api"
+	"github.com/pkg/errors"
+
+	"github.com/dgraph-io/dgraph/query"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// The requests run by an Alpha are registered while they run. They can be listed and cancelled
+// through the admin API of the Alpha running them. Cancelling a request cancels its context,
+// which gRPC propagates to the tasks it sent to the other groups.
+//
+// The timeout and the memory limit of the Alpha only apply to the requests of its clients, which
+// go through Server.Query. The requests the Alpha runs itself, e.g. for its tasks or to refresh
+// the ACLs, call doQuery directly and aren't limited.
+
+// RunningQuery describes a query or a mutation being run by this Alpha.
+type RunningQuery struct {
+	Id string `json:"id"`
+	// Query is the text of the query, without its variables.
+	Query     string    `json:"query"`
+	Mutation  bool      `json:"mutation"`
+	Namespace uint64    `json:"namespace"`
+	StartedAt time.Time `json:"startedAt"`
+	// MemoryBytes is the memory taken by the results of the query so far.
+	MemoryBytes int64 `json:"memoryBytes"`
+}
+
+type runningQuery struct {
+	info   RunningQuery
+	ctx    context.Context
+	cancel context.CancelFunc
+	mem    *query.MemoryUsage
+	// cancelled is set to 1 once the request is cancelled through the admin API.
+	cancelled int32
+}
+
+type runningQueries struct {
+	sync.Mutex
+	lastId  uint64
+	queries map[string]*runningQuery
+}
+
+var running = &runningQueries{queries: make(map[string]*runningQuery)}
+
+// limitedKey marks the context of the requests of the clients, see withQueryLimits.
+type limitedKey struct{}
+
+// withQueryLimits returns the context of a request of a client, to which the timeout and the
+// memory limit of the Alpha apply.
+func withQueryLimits(ctx context.Context) context.Context {
+	return context.WithValue(ctx, limitedKey{}, true)
+}
+
+// startQuery registers the request req, and returns the context it runs in. The request must call
+// finish on the returned runningQuery once it's over.
+func startQuery(ctx context.Context, req *api.Request) (context.Context, *runningQuery) {
+	limited, _ := ctx.Value(limitedKey{}).(bool)
+	var cancel context.CancelFunc
+	if limited && x.Config.QueryTimeout > 0 {
+		// A request with an earlier deadline keeps it.
+		ctx, cancel = context.WithTimeout(ctx, x.Config.QueryTimeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	var limit int64
+	if limited {
+		limit = x.Config.QueryMemoryLimit
+	}
+	ctx, mem := query.WithMemoryLimit(ctx, limit)
+
+	rq := &runningQuery{
+		info: RunningQuery{
+			Query:     req.Query,
+			Mutation:  len(req.Mutations) > 0,
+			Namespace: x.ExtractNamespace(ctx),
+			StartedAt: time.Now().UTC(),
+		},
+		ctx:    ctx,
+		cancel: cancel,
+		mem:    mem,
+	}
+
+	running.Lock()
+	running.lastId++
+	rq.info.Id = strconv.FormatUint(running.lastId, 10)
+	running.queries[rq.info.Id] = rq
+	running.Unlock()
+	return ctx, rq
+}
+
+// finish unregisters the request, and returns the error it ended with. The error tells if the
+// request was cancelled, or ran out of time.
+func (rq *runningQuery) finish(err error) error {
+	running.Lock()
+	delete(running.queries, rq.info.Id)
+	running.Unlock()
+	defer rq.cancel()
+
+	if err == nil {
+		return nil
+	}
+	switch {
+	case atomic.LoadInt32(&rq.cancelled) == 1:
+		return errors.Errorf("Query %s was cancelled", rq.info.Id)
+	case rq.ctx.Err() == context.DeadlineExceeded:
+		return errors.Errorf("Query %s ran for longer than its timeout of %s", rq.info.Id,
+			timeout(rq.ctx, rq.info.StartedAt))
+	}
+	return err
+}
+
+func timeout(ctx context.Context, start time.Time) time.Duration {
+	deadline, _ := ctx.Deadline()
+	return deadline.Sub(start).Round(time.Millisecond)
+}
+
+// ListRunningQueries returns the queries and mutations being run by this Alpha, from the oldest
+// one.
+func ListRunningQueries() []*RunningQuery {
+	running.Lock()
+	defer running.Unlock()
+
+	queries := make([]*RunningQuery, 0, len(running.queries))
+	for _, rq := range running.queries {
+		info := rq.info
+		info.MemoryBytes = rq.mem.Used()
+		queries = append(queries, &info)
+	}
+	sort.Slice(queries, func(i, j int) bool {
+		return queries[i].StartedAt.Before(queries[j].StartedAt)
+	})
+	return queries
+}
+
+// CancelQuery cancels the query or mutation with the given ID, run by this Alpha.
+func CancelQuery(id string) error {
+	running.Lock()
+	rq, ok := running.queries[id]
+	running.Unlock()
+	if !ok {
+		return errors.Errorf("Query %s isn't running on this alpha", id)
+	}
+	atomic.StoreInt32(&rq.cancelled, 1)
+	rq.cancel()
+	return nil
+}
@@ -0,0 +1,67 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/dgo/v200/protos/api"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/x"
+)
+
+func TestCancelQuery(t *testing.T) {
+	ctx, rq := startQuery(context.Background(), &api.Request{Query: "{ q(func: has(name)) }"})
+	queries := ListRunningQueries()
+	require.Len(t, queries, 1)
+	require.Equal(t, rq.info.Id, queries[0].Id)
+	require.Equal(t, "{ q(func: has(name)) }", queries[0].Query)
+
+	require.NoError(t, CancelQuery(rq.info.Id))
+	require.Equal(t, context.Canceled, ctx.Err())
+	err := rq.finish(ctx.Err())
+	require.EqualError(t, err, "Query "+rq.info.Id+" was cancelled")
+	require.Empty(t, ListRunningQueries())
+	require.Error(t, CancelQuery(rq.info.Id))
+}
+
+func TestQueryTimeout(t *testing.T) {
+	defer func(timeout time.Duration) {
+		x.Config.QueryTimeout = timeout
+	}(x.Config.QueryTimeout)
+	x.Config.QueryTimeout = 10 * time.Millisecond
+
+	ctx, rq := startQuery(withQueryLimits(context.Background()),
+		&api.Request{Query: "{ q(func: has(name)) }"})
+	<-ctx.Done()
+	err := rq.finish(errors.Wrap(ctx.Err(), "while processing the task"))
+	require.EqualError(t, err, "Query "+rq.info.Id+" ran for longer than its timeout of 10ms")
+
+	// The errors of the requests which weren't cancelled are kept.
+	_, rq = startQuery(context.Background(), &api.Request{Query: "{ q(func: has(name)) }"})
+	require.EqualError(t, rq.finish(errors.New("invalid query")), "invalid query")
+
+	// The requests run by the Alpha itself have no timeout.
+	ctx, rq = startQuery(context.Background(), &api.Request{Query: "{ q(func: has(name)) }"})
+	_, ok := ctx.Deadline()
+	require.False(t, ok)
+	require.NoError(t, rq.finish(nil))
+}
@@ -142,7 +142,7 @@ func PeriodicallyPostTelemetry() {
 // GetGQLSchema queries for the GraphQL schema node, and returns the uid and the GraphQL schema.
 // If multiple schema nodes were found, it returns an error.
 func GetGQLSchema() (uid, graphQLSchema string, err error) {
-	resp, err := (&Server{}).Query(context.WithValue(context.Background(), Authorize, false),
+	resp, err := (&Server{}).doQuery(context.Background(),
 		&api.Request{
 			Query: `
 			query {
@@ -150,7 +150,7 @@ func GetGQLSchema() (uid, graphQLSchema string, err error) {
 				uid
 				dgraph.graphql.schema
 			  }
-			}`})
+			}`}, NoAuthorize)
 	if err != nil {
 		return "", "", err
 	}
@@ -217,6 +217,11 @@ func validateAlterOperation(ctx context.Context, op *api.Operation) error {
 		return errors.Errorf("Only one of DropAll and DropData can be true")
 	}
 
+	if ns := x.ExtractNamespace(ctx); ns != x.GalaxyNamespace &&
+		(isDropAll(op) || op.DropOp == api.Operation_DATA) {
+		return errors.Errorf("Drop all and drop data are not allowed in namespace %d", ns)
+	}
+
 	if !isMutationAllowed(ctx) {
 		return errors.Errorf("No mutations allowed by server.")
 	}
@@ -323,11 +328,21 @@ func (s *Server) Alter(ctx context.Context, op *api.Operation) (*api.Payload, er
 	// Always print out Alter operations because they are important and rare.
 	glog.Infof("Received ALTER op: %+v", op)
 
+	ctx, err := attachNamespace(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	// check if the operation is valid
 	if err := validateAlterOperation(ctx, op); err != nil {
 		return nil, err
 	}
+	return doAlter(ctx, op)
+}
 
+// doAlter runs the alter operation, in the namespace attached to ctx. The operation must have been
+// validated and authorized already, or be run by Dgraph itself, e.g. by an admin task.
+func doAlter(ctx context.Context, op *api.Operation) (*api.Payload, error) {
 	defer glog.Infof("ALTER op: %+v done", op)
 
 	empty := &api.Payload{}
@@ -916,6 +931,8 @@ type queryContext struct {
 	// 1B) and resulting in OOM. We are limiting number of nquads which can be inserted in
 	// a single request.
 	nquadsCount int
+	// plans are the plans of the query, when it's run with the explain or profile option.
+	plans []*query.PlanNode
 }
 
 // Health handles /health and /health?all requests.
@@ -987,6 +1004,7 @@ func (s *Server) State(ctx context.Context) (*api.Response, error) {
 
 // Query handles queries or mutations
 func (s *Server) Query(ctx context.Context, req *api.Request) (*api.Response, error) {
+	ctx = withQueryLimits(ctx)
 	auth := ctx.Value(Authorize)
 	if auth == nil || auth.(bool) {
 		return s.doQuery(ctx, req, NeedAuthorize)
@@ -1010,6 +1028,15 @@ func (s *Server) doQuery(ctx context.Context, req *api.Request, doAuth AuthMode)
 		return nil, ctx.Err()
 	}
 
+	if doAuth == NeedAuthorize {
+		if ctx, rerr = attachNamespace(ctx); rerr != nil {
+			return
+		}
+		if ns := x.ExtractNamespace(ctx); isGraphQL && ns != x.GalaxyNamespace {
+			return nil, errors.Errorf("GraphQL requests are not supported in namespace %d", ns)
+		}
+	}
+
 	l := &query.Latency{}
 	l.Start = time.Now()
 
@@ -1038,6 +1065,10 @@ func (s *Server) doQuery(ctx context.Context, req *api.Request, doAuth AuthMode)
 		return
 	}
 
+	if isMutation && query.IsExplain(ctx) {
+		return nil, errors.Errorf("Only queries can be explained, not mutations")
+	}
+
 	req.Query = strings.TrimSpace(req.Query)
 	isQuery := len(req.Query) != 0
 	if !isQuery && !isMutation {
@@ -1045,6 +1076,13 @@ func (s *Server) doQuery(ctx context.Context, req *api.Request, doAuth AuthMode)
 		return nil, errors.Errorf("empty request")
 	}
 
+	// The request can be cancelled through the admin API while it runs, and the request of a
+	// client fails once it goes over the timeout or the memory limit of the Alpha.
+	ctx, rq := startQuery(ctx, req)
+	defer func() {
+		rerr = rq.finish(rerr)
+	}()
+
 	span.Annotatef(nil, "Request received: %v", req)
 	if isQuery {
 		ostats.Record(ctx, x.PendingQueries.M(1), x.NumQueries.M(1))
@@ -1057,6 +1095,9 @@ func (s *Server) doQuery(ctx context.Context, req *api.Request, doAuth AuthMode)
 	}
 
 	qc := &queryContext{req: req, latency: l, span: span, graphql: isGraphQL}
+	defer func() {
+		logSlowQuery(ctx, qc, resp, rerr)
+	}()
 	if rerr = parseRequest(qc); rerr != nil {
 		return
 	}
@@ -1098,6 +1139,12 @@ func (s *Server) doQuery(ctx context.Context, req *api.Request, doAuth AuthMode)
 		TotalNs:           uint64((time.Since(l.Start)).Nanoseconds()),
 	}
 	md := metadata.Pairs(x.DgraphCostHeader, fmt.Sprint(resp.Metrics.NumUids["_total"]))
+	if qc.plans != nil && !query.RecordPlans(ctx, qc.plans) {
+		// gRPC clients get the plans in the header of the response.
+		if js, err := json.Marshal(qc.plans); err == nil {
+			md.Set(x.DgraphPlanHeader, string(js))
+		}
+	}
 	grpc.SendHeader(ctx, md)
 	return resp, nil
 }
@@ -1179,16 +1226,23 @@ func processQuery(ctx context.Context, qc *queryContext) (*api.Response, error)
 			respMap["types"] = formatTypes(er.Types)
 		}
 		resp.Json, err = json.Marshal(respMap)
+	} else if query.IsExplain(ctx) {
+		// The query wasn't run, only its plan is returned.
+		resp.Json = []byte("{}")
 	} else if qc.req.RespFormat == api.Request_RDF {
 		resp.Rdf, err = query.ToRDF(qc.latency, er.Subgraphs)
 	} else {
-		resp.Json, err = query.ToJson(qc.latency, er.Subgraphs)
+		resp.Json, err = query.ToJson(ctx, qc.latency, er.Subgraphs)
 	}
 	if err != nil {
 		return resp, err
 	}
 	qc.span.Annotatef(nil, "Response = %s", resp.Json)
 
+	if query.IsExplain(ctx) || query.IsProfile(ctx) {
+		qc.plans = query.BuildPlans(ctx, er.Subgraphs)
+	}
+
 	// varToUID contains a map of variable name to the uids corresponding to it.
 	// It is used later for constructing set and delete mutations by replacing
 	// variables with the actual uids they correspond to.
@@ -0,0 +1,250 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/dgo/v200/protos/api"
+	"github.com/golang/glog"
+
+	"github.com/dgraph-io/dgraph/query"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// SlowQueryConf holds the options of the slow query log.
+type SlowQueryConf struct {
+	// Dir is the directory where the slow query log files are written.
+	Dir string
+	// Threshold is the latency over which a request is slow.
+	Threshold time.Duration
+	// SampleRate is the fraction of the slow requests that are logged, from 0 to 1.
+	SampleRate float64
+	// MaxSizeMB is the size in MB after which the current log file is rotated.
+	MaxSizeMB int
+	// MaxFiles is the number of log files to retain. Older ones are deleted.
+	MaxFiles int
+}
+
+var slowQueries struct {
+	sync.Mutex
+	conf *SlowQueryConf
+	log  *x.LogWriter
+}
+
+// InitSlowQueryLog starts logging the slow requests as configured by conf. Nothing is logged if
+// conf is nil.
+func InitSlowQueryLog(conf *SlowQueryConf) error {
+	if conf == nil || conf.Dir == "" {
+		return nil
+	}
+	w, err := x.NewLogWriter(conf.Dir, "slow_query", int64(conf.MaxSizeMB)<<20,
+		conf.MaxFiles, nil)
+	if err != nil {
+		return err
+	}
+	slowQueries.Lock()
+	defer slowQueries.Unlock()
+	slowQueries.conf, slowQueries.log = conf, w
+	glog.Infof("Logging the requests slower than %s to %s", conf.Threshold, conf.Dir)
+	return nil
+}
+
+// CloseSlowQueryLog stops logging the slow requests.
+func CloseSlowQueryLog() {
+	slowQueries.Lock()
+	defer slowQueries.Unlock()
+	if slowQueries.log == nil {
+		return
+	}
+	if err := slowQueries.log.Close(); err != nil {
+		glog.Errorf("While closing the slow query log: %v", err)
+	}
+	slowQueries.conf, slowQueries.log = nil, nil
+}
+
+// slowQueryEntry is a line of the slow query log.
+type slowQueryEntry struct {
+	Time time.Time `json:"time"`
+	// Query is the normalized text of the query, see normalizeQuery.
+	Query string `json:"query,omitempty"`
+	// Variables are the names of the variables of the query, their values are never logged.
+	Variables []string `json:"variables,omitempty"`
+	Mutation  bool     `json:"mutation,omitempty"`
+	// GraphQLOperation is the name of the GraphQL operation the request ran for.
+	GraphQLOperation string           `json:"graphql_operation,omitempty"`
+	User             string           `json:"user,omitempty"`
+	Namespace        uint64           `json:"namespace"`
+	ReadTs           uint64           `json:"read_ts"`
+	NumUids          uint64           `json:"num_uids"`
+	Latency          slowQueryLatency `json:"latency"`
+	Error            string           `json:"error,omitempty"`
+}
+
+// slowQueryLatency breaks the latency of a request down by stage, in milliseconds.
+type slowQueryLatency struct {
+	Total           float64 `json:"total_ms"`
+	Parsing         float64 `json:"parsing_ms"`
+	AssignTimestamp float64 `json:"assign_timestamp_ms"`
+	Processing      float64 `json:"processing_ms"`
+	Encoding        float64 `json:"encoding_ms"`
+}
+
+func durationMs(d time.Duration) float64 {
+	return float64(d) / 1e6
+}
+
+// logSlowQuery logs the request of qc if it ran for longer than the threshold of the slow query
+// log, and if it's sampled.
+func logSlowQuery(ctx context.Context, qc *queryContext, resp *api.Response, err error) {
+	slowQueries.Lock()
+	conf, w := slowQueries.conf, slowQueries.log
+	slowQueries.Unlock()
+	if w == nil {
+		return
+	}
+	total := time.Since(qc.latency.Start)
+	if total < conf.Threshold || rand.Float64() >= conf.SampleRate {
+		return
+	}
+
+	entry := slowQueryEntry{
+		Time:             qc.latency.Start.UTC(),
+		Query:            normalizeQuery(qc.req.Query),
+		Mutation:         len(qc.req.Mutations) > 0,
+		GraphQLOperation: x.ExtractGraphQLOperation(ctx),
+		User:             requestUser(ctx),
+		Namespace:        x.ExtractNamespace(ctx),
+		ReadTs:           qc.req.StartTs,
+		NumUids:          resp.GetMetrics().GetNumUids()["_total"],
+		Latency:          latencyMs(qc.latency, total),
+	}
+	for name := range qc.req.Vars {
+		entry.Variables = append(entry.Variables, name)
+	}
+	sort.Strings(entry.Variables)
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	b, jerr := json.Marshal(entry)
+	if jerr != nil {
+		glog.Errorf("While encoding the slow query log entry: %v", jerr)
+		return
+	}
+	if _, werr := w.Write(append(b, '\n')); werr != nil {
+		glog.Errorf("While writing to the slow query log: %v", werr)
+	}
+}
+
+func latencyMs(l *query.Latency, total time.Duration) slowQueryLatency {
+	return slowQueryLatency{
+		Total:           durationMs(total),
+		Parsing:         durationMs(l.Parsing),
+		AssignTimestamp: durationMs(l.AssignTimestamp),
+		Processing:      durationMs(l.Processing),
+		Encoding:        durationMs(l.Json),
+	}
+}
+
+// normalizeQuery returns the text of a DQL query with its string, number and regular expression
+// literals replaced by ?, its comments removed and its whitespace collapsed. The queries which
+// only differ by the values they look up are thus logged the same way, and the values aren't
+// logged.
+func normalizeQuery(q string) string {
+	var b strings.Builder
+	var prev byte
+	space := false
+	write := func(s string) {
+		if space && b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		space = false
+		b.WriteString(s)
+		prev = s[len(s)-1]
+	}
+	isIdent := func(c byte) bool {
+		return c == '_' || c == '.' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' ||
+			c >= 'A' && c <= 'Z'
+	}
+
+	for i := 0; i < len(q); {
+		c := q[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			space = true
+			i++
+		case c == '#':
+			// A comment runs until the end of the line.
+			for i < len(q) && q[i] != '\n' {
+				i++
+			}
+			space = true
+		case c == '"':
+			// Skip the string, along with its escaped characters.
+			for i++; i < len(q) && q[i] != '"'; i++ {
+				if q[i] == '\\' {
+					i++
+				}
+			}
+			i++
+			write("?")
+		case c == '/' && (prev == '(' || prev == ','):
+			// A regular expression, since it starts an argument. Otherwise it's a division.
+			for i++; i < len(q) && q[i] != '/'; i++ {
+				if q[i] == '\\' {
+					i++
+				}
+			}
+			i++
+			// Skip the flags too.
+			for i < len(q) && (q[i] >= 'a' && q[i] <= 'z' || q[i] >= 'A' && q[i] <= 'Z') {
+				i++
+			}
+			write("?")
+		case c == '<' && isIRI(q[i:]):
+			// IRIs are copied as they are, they're predicates.
+			j := strings.IndexByte(q[i:], '>') + 1
+			write(q[i : i+j])
+			i += j
+		case (c >= '0' && c <= '9' || c == '-' && i+1 < len(q) && q[i+1] >= '0' &&
+			q[i+1] <= '9') && (b.Len() == 0 || !isIdent(prev)):
+			// A number, unless it's part of a name.
+			i++
+			for i < len(q) && isIdent(q[i]) {
+				i++
+			}
+			write("?")
+		default:
+			write(q[i : i+1])
+			i++
+		}
+	}
+	return b.String()
+}
+
+// isIRI tells if s starts with an IRI, and not with the < operator of math.
+func isIRI(s string) bool {
+	j := strings.IndexAny(s, "> \t\r\n")
+	return j > 1 && s[j] == '>'
+}
@@ -0,0 +1,102 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/dgo/v200/protos/api"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/query"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+func TestNormalizeQuery(t *testing.T) {
+	tests := []struct {
+		in, out string
+	}{
+		{`{ q(func: eq(name, "Alice \"A\"")) { name } }`, `{ q(func: eq(name, ?)) { name } }`},
+		{"{\n  q(func: uid(0x1, 0x2), first: 10) {\n    age2\n  }\n}",
+			`{ q(func: uid(?, ?), first: ?) { age2 } }`},
+		{"{ q(func: lt(<score.1>, -1.5)) { # the best\n <name@en> } }",
+			`{ q(func: lt(<score.1>, ?)) { <name@en> } }`},
+		{`{ q(func: has(a)) { s as math(a < 10) } }`, `{ q(func: has(a)) { s as math(a < ?) } }`},
+		{`query q($name: string = "Bob") { q(func: eq(name, $name)) { name } }`,
+			`query q($name: string = ?) { q(func: eq(name, $name)) { name } }`},
+		{`{ q(func: regexp(name, /^Steven Sp.*\/[a-z]$/i)) { name } }`,
+			`{ q(func: regexp(name, ?)) { name } }`},
+		{`{ q(func: has(a)) { r as math(a / 2) } }`, `{ q(func: has(a)) { r as math(a / ?) } }`},
+	}
+	for _, tc := range tests {
+		require.Equal(t, tc.out, normalizeQuery(tc.in), tc.in)
+	}
+}
+
+func TestLogSlowQuery(t *testing.T) {
+	dir, err := ioutil.TempDir("", "slow_query")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	require.NoError(t, InitSlowQueryLog(&SlowQueryConf{
+		Dir:        dir,
+		Threshold:  time.Second,
+		SampleRate: 1,
+		MaxSizeMB:  1,
+	}))
+	defer CloseSlowQueryLog()
+
+	ctx := x.WithGraphQLOperation(context.Background(), "getUsers")
+	req := &api.Request{
+		Query:   `query q($name: string) { q(func: eq(name, $name)) { name } }`,
+		Vars:    map[string]string{"$name": "Alice"},
+		StartTs: 42,
+	}
+	resp := &api.Response{Metrics: &api.Metrics{NumUids: map[string]uint64{"_total": 7}}}
+	fast := &queryContext{req: req, latency: &query.Latency{Start: time.Now()}}
+	logSlowQuery(ctx, fast, resp, nil)
+	slow := &queryContext{req: req, latency: &query.Latency{
+		Start:      time.Now().Add(-2 * time.Second),
+		Processing: 1500 * time.Millisecond,
+	}}
+	logSlowQuery(ctx, slow, resp, nil)
+	CloseSlowQueryLog()
+
+	files, err := filepath.Glob(filepath.Join(dir, "slow_query_*.log"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	b, err := ioutil.ReadFile(files[0])
+	require.NoError(t, err)
+	require.NotContains(t, string(b), "Alice")
+
+	// Only the slow request was logged.
+	var entry slowQueryEntry
+	require.NoError(t, json.Unmarshal(b, &entry))
+	require.Equal(t, `query q($name: string) { q(func: eq(name, $name)) { name } }`, entry.Query)
+	require.Equal(t, []string{"$name"}, entry.Variables)
+	require.Equal(t, "getUsers", entry.GraphQLOperation)
+	require.Equal(t, uint64(42), entry.ReadTs)
+	require.Equal(t, uint64(7), entry.NumUids)
+	require.Equal(t, float64(1500), entry.Latency.Processing)
+	require.True(t, entry.Latency.Total >= 2000)
+}
@@ -0,0 +1,593 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/dgo/v200/protos/api"
+	"github.com/dgraph-io/ristretto/z"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// The admin operations that can take long, like exports, backups and restores, run as tasks in
+// the background. They run one at a time in the whole cluster, in the order they were submitted.
+//
+// The record of a task is stored as JSON in the dgraph.task predicate of a node, whose uid is the
+// ID of the task. The records are thus replicated through Raft: any Alpha can report the status of
+// any task, and the records survive restarts and changes of leaders. The record of a task also
+// holds its request until the task is over, so that a new leader runs the queued tasks, and the
+// running task that was interrupted by the change of leader again. An Alpha claims a task by
+// updating its record only if it didn't change since it was read, so that two Alphas never run
+// the same task. The records of the finished tasks are dropped after finishedTaskAge.
+//
+// The credentials of a task, e.g. the keys of the bucket it writes to, are never stored in its
+// record. They are only kept in the memory of the Alpha the task was submitted to, which runs
+// the task. The tasks without credentials are run by the leader of their group, which is group 1
+// unless they read the data served by another group, like the analytics tasks. The records are
+// neither backed up nor restored, a restore would otherwise run the old tasks again.
+
+// The statuses of a task.
+const (
+	TaskQueued  = "Queued"
+	TaskRunning = "Running"
+	TaskSuccess = "Success"
+	TaskFailed  = "Failed"
+)
+
+// maxQueuedTasks is the number of tasks that can wait in the queue.
+const maxQueuedTasks = 64
+
+const (
+	// taskPollInterval is how often the leader looks for queued tasks, if it isn't notified of
+	// a new one.
+	taskPollInterval = 5 * time.Second
+	// taskSaveInterval is how often the record of a running task is stored, with its progress.
+	taskSaveInterval = 10 * time.Second
+	// A running task whose record hasn't been stored for staleTaskAge was interrupted, and is
+	// run again.
+	staleTaskAge = 6 * taskSaveInterval
+	// finishedTaskAge is how long the records of the finished tasks are kept.
+	finishedTaskAge = 24 * time.Hour
+)
+
+// Task is the record of a task.
+type Task struct {
+	Id     string `json:"-"`
+	Kind   string `json:"kind"`
+	Status string `json:"status"`
+	// Node is the Raft ID of the Alpha running the task.
+	Node uint64 `json:"node"`
+	// Owner is the Raft ID of the Alpha holding the credentials of the task, which is the only
+	// one that can run it. It's 0 if the task has no credentials.
+	Owner uint64 `json:"owner,omitempty"`
+	// Group is the group whose leader runs the task if it has no credentials, e.g. the group
+	// serving the predicate it reads. It's 0 for group 1.
+	Group uint32 `json:"group,omitempty"`
+	// Progress is the fraction of the task that is done, from 0 to 1.
+	Progress float64 `json:"progress"`
+	Error    string  `json:"error,omitempty"`
+	// Output holds the locations the task wrote to, e.g. the exported files.
+	Output []string `json:"output,omitempty"`
+	// Request holds the parameters the task is run with, until it's over. GetTask doesn't
+	// return them.
+	Request   json.RawMessage `json:"request,omitempty"`
+	CreatedAt time.Time       `json:"createdAt"`
+	UpdatedAt time.Time       `json:"updatedAt"`
+
+	// record is the stored record, as it was read or last written.
+	record string
+}
+
+// TaskFunc runs a task with the parameters and the credentials it was submitted with, and
+// returns the locations it wrote to. The credentials are nil if the task has none. The operations
+// it runs report their progress through the context, see worker.WithProgress.
+type TaskFunc func(ctx context.Context, req json.RawMessage,
+	creds *worker.Credentials) ([]string, error)
+
+var tasks = struct {
+	sync.RWMutex
+	kinds map[string]TaskFunc
+	// creds holds the credentials of the tasks submitted to this Alpha, by ID.
+	creds map[string]*worker.Credentials
+	// notify wakes up the runner when a task is submitted to this Alpha.
+	notify chan struct{}
+}{
+	kinds:  make(map[string]TaskFunc),
+	creds:  make(map[string]*worker.Credentials),
+	notify: make(chan struct{}, 1),
+}
+
+// RegisterTask sets the function running the tasks of the given kind.
+func RegisterTask(kind string, run TaskFunc) {
+	tasks.Lock()
+	defer tasks.Unlock()
+	tasks.kinds[kind] = run
+}
+
+// taskContext returns the context of the requests on the records of the tasks. The records are
+// stored in the galaxy namespace, whatever the namespace of the request submitting the task.
+func taskContext(ctx context.Context) context.Context {
+	return x.AttachNamespace(context.WithValue(ctx, IsGraphql, true), x.GalaxyNamespace)
+}
+
+// saveTask stores the record of the task. The first time, a node is created for the task and its
+// uid is set as the ID of the task.
+func saveTask(ctx context.Context, t *Task) error {
+	t.UpdatedAt = time.Now().UTC()
+	val, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+	subject := t.Id
+	if subject == "" {
+		subject = "_:task"
+	}
+	req := &api.Request{
+		Mutations: []*api.Mutation{{
+			Set: []*api.NQuad{{
+				Subject:     subject,
+				Predicate:   "dgraph.task",
+				ObjectValue: &api.Value{Val: &api.Value_StrVal{StrVal: string(val)}},
+			}},
+		}},
+		CommitNow: true,
+	}
+	resp, err := (&Server{}).doQuery(taskContext(ctx), req, NoAuthorize)
+	if err != nil {
+		return err
+	}
+	if t.Id == "" {
+		t.Id = resp.Uids["task"]
+	}
+	t.record = string(val)
+	return nil
+}
+
+// updateTask stores the record of the task only if the stored one didn't change since it was
+// read. It returns false if it changed, e.g. because another Alpha claimed the task.
+func updateTask(ctx context.Context, t *Task) (bool, error) {
+	t.UpdatedAt = time.Now().UTC()
+	val, err := json.Marshal(t)
+	if err != nil {
+		return false, err
+	}
+	req := &api.Request{
+		Query: `query task($id: string, $record: string) {
+			t as var(func: uid($id)) @filter(eq(dgraph.task, $record))
+			task(func: uid(t)) {
+				uid
+			}
+		}`,
+		Vars: map[string]string{"$id": t.Id, "$record": t.record},
+		Mutations: []*api.Mutation{{
+			Cond: "@if(eq(len(t), 1))",
+			Set: []*api.NQuad{{
+				Subject:     "uid(t)",
+				Predicate:   "dgraph.task",
+				ObjectValue: &api.Value{Val: &api.Value_StrVal{StrVal: string(val)}},
+			}},
+		}},
+		CommitNow: true,
+	}
+	resp, err := (&Server{}).doQuery(taskContext(ctx), req, NoAuthorize)
+	if err != nil {
+		return false, err
+	}
+	var res struct {
+		Task []struct{} `json:"task"`
+	}
+	if err := json.Unmarshal(resp.Json, &res); err != nil {
+		return false, err
+	}
+	if len(res.Task) == 0 {
+		return false, nil
+	}
+	t.record = string(val)
+	return true, nil
+}
+
+// deleteTasks drops the records of the tasks with the given IDs.
+func deleteTasks(ctx context.Context, ids []string) error {
+	mu := &api.Mutation{}
+	for _, id := range ids {
+		mu.Del = append(mu.Del, &api.NQuad{
+			Subject:     id,
+			Predicate:   "dgraph.task",
+			ObjectValue: &api.Value{Val: &api.Value_DefaultVal{DefaultVal: x.Star}},
+		})
+	}
+	req := &api.Request{Mutations: []*api.Mutation{mu}, CommitNow: true}
+	_, err := (&Server{}).doQuery(taskContext(ctx), req, NoAuthorize)
+	return err
+}
+
+// SubmitTask stores the record of a new task of the given kind, to be run with the parameters
+// in req, which are marshalled to JSON, and the credentials creds, which may be nil. req must not
+// hold any credentials, they would be stored in the record. It returns the record, whose status
+// is queued.
+func SubmitTask(ctx context.Context, kind string, req interface{},
+	creds *worker.Credentials) (*Task, error) {
+	return submitTask(ctx, kind, req, creds, 0)
+}
+
+// submitTask is SubmitTask for a task run by the leader of the given group, if it has no
+// credentials.
+func submitTask(ctx context.Context, kind string, req interface{}, creds *worker.Credentials,
+	group uint32) (*Task, error) {
+	all, err := getTasks(ctx, 0)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while reading the queued tasks")
+	}
+	var queued int
+	for _, t := range all {
+		if t.Status == TaskQueued {
+			queued++
+		}
+	}
+	if queued >= maxQueuedTasks {
+		return nil, errors.Errorf("there are already %d queued tasks, retry later", queued)
+	}
+
+	b, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	t := &Task{Kind: kind, Status: TaskQueued, Request: b, CreatedAt: time.Now().UTC()}
+	if group != 1 {
+		t.Group = group
+	}
+	if creds != nil && (creds.AccessKey != "" || creds.SecretKey != "" ||
+		creds.SessionToken != "") {
+		t.Owner = worker.NodeId()
+	} else {
+		creds = nil
+	}
+	// The credentials are set before the record is stored, the runner may pick the task as soon
+	// as it's stored.
+	tasks.Lock()
+	if err := saveTask(ctx, t); err != nil {
+		tasks.Unlock()
+		return nil, errors.Wrapf(err, "while storing %s task", kind)
+	}
+	if creds != nil {
+		tasks.creds[t.Id] = creds
+	}
+	tasks.Unlock()
+	glog.Infof("Queued %s task %s", kind, t.Id)
+	select {
+	case tasks.notify <- struct{}{}:
+	default:
+	}
+	return t, nil
+}
+
+// GetTask returns the record of the task with the given ID, or nil if there is no such task.
+func GetTask(ctx context.Context, id string) (*Task, error) {
+	uid, err := strconv.ParseUint(id, 0, 64)
+	if err != nil || uid == 0 {
+		return nil, errors.Errorf("invalid task ID %q", id)
+	}
+	all, err := getTasks(ctx, uid)
+	if err != nil || len(all) == 0 {
+		return nil, err
+	}
+	all[0].Request = nil
+	return all[0], nil
+}
+
+// WaitForTask polls the record of the task with the given ID until the task is over, and
+// returns it.
+func WaitForTask(ctx context.Context, id string) (*Task, error) {
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		t, err := GetTask(ctx, id)
+		switch {
+		case err != nil:
+			return nil, err
+		case t == nil:
+			return nil, errors.Errorf("task %s not found", id)
+		case t.Status == TaskSuccess || t.Status == TaskFailed:
+			return t, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// getTasks returns the records of the task with the given uid, or of all the tasks if uid is 0.
+func getTasks(ctx context.Context, uid uint64) ([]*Task, error) {
+	req := &api.Request{
+		Query: `{
+			tasks(func: has(dgraph.task)) {
+				uid
+				dgraph.task
+			}
+		}`,
+		ReadOnly: true,
+	}
+	if uid != 0 {
+		req.Query = `query task($id: string) {
+			tasks(func: uid($id)) @filter(has(dgraph.task)) {
+				uid
+				dgraph.task
+			}
+		}`
+		req.Vars = map[string]string{"$id": fmt.Sprintf("%#x", uid)}
+	}
+	resp, err := (&Server{}).doQuery(taskContext(ctx), req, NoAuthorize)
+	if err != nil {
+		return nil, err
+	}
+
+	var res struct {
+		Tasks []struct {
+			Uid  string `json:"uid"`
+			Task string `json:"dgraph.task"`
+		} `json:"tasks"`
+	}
+	if err := json.Unmarshal(resp.Json, &res); err != nil {
+		return nil, err
+	}
+	all := make([]*Task, 0, len(res.Tasks))
+	for _, r := range res.Tasks {
+		t := &Task{}
+		if err := json.Unmarshal([]byte(r.Task), t); err != nil {
+			return nil, errors.Wrapf(err, "while reading task %s", r.Uid)
+		}
+		t.Id = r.Uid
+		t.record = r.Task
+		all = append(all, t)
+	}
+	return all, nil
+}
+
+// nextTask returns the record of the next task this Alpha runs, or nil if there is none. On the
+// way, it marks as failed the tasks whose credentials were lost, and drops the records of the
+// tasks that finished long ago.
+func nextTask(ctx context.Context) (*Task, error) {
+	all, err := getTasks(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	node, leads := worker.NodeId(), worker.LeadingGroup()
+	leader := leads == 1
+
+	var old []string
+	for _, t := range all {
+		switch {
+		case t.Status == TaskSuccess || t.Status == TaskFailed:
+			if leader && now.Sub(t.UpdatedAt) > finishedTaskAge {
+				old = append(old, t.Id)
+			}
+		case lostCredentials(t, now, node, leader):
+			glog.Errorf("Unable to run %s task %s, its credentials were lost", t.Kind, t.Id)
+			t.Status = TaskFailed
+			t.Error = "the credentials of the task were lost when its Alpha restarted, " +
+				"submit it again"
+			t.Request = nil
+			if _, err := updateTask(ctx, t); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(old) > 0 {
+		if err := deleteTasks(ctx, old); err != nil {
+			return nil, errors.Wrapf(err, "while dropping the finished tasks")
+		}
+	}
+	return pickNextTask(all, now, node, leads), nil
+}
+
+// lostCredentials tells if the unfinished task t can't be run anymore, because the Alpha holding
+// its credentials restarted or left the cluster. Only that Alpha and the leader of group 1 can
+// tell, the other ones return false.
+func lostCredentials(t *Task, now time.Time, node uint64, leader bool) bool {
+	switch {
+	case t.Owner == 0 || t.Status == TaskSuccess || t.Status == TaskFailed:
+		return false
+	case t.Owner == node:
+		tasks.RLock()
+		defer tasks.RUnlock()
+		_, ok := tasks.creds[t.Id]
+		return !ok
+	case !leader:
+		return false
+	case t.Status == TaskRunning:
+		// The Alpha running the task stores its record periodically, until it stops.
+		return now.Sub(t.UpdatedAt) > staleTaskAge
+	}
+	for _, group := range worker.GetMembershipState().GetGroups() {
+		if _, ok := group.GetMembers()[t.Owner]; ok {
+			return false
+		}
+	}
+	return true
+}
+
+// pickNextTask returns the task that the Alpha with the given Raft ID, leading the given group or
+// 0, runs next at the given time, or nil if it has none to run. No task is picked while another
+// one is running. The oldest of the queued tasks and of the running tasks that were interrupted
+// is picked, among the tasks that the Alpha holds the credentials of, and the tasks without
+// credentials of the group it leads.
+func pickNextTask(all []*Task, now time.Time, node uint64, leads uint32) *Task {
+	var next *Task
+	for _, t := range all {
+		switch {
+		case t.Status == TaskRunning && now.Sub(t.UpdatedAt) <= staleTaskAge:
+			return nil
+		case t.Status != TaskQueued && t.Status != TaskRunning:
+			continue
+		case t.Owner != node && (t.Owner != 0 || leads == 0 || leads != taskGroup(t)):
+			continue
+		}
+		if next == nil || t.CreatedAt.Before(next.CreatedAt) {
+			next = t
+		}
+	}
+	return next
+}
+
+// taskGroup returns the group whose leader runs the task t if it has no credentials.
+func taskGroup(t *Task) uint32 {
+	if t.Group == 0 {
+		return 1
+	}
+	return t.Group
+}
+
+// ownsTasks tells if this Alpha holds the credentials of some tasks, which it has to run.
+func ownsTasks() bool {
+	tasks.RLock()
+	defer tasks.RUnlock()
+	return len(tasks.creds) > 0
+}
+
+// claimTask marks the task as running on this Alpha. It returns false if another Alpha claimed
+// it first.
+func claimTask(ctx context.Context, t *Task) (bool, error) {
+	if t.Status == TaskRunning {
+		glog.Infof("Claiming %s task %s, it was interrupted on node %d", t.Kind, t.Id, t.Node)
+	}
+	t.Status, t.Node, t.Progress = TaskRunning, worker.NodeId(), 0
+	return updateTask(ctx, t)
+}
+
+// runTask runs the claimed task, storing its record as it makes progress.
+func runTask(ctx context.Context, t *Task) {
+	// mu guards t, which is stored periodically while the task runs.
+	var mu sync.Mutex
+	save := func() {
+		mu.Lock()
+		defer mu.Unlock()
+		if err := saveTask(ctx, t); err != nil {
+			glog.Errorf("Unable to store status of task %s: %v", t.Id, err)
+		}
+	}
+
+	tasks.RLock()
+	run, ok := tasks.kinds[t.Kind]
+	creds := tasks.creds[t.Id]
+	tasks.RUnlock()
+	defer func() {
+		tasks.Lock()
+		delete(tasks.creds, t.Id)
+		tasks.Unlock()
+	}()
+	if !ok {
+		glog.Errorf("Unable to run task %s of unknown kind %s", t.Id, t.Kind)
+		t.Status, t.Error, t.Request = TaskFailed, "unknown kind of task "+t.Kind, nil
+		save()
+		return
+	}
+	glog.Infof("Running %s task %s", t.Kind, t.Id)
+
+	// The progress is only kept in memory in between the periodic saves.
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(taskSaveInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				save()
+			}
+		}
+	}()
+	runCtx := worker.WithProgress(ctx, func(done, total int) {
+		if total > 0 && done < total {
+			mu.Lock()
+			t.Progress = float64(done) / float64(total)
+			mu.Unlock()
+		}
+	})
+	output, err := run(runCtx, t.Request, creds)
+	close(stop)
+	wg.Wait()
+
+	if err != nil {
+		glog.Errorf("%s task %s failed: %v", t.Kind, t.Id, err)
+		t.Status = TaskFailed
+		t.Error = err.Error()
+	} else {
+		glog.Infof("%s task %s succeeded", t.Kind, t.Id)
+		t.Status = TaskSuccess
+		t.Progress = 1
+		t.Output = output
+	}
+	t.Request = nil
+	// The records of the tasks are dropped by restores, storing the whole record brings the
+	// record of the restore back.
+	save()
+}
+
+// RunTasks runs the submitted tasks, one at a time, while this Alpha is the leader of its group
+// or holds the credentials of some tasks, until the closer is signaled.
+func RunTasks(closer *z.Closer) {
+	defer func() {
+		glog.Infof("RunTasks closed")
+		closer.Done()
+	}()
+
+	ticker := time.NewTicker(taskPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-closer.HasBeenClosed():
+			return
+		case <-ticker.C:
+		case <-tasks.notify:
+		}
+		for closer.Ctx().Err() == nil && (worker.LeadingGroup() != 0 || ownsTasks()) {
+			t, err := nextTask(closer.Ctx())
+			if err != nil {
+				glog.Errorf("Unable to read the queued tasks: %v", err)
+				break
+			}
+			if t == nil {
+				break
+			}
+			claimed, err := claimTask(closer.Ctx(), t)
+			if err != nil {
+				glog.Errorf("Unable to claim task %s: %v", t.Id, err)
+				break
+			}
+			if claimed {
+				runTask(closer.Ctx(), t)
+			}
+		}
+	}
+}
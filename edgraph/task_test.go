@@ -0,0 +1,119 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/worker"
+)
+
+func TestTaskRecord(t *testing.T) {
+	now := time.Date(2020, 11, 20, 10, 0, 0, 0, time.UTC)
+	task := &Task{
+		Id:        "0x2a",
+		Kind:      "export",
+		Status:    TaskSuccess,
+		Node:      3,
+		Progress:  1,
+		Output:    []string{"export/dgraph.r1.u1120.1000/g01.rdf.gz"},
+		Request:   json.RawMessage(`{"format":"rdf"}`),
+		CreatedAt: now,
+		UpdatedAt: now.Add(time.Minute),
+	}
+	b, err := json.Marshal(task)
+	require.NoError(t, err)
+	// The ID is the uid of the node holding the record, it isn't part of the record.
+	require.NotContains(t, string(b), "0x2a")
+
+	var got Task
+	require.NoError(t, json.Unmarshal(b, &got))
+	task.Id = ""
+	require.Equal(t, *task, got)
+}
+
+func TestPickNextTask(t *testing.T) {
+	now := time.Date(2020, 11, 20, 10, 0, 0, 0, time.UTC)
+	task := func(status string, created, updated time.Duration) *Task {
+		return &Task{Status: status, CreatedAt: now.Add(-created), UpdatedAt: now.Add(-updated)}
+	}
+	require.Nil(t, pickNextTask(nil, now, 1, 1))
+
+	done := task(TaskSuccess, time.Hour, time.Hour)
+	running := task(TaskRunning, 50*time.Minute, time.Second)
+	queued := task(TaskQueued, 40*time.Minute, 40*time.Minute)
+	newer := task(TaskQueued, 30*time.Minute, 30*time.Minute)
+	// The running task is still saved periodically, the queued ones wait for it.
+	require.Nil(t, pickNextTask([]*Task{done, running, newer, queued}, now, 1, 1))
+
+	// The running task was interrupted, it's run again before the queued ones.
+	running.UpdatedAt = now.Add(-staleTaskAge - time.Second)
+	require.Equal(t, running, pickNextTask([]*Task{done, running, newer, queued}, now, 1, 1))
+	running.Status = TaskSuccess
+	require.Equal(t, queued, pickNextTask([]*Task{done, running, newer, queued}, now, 1, 1))
+
+	// The tasks without credentials are only run by the leader of group 1, the ones with
+	// credentials only by the Alpha holding them.
+	require.Nil(t, pickNextTask([]*Task{done, newer, queued}, now, 1, 0))
+	require.Nil(t, pickNextTask([]*Task{done, newer, queued}, now, 1, 2))
+	queued.Owner = 2
+	require.Equal(t, newer, pickNextTask([]*Task{done, newer, queued}, now, 1, 1))
+	require.Equal(t, queued, pickNextTask([]*Task{done, newer, queued}, now, 2, 0))
+
+	// The tasks of another group are only run by the leader of that group.
+	newer.Group = 2
+	require.Nil(t, pickNextTask([]*Task{done, newer}, now, 1, 1))
+	require.Nil(t, pickNextTask([]*Task{done, newer}, now, 3, 0))
+	require.Equal(t, newer, pickNextTask([]*Task{done, newer}, now, 3, 2))
+}
+
+func TestLostCredentials(t *testing.T) {
+	now := time.Date(2020, 11, 20, 10, 0, 0, 0, time.UTC)
+	task := &Task{Id: "0x2a", Status: TaskQueued, Owner: 2, UpdatedAt: now}
+	// This Alpha holds the credentials of the task.
+	tasks.Lock()
+	tasks.creds[task.Id] = &worker.Credentials{AccessKey: "key"}
+	tasks.Unlock()
+	require.False(t, lostCredentials(task, now, 2, false))
+
+	// This Alpha restarted since the task was submitted to it.
+	tasks.Lock()
+	delete(tasks.creds, task.Id)
+	tasks.Unlock()
+	require.True(t, lostCredentials(task, now, 2, false))
+
+	// The Alpha running the task stopped.
+	task.Status = TaskRunning
+	require.False(t, lostCredentials(task, now, 1, true))
+	require.True(t, lostCredentials(task, now.Add(staleTaskAge+time.Second), 1, true))
+	require.False(t, lostCredentials(task, now.Add(staleTaskAge+time.Second), 3, false))
+
+	task.Owner = 0
+	require.False(t, lostCredentials(task, now.Add(staleTaskAge+time.Second), 1, true))
+}
+
+func TestGetTaskInvalidId(t *testing.T) {
+	for _, id := range []string{"", "0", "task", "-1"} {
+		_, err := GetTask(context.Background(), id)
+		require.Error(t, err, id)
+	}
+}
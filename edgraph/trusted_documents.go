@@ -0,0 +1,399 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/dgo/v200/protos/api"
+	"github.com/dgraph-io/ristretto/z"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+
+	"github.com/dgraph-io/dgraph/graphql/schema"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// Trusted documents are persisted queries registered by an admin through /admin, under the name
+// and the version of the client application sending them. With the graphql_trusted_documents
+// flag, they are the only operations /graphql executes: clients send the sha256 hash of a
+// document, or the document itself, and anything else is rejected. Unlike the persisted queries
+// of ProcessPersistedQuery, clients can't register new documents.
+//
+// Each Alpha counts how many times it executed each document, and adds its counts to the usage
+// of the documents every minute.
+
+// usageFlushInterval is how often the usage counts are written to the documents.
+const usageFlushInterval = time.Minute
+
+// TrustedDocument is a persisted query registered for a client application.
+type TrustedDocument struct {
+	Uid        string `json:"uid,omitempty"`
+	Client     string `json:"dgraph.graphql.p_client,omitempty"`
+	Name       string `json:"dgraph.graphql.p_name,omitempty"`
+	Version    string `json:"dgraph.graphql.p_version,omitempty"`
+	Query      string `json:"dgraph.graphql.p_query,omitempty"`
+	Sha256Hash string `json:"dgraph.graphql.p_sha256hash,omitempty"`
+	// Usage is the number of times the document was executed.
+	Usage int64 `json:"dgraph.graphql.p_usage"`
+}
+
+const trustedDocumentFields = `
+	uid
+	dgraph.graphql.p_client
+	dgraph.graphql.p_name
+	dgraph.graphql.p_version
+	dgraph.graphql.p_query
+	dgraph.graphql.p_sha256hash
+	dgraph.graphql.p_usage`
+
+// usageCounter holds the executions of each document since the counts were last written, by
+// sha256 hash.
+type usageCounter struct {
+	sync.Mutex
+	counts map[string]int64
+}
+
+var documentUsage = &usageCounter{counts: make(map[string]int64)}
+
+func (c *usageCounter) add(sha256Hash string, n int64) {
+	c.Lock()
+	defer c.Unlock()
+	c.counts[sha256Hash] += n
+}
+
+func (c *usageCounter) get(sha256Hash string) int64 {
+	c.Lock()
+	defer c.Unlock()
+	return c.counts[sha256Hash]
+}
+
+// take returns the counts and resets them.
+func (c *usageCounter) take() map[string]int64 {
+	c.Lock()
+	defer c.Unlock()
+	counts := c.counts
+	c.counts = make(map[string]int64)
+	return counts
+}
+
+// trustedDocumentContext returns the context of the requests on the trusted documents. GraphQL
+// is only served in the galaxy namespace, so that's where the documents are stored.
+func trustedDocumentContext(ctx context.Context) context.Context {
+	return x.AttachNamespace(context.WithValue(ctx, IsGraphql, true), x.GalaxyNamespace)
+}
+
+// validateTrustedDocument checks that the document has all its fields set and computes its hash.
+func validateTrustedDocument(doc *TrustedDocument) error {
+	switch {
+	case doc.Client == "":
+		return errors.Errorf("the client of a trusted document can't be empty")
+	case doc.Name == "":
+		return errors.Errorf("the name of a trusted document can't be empty")
+	case doc.Version == "":
+		return errors.Errorf("the version of a trusted document can't be empty")
+	case doc.Query == "":
+		return errors.Errorf("the query of trusted document %s@%s can't be empty",
+			doc.Name, doc.Version)
+	}
+	doc.Sha256Hash = queryHash(doc.Query)
+	return nil
+}
+
+// checkTrustedDocument checks that registering doc doesn't conflict with the registered
+// documents: the document with the same hash, if any, and the documents of the same client.
+func checkTrustedDocument(doc, sameHash *TrustedDocument, sameClient []*TrustedDocument) error {
+	if sameHash != nil && sameHash.Client != "" {
+		if sameHash.Client != doc.Client {
+			return errors.Errorf("query of %s@%s is already registered for client %q",
+				doc.Name, doc.Version, sameHash.Client)
+		}
+		if sameHash.Name != doc.Name || sameHash.Version != doc.Version {
+			return errors.Errorf("query of %s@%s is already registered as %s@%s",
+				doc.Name, doc.Version, sameHash.Name, sameHash.Version)
+		}
+	}
+	for _, d := range sameClient {
+		if d.Name == doc.Name && d.Version == doc.Version && d.Sha256Hash != doc.Sha256Hash {
+			return errors.Errorf("%s@%s is already registered with a different query",
+				doc.Name, doc.Version)
+		}
+	}
+	return nil
+}
+
+func queryTrustedDocuments(ctx context.Context, query string,
+	vars map[string]string) (map[string][]*TrustedDocument, error) {
+
+	req := &api.Request{
+		Query:    query,
+		Vars:     vars,
+		ReadOnly: true,
+	}
+	resp, err := (&Server{}).doQuery(trustedDocumentContext(ctx), req, NoAuthorize)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string][]*TrustedDocument)
+	if len(resp.Json) > 0 {
+		if err := json.Unmarshal(resp.Json, &res); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// AddTrustedDocuments registers the documents, whose hashes are set on success. Registering a
+// document again is a no-op, but the query of a document registered under a name and a version
+// can't change, and a query can only be registered once.
+func AddTrustedDocuments(ctx context.Context, docs []*TrustedDocument) error {
+	if len(docs) == 0 {
+		return nil
+	}
+	// Check the documents of the batch against each other, as if they were registered one by
+	// one. The same document can be given more than once.
+	var unique []*TrustedDocument
+	byHash := make(map[string]*TrustedDocument)
+	for _, doc := range docs {
+		if err := validateTrustedDocument(doc); err != nil {
+			return err
+		}
+		d, ok := byHash[doc.Sha256Hash]
+		if err := checkTrustedDocument(doc, d, unique); err != nil {
+			return err
+		}
+		if !ok {
+			byHash[doc.Sha256Hash] = doc
+			unique = append(unique, doc)
+		}
+	}
+
+	var nquads []*api.NQuad
+	for i, doc := range unique {
+		res, err := queryTrustedDocuments(ctx, `query Doc($sha: string, $client: string) {
+				sha(func: eq(dgraph.graphql.p_sha256hash, $sha)) {`+trustedDocumentFields+`
+				}
+				client(func: eq(dgraph.graphql.p_client, $client)) {`+trustedDocumentFields+`
+				}
+			}`, map[string]string{"$sha": doc.Sha256Hash, "$client": doc.Client})
+		if err != nil {
+			return err
+		}
+		var sameHash *TrustedDocument
+		if len(res["sha"]) > 0 {
+			sameHash = res["sha"][0]
+		}
+		if err := checkTrustedDocument(doc, sameHash, res["client"]); err != nil {
+			return err
+		}
+
+		subject := fmt.Sprintf("_:doc%d", i)
+		if sameHash != nil {
+			subject = sameHash.Uid
+		}
+		str := func(pred, val string) *api.NQuad {
+			return &api.NQuad{
+				Subject:     subject,
+				Predicate:   pred,
+				ObjectValue: &api.Value{Val: &api.Value_StrVal{StrVal: val}},
+			}
+		}
+		nquads = append(nquads,
+			str("dgraph.graphql.p_client", doc.Client),
+			str("dgraph.graphql.p_name", doc.Name),
+			str("dgraph.graphql.p_version", doc.Version),
+			str("dgraph.graphql.p_query", doc.Query),
+			str("dgraph.graphql.p_sha256hash", doc.Sha256Hash),
+			str("dgraph.type", "dgraph.graphql.persisted_query"))
+		// A persisted query registered by a client before becoming trusted has no usage yet.
+		if sameHash == nil || sameHash.Client == "" {
+			nquads = append(nquads, &api.NQuad{
+				Subject:     subject,
+				Predicate:   "dgraph.graphql.p_usage",
+				ObjectValue: &api.Value{Val: &api.Value_IntVal{IntVal: 0}},
+			})
+		} else {
+			doc.Usage = sameHash.Usage + documentUsage.get(doc.Sha256Hash)
+		}
+	}
+
+	req := &api.Request{
+		Mutations: []*api.Mutation{{Set: nquads}},
+		CommitNow: true,
+	}
+	_, err := (&Server{}).doQuery(trustedDocumentContext(ctx), req, NoAuthorize)
+	return err
+}
+
+// GetTrustedDocuments returns the documents registered for the client, or for all the clients if
+// the client is empty, ordered by client, name and version.
+func GetTrustedDocuments(ctx context.Context, client string) ([]*TrustedDocument, error) {
+	query := `query Docs($client: string) {
+			docs(func: eq(dgraph.graphql.p_client, $client)) {` + trustedDocumentFields + `
+			}
+		}`
+	vars := map[string]string{"$client": client}
+	if client == "" {
+		query = `{
+			docs(func: has(dgraph.graphql.p_client)) {` + trustedDocumentFields + `
+			}
+		}`
+		vars = nil
+	}
+	res, err := queryTrustedDocuments(ctx, query, vars)
+	if err != nil {
+		return nil, err
+	}
+	docs := res["docs"]
+	for _, doc := range docs {
+		doc.Usage += documentUsage.get(doc.Sha256Hash)
+	}
+	sort.Slice(docs, func(i, j int) bool {
+		a, b := docs[i], docs[j]
+		if a.Client != b.Client {
+			return a.Client < b.Client
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Version < b.Version
+	})
+	return docs, nil
+}
+
+// DeleteTrustedDocuments deletes the documents of the client with the given name and version.
+// An empty name or version matches all of them. It returns the deleted documents.
+func DeleteTrustedDocuments(ctx context.Context, client, name,
+	version string) ([]*TrustedDocument, error) {
+
+	if client == "" {
+		return nil, errors.Errorf("the client of the trusted documents can't be empty")
+	}
+	docs, err := GetTrustedDocuments(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	var deleted []*TrustedDocument
+	var del bytes.Buffer
+	for _, doc := range docs {
+		if (name != "" && doc.Name != name) || (version != "" && doc.Version != version) {
+			continue
+		}
+		deleted = append(deleted, doc)
+		fmt.Fprintf(&del, "<%s> * * .\n", doc.Uid)
+	}
+	if len(deleted) == 0 {
+		return nil, nil
+	}
+
+	req := &api.Request{
+		Mutations: []*api.Mutation{{DelNquads: del.Bytes()}},
+		CommitNow: true,
+	}
+	if _, err := (&Server{}).doQuery(trustedDocumentContext(ctx), req, NoAuthorize); err != nil {
+		return nil, err
+	}
+	return deleted, nil
+}
+
+// ProcessTrustedDocument replaces the query of the request with the trusted document it refers
+// to, by its sha256 hash or by its query. It fails if the request isn't a trusted document.
+func ProcessTrustedDocument(ctx context.Context, gqlReq *schema.Request) error {
+	sha256Hash := gqlReq.Extensions.PersistedQuery.Sha256Hash
+	if sha256Hash == "" {
+		if gqlReq.Query == "" {
+			return errors.New("no query or persisted query hash was provided")
+		}
+		sha256Hash = queryHash(gqlReq.Query)
+	}
+
+	res, err := queryTrustedDocuments(ctx, `query Doc($sha: string) {
+			doc(func: eq(dgraph.graphql.p_sha256hash, $sha)) @filter(has(dgraph.graphql.p_client)) {
+				dgraph.graphql.p_query
+			}
+		}`, map[string]string{"$sha": sha256Hash})
+	if err != nil {
+		glog.Errorf("Error while querying sha %s", sha256Hash)
+		return err
+	}
+	if len(res["doc"]) == 0 {
+		return errors.New("only trusted documents can be executed")
+	}
+	doc := res["doc"][0]
+	if gqlReq.Query != "" && gqlReq.Query != doc.Query {
+		return errors.New("query does not match persisted query")
+	}
+
+	gqlReq.Query = doc.Query
+	documentUsage.add(sha256Hash, 1)
+	return nil
+}
+
+// flushDocumentUsage adds the usage counts of this Alpha to the documents. The counts that
+// couldn't be written are kept for the next time.
+func flushDocumentUsage(ctx context.Context) {
+	for sha256Hash, n := range documentUsage.take() {
+		req := &api.Request{
+			Query: `query Usage($sha: string) {
+				doc as var(func: eq(dgraph.graphql.p_sha256hash, $sha)) {
+					usage as dgraph.graphql.p_usage
+					total as math(usage + ` + strconv.FormatInt(n, 10) + `)
+				}
+			}`,
+			Vars: map[string]string{"$sha": sha256Hash},
+			Mutations: []*api.Mutation{{
+				SetNquads: []byte(`uid(doc) <dgraph.graphql.p_usage> val(total) .`),
+			}},
+			CommitNow: true,
+		}
+		if _, err := (&Server{}).doQuery(trustedDocumentContext(ctx), req,
+			NoAuthorize); err != nil {
+			glog.Warningf("Unable to update the usage of trusted document %s: %v", sha256Hash, err)
+			documentUsage.add(sha256Hash, n)
+		}
+	}
+}
+
+// FlushTrustedDocumentUsage writes the usage counts of the trusted documents every minute, and a
+// last time when the closer is signaled.
+func FlushTrustedDocumentUsage(closer *z.Closer) {
+	defer func() {
+		glog.Infof("FlushTrustedDocumentUsage closed")
+		closer.Done()
+	}()
+
+	ticker := time.NewTicker(usageFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			flushDocumentUsage(closer.Ctx())
+		case <-closer.HasBeenClosed():
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			flushDocumentUsage(ctx)
+			cancel()
+			return
+		}
+	}
+}
@@ -0,0 +1,85 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateTrustedDocument(t *testing.T) {
+	doc := &TrustedDocument{Client: "web", Name: "GetUser", Version: "1.0",
+		Query: "query GetUser { getUser(id: 1) { name } }"}
+	require.NoError(t, validateTrustedDocument(doc))
+	match, err := hashMatches(doc.Query, doc.Sha256Hash)
+	require.NoError(t, err)
+	require.True(t, match)
+
+	for _, doc := range []*TrustedDocument{
+		{Name: "GetUser", Version: "1.0", Query: "{ a }"},
+		{Client: "web", Version: "1.0", Query: "{ a }"},
+		{Client: "web", Name: "GetUser", Query: "{ a }"},
+		{Client: "web", Name: "GetUser", Version: "1.0"},
+	} {
+		require.Error(t, validateTrustedDocument(doc), "%+v", doc)
+	}
+}
+
+func TestCheckTrustedDocument(t *testing.T) {
+	newDoc := func(client, name, version, query string) *TrustedDocument {
+		doc := &TrustedDocument{Client: client, Name: name, Version: version, Query: query}
+		require.NoError(t, validateTrustedDocument(doc))
+		return doc
+	}
+	v1 := newDoc("web", "GetUser", "1", "{ a }")
+	registered := []*TrustedDocument{v1}
+
+	// Registering a document again, or a new version of it, is fine.
+	require.NoError(t, checkTrustedDocument(newDoc("web", "GetUser", "1", "{ a }"), v1, registered))
+	require.NoError(t, checkTrustedDocument(newDoc("web", "GetUser", "2", "{ b }"), nil, registered))
+	// So is registering a query persisted by a client before it became trusted.
+	require.NoError(t, checkTrustedDocument(newDoc("web", "GetUser", "2", "{ b }"),
+		&TrustedDocument{Query: "{ b }"}, registered))
+
+	require.EqualError(t,
+		checkTrustedDocument(newDoc("web", "GetUser", "1", "{ b }"), nil, registered),
+		"GetUser@1 is already registered with a different query")
+	require.EqualError(t,
+		checkTrustedDocument(newDoc("web", "GetUser", "2", "{ a }"), v1, registered),
+		"query of GetUser@2 is already registered as GetUser@1")
+	require.EqualError(t,
+		checkTrustedDocument(newDoc("ios", "GetUser", "1", "{ a }"), v1, nil),
+		`query of GetUser@1 is already registered for client "web"`)
+}
+
+func TestUsageCounter(t *testing.T) {
+	c := &usageCounter{counts: make(map[string]int64)}
+	c.add("a", 1)
+	c.add("a", 1)
+	c.add("b", 1)
+	require.Equal(t, int64(2), c.get("a"))
+
+	counts := c.take()
+	require.Equal(t, map[string]int64{"a": 2, "b": 1}, counts)
+	require.Equal(t, int64(0), c.get("a"))
+
+	// The counts that couldn't be written are added back.
+	c.add("a", 1)
+	c.add("a", counts["a"])
+	require.Equal(t, int64(3), c.get("a"))
+}
@@ -2168,6 +2168,28 @@ func TestSchemaQueryWithACL(t *testing.T) {
 		"index":true,
 		"tokenizer":["exact"]
 	},
+	{
+		"predicate":"dgraph.graphql.p_client",
+		"type":"string",
+		"index":true,
+		"tokenizer":["exact"]
+	},
+	{
+		"predicate":"dgraph.graphql.p_name",
+		"type":"string"
+	},
+	{
+		"predicate":"dgraph.graphql.p_version",
+		"type":"string"
+	},
+	{
+		"predicate":"dgraph.graphql.p_usage",
+		"type":"int"
+	},
+	{
+		"predicate":"dgraph.task",
+		"type":"string"
+	},
     {
       "predicate": "dgraph.graphql.schema",
       "type": "string"
@@ -2260,6 +2282,18 @@ func TestSchemaQueryWithACL(t *testing.T) {
 			},
 			{
 				"name": "dgraph.graphql.p_sha256hash"
+			},
+			{
+				"name": "dgraph.graphql.p_client"
+			},
+			{
+				"name": "dgraph.graphql.p_name"
+			},
+			{
+				"name": "dgraph.graphql.p_version"
+			},
+			{
+				"name": "dgraph.graphql.p_usage"
 			}
 		],
 		"name": "dgraph.graphql.persisted_query"
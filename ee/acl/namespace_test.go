@@ -0,0 +1,127 @@
+// +build !oss
+
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Dgraph Community License (the "License"); you
+ * may not use this file except in compliance with the License. You
+ * may obtain a copy of the License at
+ *
+ *     https://github.com/dgraph-io/dgraph/blob/master/licenses/DCL.txt
+ */
+
+package acl
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/dgo/v200"
+	"github.com/dgraph-io/dgo/v200/protos/api"
+	"github.com/dgraph-io/dgraph/testutil"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+func addNamespace(t *testing.T, token *testutil.HttpToken, password string) string {
+	params := testutil.GraphQLParams{
+		Query: `mutation addNamespace($pass: String!) {
+			addNamespace(input: {password: $pass}) {
+				namespaceId
+			}
+		}`,
+		Variables: map[string]interface{}{"pass": password},
+	}
+	resp := makeRequestAndRefreshTokenIfNecessary(t, token, params)
+	resp.RequireNoGraphQLErrors(t)
+
+	var r struct {
+		AddNamespace struct {
+			NamespaceId json.Number
+		}
+	}
+	require.NoError(t, json.Unmarshal(resp.Data, &r))
+	return r.AddNamespace.NamespaceId.String()
+}
+
+func deleteNamespace(t *testing.T, token *testutil.HttpToken, ns string) {
+	params := testutil.GraphQLParams{
+		Query: `mutation deleteNamespace($ns: Int64!) {
+			deleteNamespace(input: {namespaceId: $ns}) {
+				namespaceId
+			}
+		}`,
+		Variables: map[string]interface{}{"ns": ns},
+	}
+	resp := makeRequestAndRefreshTokenIfNecessary(t, token, params)
+	resp.RequireNoGraphQLErrors(t)
+}
+
+// loginIntoNamespace logs into the namespace ns, which clients pass in the metadata of the login
+// request.
+func loginIntoNamespace(t *testing.T, ns, userId, password string) (*dgo.Dgraph, error) {
+	dg, err := testutil.DgraphClient(testutil.SockAddr)
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, "namespace", ns)
+	return dg, dg.Login(ctx, userId, password)
+}
+
+func queryNames(t *testing.T, dg *dgo.Dgraph) string {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	resp, err := dg.NewReadOnlyTxn().Query(ctx, `{ q(func: has(ns_name)) { ns_name } }`)
+	require.NoError(t, err)
+	return string(resp.Json)
+}
+
+func TestNamespaceAclIsolation(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	galaxy, err := testutil.DgraphClientWithGroot(testutil.SockAddr)
+	require.NoError(t, err)
+	require.NoError(t, galaxy.Alter(ctx, &api.Operation{Schema: "ns_name: string ."}))
+	_, err = galaxy.NewTxn().Mutate(ctx, &api.Mutation{
+		SetNquads: []byte(`_:a <ns_name> "galaxy" .`),
+		CommitNow: true,
+	})
+	require.NoError(t, err)
+
+	token := testutil.GrootHttpLogin(adminEndpoint)
+	resp := createUser(t, token, "ns_alice", userpassword)
+	resp.RequireNoGraphQLErrors(t)
+	defer deleteUser(t, token, "ns_alice", true)
+
+	ns := addNamespace(t, token, "nspassword")
+	defer func() {
+		deleteNamespace(t, token, ns)
+		// The users of a deleted namespace can't log into it anymore.
+		_, err := loginIntoNamespace(t, ns, x.GrootId, "nspassword")
+		require.Error(t, err)
+	}()
+
+	// The groot user of the galaxy can't log into the namespace with its password, and the
+	// users of the galaxy don't exist there.
+	_, err = loginIntoNamespace(t, ns, x.GrootId, "password")
+	require.Error(t, err)
+	_, err = loginIntoNamespace(t, ns, "ns_alice", userpassword)
+	require.Error(t, err)
+
+	nsGroot, err := loginIntoNamespace(t, ns, x.GrootId, "nspassword")
+	require.NoError(t, err)
+	require.NoError(t, nsGroot.Alter(ctx, &api.Operation{Schema: "ns_name: string ."}))
+	_, err = nsGroot.NewTxn().Mutate(ctx, &api.Mutation{
+		SetNquads: []byte(`_:a <ns_name> "tenant" .`),
+		CommitNow: true,
+	})
+	require.NoError(t, err)
+
+	// Each namespace only sees its own data.
+	require.JSONEq(t, `{"q": [{"ns_name": "tenant"}]}`, queryNames(t, nsGroot))
+	require.JSONEq(t, `{"q": [{"ns_name": "galaxy"}]}`, queryNames(t, galaxy))
+}
@@ -0,0 +1,61 @@
+// +build oss
+
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package audit
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/golang/glog"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+)
+
+// CmdAudit is the sub-command used to work with audit logs.
+var CmdAudit x.SubCommand
+
+func init() {
+	CmdAudit.Cmd = &cobra.Command{
+		Use:   "audit",
+		Short: "Enterprise feature. Not supported in oss version",
+	}
+}
+
+// InitAuditor does nothing in OSS builds, audit logging is an enterprise feature.
+func InitAuditor(conf *AuditConf) error {
+	if conf != nil && conf.Dir != "" {
+		glog.Warningf("Audit logging is an enterprise feature. Ignoring --audit_dir.")
+	}
+	return nil
+}
+
+// Close does nothing in OSS builds.
+func Close() {}
+
+// AuditRequestHttp returns the given handler as is for OSS builds.
+func AuditRequestHttp(next http.Handler) http.Handler {
+	return next
+}
+
+// AuditRequestGRPC calls the handler as is for OSS builds.
+func AuditRequestGRPC(ctx context.Context, req interface{},
+	info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	return handler(ctx, req)
+}
@@ -0,0 +1,566 @@
+// +build !oss
+
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Dgraph Community License (the "License"); you
+ * may not use this file except in compliance with the License. You
+ * may obtain a copy of the License at
+ *
+ *     https://github.com/dgraph-io/dgraph/blob/master/licenses/DCL.txt
+ */
+
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/dgraph-io/dgo/v200/protos/api"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/dgrijalva/jwt-go"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const (
+	// PoorManAuth is logged as the user of requests authenticated with the auth token
+	// instead of an ACL JWT.
+	PoorManAuth = "PoorManAuth"
+
+	// maxRequestSize caps the amount of request text written to the audit log per request.
+	maxRequestSize = 64 << 10
+
+	outcomeSuccess = "success"
+	outcomeFailure = "failure"
+
+	redacted = "****"
+)
+
+// secretFields are the GraphQL arguments and input fields that hold secrets in any schema, e.g.
+// the password of the login and updateUser operations of /admin.
+var secretFields = []string{"password", "refreshToken"}
+
+// checkpwdArg matches the password given to the checkpwd function of DQL.
+var checkpwdArg = regexp.MustCompile(`(checkpwd\s*\([^,()]*,\s*)"(?:[^"\\]|\\.)*"`)
+
+// secrets tells which parts of the requests are secrets that the audit log must not show. They
+// depend on the predicates of type password in the schema: their values are secrets, and so
+// are the GraphQL fields they store, e.g. pwd for the User.pwd predicate of a GraphQL type with
+// a @secret(field: "pwd") directive. The predicates of all the namespaces are considered.
+type secrets struct {
+	// names are the predicates of type password, sorted.
+	names []string
+	preds map[string]bool
+	// fields holds the GraphQL fields that hold secrets, in lower case.
+	fields map[string]bool
+	// nquad matches the values of the predicates in N-Quads.
+	nquad *regexp.Regexp
+	// gqlArg matches the string arguments of GraphQL operations that hold secrets.
+	gqlArg *regexp.Regexp
+	// gqlVar matches the secret arguments of GraphQL operations given by a variable.
+	gqlVar *regexp.Regexp
+}
+
+// lastSecrets caches the secrets, until the predicates of type password change.
+var lastSecrets atomic.Value
+
+// currentSecrets returns the secrets for the current schema. Zero has none, it only redacts
+// the secretFields.
+func currentSecrets() *secrets {
+	names := []string{"dgraph.password"}
+	for _, attr := range schema.State().Predicates() {
+		if typ, err := schema.State().TypeOf(attr); err == nil && typ == types.PasswordID {
+			if name := x.ParseAttr(attr); name != "dgraph.password" {
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names[1:])
+	if s, ok := lastSecrets.Load().(*secrets); ok && equalStrings(s.names, names) {
+		return s
+	}
+
+	s := &secrets{names: names, preds: make(map[string]bool), fields: make(map[string]bool)}
+	var preds, fields []string
+	addField := func(f string) {
+		if f = strings.ToLower(f); !s.fields[f] {
+			s.fields[f] = true
+			fields = append(fields, regexp.QuoteMeta(f))
+		}
+	}
+	for _, f := range secretFields {
+		addField(f)
+	}
+	for _, name := range names {
+		s.preds[name] = true
+		preds = append(preds, regexp.QuoteMeta(name))
+		addField(name[strings.LastIndex(name, ".")+1:])
+	}
+	s.nquad = regexp.MustCompile(`(<(?:` + strings.Join(preds, "|") + `)>\s*)"(?:[^"\\]|\\.)*"`)
+	s.gqlArg = regexp.MustCompile(`(?i)\b(` + strings.Join(fields, "|") +
+		`)(\s*:\s*)"(?:[^"\\]|\\.)*"`)
+	s.gqlVar = regexp.MustCompile(`(?i)\b(?:` + strings.Join(fields, "|") + `)\s*:\s*\$(\w+)`)
+	lastSecrets.Store(s)
+	return s
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// AuditEvent is a single entry of the audit log.
+type AuditEvent struct {
+	Time       string  `json:"time"`
+	Server     string  `json:"server"`
+	User       string  `json:"user"`
+	ClientIP   string  `json:"client_ip"`
+	Transport  string  `json:"transport"`
+	Endpoint   string  `json:"endpoint"`
+	Operation  string  `json:"operation"`
+	Request    string  `json:"request,omitempty"`
+	Outcome    string  `json:"outcome"`
+	StatusCode int     `json:"status_code,omitempty"`
+	Error      string  `json:"error,omitempty"`
+	LatencyMs  float64 `json:"latency_ms"`
+}
+
+type auditLogger struct {
+	enabled uint32
+	server  string
+	log     *x.LogWriter
+}
+
+var auditor = &auditLogger{}
+
+// InitAuditor starts writing the audit log as described by the given configuration.
+func InitAuditor(conf *AuditConf) error {
+	if conf == nil || conf.Dir == "" {
+		return nil
+	}
+	w, err := newLogWriter(conf.Dir, "audit", int64(conf.MaxSizeMB)<<20, conf.MaxFiles,
+		conf.EncryptionKey)
+	if err != nil {
+		return errors.Wrapf(err, "while initializing audit log")
+	}
+	auditor.server = conf.Server
+	auditor.log = w
+	atomic.StoreUint32(&auditor.enabled, 1)
+	glog.Infof("Audit logs are written to %s. Encrypted: %v", conf.Dir, conf.EncryptionKey != nil)
+	return nil
+}
+
+// Close stops audit logging and closes the current log file.
+func Close() {
+	if atomic.SwapUint32(&auditor.enabled, 0) == 0 {
+		return
+	}
+	if err := auditor.log.Close(); err != nil {
+		glog.Errorf("Error while closing audit log: %v", err)
+	}
+}
+
+func (a *auditLogger) isEnabled() bool {
+	return atomic.LoadUint32(&a.enabled) == 1
+}
+
+func (a *auditLogger) audit(e *AuditEvent) {
+	if !a.isEnabled() {
+		return
+	}
+	e.Server = a.server
+	b, err := json.Marshal(e)
+	if err != nil {
+		glog.Errorf("Unable to marshal audit event: %v", err)
+		return
+	}
+	if _, err := a.log.Write(append(b, '\n')); err != nil {
+		glog.Errorf("Unable to write audit event: %v", err)
+	}
+}
+
+// responseWriter records the outcome of an HTTP request.
+type responseWriter struct {
+	http.ResponseWriter
+	statusCode int
+	wrote      bool
+	failed     bool
+}
+
+func (rw *responseWriter) WriteHeader(code int) {
+	rw.statusCode = code
+	rw.ResponseWriter.WriteHeader(code)
+}
+
+func (rw *responseWriter) Write(b []byte) (int, error) {
+	if !rw.wrote {
+		// Dgraph reports most errors with a 200 status code and an errors list in the body.
+		rw.wrote = true
+		rw.failed = bytes.HasPrefix(bytes.TrimSpace(b), []byte(`{"errors"`))
+	}
+	return rw.ResponseWriter.Write(b)
+}
+
+// Hijack lets the subscriptions upgrade the connection to a WebSocket.
+func (rw *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hj, ok := rw.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("The response writer doesn't support hijacking")
+	}
+	rw.statusCode = http.StatusSwitchingProtocols
+	return hj.Hijack()
+}
+
+// AuditRequestHttp wraps the given handler so that each request it serves is recorded in
+// the audit log, if audit logging is enabled.
+func AuditRequestHttp(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !auditor.isEnabled() {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		var body []byte
+		if r.Body != nil {
+			var err error
+			if body, err = ioutil.ReadAll(r.Body); err != nil {
+				x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+				return
+			}
+			r.Body = ioutil.NopCloser(bytes.NewReader(body))
+		}
+
+		start := time.Now()
+		rw := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+		next.ServeHTTP(rw, r)
+
+		e := &AuditEvent{
+			Time:       start.UTC().Format(time.RFC3339Nano),
+			User:       httpUser(r),
+			ClientIP:   httpClientIP(r),
+			Transport:  "http",
+			Endpoint:   r.URL.Path,
+			Operation:  httpOperation(r.URL.Path),
+			Request:    truncate(requestText(r, body)),
+			Outcome:    outcomeSuccess,
+			StatusCode: rw.statusCode,
+			LatencyMs:  x.SinceMs(start),
+		}
+		if rw.failed || rw.statusCode >= http.StatusBadRequest {
+			e.Outcome = outcomeFailure
+		}
+		auditor.audit(e)
+	})
+}
+
+// AuditRequestGRPC is a unary server interceptor that records each gRPC request in the
+// audit log, if audit logging is enabled.
+func AuditRequestGRPC(ctx context.Context, req interface{},
+	info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if !auditor.isEnabled() {
+		return handler(ctx, req)
+	}
+
+	start := time.Now()
+	resp, err := handler(ctx, req)
+
+	method := info.FullMethod[strings.LastIndex(info.FullMethod, "/")+1:]
+	e := &AuditEvent{
+		Time:      start.UTC().Format(time.RFC3339Nano),
+		User:      grpcUser(ctx),
+		ClientIP:  grpcClientIP(ctx),
+		Transport: "grpc",
+		Endpoint:  info.FullMethod,
+		Operation: strings.ToLower(method),
+		Outcome:   outcomeSuccess,
+		LatencyMs: x.SinceMs(start),
+	}
+	switch r := req.(type) {
+	case *api.Request:
+		if len(r.Mutations) > 0 {
+			e.Operation = "mutate"
+		}
+		e.Request = truncate(requestString(r))
+	case *api.Operation:
+		e.Operation = "alter"
+		e.Request = truncate(r.String())
+	case *api.LoginRequest:
+		// Never write passwords or refresh tokens to the audit log.
+		e.User = r.Userid
+	case *api.TxnContext:
+		e.Request = truncate(r.String())
+	}
+	if err != nil {
+		e.Outcome = outcomeFailure
+		e.Error = err.Error()
+	}
+	auditor.audit(e)
+	return resp, err
+}
+
+// requestString returns the text of a DQL request, without the values of the predicates of type
+// password and the passwords given to checkpwd.
+func requestString(r *api.Request) string {
+	sec := currentSecrets()
+	var sb strings.Builder
+	sb.WriteString(sec.redactDQL(r.Query))
+	for _, mu := range r.Mutations {
+		if mu.Cond != "" {
+			sb.WriteString("\n" + mu.Cond)
+		}
+		if len(mu.SetNquads) > 0 {
+			sb.WriteString("\nset: " + sec.redactDQL(string(mu.SetNquads)))
+		}
+		if len(mu.SetJson) > 0 {
+			sb.WriteString("\nset_json: " + sec.redactJSON(mu.SetJson))
+		}
+		if len(mu.DelNquads) > 0 {
+			sb.WriteString("\ndelete: " + sec.redactDQL(string(mu.DelNquads)))
+		}
+		if len(mu.DeleteJson) > 0 {
+			sb.WriteString("\ndelete_json: " + sec.redactJSON(mu.DeleteJson))
+		}
+	}
+	return sb.String()
+}
+
+func requestText(r *http.Request, body []byte) string {
+	sec := currentSecrets()
+	graphql := strings.HasPrefix(r.URL.Path, "/admin") || strings.HasPrefix(r.URL.Path, "/graphql")
+	switch {
+	case len(body) > 0 && graphql:
+		return sec.redactGraphQLBody(body)
+	case len(body) > 0:
+		return sec.redactDQLBody(body)
+	case graphql:
+		// GET requests carry the operation in the URL, e.g. /admin?query=...
+		params := r.URL.Query()
+		q := params.Get("query")
+		if q != "" {
+			params.Set("query", sec.redactGraphQL(q))
+		}
+		if vars := params.Get("variables"); vars != "" {
+			params.Set("variables", sec.redactVariables(q, vars))
+		}
+		return params.Encode()
+	}
+	return r.URL.RawQuery
+}
+
+// redactDQL returns the DQL query or N-Quads without the values of the predicates of type
+// password and the passwords given to checkpwd.
+func (sec *secrets) redactDQL(q string) string {
+	q = sec.nquad.ReplaceAllString(q, `$1"`+redacted+`"`)
+	return checkpwdArg.ReplaceAllString(q, `$1"`+redacted+`"`)
+}
+
+// redactJSON returns the JSON mutation without the values of the predicates of type password.
+func (sec *secrets) redactJSON(b []byte) string {
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return redacted
+	}
+	redactKeys(v, sec.isPred)
+	out, err := json.Marshal(v)
+	if err != nil {
+		return redacted
+	}
+	return string(out)
+}
+
+// redactDQLBody returns the body of a DQL request without its secrets. The body is either the
+// query or mutation itself, or a JSON object holding it, like the JSON mutations.
+func (sec *secrets) redactDQLBody(body []byte) string {
+	var req map[string]interface{}
+	if err := json.Unmarshal(body, &req); err != nil {
+		return sec.redactDQL(string(body))
+	}
+	redactKeys(req, sec.isPred)
+	if q, ok := req["query"].(string); ok {
+		req["query"] = sec.redactDQL(q)
+	}
+	b, err := json.Marshal(req)
+	if err != nil {
+		return redacted
+	}
+	return string(b)
+}
+
+// redactGraphQLBody returns the body of a GraphQL request without the secrets it holds, like
+// AuditRequestGRPC does for login requests. The body is either a JSON object with the query and
+// its variables, or the query itself.
+func (sec *secrets) redactGraphQLBody(body []byte) string {
+	var req map[string]interface{}
+	if err := json.Unmarshal(body, &req); err != nil {
+		return sec.redactGraphQL(string(body))
+	}
+	q, _ := req["query"].(string)
+	if q != "" {
+		req["query"] = sec.redactGraphQL(q)
+	}
+	sec.redactSecrets(q, req["variables"])
+	b, err := json.Marshal(req)
+	if err != nil {
+		return sec.redactGraphQL(string(body))
+	}
+	return string(b)
+}
+
+func (sec *secrets) redactGraphQL(q string) string {
+	return sec.gqlArg.ReplaceAllString(q, `$1$2"`+redacted+`"`)
+}
+
+func (sec *secrets) redactVariables(q, vars string) string {
+	var v interface{}
+	if err := json.Unmarshal([]byte(vars), &v); err != nil {
+		return redacted
+	}
+	sec.redactSecrets(q, v)
+	b, err := json.Marshal(v)
+	if err != nil {
+		return sec.redactGraphQL(vars)
+	}
+	return string(b)
+}
+
+// redactSecrets replaces the secrets found in the variables of the GraphQL operation q: the
+// variables passed as a secret argument, and the secret fields of the input objects, at any
+// depth.
+func (sec *secrets) redactSecrets(q string, vars interface{}) {
+	if vars, ok := vars.(map[string]interface{}); ok {
+		for _, m := range sec.gqlVar.FindAllStringSubmatch(q, -1) {
+			if _, ok := vars[m[1]]; ok {
+				vars[m[1]] = redacted
+			}
+		}
+	}
+	redactKeys(vars, sec.isField)
+}
+
+// redactKeys replaces the values of the keys that are secrets, at any depth.
+func redactKeys(v interface{}, secret func(key string) bool) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, val := range v {
+			if secret(key) {
+				v[key] = redacted
+				continue
+			}
+			redactKeys(val, secret)
+		}
+	case []interface{}:
+		for _, val := range v {
+			redactKeys(val, secret)
+		}
+	}
+}
+
+func (sec *secrets) isPred(key string) bool {
+	return sec.preds[key]
+}
+
+func (sec *secrets) isField(key string) bool {
+	return sec.fields[strings.ToLower(key)]
+}
+
+func truncate(s string) string {
+	if len(s) <= maxRequestSize {
+		return s
+	}
+	return s[:maxRequestSize] + "..."
+}
+
+func httpOperation(path string) string {
+	switch {
+	case strings.HasPrefix(path, "/admin"):
+		return "admin"
+	case strings.HasPrefix(path, "/query"):
+		return "query"
+	case strings.HasPrefix(path, "/mutate"):
+		return "mutate"
+	}
+	return strings.Trim(path, "/")
+}
+
+func httpUser(r *http.Request) string {
+	if token := r.Header.Get("X-Dgraph-AccessToken"); token != "" {
+		return userFromJwt(token)
+	}
+	if r.Header.Get("X-Dgraph-AuthToken") != "" {
+		return PoorManAuth
+	}
+	return ""
+}
+
+func httpClientIP(r *http.Request) string {
+	if fwd := r.Header.Get("X-Forwarded-For"); fwd != "" {
+		return strings.TrimSpace(strings.Split(fwd, ",")[0])
+	}
+	if ip, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return ip
+	}
+	return r.RemoteAddr
+}
+
+func grpcUser(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if token := md.Get("accessJwt"); len(token) > 0 {
+		return userFromJwt(token[0])
+	}
+	if len(md.Get("auth-token")) > 0 {
+		return PoorManAuth
+	}
+	return ""
+}
+
+func grpcClientIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	if ip, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+		return ip
+	}
+	return p.Addr.String()
+}
+
+// userFromJwt returns the user id claimed by the given access JWT, or an empty string if it
+// claims none. The token is not verified here, that is done by the request handlers. For a token
+// that can't be parsed, it returns the parsing error, the token itself is never logged.
+func userFromJwt(token string) string {
+	claims := jwt.MapClaims{}
+	if _, _, err := new(jwt.Parser).ParseUnverified(token, claims); err != nil {
+		return fmt.Sprintf("invalid jwt: %v", err)
+	}
+	if userId, ok := claims["userid"].(string); ok {
+		return userId
+	}
+	return ""
+}
@@ -0,0 +1,132 @@
+// +build !oss
+
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Dgraph Community License (the "License"); you
+ * may not use this file except in compliance with the License. You
+ * may obtain a copy of the License at
+ *
+ *     https://github.com/dgraph-io/dgraph/blob/master/licenses/DCL.txt
+ */
+
+package audit
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/dgraph-io/dgo/v200/protos/api"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestTextRedactsSecrets(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		body     string
+		contains []string
+	}{
+		{
+			name: "login arguments",
+			path: "/admin",
+			body: `{"query": "mutation { login(userId: \"groot\", password: \"s3cret\") ` +
+				`{ response { accessJWT } } }"}`,
+			contains: []string{`userId: \"groot\"`, `password: \"****\"`},
+		},
+		{
+			name: "login variables",
+			path: "/admin",
+			body: `{"query": "mutation($pwd: String!, $token: String) { login(userId: \"groot\", ` +
+				`password: $pwd, refreshToken: $token) { response { accessJWT } } }", ` +
+				`"variables": {"pwd": "s3cret", "token": "t0ken"}}`,
+			contains: []string{`"pwd":"****"`, `"token":"****"`},
+		},
+		{
+			name: "updateUser input",
+			path: "/admin",
+			body: `{"query": "mutation($patch: UpdateUserInput!) { updateUser(input: $patch) { ` +
+				`user { name } } }", "variables": {"patch": {"filter": {"name": {"eq": "alice"}}, ` +
+				`"set": {"password": "s3cret"}}}}`,
+			contains: []string{`"password":"****"`, `"alice"`},
+		},
+		{
+			name:     "raw GraphQL body",
+			path:     "/admin",
+			body:     `mutation { updateUser(input: {set: {password: "s3cret"}}) { user { name } } }`,
+			contains: []string{`password: "****"`},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", tc.path, strings.NewReader(tc.body))
+			text := requestText(r, []byte(tc.body))
+			require.NotContains(t, text, "s3cret")
+			require.NotContains(t, text, "t0ken")
+			for _, s := range tc.contains {
+				require.Contains(t, text, s)
+			}
+		})
+	}
+
+	q := url.Values{}
+	q.Set("query", `mutation { login(userId: "groot", password: "s3cret") { response { `+
+		`accessJWT } } }`)
+	r := httptest.NewRequest("GET", "/admin?"+q.Encode(), nil)
+	require.NotContains(t, requestText(r, nil), "s3cret")
+
+	// DQL requests are logged as they are, but for their passwords.
+	body := `{ q(func: eq(name, "alice")) { name } }`
+	r = httptest.NewRequest("POST", "/query", strings.NewReader(body))
+	require.Equal(t, body, requestText(r, []byte(body)))
+
+	for _, body := range []string{
+		`{ q(func: eq(dgraph.xid, "groot")) { checkpwd(dgraph.password, "s3cret") } }`,
+		`{ set { _:u <dgraph.xid> "alice" . _:u <dgraph.password> "s3cret" . } }`,
+		`{"set": [{"dgraph.xid": "alice", "dgraph.password": "s3cret"}]}`,
+		`{"query": "{ q(func: uid(0x1)) { checkpwd(dgraph.password, \"s3cret\") } }"}`,
+	} {
+		r = httptest.NewRequest("POST", "/mutate", strings.NewReader(body))
+		text := requestText(r, []byte(body))
+		require.NotContains(t, text, "s3cret", body)
+		require.Contains(t, text, redacted, body)
+	}
+}
+
+func TestRequestStringRedactsPasswords(t *testing.T) {
+	// User.pwd is the predicate of the pwd field of a GraphQL type with @secret(field: "pwd").
+	require.NoError(t, schema.ParseBytes([]byte(`
+		name: string .
+		User.pwd: password .
+	`), 1))
+	defer schema.State().DeleteAll()
+
+	req := &api.Request{
+		Query: `{ q(func: eq(name, "alice")) { checkpwd(User.pwd, "s3cret") } }`,
+		Mutations: []*api.Mutation{
+			{SetNquads: []byte(`_:u <name> "alice" .
+				_:u <User.pwd> "s3cret" .`)},
+			{SetJson: []byte(`[{"name": "bob", "User.pwd": "s3cret"}]`)},
+			{DelNquads: []byte(`<0x1> <User.pwd> "s3cret" .`)},
+		},
+	}
+	text := requestString(req)
+	require.NotContains(t, text, "s3cret")
+	require.Contains(t, text, `"alice"`)
+	require.Contains(t, text, `"bob"`)
+
+	// The GraphQL field of the predicate is a secret too.
+	body := `{"query": "mutation($u: AddUserInput!) { addUser(input: [$u]) { user { name } } }",` +
+		` "variables": {"u": {"name": "alice", "pwd": "s3cret"}}}`
+	r := httptest.NewRequest("POST", "/graphql", strings.NewReader(body))
+	text = requestText(r, []byte(body))
+	require.NotContains(t, text, "s3cret")
+	require.Contains(t, text, `"alice"`)
+
+	body = `query { checkUserPassword(name: "alice", pwd: "s3cret") { name } }`
+	r = httptest.NewRequest("POST", "/graphql", strings.NewReader(body))
+	require.NotContains(t, requestText(r, []byte(body)), "s3cret")
+}
@@ -0,0 +1,72 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package audit
+
+import (
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// AuditConf holds the options for audit logging.
+type AuditConf struct {
+	// Dir is the directory where the audit log files are written.
+	Dir string
+	// MaxSizeMB is the size in MB after which the current log file is rotated.
+	MaxSizeMB int
+	// MaxFiles is the number of log files to retain. Older ones are deleted.
+	MaxFiles int
+	// EncryptionKey, if set, is used to encrypt the audit log files.
+	EncryptionKey x.SensitiveByteSlice
+	// Server identifies the node writing the log, e.g. "alpha" or "zero".
+	Server string
+}
+
+// RegisterFlags registers the flags used to configure audit logging.
+func RegisterFlags(flag *pflag.FlagSet) {
+	flag.String("audit_dir", "",
+		"If set, all requests are recorded in audit log files inside this directory. "+
+			"Enterprise feature.")
+	flag.Int("audit_size_mb", 100,
+		"Size in MB after which the audit log file is rotated. Enterprise feature.")
+	flag.Int("audit_max_files", 10,
+		"Number of audit log files to retain, older files are deleted. "+
+			"Use 0 to retain all files. Enterprise feature.")
+	flag.Bool("audit_encrypt", false,
+		"Encrypt the audit logs with the key given by --encryption_key_file or Vault. "+
+			"Use 'dgraph audit decrypt' to read them. Enterprise feature.")
+}
+
+// GetAuditConf returns the audit configuration set through the flags, or nil if audit
+// logging is disabled. The key is only used if encryption of the logs was requested.
+func GetAuditConf(conf *viper.Viper, server string, key x.SensitiveByteSlice) *AuditConf {
+	dir := conf.GetString("audit_dir")
+	if dir == "" {
+		return nil
+	}
+	ac := &AuditConf{
+		Dir:       dir,
+		MaxSizeMB: conf.GetInt("audit_size_mb"),
+		MaxFiles:  conf.GetInt("audit_max_files"),
+		Server:    server,
+	}
+	if conf.GetBool("audit_encrypt") {
+		x.AssertTruef(key != nil, "--audit_encrypt requires an encryption key")
+		ac.EncryptionKey = key
+	}
+	return ac
+}
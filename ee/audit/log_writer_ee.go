@@ -0,0 +1,29 @@
+// +build !oss
+
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Dgraph Community License (the "License"); you
+ * may not use this file except in compliance with the License. You
+ * may obtain a copy of the License at
+ *
+ *     https://github.com/dgraph-io/dgraph/blob/master/licenses/DCL.txt
+ */
+
+package audit
+
+import (
+	"io"
+
+	"github.com/dgraph-io/dgraph/ee/enc"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// newLogWriter returns a writer of the audit logs to size-limited files in dir. Each file is
+// encrypted with key, if it's set.
+func newLogWriter(dir, prefix string, maxSize int64, maxFiles int,
+	key x.SensitiveByteSlice) (*x.LogWriter, error) {
+	return x.NewLogWriter(dir, prefix, maxSize, maxFiles, func(w io.Writer) (io.Writer, error) {
+		return enc.GetWriter(key, w)
+	})
+}
@@ -0,0 +1,106 @@
+// +build !oss
+
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Dgraph Community License (the "License"); you
+ * may not use this file except in compliance with the License. You
+ * may obtain a copy of the License at
+ *
+ *     https://github.com/dgraph-io/dgraph/blob/master/licenses/DCL.txt
+ */
+
+package audit
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogWriterRotation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	w, err := newLogWriter(dir, "audit", 100, 2, nil)
+	require.NoError(t, err)
+	line := bytes.Repeat([]byte("a"), 59)
+	for i := 0; i < 4; i++ {
+		_, err := w.Write(append(line, '\n'))
+		require.NoError(t, err)
+		// File names have millisecond resolution.
+		time.Sleep(2 * time.Millisecond)
+	}
+	require.NoError(t, w.Close())
+
+	files, err := filepath.Glob(filepath.Join(dir, "audit_*.log"))
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	for _, f := range files {
+		b, err := ioutil.ReadFile(f)
+		require.NoError(t, err)
+		require.Equal(t, 60, len(b))
+	}
+}
+
+func TestLogWriterEncryption(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	key := []byte("1234567890123456")
+	w, err := newLogWriter(dir, "audit", 1<<20, 0, key)
+	require.NoError(t, err)
+	entry := []byte(`{"user":"groot","operation":"alter"}` + "\n")
+	_, err = w.Write(entry)
+	require.NoError(t, err)
+	_, err = w.Write(entry)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	files, err := filepath.Glob(filepath.Join(dir, "audit_*.log"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	raw, err := ioutil.ReadFile(files[0])
+	require.NoError(t, err)
+	require.False(t, bytes.Contains(raw, []byte("groot")))
+
+	var out bytes.Buffer
+	require.NoError(t, decryptLog(key, bytes.NewReader(raw), &out))
+	require.Equal(t, append(entry, entry...), out.Bytes())
+}
+
+func TestLogWriterRotationSameMillisecond(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	key := []byte("1234567890123456")
+	entry := []byte(`{"user":"groot","operation":"alter"}` + "\n")
+	// Every entry is written to a new file, most likely within the same millisecond.
+	w, err := newLogWriter(dir, "audit", int64(len(entry)), 0, key)
+	require.NoError(t, err)
+	for i := 0; i < 5; i++ {
+		_, err = w.Write(entry)
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+
+	files, err := filepath.Glob(filepath.Join(dir, "audit_*.log"))
+	require.NoError(t, err)
+	// The first file only holds the IV, it's rotated before the first entry.
+	require.Len(t, files, 6)
+	var all bytes.Buffer
+	for _, f := range files {
+		raw, err := ioutil.ReadFile(f)
+		require.NoError(t, err)
+		require.NoError(t, decryptLog(key, bytes.NewReader(raw), &all))
+	}
+	require.Equal(t, bytes.Repeat(entry, 5), all.Bytes())
+}
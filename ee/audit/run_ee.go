@@ -0,0 +1,113 @@
+// +build !oss
+
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Dgraph Community License (the "License"); you
+ * may not use this file except in compliance with the License. You
+ * may obtain a copy of the License at
+ *
+ *     https://github.com/dgraph-io/dgraph/blob/master/licenses/DCL.txt
+ */
+
+package audit
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/dgraph-io/dgraph/ee/enc"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// CmdAudit is the sub-command used to work with audit logs.
+var CmdAudit x.SubCommand
+
+func init() {
+	CmdAudit.Cmd = &cobra.Command{
+		Use:   "audit",
+		Short: "Dgraph audit tool",
+	}
+
+	subcommands := initSubcommands()
+	for _, sc := range subcommands {
+		CmdAudit.Cmd.AddCommand(sc.Cmd)
+		sc.Conf = viper.New()
+		if err := sc.Conf.BindPFlags(sc.Cmd.Flags()); err != nil {
+			glog.Fatalf("Unable to bind flags for command %v: %v", sc, err)
+		}
+		sc.Conf.SetEnvPrefix(sc.EnvPrefix)
+	}
+}
+
+func initSubcommands() []*x.SubCommand {
+	var cmdDecrypt x.SubCommand
+	cmdDecrypt.Cmd = &cobra.Command{
+		Use:   "decrypt",
+		Short: "Run Dgraph audit tool to decrypt an audit log file",
+		Long: `
+Decrypts an audit log file written by an Alpha or Zero running with --audit_encrypt.
+The key must be the one that the node used to encrypt the file.
+
+Usage example:
+
+$ dgraph audit decrypt --in audit_2020-11-01T10-00-00.000.log --out audit.json \
+	--encryption_key_file ./enc_key
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := decrypt(cmdDecrypt.Conf); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	decFlags := cmdDecrypt.Cmd.Flags()
+	decFlags.String("in", "", "Path to the encrypted audit log file.")
+	decFlags.String("out", "", "Path of the decrypted output file. Defaults to stdout.")
+	enc.RegisterFlags(decFlags)
+	_ = cmdDecrypt.Cmd.MarkFlagRequired("in")
+
+	return []*x.SubCommand{&cmdDecrypt}
+}
+
+func decrypt(conf *viper.Viper) error {
+	key, err := enc.ReadKey(conf)
+	if err != nil {
+		return err
+	}
+	if key == nil {
+		return errors.New("no encryption key provided")
+	}
+
+	in, err := os.Open(conf.GetString("in"))
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	var out io.Writer = os.Stdout
+	if path := conf.GetString("out"); path != "" {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+	return decryptLog(key, in, out)
+}
+
+func decryptLog(key x.SensitiveByteSlice, in io.Reader, out io.Writer) error {
+	r, err := enc.GetReader(key, in)
+	if err != nil {
+		return errors.Wrapf(err, "while reading audit log")
+	}
+	_, err = io.Copy(out, r)
+	return err
+}
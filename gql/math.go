@@ -25,6 +25,7 @@ import (
 	"github.com/dgraph-io/dgraph/types"
 	"github.com/dgraph-io/dgraph/x"
 	"github.com/pkg/errors"
+	geom "github.com/twpayne/go-geom"
 )
 
 type mathTreeStack struct{ a []*MathTree }
@@ -233,6 +234,14 @@ loop:
 				return nil, false, err
 			}
 			if peekIt[0].Typ == itemLeftRound {
+				if lval == geoDistanceFunc {
+					child, err := parseGeoDistance(it)
+					if err != nil {
+						return nil, false, err
+					}
+					valueStack.push(child)
+					continue
+				}
 				again := false
 				if !isMathFunc(item.Val) {
 					return nil, false, errors.Errorf("Unknown math function: %v", item.Val)
@@ -346,6 +355,86 @@ loop:
 	return res, false, err
 }
 
+// geoDistanceFunc is the math function computing the distance in meters from the geo values of a
+// value variable to a point, e.g. geo_distance(loc, [-122.4, 37.8]).
+const geoDistanceFunc = "geo_distance"
+
+// parseGeoDistance parses the arguments of geo_distance, from the ( following its name. The point
+// is kept as the constant of the second child.
+func parseGeoDistance(it *lex.ItemIterator) (*MathTree, error) {
+	expect := func(typ lex.ItemType, what string) (lex.Item, error) {
+		if !it.Next() {
+			return lex.Item{}, errors.Errorf("Unexpected EOF in %s, expected %s",
+				geoDistanceFunc, what)
+		}
+		item := it.Item()
+		if item.Typ != typ {
+			return item, errors.Errorf("Expected %s in %s but got %q", what, geoDistanceFunc,
+				item.Val)
+		}
+		return item, nil
+	}
+	coord := func() (float64, error) {
+		item, err := expect(itemName, "a coordinate")
+		sign := 1.0
+		if err != nil && item.Typ == itemMathOp && item.Val == "-" {
+			sign = -1
+			item, err = expect(itemName, "a coordinate")
+		}
+		if err != nil {
+			return 0, err
+		}
+		v, err := strconv.ParseFloat(item.Val, 64)
+		if err != nil {
+			return 0, errors.Errorf("Invalid coordinate %q in %s", item.Val, geoDistanceFunc)
+		}
+		return sign * v, nil
+	}
+
+	if _, err := expect(itemLeftRound, "("); err != nil {
+		return nil, err
+	}
+	v, err := expect(itemName, "a value variable")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := expect(itemComma, ","); err != nil {
+		return nil, err
+	}
+	if _, err := expect(itemLeftSquare, "["); err != nil {
+		return nil, err
+	}
+	lon, err := coord()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := expect(itemComma, ","); err != nil {
+		return nil, err
+	}
+	lat, err := coord()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := expect(itemRightSquare, "]"); err != nil {
+		return nil, err
+	}
+	if _, err := expect(itemRightRound, ")"); err != nil {
+		return nil, err
+	}
+	if lon < -180 || lon > 180 || lat < -90 || lat > 90 {
+		return nil, errors.Errorf("Invalid point [%v, %v] in %s", lon, lat, geoDistanceFunc)
+	}
+
+	pt := geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{lon, lat})
+	return &MathTree{
+		Fn: geoDistanceFunc,
+		Child: []*MathTree{
+			{Var: v.Val},
+			{Const: types.Val{Tid: types.GeoID, Value: pt}},
+		},
+	}, nil
+}
+
 // debugString converts mathTree to a string. Good for testing, debugging.
 // nolint: unused
 func (t *MathTree) debugString() string {
@@ -373,6 +462,10 @@ func (t *MathTree) stringHelper(buf *bytes.Buffer) {
 				t.Const.Value.(float64), 'E', -1, 64))
 		case types.IntID:
 			leafStr, err = buf.WriteString(strconv.FormatInt(t.Const.Value.(int64), 10))
+		case types.GeoID:
+			c := t.Const.Value.(*geom.Point).Coords()
+			leafStr, err = buf.WriteString("[" + strconv.FormatFloat(c.X(), 'E', -1, 64) + "," +
+				strconv.FormatFloat(c.Y(), 'E', -1, 64) + "]")
 		}
 		x.Check2(leafStr, err)
 		return
@@ -382,7 +475,7 @@ func (t *MathTree) stringHelper(buf *bytes.Buffer) {
 	switch t.Fn {
 	case "+", "-", "/", "*", "%", "exp", "ln", "cond", "min",
 		"sqrt", "max", "<", ">", "<=", ">=", "==", "!=", "u-",
-		"logbase", "pow":
+		"logbase", "pow", geoDistanceFunc:
 		x.Check2(buf.WriteString(t.Fn))
 	default:
 		x.Fatalf("Unknown operator: %q", t.Fn)
@@ -31,12 +31,13 @@ import (
 )
 
 const (
-	uidFunc   = "uid"
-	valueFunc = "val"
-	typFunc   = "type"
-	lenFunc   = "len"
-	countFunc = "count"
-	uidInFunc = "uid_in"
+	uidFunc       = "uid"
+	valueFunc     = "val"
+	typFunc       = "type"
+	lenFunc       = "len"
+	countFunc     = "count"
+	uidInFunc     = "uid_in"
+	similarToFunc = "similar_to"
 )
 
 var (
@@ -111,6 +112,9 @@ type GroupByAttr struct {
 	Attr  string
 	Alias string
 	Langs []string
+	// Interval is the day, month or year that the DateTime values of the attribute are truncated
+	// to, so that the values of the same interval are in the same group.
+	Interval string
 }
 
 // FacetOrder stores ordering for single facet key.
@@ -1619,7 +1623,7 @@ func validFuncName(name string) bool {
 
 	switch name {
 	case "regexp", "anyofterms", "allofterms", "alloftext", "anyoftext",
-		"has", "uid", "uid_in", "anyof", "allof", "type", "match":
+		"has", "uid", "uid_in", "anyof", "allof", "type", "match", similarToFunc:
 		return true
 	}
 	return false
@@ -1783,6 +1787,10 @@ L:
 				case function.Name == "uid_in":
 					err = parseFuncArgs(it, function)
 
+				case function.Name == similarToFunc:
+					// The vector is collected the same way as geo coordinates.
+					err = parseGeoArgs(it, function)
+
 				default:
 					err = itemInFunc.Errorf("Unexpected character [ while parsing request.")
 				}
@@ -2228,10 +2236,19 @@ loop:
 					return err
 				}
 			}
+			var interval string
+			items, err = it.Peek(1)
+			if err == nil && items[0].Typ == itemLeftRound {
+				it.Next() // consume '('
+				if interval, err = parseGroupbyInterval(it); err != nil {
+					return err
+				}
+			}
 			attrLang := GroupByAttr{
-				Attr:  val,
-				Alias: alias,
-				Langs: langs,
+				Attr:     val,
+				Alias:    alias,
+				Langs:    langs,
+				Interval: interval,
 			}
 			alias = ""
 			gq.GroupbyAttrs = append(gq.GroupbyAttrs, attrLang)
@@ -2249,6 +2266,31 @@ loop:
 	return nil
 }
 
+// parseGroupbyInterval parses the interval argument of an attribute of the groupby directive,
+// e.g. dob(interval: month). The iterator is at the left round bracket.
+func parseGroupbyInterval(it *lex.ItemIterator) (string, error) {
+	var interval string
+	expected := []lex.ItemType{itemName, itemColon, itemName, itemRightRound}
+	for i, typ := range expected {
+		if !it.Next() {
+			return "", it.Errorf("Expected an interval in groupby")
+		}
+		item := it.Item()
+		if item.Typ != typ || (i == 0 && item.Val != "interval") {
+			return "", item.Errorf("Expected interval: day, month or year in groupby, got: %v",
+				item.Val)
+		}
+		if i == 2 {
+			interval = item.Val
+		}
+	}
+	switch interval {
+	case "day", "month", "year":
+		return interval, nil
+	}
+	return "", it.Errorf("Invalid interval %s in groupby, expected day, month or year", interval)
+}
+
 // parseFilter parses the filter directive to produce a QueryFilter / parse tree.
 func parseFilter(it *lex.ItemIterator) (*FilterTree, error) {
 	it.Next()
@@ -3310,7 +3352,8 @@ func isMathBlock(name string) bool {
 }
 
 func isGeoFunc(name string) bool {
-	return name == "near" || name == "contains" || name == "within" || name == "intersects"
+	return name == "near" || name == "contains" || name == "within" || name == "intersects" ||
+		name == "bbox"
 }
 
 func IsInequalityFn(name string) bool {
@@ -509,6 +509,42 @@ func TestParseQueryWithVarValAggNested2(t *testing.T) {
 		res.Query[1].Children[0].Children[4].MathExp.debugString())
 }
 
+func TestParseGeoDistance(t *testing.T) {
+	query := `
+	{
+		me(func: uid(L), orderasc: val(d)) {
+			name
+			val(d)
+		}
+
+		L as var(func: bbox(loc, [-122.5, 37.5, -122, 38])) {
+			l as loc
+			d as math(geo_distance(l, [-122.4194, 37.7749]) / 1000)
+		}
+	}
+`
+	res, err := Parse(Request{Str: query})
+	require.NoError(t, err)
+	require.Equal(t, "bbox", res.Query[1].Func.Name)
+	require.Equal(t, "[-122.5,37.5,-122,38]", res.Query[1].Func.Args[0].Value)
+	require.EqualValues(t, "(/ (geo_distance l [-1.224194E+02,3.77749E+01]) 1000)",
+		res.Query[1].Children[1].MathExp.debugString())
+}
+
+func TestParseGeoDistanceError(t *testing.T) {
+	for _, exp := range []string{
+		"geo_distance(l)",
+		"geo_distance(l, [1])",
+		"geo_distance(l, [1, 2, 3])",
+		"geo_distance(l, [1, a])",
+		"geo_distance(l, [1, 91])",
+	} {
+		query := `{ me(func: uid(1)) { l as loc d as math(` + exp + `) } }`
+		_, err := Parse(Request{Str: query})
+		require.Error(t, err, exp)
+	}
+}
+
 func TestParseQueryWithVarValAggNested4(t *testing.T) {
 	query := `
 	{
@@ -2464,6 +2500,38 @@ func TestParseFilter_Geo1(t *testing.T) {
 	require.Equal(t, false, resp.Query[0].Children[0].Filter.Func.Args[1].IsValueVar)
 }
 
+func TestParseSimilarTo(t *testing.T) {
+	query := `
+	query {
+		me(func: similar_to(embedding, 3, [0.5, -1.25, 2])) {
+			name
+		}
+	}
+`
+	resp, err := Parse(Request{Str: query})
+	require.NoError(t, err)
+	require.Equal(t, "similar_to", resp.Query[0].Func.Name)
+	require.Equal(t, "embedding", resp.Query[0].Func.Attr)
+	require.Equal(t, "3", resp.Query[0].Func.Args[0].Value)
+	require.Equal(t, "[0.5,-1.25,2]", resp.Query[0].Func.Args[1].Value)
+}
+
+func TestParseSimilarToVariable(t *testing.T) {
+	query := `
+	query test($vec: string) {
+		me(func: similar_to(embedding, 3, $vec)) {
+			name
+		}
+	}
+`
+	resp, err := Parse(Request{
+		Str:       query,
+		Variables: map[string]string{"$vec": "[0.5, -1.25, 2]"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "[0.5, -1.25, 2]", resp.Query[0].Func.Args[1].Value)
+}
+
 func TestParseFilter_Geo2(t *testing.T) {
 	query := `
 	query {
@@ -3266,6 +3334,49 @@ func TestParseGroupbyWithAliasForError(t *testing.T) {
 	require.Contains(t, err.Error(), "Can't use keyword first as alias in groupby")
 }
 
+func TestParseGroupbyWithInterval(t *testing.T) {
+	query := `
+	query {
+		me(func: uid(0x1)) {
+			friends @groupby(Year: dob(interval: year), name@en) {
+				count(uid)
+			}
+		}
+	}
+`
+	res, err := Parse(Request{Str: query})
+	require.NoError(t, err)
+	attrs := res.Query[0].Children[0].GroupbyAttrs
+	require.Equal(t, 2, len(attrs))
+	require.Equal(t, GroupByAttr{Attr: "dob", Alias: "Year", Interval: "year"}, attrs[0])
+	require.Equal(t, "", attrs[1].Interval)
+	require.Equal(t, []string{"en"}, attrs[1].Langs)
+
+	query = `
+	query {
+		me(func: uid(0x1)) {
+			friends @groupby(dob(interval: week)) {
+				count(uid)
+			}
+		}
+	}
+`
+	_, err = Parse(Request{Str: query})
+	require.Contains(t, err.Error(), "Invalid interval week in groupby")
+
+	query = `
+	query {
+		me(func: uid(0x1)) {
+			friends @groupby(dob(first: 10)) {
+				count(uid)
+			}
+		}
+	}
+`
+	_, err = Parse(Request{Str: query})
+	require.Contains(t, err.Error(), "Expected interval: day, month or year in groupby")
+}
+
 func TestParseGroupbyError(t *testing.T) {
 	// predicates not allowed inside groupby.
 	query := `
@@ -48,6 +48,7 @@ const (
 	// GraphQL schema for /admin endpoint.
 	graphqlAdminSchema = `
 	scalar DateTime
+	scalar Int64
 
 	"""
 	Data about the GraphQL schema being served by Dgraph.
@@ -80,11 +81,21 @@ const (
 	}
 
 	"""
-	PersistedQuery contains the query and sha256hash of the query.
+	PersistedQuery contains the query and sha256hash of the query. The client, name, version
+	and usage are only set for the trusted documents registered through /admin.
 	"""
 	type PersistedQuery @dgraph(type: "dgraph.graphql.persisted_query") {
 		query: String! @dgraph(pred: "dgraph.graphql.p_query")
 		sha256Hash: String! @id @dgraph(pred: "dgraph.graphql.p_sha256hash")
+		client: String @dgraph(pred: "dgraph.graphql.p_client")
+		name: String @dgraph(pred: "dgraph.graphql.p_name")
+		version: String @dgraph(pred: "dgraph.graphql.p_version")
+
+		"""
+		Number of times the document was executed. The counts of each alpha are added
+		every minute.
+		"""
+		usage: Int64 @dgraph(pred: "dgraph.graphql.p_usage")
 	}
 
 	"""
@@ -211,10 +222,17 @@ const (
 
 	input ExportInput {
 		"""
-		Data format for the export, e.g. "rdf" or "json" (default: "rdf")
+		Data format for the export, e.g. "rdf", "json", "csv" or "parquet" (default: "rdf").
+		The "csv" and "parquet" formats write a file per type, with a column per predicate.
 		"""
 		format: String
 
+		"""
+		Namespace to export (default: 0). Its predicates and types are written without the
+		namespace, so the export can be loaded into any namespace.
+		"""
+		namespace: Int64
+
 		"""
 		Destination for the export: e.g. Minio or S3 bucket or /absolute/path
 		"""
@@ -241,6 +259,60 @@ const (
 		anonymous: Boolean
 	}
 
+	input RunAnalyticsInput {
+		"""
+		Uid predicate whose edges the algorithms run over.
+		"""
+		predicate: String!
+
+		"""
+		Namespace of the predicate, where the results are written too (default: 0).
+		"""
+		namespace: Int64
+
+		"""
+		Algorithms to run (default: pagerank). Each one writes the predicates named after prefix:
+		pagerank writes <prefix>pagerank, the PageRank of the node, a float.
+		wcc writes <prefix>component, the weakly connected component of the node, identified by
+		its smallest uid, an int.
+		lpa writes <prefix>community, the community of the node found by label propagation,
+		identified by the uid it started from, an int.
+		degree writes <prefix>in_degree and <prefix>out_degree, the edges in and out of the node,
+		ints, and <prefix>degree_centrality, a float.
+		"""
+		algorithms: [String!]
+
+		"""
+		Prefix of the predicates the results are written to (default: the predicate followed
+		by a dot, e.g. follows.pagerank).  It can't start with dgraph.
+		"""
+		prefix: String
+
+		"""
+		Maximum number of iterations of PageRank and label propagation (default: 20).
+		"""
+		iterations: Int
+
+		"""
+		Damping factor of PageRank (default: 0.85).
+		"""
+		damping: Float
+
+		"""
+		PageRank stops once the ranks change by less than this in total (default: 1e-6).
+		"""
+		tolerance: Float
+	}
+
+	type RunAnalyticsPayload {
+		response: Response
+
+		"""
+		ID of the analytics task, to query its status with the task query.
+		"""
+		taskId: String
+	}
+
 	type Response {
 		code: String
 		message: String
@@ -248,7 +320,114 @@ const (
 
 	type ExportPayload {
 		response: Response
+
+		"""
+		Always empty, the exported files are in the output of the export task.
+		"""
 		exportedFiles: [String]
+
+		"""
+		ID of the export task, to query its status with the task query.
+		"""
+		taskId: String
+	}
+
+	"""
+	The status of an export, backup, restore or analytics task.
+	"""
+	type TaskPayload {
+		id: String
+
+		"""
+		Kind of the task: export, backup, restore or analytics.
+		"""
+		kind: String
+
+		"""
+		Status of the task: Queued, Running, Success or Failed.
+		"""
+		status: String
+
+		"""
+		Raft ID of the alpha running the task.
+		"""
+		node: Int
+
+		"""
+		Fraction of the task that is done, from 0 to 1.
+		"""
+		progress: Float
+
+		"""
+		Error message if the task failed.
+		"""
+		error: String
+
+		"""
+		Locations written by the task once it succeeded, e.g. the exported files, the
+		destination of the backup or the predicates written by the analytics.
+		"""
+		output: [String]
+
+		createdAt: DateTime
+		updatedAt: DateTime
+	}
+
+	"""
+	A query or a mutation being run by the alpha.
+	"""
+	type RunningQuery {
+		id: String
+
+		"""
+		Text of the query, without its variables.
+		"""
+		query: String
+
+		"""
+		True if the request has mutations.
+		"""
+		mutation: Boolean
+
+		namespace: Int64
+		startedAt: DateTime
+
+		"""
+		Memory taken by the results of the query so far, in bytes.
+		"""
+		memoryBytes: Int64
+	}
+
+	type CancelQueryPayload {
+		response: Response
+	}
+
+	input TrustedDocumentInput {
+
+		"""
+		Client application sending the document.
+		"""
+		client: String!
+
+		"""
+		Name of the document in the client application, e.g. the name of the operation.
+		"""
+		name: String!
+
+		"""
+		Version of the client application, or of the document.
+		"""
+		version: String!
+
+		"""
+		The GraphQL document. Clients can send it, or its sha256 hash as a persisted query.
+		"""
+		query: String!
+	}
+
+	type TrustedDocumentsPayload {
+		response: Response
+		documents: [PersistedQuery]
 	}
 
 	type DrainingPayload {
@@ -291,6 +470,21 @@ const (
 		config: Config
 		getAllowedCORSOrigins: Cors
 		querySchemaHistory(first: Int, offset: Int): [SchemaHistory]
+
+		"""
+		Get the status of an export, backup, restore or analytics task.
+		"""
+		task(id: String!): TaskPayload
+
+		"""
+		Get the trusted documents of a client application, or of all of them.
+		"""
+		queryTrustedDocuments(client: String): [PersistedQuery]
+
+		"""
+		Get the queries and mutations being run by this alpha.
+		"""
+		listRunningQueries: [RunningQuery]
 		` + adminQueries + `
 	}
 
@@ -303,12 +497,22 @@ const (
 		updateGQLSchema(input: UpdateGQLSchemaInput!) : UpdateGQLSchemaPayload
 
 		"""
-		Starts an export of all data in the cluster.  Export format should be 'rdf' (the default
-		if no format is given), or 'json'.
+		Queues an export of all data in the cluster.  Export format should be 'rdf' (the default
+		if no format is given), 'json', 'csv' or 'parquet'.  The export runs in the background,
+		use the task query to follow it.
 		See : https://dgraph.io/docs/deploy/#export-database
 		"""
 		export(input: ExportInput!): ExportPayload
 
+		"""
+		Queues a task running graph algorithms over the edges of a uid predicate.  Their results
+		are written as new predicates of the nodes, which can be queried and indexed like any
+		other predicate.  The task is run by the group serving the predicate, which serves the
+		new predicates too.  Their indexes must be dropped before running it again, and added
+		back once it's done.  Use the task query to follow it.
+		"""
+		runAnalytics(input: RunAnalyticsInput!): RunAnalyticsPayload
+
 		"""
 		Set (or unset) the cluster draining mode.  In draining mode no further requests are served.
 		"""
@@ -326,6 +530,25 @@ const (
 
 		replaceAllowedCORSOrigins(origins: [String]): Cors
 
+		"""
+		Register the documents that clients can execute on /graphql when Dgraph runs with
+		--graphql_trusted_documents.  A query can only be registered once, and the query of
+		a name and a version can't change.
+		"""
+		addTrustedDocuments(input: [TrustedDocumentInput!]!): TrustedDocumentsPayload
+
+		"""
+		Delete the trusted documents of a client application. All the names, or all the
+		versions, are deleted if no name, or no version, is given.
+		"""
+		deleteTrustedDocuments(client: String!, name: String, version: String): TrustedDocumentsPayload
+
+		"""
+		Cancel a query or a mutation being run by this alpha, given by its ID in
+		listRunningQueries.  The query is cancelled in all the groups it reached.
+		"""
+		cancelQuery(id: String!): CancelQueryPayload
+
 		` + adminMutations + `
 	}
  `
@@ -347,11 +570,14 @@ var (
 		resolve.LoggingMWMutation,
 	}
 	adminQueryMWConfig = map[string]resolve.QueryMiddlewares{
-		"health":        {resolve.IpWhitelistingMW4Query, resolve.LoggingMWQuery}, // dgraph checks Guardian auth for health
-		"state":         {resolve.IpWhitelistingMW4Query, resolve.LoggingMWQuery}, // dgraph checks Guardian auth for state
-		"config":        commonAdminQueryMWs,
-		"listBackups":   commonAdminQueryMWs,
-		"getGQLSchema":  commonAdminQueryMWs,
+		"health":                {resolve.IpWhitelistingMW4Query, resolve.LoggingMWQuery}, // dgraph checks Guardian auth for health
+		"state":                 {resolve.IpWhitelistingMW4Query, resolve.LoggingMWQuery}, // dgraph checks Guardian auth for state
+		"config":                commonAdminQueryMWs,
+		"listBackups":           commonAdminQueryMWs,
+		"getGQLSchema":          commonAdminQueryMWs,
+		"task":                  commonAdminQueryMWs,
+		"queryTrustedDocuments": commonAdminQueryMWs,
+		"listRunningQueries":    commonAdminQueryMWs,
 		// for queries and mutations related to User/Group, dgraph handles Guardian auth,
 		// so no need to apply GuardianAuth Middleware
 		"queryGroup":            {resolve.IpWhitelistingMW4Query, resolve.LoggingMWQuery},
@@ -363,14 +589,20 @@ var (
 		"getAllowedCORSOrigins": {resolve.IpWhitelistingMW4Query, resolve.LoggingMWQuery},
 	}
 	adminMutationMWConfig = map[string]resolve.MutationMiddlewares{
-		"backup":          commonAdminMutationMWs,
-		"config":          commonAdminMutationMWs,
-		"draining":        commonAdminMutationMWs,
-		"export":          commonAdminMutationMWs,
-		"login":           {resolve.IpWhitelistingMW4Mutation, resolve.LoggingMWMutation},
-		"restore":         commonAdminMutationMWs,
-		"shutdown":        commonAdminMutationMWs,
-		"updateGQLSchema": commonAdminMutationMWs,
+		"addNamespace":           commonAdminMutationMWs,
+		"addTrustedDocuments":    commonAdminMutationMWs,
+		"deleteTrustedDocuments": commonAdminMutationMWs,
+		"backup":                 commonAdminMutationMWs,
+		"cancelQuery":            commonAdminMutationMWs,
+		"config":                 commonAdminMutationMWs,
+		"deleteNamespace":        commonAdminMutationMWs,
+		"draining":               commonAdminMutationMWs,
+		"export":                 commonAdminMutationMWs,
+		"login":                  {resolve.IpWhitelistingMW4Mutation, resolve.LoggingMWMutation},
+		"restore":                commonAdminMutationMWs,
+		"runAnalytics":           commonAdminMutationMWs,
+		"shutdown":               commonAdminMutationMWs,
+		"updateGQLSchema":        commonAdminMutationMWs,
 		// for queries and mutations related to User/Group, dgraph handles Guardian auth,
 		// so no need to apply GuardianAuth Middleware
 		"addUser":                   {resolve.IpWhitelistingMW4Mutation, resolve.LoggingMWMutation},
@@ -446,6 +678,8 @@ type adminServer struct {
 	fns               *resolve.ResolverFns
 	withIntrospection bool
 	globalEpoch       *uint64
+	// cache is the response cache of the main graphql endpoint, if it's enabled.
+	cache *resolve.ResponseCache
 }
 
 // NewServers initializes the GraphQL servers.  It sets up an empty server for the
@@ -496,6 +730,12 @@ func newAdminResolver(
 		withIntrospection: withIntrospection,
 		globalEpoch:       epoch,
 	}
+	if x.Config.GraphqlResponseCacheMb > 0 {
+		server.cache, err = resolve.NewResponseCache(x.Config.GraphqlResponseCacheMb << 20)
+		if err != nil {
+			x.Panic(err)
+		}
+	}
 
 	prefix := x.DataKey(worker.GqlSchemaPred, 0)
 	// Remove uid from the key, to get the correct prefix
@@ -567,13 +807,19 @@ func newAdminResolver(
 func newAdminResolverFactory() resolve.ResolverFactory {
 
 	adminMutationResolvers := map[string]resolve.MutationResolverFunc{
-		"backup":   resolveBackup,
-		"config":   resolveUpdateConfig,
-		"draining": resolveDraining,
-		"export":   resolveExport,
-		"login":    resolveLogin,
-		"restore":  resolveRestore,
-		"shutdown": resolveShutdown,
+		"addNamespace":           resolveAddNamespace,
+		"addTrustedDocuments":    resolveAddTrustedDocuments,
+		"deleteTrustedDocuments": resolveDeleteTrustedDocuments,
+		"backup":                 resolveBackup,
+		"cancelQuery":            resolveCancelQuery,
+		"config":                 resolveUpdateConfig,
+		"deleteNamespace":        resolveDeleteNamespace,
+		"draining":               resolveDraining,
+		"export":                 resolveExport,
+		"login":                  resolveLogin,
+		"restore":                resolveRestore,
+		"runAnalytics":           resolveRunAnalytics,
+		"shutdown":               resolveShutdown,
 	}
 
 	rf := resolverFactoryWithErrorMsg(errResolverNotFound).
@@ -591,6 +837,15 @@ func newAdminResolverFactory() resolve.ResolverFactory {
 		WithQueryResolver("listBackups", func(q schema.Query) resolve.QueryResolver {
 			return resolve.QueryResolverFunc(resolveListBackups)
 		}).
+		WithQueryResolver("task", func(q schema.Query) resolve.QueryResolver {
+			return resolve.QueryResolverFunc(resolveTask)
+		}).
+		WithQueryResolver("queryTrustedDocuments", func(q schema.Query) resolve.QueryResolver {
+			return resolve.QueryResolverFunc(resolveQueryTrustedDocuments)
+		}).
+		WithQueryResolver("listRunningQueries", func(q schema.Query) resolve.QueryResolver {
+			return resolve.QueryResolverFunc(resolveListRunningQueries)
+		}).
 		WithMutationResolver("updateGQLSchema", func(m schema.Mutation) resolve.MutationResolver {
 			return resolve.MutationResolverFunc(
 				func(ctx context.Context, m schema.Mutation) (*resolve.Resolved, bool) {
@@ -865,7 +1120,11 @@ func (as *adminServer) resetSchema(gqlSchema schema.Schema) {
 	// Increment the Epoch when you get a new schema. So, that subscription's local epoch
 	// will match against global epoch to terminate the current subscriptions.
 	atomic.AddUint64(as.globalEpoch, 1)
-	as.gqlServer.ServeGQL(resolve.New(gqlSchema, resolverFactory))
+	// The cached responses were computed with the old schema.
+	if as.cache != nil {
+		as.cache.Reset()
+	}
+	as.gqlServer.ServeGQL(resolve.New(gqlSchema, resolverFactory).WithResponseCache(as.cache))
 
 	// reset status to up, as now we are serving the new schema
 	mainHealthStore.up()
@@ -0,0 +1,72 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/dgraph-io/dgraph/edgraph"
+	"github.com/dgraph-io/dgraph/graphql/resolve"
+	"github.com/dgraph-io/dgraph/graphql/schema"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+)
+
+func resolveRunAnalytics(ctx context.Context, m schema.Mutation) (*resolve.Resolved, bool) {
+	glog.Info("Got runAnalytics request through GraphQL admin API")
+
+	req, err := getAnalyticsInput(m)
+	if err != nil {
+		return resolve.EmptyResult(m, err), false
+	}
+	t, err := edgraph.SubmitAnalytics(ctx, req)
+	if err != nil {
+		return resolve.EmptyResult(m, err), false
+	}
+
+	return &resolve.Resolved{
+		Data:  map[string]interface{}{m.Name(): taskResponse(t)},
+		Field: m,
+	}, true
+}
+
+func getAnalyticsInput(m schema.Mutation) (*edgraph.AnalyticsRequest, error) {
+	input, _ := m.ArgValue(schema.InputArgName).(map[string]interface{})
+	var ns uint64
+	if v, ok := input["namespace"]; ok && v != nil {
+		// Int64 values may reach here as a string or a number, depending on how they were sent.
+		var err error
+		if ns, err = strconv.ParseUint(fmt.Sprint(v), 10, 64); err != nil {
+			return nil, errors.Errorf("invalid namespace: %v", v)
+		}
+		delete(input, "namespace")
+	}
+
+	inputByts, err := json.Marshal(input)
+	if err != nil {
+		return nil, schema.GQLWrapf(err, "couldn't get input argument")
+	}
+	var req edgraph.AnalyticsRequest
+	if err := json.Unmarshal(inputByts, &req); err != nil {
+		return nil, schema.GQLWrapf(err, "couldn't get input argument")
+	}
+	req.Namespace = ns
+	return &req, nil
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/json"
 
+	"github.com/dgraph-io/dgraph/edgraph"
 	"github.com/dgraph-io/dgraph/graphql/resolve"
 	"github.com/dgraph-io/dgraph/graphql/schema"
 	"github.com/dgraph-io/dgraph/protos/pb"
@@ -32,6 +33,30 @@ type backupInput struct {
 	ForceFull bool
 }
 
+// backupTask holds the parameters of a backup task.
+type backupTask struct {
+	Req       *pb.BackupRequest
+	ForceFull bool
+}
+
+func init() {
+	edgraph.RegisterTask("backup", func(ctx context.Context, b json.RawMessage,
+		creds *worker.Credentials) ([]string, error) {
+		var bt backupTask
+		if err := json.Unmarshal(b, &bt); err != nil {
+			return nil, err
+		}
+		if creds != nil {
+			bt.Req.AccessKey, bt.Req.SecretKey, bt.Req.SessionToken =
+				creds.AccessKey, creds.SecretKey, creds.SessionToken
+		}
+		if err := worker.ProcessBackupRequest(ctx, bt.Req, bt.ForceFull); err != nil {
+			return nil, err
+		}
+		return []string{bt.Req.Destination}, nil
+	})
+}
+
 func resolveBackup(ctx context.Context, m schema.Mutation) (*resolve.Resolved, bool) {
 	glog.Info("Got backup request")
 
@@ -40,20 +65,31 @@ func resolveBackup(ctx context.Context, m schema.Mutation) (*resolve.Resolved, b
 		return resolve.EmptyResult(m, err), false
 	}
 
-	err = worker.ProcessBackupRequest(context.Background(), &pb.BackupRequest{
+	req := &pb.BackupRequest{
 		Destination:  input.Destination,
 		AccessKey:    input.AccessKey,
 		SecretKey:    input.SecretKey,
 		SessionToken: input.SessionToken,
 		Anonymous:    input.Anonymous,
-	}, input.ForceFull)
-
+	}
+	if err := worker.VerifyBackupRequest(req); err != nil {
+		return resolve.EmptyResult(m, err), false
+	}
+	// The credentials aren't stored in the record of the task.
+	creds := &worker.Credentials{
+		AccessKey:    req.AccessKey,
+		SecretKey:    req.SecretKey,
+		SessionToken: req.SessionToken,
+	}
+	req.AccessKey, req.SecretKey, req.SessionToken = "", "", ""
+	t, err := edgraph.SubmitTask(ctx, "backup", &backupTask{Req: req, ForceFull: input.ForceFull},
+		creds)
 	if err != nil {
 		return resolve.EmptyResult(m, err), false
 	}
 
 	return &resolve.Resolved{
-		Data:  map[string]interface{}{m.Name(): response("Success", "Backup completed.")},
+		Data:  map[string]interface{}{m.Name(): taskResponse(t)},
 		Field: m,
 	}, true
 }
@@ -54,6 +54,11 @@ const adminTypes = `
 
 	type BackupPayload {
 		response: Response
+
+		"""
+		ID of the backup task, to query its status with the task query.
+		"""
+		taskId: String
 	}
 
 	input RestoreInput {
@@ -144,6 +149,11 @@ const adminTypes = `
 		Includes the error message if the operation failed.
 		"""
 		message: String
+
+		"""
+		ID of the restore task, to query its status with the task query.
+		"""
+		taskId: String
 	}
 
 	input ListBackupsInput {
@@ -412,18 +422,40 @@ const adminTypes = `
 	type DeleteGroupPayload {
 		msg: String
 		numUids: Int
+	}
+
+	input AddNamespaceInput {
+
+		"""
+		Password of the groot user of the new namespace.
+		"""
+		password: String!
+	}
+
+	input DeleteNamespaceInput {
+
+		"""
+		Id of the namespace to delete.
+		"""
+		namespaceId: Int64!
+	}
+
+	type NamespacePayload {
+		namespaceId: Int64
+		message: String
 	}`
 
 const adminMutations = `
 
 	"""
-	Start a binary backup.  See : https://dgraph.io/docs/enterprise-features/#binary-backups
+	Queue a binary backup.  The backup runs in the background, use the task query to follow it.
+	See : https://dgraph.io/docs/enterprise-features/#binary-backups
 	"""
 	backup(input: BackupInput!) : BackupPayload
 
 	"""
-	Start restoring a binary backup.  See :
-		https://dgraph.io/docs/enterprise-features/#binary-backups
+	Queue the restore of a binary backup.  The restore runs in the background, use the task
+	query to follow it.  See : https://dgraph.io/docs/enterprise-features/#binary-backups
 	"""
 	restore(input: RestoreInput!) : RestorePayload
 
@@ -462,7 +494,18 @@ const adminMutations = `
 	updateGroup(input: UpdateGroupInput!): AddGroupPayload
 
 	deleteGroup(filter: GroupFilter!): DeleteGroupPayload
-	deleteUser(filter: UserFilter!): DeleteUserPayload`
+	deleteUser(filter: UserFilter!): DeleteUserPayload
+
+	"""
+	Add a namespace. The namespace gets its own schema, data and ACL groups, and a groot
+	user with the given password to log into it.
+	"""
+	addNamespace(input: AddNamespaceInput!): NamespacePayload
+
+	"""
+	Delete a namespace along with all its data, schema and ACL groups.
+	"""
+	deleteNamespace(input: DeleteNamespaceInput!): NamespacePayload`
 
 const adminQueries = `
 	getUser(name: String!): User
@@ -19,7 +19,10 @@ package admin
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"strconv"
 
+	"github.com/dgraph-io/dgraph/edgraph"
 	"github.com/dgraph-io/dgraph/graphql/resolve"
 	"github.com/dgraph-io/dgraph/graphql/schema"
 	"github.com/dgraph-io/dgraph/protos/pb"
@@ -29,10 +32,26 @@ import (
 )
 
 type exportInput struct {
-	Format string
+	Format    string
+	Namespace uint64
 	DestinationFields
 }
 
+func init() {
+	edgraph.RegisterTask("export", func(ctx context.Context, b json.RawMessage,
+		creds *worker.Credentials) ([]string, error) {
+		var req pb.ExportRequest
+		if err := json.Unmarshal(b, &req); err != nil {
+			return nil, err
+		}
+		if creds != nil {
+			req.AccessKey, req.SecretKey, req.SessionToken =
+				creds.AccessKey, creds.SecretKey, creds.SessionToken
+		}
+		return worker.ExportOverNetwork(ctx, &req)
+	})
+}
+
 func resolveExport(ctx context.Context, m schema.Mutation) (*resolve.Resolved, bool) {
 
 	glog.Info("Got export request through GraphQL admin API")
@@ -50,37 +69,41 @@ func resolveExport(ctx context.Context, m schema.Mutation) (*resolve.Resolved, b
 		}
 	}
 
-	files, err := worker.ExportOverNetwork(context.Background(), &pb.ExportRequest{
-		Format:       format,
-		Destination:  input.Destination,
+	// The credentials aren't stored in the record of the task.
+	req := &pb.ExportRequest{
+		Format:      format,
+		Namespace:   input.Namespace,
+		Destination: input.Destination,
+		Anonymous:   input.Anonymous,
+	}
+	creds := &worker.Credentials{
 		AccessKey:    input.AccessKey,
 		SecretKey:    input.SecretKey,
 		SessionToken: input.SessionToken,
-		Anonymous:    input.Anonymous,
-	})
+	}
+	t, err := edgraph.SubmitTask(ctx, "export", req, creds)
 	if err != nil {
 		return resolve.EmptyResult(m, err), false
 	}
 
-	responseData := response("Success", "Export completed.")
-	responseData["exportedFiles"] = toGraphQLArray(files)
-
 	return &resolve.Resolved{
-		Data:  map[string]interface{}{m.Name(): responseData},
+		Data:  map[string]interface{}{m.Name(): taskResponse(t)},
 		Field: m,
 	}, true
 }
 
-func toGraphQLArray(s []string) []interface{} {
-	outputFiles := make([]interface{}, 0, len(s))
-	for _, f := range s {
-		outputFiles = append(outputFiles, f)
+func getExportInput(m schema.Mutation) (*exportInput, error) {
+	inputArg, _ := m.ArgValue(schema.InputArgName).(map[string]interface{})
+	var ns uint64
+	if v, ok := inputArg["namespace"]; ok && v != nil {
+		// Int64 values may reach here as a string or a number, depending on how they were sent.
+		var err error
+		if ns, err = strconv.ParseUint(fmt.Sprint(v), 10, 64); err != nil {
+			return nil, errors.Errorf("invalid namespace: %v", v)
+		}
+		delete(inputArg, "namespace")
 	}
-	return outputFiles
-}
 
-func getExportInput(m schema.Mutation) (*exportInput, error) {
-	inputArg := m.ArgValue(schema.InputArgName)
 	inputByts, err := json.Marshal(inputArg)
 	if err != nil {
 		return nil, schema.GQLWrapf(err, "couldn't get input argument")
@@ -88,5 +111,6 @@ func getExportInput(m schema.Mutation) (*exportInput, error) {
 
 	var input exportInput
 	err = json.Unmarshal(inputByts, &input)
+	input.Namespace = ns
 	return &input, schema.GQLWrapf(err, "couldn't get input argument")
 }
@@ -0,0 +1,67 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package admin
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/dgraph-io/dgraph/edgraph"
+	"github.com/dgraph-io/dgraph/graphql/resolve"
+	"github.com/dgraph-io/dgraph/graphql/schema"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+)
+
+func resolveAddNamespace(ctx context.Context, m schema.Mutation) (*resolve.Resolved, bool) {
+	glog.Info("Got addNamespace request through GraphQL admin API")
+
+	input, _ := m.ArgValue(schema.InputArgName).(map[string]interface{})
+	password, _ := input["password"].(string)
+	ns, err := edgraph.CreateNamespace(ctx, password)
+	if err != nil {
+		return resolve.EmptyResult(m, err), false
+	}
+	return namespaceResult(m, ns, "Created namespace successfully"), true
+}
+
+func resolveDeleteNamespace(ctx context.Context, m schema.Mutation) (*resolve.Resolved, bool) {
+	glog.Info("Got deleteNamespace request through GraphQL admin API")
+
+	input, _ := m.ArgValue(schema.InputArgName).(map[string]interface{})
+	// Int64 values may reach here as a string or a number, depending on how they were sent.
+	ns, err := strconv.ParseUint(fmt.Sprint(input["namespaceId"]), 10, 64)
+	if err != nil {
+		return resolve.EmptyResult(m, errors.Errorf("invalid namespace id: %v",
+			input["namespaceId"])), false
+	}
+	if err := edgraph.DeleteNamespace(ctx, ns); err != nil {
+		return resolve.EmptyResult(m, err), false
+	}
+	return namespaceResult(m, ns, "Deleted namespace successfully"), true
+}
+
+func namespaceResult(m schema.Mutation, ns uint64, msg string) *resolve.Resolved {
+	return &resolve.Resolved{
+		Data: map[string]interface{}{m.Name(): map[string]interface{}{
+			"namespaceId": strconv.FormatUint(ns, 10),
+			"message":     msg,
+		}},
+		Field: m,
+	}
+}
@@ -19,7 +19,9 @@ package admin
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 
+	"github.com/dgraph-io/dgraph/edgraph"
 	"github.com/dgraph-io/dgraph/graphql/resolve"
 	"github.com/dgraph-io/dgraph/graphql/schema"
 	"github.com/dgraph-io/dgraph/protos/pb"
@@ -44,6 +46,24 @@ type restoreInput struct {
 	VaultFormat       string
 }
 
+func init() {
+	edgraph.RegisterTask("restore", func(ctx context.Context, b json.RawMessage,
+		creds *worker.Credentials) ([]string, error) {
+		var req pb.RestoreRequest
+		if err := json.Unmarshal(b, &req); err != nil {
+			return nil, err
+		}
+		if creds != nil {
+			req.AccessKey, req.SecretKey, req.SessionToken =
+				creds.AccessKey, creds.SecretKey, creds.SessionToken
+		}
+		if err := worker.ProcessRestoreRequest(ctx, &req); err != nil {
+			return nil, err
+		}
+		return []string{req.Location}, nil
+	})
+}
+
 func resolveRestore(ctx context.Context, m schema.Mutation) (*resolve.Resolved, bool) {
 	input, err := getRestoreInput(m)
 	if err != nil {
@@ -66,7 +86,18 @@ func resolveRestore(ctx context.Context, m schema.Mutation) (*resolve.Resolved,
 		VaultField:        input.VaultField,
 		VaultFormat:       input.VaultFormat,
 	}
-	err = worker.ProcessRestoreRequest(context.Background(), &req)
+	var t *edgraph.Task
+	err = worker.VerifyRestoreRequest(ctx, &req)
+	if err == nil {
+		// The credentials aren't stored in the record of the task.
+		creds := &worker.Credentials{
+			AccessKey:    req.AccessKey,
+			SecretKey:    req.SecretKey,
+			SessionToken: req.SessionToken,
+		}
+		req.AccessKey, req.SecretKey, req.SessionToken = "", "", ""
+		t, err = edgraph.SubmitTask(ctx, "restore", &req, creds)
+	}
 	if err != nil {
 		return &resolve.Resolved{
 			Data: map[string]interface{}{m.Name(): map[string]interface{}{
@@ -80,7 +111,8 @@ func resolveRestore(ctx context.Context, m schema.Mutation) (*resolve.Resolved,
 	return &resolve.Resolved{
 		Data: map[string]interface{}{m.Name(): map[string]interface{}{
 			"code":    "Success",
-			"message": "Restore operation started.",
+			"message": fmt.Sprintf("Queued restore task %s.", t.Id),
+			"taskId":  t.Id,
 		}},
 		Field: m,
 	}, true
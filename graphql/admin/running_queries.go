@@ -0,0 +1,60 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/dgraph-io/dgraph/edgraph"
+	"github.com/dgraph-io/dgraph/graphql/resolve"
+	"github.com/dgraph-io/dgraph/graphql/schema"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+)
+
+func resolveListRunningQueries(ctx context.Context, q schema.Query) *resolve.Resolved {
+	b, err := json.Marshal(edgraph.ListRunningQueries())
+	if err != nil {
+		return resolve.EmptyResult(q, err)
+	}
+	var result []interface{}
+	if err := json.Unmarshal(b, &result); err != nil {
+		return resolve.EmptyResult(q, err)
+	}
+	return &resolve.Resolved{
+		Data:  map[string]interface{}{q.Name(): result},
+		Field: q,
+	}
+}
+
+func resolveCancelQuery(ctx context.Context, m schema.Mutation) (*resolve.Resolved, bool) {
+	id, ok := m.ArgValue("id").(string)
+	if !ok {
+		return resolve.EmptyResult(m, errors.Errorf("query ID must be a string")), false
+	}
+	glog.Infof("Got a request to cancel query %s through GraphQL admin API", id)
+	if err := edgraph.CancelQuery(id); err != nil {
+		return resolve.EmptyResult(m, err), false
+	}
+	return &resolve.Resolved{
+		Data: map[string]interface{}{
+			m.Name(): response("Success", fmt.Sprintf("Cancelled query %s.", id))},
+		Field: m,
+	}, true
+}
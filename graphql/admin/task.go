@@ -0,0 +1,63 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/dgraph-io/dgraph/edgraph"
+	"github.com/dgraph-io/dgraph/graphql/resolve"
+	"github.com/dgraph-io/dgraph/graphql/schema"
+	"github.com/pkg/errors"
+)
+
+// taskResponse returns the payload of a mutation that queued a task.
+func taskResponse(t *edgraph.Task) map[string]interface{} {
+	data := response("Success", fmt.Sprintf("Queued %s task %s.", t.Kind, t.Id))
+	data["taskId"] = t.Id
+	return data
+}
+
+func resolveTask(ctx context.Context, q schema.Query) *resolve.Resolved {
+	id, ok := q.ArgValue("id").(string)
+	if !ok {
+		return resolve.EmptyResult(q, errors.Errorf("task ID must be a string"))
+	}
+	t, err := edgraph.GetTask(ctx, id)
+	if err != nil {
+		return resolve.EmptyResult(q, err)
+	}
+	if t == nil {
+		return resolve.EmptyResult(q, errors.Errorf("task %s not found", id))
+	}
+
+	b, err := json.Marshal(t)
+	if err != nil {
+		return resolve.EmptyResult(q, err)
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal(b, &result); err != nil {
+		return resolve.EmptyResult(q, err)
+	}
+	result["id"] = t.Id
+	return &resolve.Resolved{
+		Data:  map[string]interface{}{q.Name(): result},
+		Field: q,
+	}
+}
@@ -0,0 +1,113 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/dgraph-io/dgraph/edgraph"
+	"github.com/dgraph-io/dgraph/graphql/resolve"
+	"github.com/dgraph-io/dgraph/graphql/schema"
+	"github.com/golang/glog"
+)
+
+// trustedDocuments returns the documents as the PersistedQuery type of the admin schema.
+func trustedDocuments(docs []*edgraph.TrustedDocument) []interface{} {
+	out := make([]interface{}, 0, len(docs))
+	for _, doc := range docs {
+		out = append(out, map[string]interface{}{
+			"query":      doc.Query,
+			"sha256Hash": doc.Sha256Hash,
+			"client":     doc.Client,
+			"name":       doc.Name,
+			"version":    doc.Version,
+			"usage":      doc.Usage,
+		})
+	}
+	return out
+}
+
+func resolveAddTrustedDocuments(ctx context.Context, m schema.Mutation) (*resolve.Resolved, bool) {
+	glog.Info("Got addTrustedDocuments request through GraphQL admin API")
+
+	inputByts, err := json.Marshal(m.ArgValue(schema.InputArgName))
+	if err != nil {
+		return resolve.EmptyResult(m, schema.GQLWrapf(err, "couldn't get input argument")), false
+	}
+	var input []struct {
+		Client  string
+		Name    string
+		Version string
+		Query   string
+	}
+	if err := json.Unmarshal(inputByts, &input); err != nil {
+		return resolve.EmptyResult(m, schema.GQLWrapf(err, "couldn't get input argument")), false
+	}
+	var docs []*edgraph.TrustedDocument
+	for _, in := range input {
+		docs = append(docs, &edgraph.TrustedDocument{
+			Client:  in.Client,
+			Name:    in.Name,
+			Version: in.Version,
+			Query:   in.Query,
+		})
+	}
+
+	if err := edgraph.AddTrustedDocuments(ctx, docs); err != nil {
+		return resolve.EmptyResult(m, err), false
+	}
+	data := response("Success", fmt.Sprintf("Added %d trusted documents.", len(docs)))
+	data["documents"] = trustedDocuments(docs)
+	return &resolve.Resolved{
+		Data:  map[string]interface{}{m.Name(): data},
+		Field: m,
+	}, true
+}
+
+func resolveDeleteTrustedDocuments(ctx context.Context,
+	m schema.Mutation) (*resolve.Resolved, bool) {
+
+	glog.Info("Got deleteTrustedDocuments request through GraphQL admin API")
+
+	client, _ := m.ArgValue("client").(string)
+	name, _ := m.ArgValue("name").(string)
+	version, _ := m.ArgValue("version").(string)
+	docs, err := edgraph.DeleteTrustedDocuments(ctx, client, name, version)
+	if err != nil {
+		return resolve.EmptyResult(m, err), false
+	}
+	data := response("Success", fmt.Sprintf("Deleted %d trusted documents.", len(docs)))
+	data["documents"] = trustedDocuments(docs)
+	return &resolve.Resolved{
+		Data:  map[string]interface{}{m.Name(): data},
+		Field: m,
+	}, true
+}
+
+func resolveQueryTrustedDocuments(ctx context.Context, q schema.Query) *resolve.Resolved {
+	client, _ := q.ArgValue("client").(string)
+	docs, err := edgraph.GetTrustedDocuments(ctx, client)
+	if err != nil {
+		return resolve.EmptyResult(q, err)
+	}
+	return &resolve.Resolved{
+		Data:  map[string]interface{}{q.Name(): trustedDocuments(docs)},
+		Field: q,
+	}
+}
@@ -55,20 +55,43 @@ var (
 		jwt.SigningMethodHS256.Name: jwt.SigningMethodHS256,
 		jwt.SigningMethodHS384.Name: jwt.SigningMethodHS384,
 		jwt.SigningMethodHS512.Name: jwt.SigningMethodHS512,
+		jwt.SigningMethodES256.Name: jwt.SigningMethodES256,
+		jwt.SigningMethodES384.Name: jwt.SigningMethodES384,
+		jwt.SigningMethodES512.Name: jwt.SigningMethodES512,
+		SigningMethodEd25519.Alg():  SigningMethodEd25519,
 	}
 )
 
+// jwkRefetchInterval is the minimum time between two fetches of the JWKs caused by JWTs signed
+// with unknown keys, so that such JWTs can't be used to flood the JWKUrl with requests.
+const jwkRefetchInterval = time.Minute
+
 type AuthMeta struct {
 	VerificationKey string
 	JWKUrl          string
 	jwkSet          *jose.JSONWebKeySet
 	expiryTime      time.Time
-	RSAPublicKey    *rsa.PublicKey `json:"-"` // Ignoring this field
-	Header          string
-	Namespace       string
-	Algo            string
-	SigningMethod   jwt.SigningMethod `json:"-"` // Ignoring this field
-	Audience        []string
+	// fetchTime is when the JWKs were last fetched.
+	fetchTime    time.Time
+	RSAPublicKey *rsa.PublicKey `json:"-"` // Ignoring this field
+	// publicKey is the verification key of the ECDSA and EdDSA algorithms.
+	publicKey     interface{}
+	Header        string
+	Namespace     string
+	Algo          string
+	SigningMethod jwt.SigningMethod `json:"-"` // Ignoring this field
+	Audience      []string
+	// Issuer is the `iss` claim of the JWTs verified with this configuration. If it's empty, the
+	// JWTs of any issuer not listed in Issuers are verified with it.
+	Issuer string
+	// Issuers lists the configurations of other identity providers, each with its own keys,
+	// namespace and audience. Only their Issuer, VerificationKey, JWKUrl, Algo, Namespace and
+	// Audience are used, the Namespace defaults to the one of the main configuration.
+	Issuers []*AuthMeta
+	// ClockSkew is the leeway given to the clocks of the identity providers when checking the
+	// `exp` and `nbf` claims, e.g. "30s".
+	ClockSkew       string
+	clockSkew       time.Duration
 	httpClient      *http.Client
 	ClosedByDefault bool
 	sync.RWMutex
@@ -78,11 +101,75 @@ type AuthMeta struct {
 func (a *AuthMeta) validate() error {
 	var fields string
 
+	// The main configuration needs no key if the JWTs are verified by the issuers.
+	if len(a.Issuers) == 0 || a.JWKUrl != "" || a.VerificationKey != "" || a.Algo != "" {
+		keyFields, err := a.validateKey()
+		if err != nil {
+			return err
+		}
+		fields += keyFields
+	}
+
+	if a.Header == "" {
+		fields += " `Header`"
+	}
+
+	if a.Namespace == "" {
+		fields += " `Namespace`"
+	}
+
+	if len(fields) > 0 {
+		return fmt.Errorf("required field missing in Dgraph.Authorization:%s", fields)
+	}
+
+	issuers := make(map[string]bool)
+	if a.Issuer != "" {
+		issuers[a.Issuer] = true
+	}
+	for _, issuer := range a.Issuers {
+		if issuer.Issuer == "" {
+			return fmt.Errorf("required field missing in Dgraph.Authorization Issuers: `Issuer`")
+		}
+		if issuers[issuer.Issuer] {
+			return fmt.Errorf("issuer %s is given more than once in Dgraph.Authorization",
+				issuer.Issuer)
+		}
+		issuers[issuer.Issuer] = true
+
+		if issuer.Namespace == "" {
+			issuer.Namespace = a.Namespace
+		}
+		keyFields, err := issuer.validateKey()
+		if err != nil {
+			return errors.Wrapf(err, "issuer %s", issuer.Issuer)
+		}
+		if len(keyFields) > 0 {
+			return fmt.Errorf("required field missing in Dgraph.Authorization for issuer %s:%s",
+				issuer.Issuer, keyFields)
+		}
+	}
+
+	if a.ClockSkew != "" {
+		clockSkew, err := time.ParseDuration(a.ClockSkew)
+		if err != nil || clockSkew < 0 {
+			return fmt.Errorf("invalid ClockSkew %q in Dgraph.Authorization, it must be a"+
+				" positive duration like 30s", a.ClockSkew)
+		}
+		a.clockSkew = clockSkew
+	}
+	return nil
+}
+
+// validateKey returns the missing fields needed to verify the JWTs.
+func (a *AuthMeta) validateKey() (string, error) {
+	var fields string
+
 	// If JWKUrl is provided, we don't expect (VerificationKey, Algo),
 	// they are needed only if JWKUrl is not present there.
 	if a.JWKUrl != "" {
 		if a.VerificationKey != "" || a.Algo != "" {
-			return fmt.Errorf("expecting either JWKUrl or (VerificationKey, Algo), both were given")
+			return "", fmt.Errorf(
+				"expecting either JWKUrl or (VerificationKey, Algo), both were given")
 		}
 
 		// Audience should be a required field if JWKUrl is provided.
@@ -98,19 +185,7 @@ func (a *AuthMeta) validate() error {
 			fields += " `Algo`"
 		}
 	}
-
-	if a.Header == "" {
-		fields += " `Header`"
-	}
-
-	if a.Namespace == "" {
-		fields += " `Namespace`"
-	}
-
-	if len(fields) > 0 {
-		return fmt.Errorf("required field missing in Dgraph.Authorization:%s", fields)
-	}
-	return nil
+	return fields, nil
 }
 
 func Parse(schema string) (*AuthMeta, error) {
@@ -129,6 +204,11 @@ func Parse(schema string) (*AuthMeta, error) {
 		if algoErr := meta.initSigningMethod(); algoErr != nil {
 			return nil, algoErr
 		}
+		for _, issuer := range meta.Issuers {
+			if algoErr := issuer.initSigningMethod(); algoErr != nil {
+				return nil, errors.Wrapf(algoErr, "issuer %s", issuer.Issuer)
+			}
+		}
 
 		return &meta, nil
 	}
@@ -176,20 +256,40 @@ func ParseAuthMeta(schema string) (*AuthMeta, error) {
 		return nil, err
 	}
 
-	if _, ok := metaInfo.SigningMethod.(*jwt.SigningMethodRSA); ok {
-		// The jwt library internally uses `bytes.IndexByte(data, '\n')` to fetch new line and fails
-		// if we have newline "\n" as ASCII value {92,110} instead of the actual ASCII value of 10.
-		// To fix this we replace "\n" with new line's ASCII value.
-		bytekey := bytes.ReplaceAll([]byte(metaInfo.VerificationKey), []byte{92, 110}, []byte{10})
-
-		if metaInfo.RSAPublicKey, err = jwt.ParseRSAPublicKeyFromPEM(bytekey); err != nil {
-			return nil, err
+	if metaInfo == nil {
+		return nil, nil
+	}
+	if err := metaInfo.parsePublicKey(); err != nil {
+		return nil, err
+	}
+	for _, issuer := range metaInfo.Issuers {
+		if err := issuer.parsePublicKey(); err != nil {
+			return nil, errors.Wrapf(err, "issuer %s", issuer.Issuer)
 		}
 	}
 
 	return metaInfo, nil
 }
 
+// parsePublicKey parses the PEM encoded VerificationKey of the asymmetric algorithms.
+func (a *AuthMeta) parsePublicKey() error {
+	// The jwt library internally uses `bytes.IndexByte(data, '\n')` to fetch new line and fails
+	// if we have newline "\n" as ASCII value {92,110} instead of the actual ASCII value of 10.
+	// To fix this we replace "\n" with new line's ASCII value.
+	bytekey := bytes.ReplaceAll([]byte(a.VerificationKey), []byte{92, 110}, []byte{10})
+
+	var err error
+	switch a.SigningMethod.(type) {
+	case *jwt.SigningMethodRSA:
+		a.RSAPublicKey, err = jwt.ParseRSAPublicKeyFromPEM(bytekey)
+	case *jwt.SigningMethodECDSA:
+		a.publicKey, err = jwt.ParseECPublicKeyFromPEM(bytekey)
+	case *SigningMethodEdDSA:
+		a.publicKey, err = parseEdPublicKeyFromPEM(bytekey)
+	}
+	return err
+}
+
 func GetHeader() string {
 	authMeta.RLock()
 	defer authMeta.RUnlock()
@@ -238,6 +338,56 @@ func (a *AuthMeta) rsaPublicKey() *rsa.PublicKey {
 	return a.RSAPublicKey
 }
 
+func (a *AuthMeta) getPublicKey() interface{} {
+	a.RLock()
+	defer a.RUnlock()
+	return a.publicKey
+}
+
+func (a *AuthMeta) audience() []string {
+	a.RLock()
+	defer a.RUnlock()
+	return a.Audience
+}
+
+func (a *AuthMeta) leeway() time.Duration {
+	a.RLock()
+	defer a.RUnlock()
+	return a.clockSkew
+}
+
+func (a *AuthMeta) hasKey() bool {
+	a.RLock()
+	defer a.RUnlock()
+	return a.JWKUrl != "" || a.Algo != ""
+}
+
+// verifierFor returns the configuration verifying the JWT: the one of the issuer of the JWT, or
+// else the main configuration if it accepts JWTs of any issuer.
+func (a *AuthMeta) verifierFor(jwtStr string) (*AuthMeta, error) {
+	a.RLock()
+	issuers := a.Issuers
+	issuer := a.Issuer
+	a.RUnlock()
+	if len(issuers) == 0 && issuer == "" {
+		return a, nil
+	}
+
+	var claims jwt.StandardClaims
+	if _, _, err := jwt.NewParser().ParseUnverified(jwtStr, &claims); err != nil {
+		return nil, errors.Errorf("unable to parse jwt token:%v", err)
+	}
+	for _, m := range issuers {
+		if m.Issuer == claims.Issuer {
+			return m, nil
+		}
+	}
+	if a.hasKey() && (issuer == "" || issuer == claims.Issuer) {
+		return a, nil
+	}
+	return nil, errors.Errorf("JWT `iss` value %q doesn't match with any issuer", claims.Issuer)
+}
+
 func SetAuthMeta(m *AuthMeta) {
 	authMeta.Lock()
 	defer authMeta.Unlock()
@@ -252,6 +402,12 @@ func SetAuthMeta(m *AuthMeta) {
 	authMeta.Algo = m.Algo
 	authMeta.SigningMethod = m.SigningMethod
 	authMeta.Audience = m.Audience
+	authMeta.fetchTime = m.fetchTime
+	authMeta.publicKey = m.publicKey
+	authMeta.Issuer = m.Issuer
+	authMeta.Issuers = m.Issuers
+	authMeta.ClockSkew = m.ClockSkew
+	authMeta.clockSkew = m.clockSkew
 	authMeta.httpClient = m.httpClient
 	authMeta.ClosedByDefault = m.ClosedByDefault
 }
@@ -276,6 +432,9 @@ func AttachAuthorizationJwt(ctx context.Context, r *http.Request) context.Contex
 type CustomClaims struct {
 	AuthVariables map[string]interface{}
 	jwt.StandardClaims
+	// namespace is the claim holding the auth variables. It defaults to the Namespace of the
+	// main configuration.
+	namespace string
 }
 
 func (c *CustomClaims) UnmarshalJSON(data []byte) error {
@@ -290,7 +449,11 @@ func (c *CustomClaims) UnmarshalJSON(data []byte) error {
 	}
 
 	// Unmarshal the auth variables for a particular namespace.
-	if authValue, ok := result[authMeta.namespace()]; ok {
+	namespace := c.namespace
+	if namespace == "" {
+		namespace = authMeta.namespace()
+	}
+	if authValue, ok := result[namespace]; ok {
 		if authJson, ok := authValue.(string); ok {
 			if err := json.Unmarshal([]byte(authJson), &c.AuthVariables); err != nil {
 				return err
@@ -302,20 +465,20 @@ func (c *CustomClaims) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-func (c *CustomClaims) validateAudience() error {
+func (c *CustomClaims) validateAudience(audience []string) error {
 	// If there's no audience claim, ignore
 	if c.Audience == nil || len(c.Audience) == 0 {
 		return nil
 	}
 
 	// If there is an audience claim, but no value provided, fail
-	if authMeta.Audience == nil {
+	if audience == nil {
 		return fmt.Errorf("audience value was expected but not provided")
 	}
 
 	var match = false
 	for _, audStr := range c.Audience {
-		for _, expectedAudStr := range authMeta.Audience {
+		for _, expectedAudStr := range audience {
 			if subtle.ConstantTimeCompare([]byte(audStr), []byte(expectedAudStr)) == 1 {
 				match = true
 				break
@@ -360,77 +523,106 @@ func GetJwtToken(ctx context.Context) string {
 }
 
 func validateJWTCustomClaims(jwtStr string) (*CustomClaims, error) {
-	jwkURL := authMeta.jwkURL()
+	verifier, err := authMeta.verifierFor(jwtStr)
+	if err != nil {
+		return nil, err
+	}
+	jwkURL := verifier.jwkURL()
+
+	// The JWT library supports comparison of `aud` in JWT against a single string. Hence, we
+	// disable the `aud` claim verification at the library end using `WithoutAudienceValidation` and
+	// use our custom validation function `validateAudience`.
+	opts := []jwt.ParserOption{jwt.WithoutAudienceValidation(), jwt.WithLeeway(authMeta.leeway())}
+	claims := &CustomClaims{namespace: verifier.namespace()}
 
 	var token *jwt.Token
-	var err error
 	// Verification through JWKUrl
 	if jwkURL != "" {
-		if authMeta.isExpired() {
-			err = authMeta.refreshJWK()
+		if verifier.isExpired() {
+			err = verifier.refreshJWK()
 			if err != nil {
 				return nil, errors.Wrap(err, "while refreshing JWK from the URL")
 			}
 		}
 
-		token, err =
-			jwt.ParseWithClaims(jwtStr, &CustomClaims{}, func(token *jwt.Token) (interface{}, error) {
-				kid := token.Header["kid"]
-				if kid == nil {
-					return nil, errors.Errorf("kid not present in JWT")
-				}
-
-				set := authMeta.getJWKSet()
-				signingKeys := set.Key(kid.(string))
-				if len(signingKeys) == 0 {
-					return nil, errors.Errorf("Invalid kid")
-				}
-				return signingKeys[0].Key, nil
-			}, jwt.WithoutAudienceValidation())
+		token, err = jwt.ParseWithClaims(jwtStr, claims, verifier.jwkKey, opts...)
 	} else {
-		amAlgo := authMeta.algo()
+		amAlgo := verifier.algo()
 		if amAlgo == "" {
 			return nil, fmt.Errorf(
 				"jwt token cannot be validated because verification algorithm is not set")
 		}
 
-		// The JWT library supports comparison of `aud` in JWT against a single string. Hence, we
-		// disable the `aud` claim verification at the library end using `WithoutAudienceValidation` and
-		// use our custom validation function `validateAudience`.
 		token, err =
-			jwt.ParseWithClaims(jwtStr, &CustomClaims{}, func(token *jwt.Token) (interface{}, error) {
+			jwt.ParseWithClaims(jwtStr, claims, func(token *jwt.Token) (interface{}, error) {
 				algo, _ := token.Header["alg"].(string)
 				if algo != amAlgo {
 					return nil, errors.Errorf("unexpected signing method: Expected %s Found %s",
 						amAlgo, algo)
 				}
 
-				switch authMeta.SigningMethod.(type) {
+				switch verifier.SigningMethod.(type) {
 				case *jwt.SigningMethodHMAC:
-					return []byte(authMeta.verificationKey()), nil
+					return []byte(verifier.verificationKey()), nil
 				case *jwt.SigningMethodRSA:
-					return authMeta.rsaPublicKey(), nil
+					return verifier.rsaPublicKey(), nil
+				case *jwt.SigningMethodECDSA, *SigningMethodEdDSA:
+					return verifier.getPublicKey(), nil
 				}
 
 				return nil, errors.Errorf("couldn't parse signing method from token header: %s", algo)
-			}, jwt.WithoutAudienceValidation())
+			}, opts...)
 	}
 
 	if err != nil {
 		return nil, errors.Errorf("unable to parse jwt token:%v", err)
 	}
 
-	claims, ok := token.Claims.(*CustomClaims)
-	if !ok || !token.Valid {
+	if !token.Valid {
 		return nil, errors.Errorf("claims in jwt token is not map claims")
 	}
 
-	if err := claims.validateAudience(); err != nil {
+	if err := claims.validateAudience(verifier.audience()); err != nil {
 		return nil, err
 	}
 	return claims, nil
 }
 
+// jwkKey returns the key of the JWKs that signed the token. If there is no such key, the keys
+// may have been rotated by the identity provider, so they are fetched again.
+func (a *AuthMeta) jwkKey(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		return nil, errors.Errorf("kid not present in JWT")
+	}
+
+	signingKeys := a.jwkKeys(kid)
+	if len(signingKeys) == 0 && a.canRefetchJWKs() {
+		if err := a.FetchJWKs(); err != nil {
+			return nil, errors.Wrap(err, "while refreshing JWK from the URL")
+		}
+		signingKeys = a.jwkKeys(kid)
+	}
+	if len(signingKeys) == 0 {
+		return nil, errors.Errorf("Invalid kid")
+	}
+	return signingKeys[0].Key, nil
+}
+
+func (a *AuthMeta) jwkKeys(kid string) []jose.JSONWebKey {
+	set := a.getJWKSet()
+	if set == nil {
+		return nil
+	}
+	return set.Key(kid)
+}
+
+func (a *AuthMeta) canRefetchJWKs() bool {
+	a.RLock()
+	defer a.RUnlock()
+	return time.Since(a.fetchTime) >= jwkRefetchInterval
+}
+
 // FetchJWKs fetches the JSON Web Key set from a JWKUrl. It acquires a Lock over a as some of the
 // properties of AuthMeta are modified in the process.
 func (a *AuthMeta) FetchJWKs() error {
@@ -440,6 +632,10 @@ func (a *AuthMeta) FetchJWKs() error {
 	if a.JWKUrl == "" {
 		return errors.Errorf("No JWKUrl supplied")
 	}
+	if a.httpClient == nil {
+		a.httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	a.fetchTime = time.Now()
 
 	req, err := http.NewRequest("GET", a.JWKUrl, nil)
 	if err != nil {
@@ -492,6 +688,21 @@ func (a *AuthMeta) FetchJWKs() error {
 	return nil
 }
 
+// FetchAllJWKs fetches the JSON Web Key sets of the main configuration and of the issuers that
+// have a JWKUrl.
+func (a *AuthMeta) FetchAllJWKs() error {
+	for _, m := range append([]*AuthMeta{a}, a.Issuers...) {
+		if m.JWKUrl == "" {
+			continue
+		}
+		m.InitHttpClient()
+		if err := m.FetchJWKs(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (a *AuthMeta) refreshJWK() error {
 	var err error
 	for i := 0; i < 3; i++ {
@@ -524,8 +735,9 @@ func (a *AuthMeta) initSigningMethod() error {
 	a.Lock()
 	defer a.Unlock()
 
-	// configurations using JWK URLs do not use signing methods.
-	if a.JWKUrl != "" {
+	// configurations using JWK URLs do not use signing methods, and neither do the main
+	// configurations leaving the verification to the issuers.
+	if a.JWKUrl != "" || (a.Algo == "" && a.VerificationKey == "" && len(a.Issuers) > 0) {
 		return nil
 	}
 
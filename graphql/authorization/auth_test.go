@@ -0,0 +1,170 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package authorization
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go/v4"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ed25519"
+)
+
+func pemPublicKey(t *testing.T, key interface{}) string {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	require.NoError(t, err)
+	pemKey := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+	// The keys are written on one line in the schema, see ParseAuthMeta.
+	return strings.Replace(string(pemKey), "\n", `\n`, -1)
+}
+
+func signToken(t *testing.T, method jwt.SigningMethod, key interface{},
+	claims map[string]interface{}) string {
+
+	token, err := jwt.NewWithClaims(method, jwt.MapClaims(claims)).SignedString(key)
+	require.NoError(t, err)
+	return token
+}
+
+func setAuthMeta(t *testing.T, meta string) {
+	m, err := ParseAuthMeta(AuthMetaHeader + meta)
+	require.NoError(t, err)
+	SetAuthMeta(m)
+}
+
+func TestAsymmetricAlgorithms(t *testing.T) {
+	defer SetAuthMeta(&AuthMeta{})
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	edPublicKey, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	claims := map[string]interface{}{
+		"https://xyz.io/jwt/claims": map[string]interface{}{"USER": "alice"},
+		"exp":                       time.Now().Add(time.Hour).Unix(),
+	}
+	for _, tc := range []struct {
+		method     jwt.SigningMethod
+		publicKey  interface{}
+		privateKey interface{}
+	}{
+		{jwt.SigningMethodES256, &ecKey.PublicKey, ecKey},
+		{SigningMethodEd25519, edPublicKey, edKey},
+	} {
+		setAuthMeta(t, fmt.Sprintf(`{"Header": "X-Auth", "Namespace": "https://xyz.io/jwt/claims",
+			"Algo": "%s", "VerificationKey": "%s"}`, tc.method.Alg(), pemPublicKey(t, tc.publicKey)))
+
+		got, err := validateJWTCustomClaims(signToken(t, tc.method, tc.privateKey, claims))
+		require.NoError(t, err, tc.method.Alg())
+		require.Equal(t, map[string]interface{}{"USER": "alice"}, got.AuthVariables)
+
+		// A token signed with HS256 using the public key as secret is rejected.
+		_, err = validateJWTCustomClaims(signToken(t, jwt.SigningMethodHS256,
+			[]byte(pemPublicKey(t, tc.publicKey)), claims))
+		require.Error(t, err, tc.method.Alg())
+	}
+}
+
+func TestMultipleIssuers(t *testing.T) {
+	defer SetAuthMeta(&AuthMeta{})
+
+	setAuthMeta(t, `{"Header": "X-Auth", "Namespace": "https://xyz.io/jwt/claims",
+		"Issuers": [
+			{"Issuer": "https://a.io", "Algo": "HS256", "VerificationKey": "secret-a",
+				"Audience": ["aud-a"]},
+			{"Issuer": "https://b.io", "Algo": "HS256", "VerificationKey": "secret-b",
+				"Namespace": "https://b.io/claims"}
+		]}`)
+
+	exp := time.Now().Add(time.Hour).Unix()
+	got, err := validateJWTCustomClaims(signToken(t, jwt.SigningMethodHS256, []byte("secret-a"),
+		map[string]interface{}{"iss": "https://a.io", "aud": "aud-a", "exp": exp,
+			"https://xyz.io/jwt/claims": map[string]interface{}{"USER": "a"}}))
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"USER": "a"}, got.AuthVariables)
+
+	got, err = validateJWTCustomClaims(signToken(t, jwt.SigningMethodHS256, []byte("secret-b"),
+		map[string]interface{}{"iss": "https://b.io", "exp": exp,
+			"https://b.io/claims": map[string]interface{}{"USER": "b"}}))
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"USER": "b"}, got.AuthVariables)
+
+	// The audience is checked per issuer.
+	_, err = validateJWTCustomClaims(signToken(t, jwt.SigningMethodHS256, []byte("secret-a"),
+		map[string]interface{}{"iss": "https://a.io", "aud": "aud-b", "exp": exp}))
+	require.EqualError(t, err, "JWT `aud` value doesn't match with the audience")
+
+	// A token must be signed with the key of its issuer.
+	_, err = validateJWTCustomClaims(signToken(t, jwt.SigningMethodHS256, []byte("secret-b"),
+		map[string]interface{}{"iss": "https://a.io", "exp": exp}))
+	require.Error(t, err)
+
+	_, err = validateJWTCustomClaims(signToken(t, jwt.SigningMethodHS256, []byte("secret-a"),
+		map[string]interface{}{"iss": "https://c.io", "exp": exp}))
+	require.EqualError(t, err, "JWT `iss` value \"https://c.io\" doesn't match with any issuer")
+}
+
+func TestInvalidIssuers(t *testing.T) {
+	for _, meta := range []string{
+		`{"Header": "X-Auth", "Namespace": "ns", "Issuers": [{"Algo": "HS256",
+			"VerificationKey": "secret"}]}`,
+		`{"Header": "X-Auth", "Namespace": "ns", "Issuers": [{"Issuer": "a", "Algo": "HS256"}]}`,
+		`{"Header": "X-Auth", "Namespace": "ns", "Issuers": [
+			{"Issuer": "a", "Algo": "HS256", "VerificationKey": "secret"},
+			{"Issuer": "a", "Algo": "HS256", "VerificationKey": "secret"}]}`,
+		`{"Header": "X-Auth", "Namespace": "ns", "Issuers": [{"Issuer": "a", "Algo": "PS256",
+			"VerificationKey": "secret"}]}`,
+		`{"Header": "X-Auth", "Namespace": "ns", "Algo": "HS256", "VerificationKey": "secret",
+			"ClockSkew": "-1s"}`,
+	} {
+		_, err := Parse(AuthMetaHeader + meta)
+		require.Error(t, err, meta)
+	}
+}
+
+func TestClockSkew(t *testing.T) {
+	defer SetAuthMeta(&AuthMeta{})
+
+	token := signToken(t, jwt.SigningMethodHS256, []byte("secret"), map[string]interface{}{
+		"exp": time.Now().Add(-10 * time.Second).Unix(),
+	})
+	meta := map[string]interface{}{
+		"Header": "X-Auth", "Namespace": "ns", "Algo": "HS256", "VerificationKey": "secret",
+	}
+	b, err := json.Marshal(meta)
+	require.NoError(t, err)
+	setAuthMeta(t, string(b))
+	_, err = validateJWTCustomClaims(token)
+	require.Error(t, err)
+
+	meta["ClockSkew"] = "1m"
+	b, err = json.Marshal(meta)
+	require.NoError(t, err)
+	setAuthMeta(t, string(b))
+	_, err = validateJWTCustomClaims(token)
+	require.NoError(t, err)
+}
@@ -0,0 +1,94 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package authorization
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+
+	"github.com/dgrijalva/jwt-go/v4"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ed25519"
+)
+
+// SigningMethodEdDSA implements the EdDSA signing method with Ed25519 keys (RFC 8037), which the
+// jwt library doesn't provide. It expects an ed25519.PrivateKey for signing and an
+// ed25519.PublicKey for verification.
+type SigningMethodEdDSA struct{}
+
+// SigningMethodEd25519 is the EdDSA signing method, registered as the EdDSA algorithm.
+var SigningMethodEd25519 = &SigningMethodEdDSA{}
+
+func init() {
+	jwt.RegisterSigningMethod(SigningMethodEd25519.Alg(), func() jwt.SigningMethod {
+		return SigningMethodEd25519
+	})
+}
+
+// Alg implements the Alg method from jwt.SigningMethod.
+func (m *SigningMethodEdDSA) Alg() string {
+	return "EdDSA"
+}
+
+// Verify implements the Verify method from jwt.SigningMethod.
+func (m *SigningMethodEdDSA) Verify(signingString, signature string, key interface{}) error {
+	publicKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return jwt.NewInvalidKeyTypeError("ed25519.PublicKey", key)
+	}
+	if len(publicKey) != ed25519.PublicKeySize {
+		return &jwt.InvalidKeyError{Message: "ed25519 public key has an invalid size"}
+	}
+
+	sig, err := jwt.DecodeSegment(signature)
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(publicKey, []byte(signingString), sig) {
+		return new(jwt.InvalidSignatureError)
+	}
+	return nil
+}
+
+// Sign implements the Sign method from jwt.SigningMethod.
+func (m *SigningMethodEdDSA) Sign(signingString string, key interface{}) (string, error) {
+	privateKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return "", jwt.NewInvalidKeyTypeError("ed25519.PrivateKey", key)
+	}
+	if len(privateKey) != ed25519.PrivateKeySize {
+		return "", &jwt.InvalidKeyError{Message: "ed25519 private key has an invalid size"}
+	}
+	return jwt.EncodeSegment(ed25519.Sign(privateKey, []byte(signingString))), nil
+}
+
+// parseEdPublicKeyFromPEM parses a PEM encoded PKIX Ed25519 public key.
+func parseEdPublicKeyFromPEM(key []byte) (ed25519.PublicKey, error) {
+	block, _ := pem.Decode(key)
+	if block == nil {
+		return nil, errors.Errorf("invalid key: key must be PEM encoded")
+	}
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	publicKey, ok := parsed.(ed25519.PublicKey)
+	if !ok {
+		return nil, errors.Errorf("key is not a valid Ed25519 public key")
+	}
+	return publicKey, nil
+}
@@ -83,6 +83,26 @@ func writeQuery(b *strings.Builder, query *gql.GraphQuery, prefix string) {
 		x.Check2(b.WriteRune(')'))
 	}
 
+	if query.IsGroupby {
+		x.Check2(b.WriteString(" @groupby("))
+		for i, attr := range query.GroupbyAttrs {
+			if i != 0 {
+				x.Check2(b.WriteString(", "))
+			}
+			if attr.Alias != "" {
+				x.Check2(b.WriteString(attr.Alias))
+				x.Check2(b.WriteString(" : "))
+			}
+			x.Check2(b.WriteString(attr.Attr))
+			if attr.Interval != "" {
+				x.Check2(b.WriteString("(interval: "))
+				x.Check2(b.WriteString(attr.Interval))
+				x.Check2(b.WriteRune(')'))
+			}
+		}
+		x.Check2(b.WriteRune(')'))
+	}
+
 	if len(query.Cascade) != 0 {
 		if query.Cascade[0] == "__all__" {
 			x.Check2(b.WriteString(" @cascade"))
@@ -149,9 +169,10 @@ func writeRoot(b *strings.Builder, q *gql.GraphQuery) {
 		writeUIDFunc(b, q.Func.UID, q.Func.Args)
 	case q.Func.Name == "type" && len(q.Func.Args) == 1:
 		x.Check2(b.WriteString(fmt.Sprintf("(func: type(%s)", q.Func.Args[0].Value)))
-	case q.Func.Name == "eq" && len(q.Func.Args) == 2:
-		x.Check2(b.WriteString(fmt.Sprintf("(func: eq(%s, %s)", q.Func.Args[0].Value,
-			q.Func.Args[1].Value)))
+	case q.Func.Name == "eq" && len(q.Func.Args) >= 2:
+		x.Check2(b.WriteString("(func: eq("))
+		writeFilterArguments(b, q.Func.Args)
+		x.Check2(b.WriteRune(')'))
 	}
 	writeOrderAndPage(b, q, true)
 	x.Check2(b.WriteRune(')'))
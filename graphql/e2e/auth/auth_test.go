@@ -340,6 +340,72 @@ func TestAddMutationWithXid(t *testing.T) {
 	tweet.DeleteByID(t, user, metaInfo)
 }
 
+func TestUpsertMutationWithNestedXidChecksUpdateAuth(t *testing.T) {
+	tweet := common.Tweets{
+		Id:        "tweet1",
+		Text:      "abc",
+		Timestamp: "2020-10-10",
+	}
+	addTweetsParams := &common.GraphQLParams{
+		Headers: common.GetJWT(t, "foo", "", metaInfo),
+		Query: `mutation addTweets($tweet: AddTweetsInput!){
+			addTweets(input: [$tweet]) {
+				numUids
+			}
+		}`,
+		Variables: map[string]interface{}{"tweet": tweet},
+	}
+	gqlResponse := addTweetsParams.ExecuteAsPost(t, common.GraphqlURL)
+	common.RequireNoGQLErrors(t, gqlResponse)
+	defer tweet.DeleteByID(t, "foo", metaInfo)
+
+	upsertUser := func(user string) *common.GraphQLResponse {
+		params := &common.GraphQLParams{
+			Headers: common.GetJWT(t, user, "", metaInfo),
+			Query: `mutation addUser($user: AddUserInput!) {
+				addUser(input: [$user], upsert: true) {
+					numUids
+				}
+			}`,
+			Variables: map[string]interface{}{"user": map[string]interface{}{
+				"username": "upsertUser",
+				"password": "password",
+				"tweets": []interface{}{map[string]interface{}{
+					"id":        tweet.Id,
+					"text":      "updated",
+					"timestamp": tweet.Timestamp,
+				}},
+			}},
+		}
+		return params.ExecuteAsPost(t, common.GraphqlURL)
+	}
+	queryTweetText := func() string {
+		params := &common.GraphQLParams{
+			Headers: common.GetJWT(t, "foo", "admin", metaInfo),
+			Query: `query getTweets($id: String!) {
+				getTweets(id: $id) {
+					text
+				}
+			}`,
+			Variables: map[string]interface{}{"id": tweet.Id},
+		}
+		gqlResponse := params.ExecuteAsPost(t, common.GraphqlURL)
+		common.RequireNoGQLErrors(t, gqlResponse)
+		return string(gqlResponse.Data)
+	}
+
+	// The existing tweet can't be updated by a user that fails its update rule.
+	gqlResponse = upsertUser("bar")
+	require.Equal(t, len(gqlResponse.Errors), 1)
+	require.Contains(t, gqlResponse.Errors[0].Message, "authorization failed")
+	require.JSONEq(t, `{"getTweets": {"text": "abc"}}`, queryTweetText())
+
+	gqlResponse = upsertUser("foo")
+	common.RequireNoGQLErrors(t, gqlResponse)
+	defer deleteUser(t, "upsertUser")
+	require.JSONEq(t, `{"getTweets": {"text": "updated"}}`, queryTweetText())
+}
+
 func TestAuthWithDgraphDirective(t *testing.T) {
 	students := []Student{
 		{
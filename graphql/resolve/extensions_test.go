@@ -17,10 +17,14 @@
 package resolve
 
 import (
+	"context"
+	"encoding/json"
 	"testing"
 	"time"
 
+	"github.com/dgraph-io/dgraph/graphql/schema"
 	"github.com/dgraph-io/dgraph/graphql/test"
+	"github.com/dgraph-io/dgraph/x"
 	"github.com/stretchr/testify/require"
 )
 
@@ -226,3 +230,63 @@ func TestMultipleMutationsPropagateExtensionsCorrectly(t *testing.T) {
 		}
 	}
 }
+
+func TestCostLimitsRejectOperations(t *testing.T) {
+	gqlSchema := test.LoadSchemaFromString(t, testGQLSchema)
+	query := `
+	query {
+      queryAuthor(first: 2) {
+        postsRequired(first: 3) {
+          author {
+            name
+          }
+        }
+      }
+    }`
+	ex := &executor{resp: `{"queryAuthor": []}`}
+	newResolver := func() *RequestResolver {
+		return New(gqlSchema, NewResolverFactory(nil, nil).WithConventionResolvers(gqlSchema,
+			&ResolverFns{Qrw: NewQueryRewriter(), Ex: ex}))
+	}
+
+	resp := newResolver().Resolve(context.Background(), &schema.Request{Query: query})
+	require.Nil(t, resp.Errors)
+	require.Equal(t, uint64(9), resp.Extensions.Cost)
+
+	defer func(c x.Options) { x.Config = c }(x.Config)
+	x.Config.GraphqlMaxDepth, x.Config.GraphqlMaxCost = 3, 5
+	resp = newResolver().Resolve(context.Background(), &schema.Request{Query: query})
+	require.Empty(t, resp.Data.String())
+	require.Len(t, resp.Errors, 1)
+	require.Equal(t, "Operation has depth 4, more than the maximum depth 3.", resp.Errors[0].Message)
+
+	x.Config.GraphqlMaxDepth = 0
+	resp = newResolver().Resolve(context.Background(), &schema.Request{Query: query})
+	require.Empty(t, resp.Data.String())
+	require.Len(t, resp.Errors, 1)
+	require.Equal(t, "Operation has cost 9, more than the maximum cost 5.", resp.Errors[0].Message)
+	require.Equal(t, uint64(9), resp.Extensions.Cost)
+
+	// The cost is computed with the values of the variables.
+	query = `
+	query($n: Int) {
+      queryAuthor(first: $n) {
+        postsRequired(first: 3) {
+          author {
+            name
+          }
+        }
+      }
+    }`
+	resp = newResolver().Resolve(context.Background(), &schema.Request{Query: query,
+		Variables: map[string]interface{}{"n": json.Number("1")}})
+	require.Nil(t, resp.Errors)
+	require.Equal(t, uint64(5), resp.Extensions.Cost)
+
+	resp = newResolver().Resolve(context.Background(), &schema.Request{Query: query,
+		Variables: map[string]interface{}{"n": json.Number("100")}})
+	require.Empty(t, resp.Data.String())
+	require.Len(t, resp.Errors, 1)
+	require.Equal(t, "Operation has cost 401, more than the maximum cost 5.",
+		resp.Errors[0].Message)
+}
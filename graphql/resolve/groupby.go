@@ -0,0 +1,325 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resolve
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dgraph-io/dgraph/gql"
+	"github.com/dgraph-io/dgraph/graphql/schema"
+)
+
+// groupByAggregates are the suffixes of the aggregate fields of a group, and the DQL function
+// that computes each of them.
+var groupByAggregates = map[string]string{
+	"Min": "min",
+	"Max": "max",
+	"Sum": "sum",
+	"Avg": "avg",
+}
+
+// groupByQuery rewrites a groupBy query into a DQL @groupby query, keyed by the fields of the by
+// argument. The DateTime fields with an interval are truncated to it by Dgraph. The count of every
+// group is always queried, along with the aggregates needed by the having argument.
+//
+//	query {
+//	  groupByPost(by: [{field: category}, {field: datePublished, interval: year}]) {
+//	    category datePublished count scoreAvg
+//	  }
+//	}
+//
+// becomes
+//
+//	query {
+//	  groupByPost(func: type(Post)) @groupby(category : Post.category,
+//	      datePublished : Post.datePublished(interval: year)) {
+//	    count : count(uid)
+//	    scoreAvg : avg(Post.score)
+//	  }
+//	}
+func groupByQuery(query schema.Query, authRw *authRewriter) ([]*gql.GraphQuery, error) {
+	keys, err := query.GroupByArg()
+	if err != nil {
+		return nil, err
+	}
+	mainType := query.ConstructedFor()
+
+	dgQuery, rbac := addCommonRules(query, mainType, authRw)
+	if rbac == schema.Negative {
+		return dgQuery, nil
+	}
+
+	filter, _ := query.ArgValue("filter").(map[string]interface{})
+	_ = addFilter(dgQuery[0], mainType, filter)
+
+	mainQuery := dgQuery[0]
+	mainQuery.IsGroupby = true
+	for _, key := range keys {
+		mainQuery.GroupbyAttrs = append(mainQuery.GroupbyAttrs, gql.GroupByAttr{
+			Attr:     key.Field.DgraphPredicate(),
+			Alias:    key.Field.Name(),
+			Interval: key.Interval,
+		})
+	}
+
+	fields := make(map[string]schema.FieldDefinition)
+	for _, fld := range mainType.Fields() {
+		fields[fld.Name()] = fld
+	}
+	aggregates := make(map[string]bool)
+	for _, f := range query.SelectionSet() {
+		aggregates[f.Name()] = true
+	}
+	having, _ := query.ArgValue("having").(map[string]interface{})
+	for name := range having {
+		aggregates[name] = true
+	}
+	names := make([]string, 0, len(aggregates))
+	for name := range aggregates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	mainQuery.Children = []*gql.GraphQuery{{Alias: "count", Attr: "count(uid)"}}
+	for _, name := range names {
+		if len(name) <= 3 || fields[name] != nil {
+			// The count, and the keys of the groups.
+			continue
+		}
+		fn, ok := groupByAggregates[name[len(name)-3:]]
+		fld := fields[name[:len(name)-3]]
+		if !ok || fld == nil {
+			continue
+		}
+		mainQuery.Children = append(mainQuery.Children, &gql.GraphQuery{
+			Alias: name,
+			Attr:  fn + "(" + fld.DgraphPredicate() + ")",
+		})
+	}
+
+	return authRw.addAuthQueries(mainType, dgQuery, rbac), nil
+}
+
+// groupByCompletion turns the result of the DQL @groupby query into the list of groups of the
+// groupBy query. The groups that don't match the having argument are dropped, and the groups are
+// ordered by their keys.
+func groupByCompletion(ctx context.Context, resolved *Resolved) {
+	query, ok := resolved.Field.(schema.Query)
+	if !ok {
+		return
+	}
+	data, ok := resolved.Data.(map[string]interface{})
+	if !ok {
+		return
+	}
+	args, err := query.GroupByArg()
+	if err != nil {
+		// The query couldn't have been rewritten, so there's nothing to complete.
+		return
+	}
+	keys := make([]string, 0, len(args))
+	for _, arg := range args {
+		keys = append(keys, arg.Field.Name())
+	}
+
+	// Dgraph returns the groups as {"groupByPost": [{"@groupby": [{...}, {...}]}]}.
+	var groups []map[string]interface{}
+	result, _ := data[query.DgraphAlias()].([]interface{})
+	for _, r := range result {
+		res, _ := r.(map[string]interface{})
+		grouped, _ := res["@groupby"].([]interface{})
+		for _, g := range grouped {
+			if group, ok := g.(map[string]interface{}); ok {
+				groups = append(groups, group)
+			}
+		}
+	}
+
+	having, _ := query.ArgValue("having").(map[string]interface{})
+	groups = filterGroups(groups, having)
+	sortGroups(groups, keys)
+
+	completed := make([]interface{}, 0, len(groups))
+	for _, group := range groups {
+		res := make(map[string]interface{})
+		for _, f := range query.SelectionSet() {
+			res[f.DgraphAlias()] = group[f.Name()]
+		}
+		completed = append(completed, res)
+	}
+	data[query.DgraphAlias()] = completed
+}
+
+// filterGroups returns the groups whose aggregates match all the filters of the having argument,
+// e.g. {count: {gt: 1}, scoreAvg: {between: {min: 1, max: 5}}}.
+func filterGroups(groups []map[string]interface{},
+	having map[string]interface{}) []map[string]interface{} {
+
+	if len(having) == 0 {
+		return groups
+	}
+	res := groups[:0]
+	for _, group := range groups {
+		matches := true
+		for name, filter := range having {
+			f, _ := filter.(map[string]interface{})
+			if !matchesGroupFilter(group[name], f) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			res = append(res, group)
+		}
+	}
+	return res
+}
+
+func matchesGroupFilter(val interface{}, filter map[string]interface{}) bool {
+	v, ok := toFloat(val)
+	if !ok {
+		return len(filter) == 0
+	}
+	for op, arg := range filter {
+		if op == "between" {
+			bounds, _ := arg.(map[string]interface{})
+			min, okMin := argToFloat(bounds["min"])
+			max, okMax := argToFloat(bounds["max"])
+			if !okMin || !okMax || v < min || v > max {
+				return false
+			}
+			continue
+		}
+
+		a, ok := argToFloat(arg)
+		if !ok {
+			return false
+		}
+		switch op {
+		case "eq":
+			ok = v == a
+		case "le":
+			ok = v <= a
+		case "lt":
+			ok = v < a
+		case "ge":
+			ok = v >= a
+		case "gt":
+			ok = v > a
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// sortGroups orders the groups by their keys, in the order of the by argument. The groups
+// without a value for a key come first.
+func sortGroups(groups []map[string]interface{}, keys []string) {
+	sort.SliceStable(groups, func(i, j int) bool {
+		for _, key := range keys {
+			if c := compareGroupValues(groups[i][key], groups[j][key]); c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+}
+
+// compareGroupValues compares two values of a group, which are of the same type: numbers,
+// DateTimes, strings or booleans.
+func compareGroupValues(a, b interface{}) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+
+	if x, ok := toFloat(a); ok {
+		if y, ok := toFloat(b); ok {
+			return compareFloats(x, y)
+		}
+	}
+	if x, ok := a.(bool); ok {
+		if y, ok := b.(bool); ok && x != y {
+			if !x {
+				return -1
+			}
+			return 1
+		}
+		return 0
+	}
+
+	x, _ := a.(string)
+	y, _ := b.(string)
+	tx, errX := time.Parse(time.RFC3339Nano, x)
+	ty, errY := time.Parse(time.RFC3339Nano, y)
+	switch {
+	case errX == nil && errY == nil && tx.Before(ty):
+		return -1
+	case errX == nil && errY == nil && tx.After(ty):
+		return 1
+	case errX == nil && errY == nil:
+		return 0
+	}
+	return strings.Compare(x, y)
+}
+
+func compareFloats(x, y float64) int {
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
+
+// toFloat converts a number of a group to a float64.
+func toFloat(val interface{}) (float64, bool) {
+	switch v := val.(type) {
+	case float64:
+		return v, true
+	case int64:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// argToFloat converts a number of the having argument to a float64. Int64 numbers can be given as
+// strings.
+func argToFloat(val interface{}) (float64, bool) {
+	if s, ok := val.(string); ok {
+		f, err := strconv.ParseFloat(s, 64)
+		return f, err == nil
+	}
+	return toFloat(val)
+}
@@ -0,0 +1,54 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resolve
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func decodeGroups(t *testing.T, s string) []map[string]interface{} {
+	var groups []map[string]interface{}
+	d := json.NewDecoder(strings.NewReader(s))
+	d.UseNumber()
+	require.NoError(t, d.Decode(&groups))
+	return groups
+}
+
+func TestGroupByFiltersAndSortsGroups(t *testing.T) {
+	groups := decodeGroups(t, `[
+		{"name": "B", "count": 1, "repAvg": 2},
+		{"name": "C", "count": 3, "repAvg": 4.5},
+		{"name": "A", "count": 2},
+		{"name": "D", "count": 4, "repAvg": 1}
+	]`)
+
+	groups = filterGroups(groups, map[string]interface{}{
+		"count":  map[string]interface{}{"ge": int64(2)},
+		"repAvg": map[string]interface{}{"between": map[string]interface{}{"min": 1.0, "max": 5.0}},
+	})
+	sortGroups(groups, []string{"name"})
+	b, err := json.Marshal(groups)
+	require.NoError(t, err)
+	require.JSONEq(t, `[
+		{"name": "C", "count": 3, "repAvg": 4.5},
+		{"name": "D", "count": 4, "repAvg": 1}
+	]`, string(b))
+}
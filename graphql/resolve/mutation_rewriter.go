@@ -78,6 +78,9 @@ type xidMetadata struct {
 	seenAtTopLevel map[string]bool
 	// queryExists tells whether the query part in upsert has already been created for xidVariable
 	queryExists map[string]bool
+	// upsert tells whether the objects whose xid already exists are updated, instead of failing
+	// the mutation
+	upsert bool
 }
 
 // A mutationBuilder can build a json mutation []byte from a mutationFragment
@@ -266,6 +269,7 @@ func (mrw *AddRewriter) Rewrite(ctx context.Context, m schema.Mutation) ([]*Upse
 	val, _ := m.ArgValue(schema.InputArgName).([]interface{})
 	varGen := NewVariableGenerator()
 	xidMd := newXidMetadata()
+	xidMd.upsert, _ = m.ArgValue(schema.UpsertArgName).(bool)
 	var errs error
 
 	mutationsAllSec := []*dgoapi.Mutation{}
@@ -365,6 +369,12 @@ func (mrw *AddRewriter) FromMutationResult(
 		node := strings.TrimPrefix(frag[0].
 			fragment.(map[string]interface{})["uid"].(string), "_:")
 		val, ok := assigned[node]
+		if !ok && strings.HasPrefix(node, "uid(") {
+			// An upserted object that already existed keeps its uid, found by the upsert query.
+			if existing := extractMutated(result, node[4:len(node)-1]); len(existing) == 1 {
+				val, ok = existing[0], true
+			}
+		}
 		if !ok {
 			continue
 		}
@@ -379,7 +389,7 @@ func (mrw *AddRewriter) FromMutationResult(
 		uids = append(uids, uid)
 	}
 
-	if len(assigned) == 0 && errs == nil {
+	if len(assigned) == 0 && len(uids) == 0 && errs == nil {
 		errs = schema.AsGQLErrors(errors.Errorf("no new node was created"))
 	}
 
@@ -1048,6 +1058,11 @@ func rewriteObject(
 		if xid != nil && xidString != "" {
 			xidFrag = asXIDReference(ctx, srcField, srcUID, typ, xid.Name(), xidString,
 				variable, withAdditionalDeletes, varGen, xidMetadata)
+			if xidMetadata.upsert && setScalarFields(xidFrag, typ, obj) {
+				// When upserting, the existing object is also updated with the values given
+				// for it, not only linked to, so it must pass the update auth rules.
+				addUpsertAuth(ctx, xidFrag, varGen, typ, variable)
+			}
 
 			// Inverse Link is added as a Part of asXIDReference so we delete any provided
 			// Link to the object.
@@ -1132,12 +1147,18 @@ func rewriteObject(
 
 	var myUID string
 	newObj := make(map[string]interface{}, len(obj))
+	upsertTopLevel := atTopLevel && xidString != "" && xidMetadata.upsert
 
 	if !atTopLevel || topLevelAdd {
 		dgraphTypes := []string{typ.DgraphName()}
 		dgraphTypes = append(dgraphTypes, typ.Interfaces()...)
 		newObj["dgraph.type"] = dgraphTypes
 		myUID = fmt.Sprintf("_:%s", variable)
+		if upsertTopLevel {
+			// The object is either the existing node with this xid, or a new node if there's
+			// none, see below.
+			myUID = fmt.Sprintf("uid(%s)", variable)
+		}
 
 		if xid == nil || deepXID > 2 {
 			// If this object had an overwritten value for the inverse field, then we don't want to
@@ -1209,6 +1230,25 @@ func rewriteObject(
 			err = x.GqlErrorf("GraphQL debug: id already exists for type %s", typ.Name())
 		}
 		frag.check = checkQueryResult(variable, err, nil)
+
+		if upsertTopLevel {
+			// Dgraph creates a new node for uid(variable) if the xid doesn't exist yet, and
+			// that's the node we authorize as new.
+			delete(frag.newNodes, variable)
+			frag.newNodes[myUID] = typ
+
+			// The same object also updates the existing node, if there is one.
+			updObj := make(map[string]interface{}, len(newObj))
+			for k, v := range newObj {
+				updObj[k] = v
+			}
+			updFrag := newFragment(updObj)
+			updFrag.conditions = []string{fmt.Sprintf("eq(len(%s), 1)", variable)}
+			updFrag.check = checkQueryResult(variable, nil,
+				errors.Errorf("id %s doesn't exist for type %s", xidString, typ.Name()))
+			addUpsertAuth(ctx, updFrag, varGen, typ, variable)
+			results.secondPass = append(results.secondPass, updFrag)
+		}
 	}
 
 	if xid != nil && !atTopLevel {
@@ -1402,6 +1442,40 @@ func rewriteUnionField(ctx context.Context,
 		withAdditionalDeletes, obj, deepXID, xidMetadata)
 }
 
+// setScalarFields adds the scalar values of obj, other than its xid, to frag, which links to the
+// existing node for obj.  That's how upserts update the nested objects that already exist.  It
+// returns whether any value was added.
+func setScalarFields(frag *mutationFragment, typ schema.Type, obj map[string]interface{}) bool {
+	result := frag.fragment.(map[string]interface{})
+	set := false
+	for field, val := range obj {
+		fieldDef := typ.Field(field)
+		if val == nil || fieldDef == nil || fieldDef.HasIDDirective() {
+			continue
+		}
+
+		fieldName := typ.DgraphPredicate(field)
+		if strings.HasPrefix(fieldName, "<") && strings.HasSuffix(fieldName, ">") {
+			fieldName = fieldName[1 : len(fieldName)-1]
+		}
+
+		switch {
+		case fieldDef.Type().IsGeo():
+			if geo, ok := val.(map[string]interface{}); ok {
+				result[fieldName] = map[string]interface{}{
+					"type":        fieldDef.Type().Name(),
+					"coordinates": rewriteGeoObject(geo, fieldDef.Type()),
+				}
+				set = true
+			}
+		case fieldDef.Type().IsInbuiltOrEnumType():
+			result[fieldName] = val
+			set = true
+		}
+	}
+	return set
+}
+
 // rewriteGeoObject rewrites the given value correctly based on the underlying Geo type.
 // Currently, it supports Point, Polygon and MultiPolygon.
 func rewriteGeoObject(val map[string]interface{}, typ schema.Type) []interface{} {
@@ -1816,6 +1890,62 @@ func addDelete(
 	frag.check = authCheck(frag.check, targetVar)
 }
 
+// addUpsertAuth guards frag, which updates the existing node in variable as part of an
+// upsert, with the update auth rules of typ.  The node is only updated if it passes them:
+//
+// Author2 as Author1.auth(func: uid(Author1)) @filter(...auth filter...) { uid }
+// condition : eq(len(Author2), 1)
+func addUpsertAuth(
+	ctx context.Context,
+	frag *mutationFragment,
+	varGen *VariableGenerator,
+	typ schema.Type,
+	variable string) {
+
+	rn := updateAuthSelector(typ)
+	if rn == nil {
+		return
+	}
+
+	customClaims, err := authorization.ExtractCustomClaims(ctx)
+	if err != nil {
+		frag.err = schema.GQLWrapf(err, "authorization failed")
+		return
+	}
+
+	authRw := &authRewriter{
+		authVariables: customClaims.AuthVariables,
+		varGen:        varGen,
+		varName:       variable,
+		selector:      updateAuthSelector,
+		parentVarName: typ.Name() + "Root",
+		hasAuthRules:  true,
+	}
+
+	authVar := varGen.Next(typ, "", "", false)
+	qry := &gql.GraphQuery{
+		Var:  authVar,
+		Attr: variable + ".auth",
+	}
+	if rn.EvaluateStatic(authRw.authVariables) == schema.Negative {
+		// An empty block, so the node is never updated.
+		qry.Attr += "()"
+		frag.queries = append(frag.queries, qry)
+	} else {
+		authQueries, authFilter := authRw.rewriteAuthQueries(typ)
+		qry.Func = &gql.Function{
+			Name: "uid",
+			Args: []gql.Arg{{Value: variable}},
+		}
+		qry.Filter = authFilter
+		qry.Children = []*gql.GraphQuery{{Attr: "uid"}}
+		frag.queries = append(frag.queries, qry)
+		frag.queries = append(frag.queries, authQueries...)
+	}
+	frag.conditions = append(frag.conditions, fmt.Sprintf("eq(len(%s), 1)", authVar))
+	frag.check = authCheck(frag.check, variable)
+}
+
 func authCheck(chk resultChecker, qry string) resultChecker {
 	return func(m map[string]interface{}) error {
 
@@ -291,6 +291,31 @@ func TestMutationQueryRewriting(t *testing.T) {
 	}
 }
 
+func TestUpsertMutationQueryRewriting(t *testing.T) {
+	gqlSchema := test.LoadSchemaFromFile(t, "schema.graphql")
+	op, err := gqlSchema.Operation(
+		&schema.Request{
+			Query: `mutation {
+				addState(input: [{code: "nsw", name: "NSW"}], upsert: true) {
+					state { code }
+				}
+			}`,
+		})
+	require.NoError(t, err)
+	gqlMutation := test.GetMutation(t, op)
+
+	rewriter := NewAddRewriter()
+	_, err = rewriter.Rewrite(context.Background(), gqlMutation)
+	require.NoError(t, err)
+
+	// nsw already existed, so no node was created, and the query reads the existing one.
+	dgQuery, err := rewriter.FromMutationResult(context.Background(), gqlMutation,
+		map[string]string{},
+		map[string]interface{}{"State2": []interface{}{map[string]interface{}{"uid": "0x4"}}})
+	require.NoError(t, err)
+	require.Contains(t, dgraph.AsString(dgQuery), "uid(0x4)")
+}
+
 func TestCustomHTTPMutation(t *testing.T) {
 	b, err := ioutil.ReadFile("custom_mutation_test.yaml")
 	require.NoError(t, err, "Unable to read test file")
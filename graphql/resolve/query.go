@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"strconv"
 
 	"github.com/golang/glog"
@@ -29,6 +30,7 @@ import (
 	"github.com/dgraph-io/dgraph/gql"
 	"github.com/dgraph-io/dgraph/graphql/dgraph"
 	"github.com/dgraph-io/dgraph/graphql/schema"
+	"github.com/dgraph-io/dgraph/posting"
 	"github.com/dgraph-io/dgraph/x"
 )
 
@@ -133,18 +135,34 @@ func (qr *queryResolver) rewriteAndExecute(ctx context.Context, query schema.Que
 			// the keys in dgoapi.Request{}.Vars are assumed to be prefixed with $
 			vars["$"+k] = vStr
 		}
+		RecordReadPredicatesOfDQL(ctx, qry, vars)
 	} else {
 		dgQuery, err := qr.queryRewriter.Rewrite(ctx, query)
 		if err != nil {
 			return emptyResult(schema.GQLWrapf(err, "couldn't rewrite query %s",
 				query.ResponseName()))
 		}
+		recordReadPredicates(ctx, dgQuery)
 		qry = dgraph.AsString(dgQuery)
 	}
+	if selectsCustomFields(query) {
+		// The fields resolved by @custom or @lambda don't read predicates.
+		recordUnknownReadPredicates(ctx)
+	}
+
+	req := &dgoapi.Request{Query: qry, Vars: vars, ReadOnly: true}
+	if asOf := query.Operation().AsOf(); asOf != "" {
+		ts, err := posting.ReadTsAt(asOf)
+		if err != nil {
+			return emptyResult(schema.GQLWrapf(err, "couldn't read query %s as of %s",
+				query.ResponseName(), asOf))
+		}
+		req.StartTs = ts
+	}
 
 	queryTimer := newtimer(ctx, &dgraphQueryDuration.OffsetDuration)
 	queryTimer.Start()
-	resp, err := qr.executor.Execute(ctx, &dgoapi.Request{Query: qry, Vars: vars, ReadOnly: true})
+	resp, err := qr.executor.Execute(ctx, req)
 	queryTimer.Stop()
 
 	if err != nil {
@@ -175,6 +193,88 @@ func resolveIntrospection(ctx context.Context, q schema.Query) *Resolved {
 	}
 }
 
+// resolveApolloServiceQuery resolves the _service query, which an Apollo Federation gateway uses
+// to get the schema.
+func resolveApolloServiceQuery(ctx context.Context, q schema.Query) *Resolved {
+	return &Resolved{
+		Data: map[string]interface{}{
+			q.DgraphAlias(): map[string]interface{}{"sdl": q.Operation().Schema().ServiceSDL()},
+		},
+		Field: q,
+	}
+}
+
+// entitiesQueryCompletion puts the entities found by an _entities query in the order of the
+// representations, with a null for the ones that weren't found, as expected by the gateway.
+func entitiesQueryCompletion(ctx context.Context, resolved *Resolved) {
+	query, ok := resolved.Field.(schema.Query)
+	if !ok {
+		return
+	}
+	data, ok := resolved.Data.(map[string]interface{})
+	if !ok {
+		return
+	}
+	entities, ok := data[query.DgraphAlias()].([]interface{})
+	if !ok {
+		return
+	}
+	groups, err := query.RepresentationsArg()
+	if err != nil {
+		// The query couldn't have been rewritten, so there's nothing to order.
+		return
+	}
+
+	// The entities are found by their type and their key. A node found for a type may also be
+	// of another type, so the keys of the types it doesn't have are ignored.
+	found := make(map[string]interface{}, len(entities))
+	for _, e := range entities {
+		entity, ok := e.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		types, _ := entity["dgraph.type"].([]interface{})
+		for _, reps := range groups {
+			key, ok := entity[entityKeyAlias+"."+reps.TypeDefn.Name()]
+			if ok && hasType(types, reps.TypeDefn.DgraphName()) {
+				found[reps.TypeDefn.Name()+"/"+entityKey(key, reps.KeyField.IsID())] = entity
+			}
+		}
+	}
+	var numReps int
+	for _, reps := range groups {
+		numReps += len(reps.KeyVals)
+	}
+	ordered := make([]interface{}, numReps)
+	for _, reps := range groups {
+		for i, val := range reps.KeyVals {
+			ordered[reps.Positions[i]] =
+				found[reps.TypeDefn.Name()+"/"+entityKey(val, reps.KeyField.IsID())]
+		}
+	}
+	data[query.DgraphAlias()] = ordered
+}
+
+func hasType(types []interface{}, name string) bool {
+	for _, t := range types {
+		if t == name {
+			return true
+		}
+	}
+	return false
+}
+
+// entityKey returns the key of an entity as a string that's the same for the value in the
+// representations and the one returned by Dgraph.
+func entityKey(val interface{}, isID bool) string {
+	if id, ok := val.(string); ok && isID {
+		if uid, err := strconv.ParseUint(id, 0, 64); err == nil {
+			return strconv.FormatUint(uid, 10)
+		}
+	}
+	return fmt.Sprint(val)
+}
+
 // converts scalar values received from GraphQL arguments to go string
 // If it is a scalar only possible cases are: string, bool, int64, float64 and nil.
 func convertScalarToString(val interface{}) (string, error) {
@@ -19,6 +19,7 @@ package resolve
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"sort"
 	"strconv"
@@ -34,6 +35,9 @@ import (
 
 type queryRewriter struct{}
 
+// entityKeyAlias is the alias of the key field added to the rewritten _entities query.
+const entityKeyAlias = "dgraph.entityKey"
+
 type authRewriter struct {
 	authVariables map[string]interface{}
 	isWritingAuth bool
@@ -155,11 +159,143 @@ func (qr *queryRewriter) Rewrite(
 		return passwordQuery(gqlQuery, authRw)
 	case schema.AggregateQuery:
 		return aggregateQuery(gqlQuery, authRw), nil
+	case schema.EntitiesQuery:
+		return entitiesQuery(gqlQuery, authRw)
+	case schema.GroupByQuery:
+		return groupByQuery(gqlQuery, authRw)
 	default:
 		return nil, errors.Errorf("unimplemented query type %s", gqlQuery.QueryType())
 	}
 }
 
+// entitiesQuery rewrites the _entities query of Apollo Federation. The representations are
+// grouped by type, and the entities of each type are looked up by their key field in a query of
+// their own. The key field is either the ID field of the type or a field with the @id directive.
+// The key of each entity is also queried, so that entitiesQueryCompletion can put them in the
+// order of the representations.
+//
+//	query {
+//	  _entities(representations: [
+//	    {__typename: "Astronaut", id: "0x1"},
+//	    {__typename: "Mission", code: "apollo-11"}
+//	  ]) {
+//	    ... on Astronaut { name }
+//	    ... on Mission { designation }
+//	  }
+//	}
+//
+// becomes
+//
+//	query {
+//	  Astronaut1 as var(func: uid(0x1)) @filter(type(Astronaut))
+//	  Mission3 as var(func: eq(Mission.code, "apollo-11")) @filter(type(Mission))
+//	  _entities(func: uid(Astronaut1, Mission3)) {
+//	    dgraph.type
+//	    name : Astronaut.name
+//	    designation : Mission.designation
+//	    dgraph.uid : uid
+//	    dgraph.entityKey.Astronaut : uid
+//	    dgraph.entityKey.Mission : Mission.code
+//	  }
+//	}
+func entitiesQuery(query schema.Query, authRw *authRewriter) ([]*gql.GraphQuery, error) {
+	groups, err := query.RepresentationsArg()
+	if err != nil {
+		return nil, err
+	}
+
+	dgQuery := []*gql.GraphQuery{{
+		Attr: query.Name(),
+	}}
+	var lookups []*gql.GraphQuery
+	var lookupVars []gql.Arg
+	var keyChildren []*gql.GraphQuery
+	for _, reps := range groups {
+		typ := reps.TypeDefn
+		rbac := authRw.evaluateStaticRules(typ)
+		if rbac == schema.Negative {
+			continue
+		}
+		lookup, err := entitiesLookup(reps, authRw.varGen.Next(typ, "", "", false))
+		if err != nil {
+			return nil, err
+		}
+		// Each type has its own root query for its auth rules.
+		typeAuthRw := *authRw
+		typeAuthRw.parentVarName = typ.Name() + "Root"
+		lookups = append(lookups, typeAuthRw.addAuthQueries(typ,
+			[]*gql.GraphQuery{lookup}, rbac)...)
+		lookupVars = append(lookupVars, gql.Arg{Value: lookup.Var})
+
+		keyChild := &gql.GraphQuery{Alias: entityKeyAlias + "." + typ.Name(), Attr: "uid"}
+		if !reps.KeyField.IsID() {
+			keyChild.Attr = reps.KeyField.DgraphPredicate()
+		}
+		keyChildren = append(keyChildren, keyChild)
+	}
+	if len(lookups) == 0 {
+		dgQuery[0].Attr = dgQuery[0].Attr + "()"
+		return dgQuery, nil
+	}
+
+	dgQuery[0].Func = &gql.Function{Name: "uid", Args: lookupVars}
+	if authRw != nil && authRw.hasAuthRules {
+		// The auth queries of the fields start from the root query.
+		lookups = append(lookups, &gql.GraphQuery{
+			Var:  authRw.parentVarName,
+			Attr: "var",
+			Func: dgQuery[0].Func,
+		})
+		dgQuery[0].Func = &gql.Function{Name: "uid",
+			Args: []gql.Arg{{Value: authRw.parentVarName}}}
+	}
+
+	selectionAuth := addSelectionSetFrom(dgQuery[0], query, authRw)
+	addUID(dgQuery[0])
+	dgQuery[0].Children = append(dgQuery[0].Children, keyChildren...)
+
+	dgQuery = append(lookups, dgQuery...)
+	return append(dgQuery, selectionAuth...), nil
+}
+
+// entitiesLookup returns the query that looks up the entities of the representations by their
+// key field, and stores them in the variable varName.
+func entitiesLookup(reps *schema.EntityRepresentations,
+	varName string) (*gql.GraphQuery, error) {
+	typ := reps.TypeDefn
+	lookup := &gql.GraphQuery{Attr: "var", Var: varName}
+	if reps.KeyField.IsID() {
+		uids := make([]uint64, 0, len(reps.KeyVals))
+		for _, val := range reps.KeyVals {
+			id, ok := val.(string)
+			uid, err := strconv.ParseUint(id, 0, 64)
+			if !ok || err != nil {
+				return nil, errors.Errorf("ID %v of the representations of %s couldn't be "+
+					"parsed", val, typ.Name())
+			}
+			uids = append(uids, uid)
+		}
+		addUIDFunc(lookup, uids)
+	} else {
+		args := []gql.Arg{{Value: reps.KeyField.DgraphPredicate()}}
+		for _, val := range reps.KeyVals {
+			switch val.(type) {
+			case string, json.Number, int64, float64, bool:
+			default:
+				return nil, errors.Errorf("key %v of the representations of %s isn't a scalar",
+					val, typ.Name())
+			}
+			args = append(args, gql.Arg{Value: maybeQuoteArg("eq", val)})
+		}
+		lookup.Func = &gql.Function{
+			Name: "eq",
+			Args: args,
+		}
+	}
+	addTypeFilter(lookup, typ)
+	return lookup, nil
+}
+
 func aggregateQuery(query schema.Query, authRw *authRewriter) []*gql.GraphQuery {
 
 	// Get the type which the count query is written for
@@ -71,6 +71,34 @@ func TestQueryRewriting(t *testing.T) {
 	}
 }
 
+func TestEntitiesQueryCompletion(t *testing.T) {
+	gqlSchema := test.LoadSchemaFromFile(t, "schema.graphql")
+	op, err := gqlSchema.Operation(&schema.Request{Query: `query {
+		_entities(representations: [{__typename: "Mission", code: "apollo-11"},
+			{__typename: "Astronaut", id: "0x1"}, {__typename: "Astronaut", id: "0x2"},
+			{__typename: "Mission", code: "apollo-13"}]) {
+			... on Astronaut { name }
+			... on Mission { designation }
+		}
+	}`})
+	require.NoError(t, err)
+	query := test.GetQuery(t, op)
+
+	neil := map[string]interface{}{"dgraph.type": []interface{}{"Astronaut"}, "name": "Neil",
+		"dgraph.entityKey.Astronaut": "0x1", "dgraph.entityKey.Mission": nil}
+	// 0x2 was found as a Mission, but it isn't an Astronaut.
+	apollo11 := map[string]interface{}{"dgraph.type": []interface{}{"Mission"},
+		"designation": "Apollo 11", "dgraph.entityKey.Astronaut": "0x2",
+		"dgraph.entityKey.Mission": "apollo-11"}
+	resolved := &Resolved{
+		Data:  map[string]interface{}{"_entities": []interface{}{apollo11, neil}},
+		Field: query,
+	}
+	entitiesQueryCompletion(context.Background(), resolved)
+	require.Equal(t, map[string]interface{}{"_entities": []interface{}{apollo11, neil, nil, nil}},
+		resolved.Data)
+}
+
 type HTTPRewritingCase struct {
 	Name             string
 	GQLQuery         string
@@ -130,6 +130,7 @@ type ResultCompleter interface {
 type RequestResolver struct {
 	schema    schema.Schema
 	resolvers ResolverFactory
+	cache     *ResponseCache
 }
 
 // A resolverFactory is the main implementation of ResolverFactory.  It stores a
@@ -272,6 +273,24 @@ func (rf *resolverFactory) WithConventionResolvers(
 		})
 	}
 
+	for _, q := range s.Queries(schema.GroupByQuery) {
+		rf.WithQueryResolver(q, func(q schema.Query) QueryResolver {
+			return NewQueryResolver(fns.Qrw, fns.Ex, CompletionFunc(groupByCompletion))
+		})
+	}
+
+	for _, q := range s.Queries(schema.EntitiesQuery) {
+		rf.WithQueryResolver(q, func(q schema.Query) QueryResolver {
+			return NewQueryResolver(fns.Qrw, fns.Ex, CompletionFunc(entitiesQueryCompletion))
+		})
+	}
+
+	for _, q := range s.Queries(schema.ApolloServiceQuery) {
+		rf.WithQueryResolver(q, func(q schema.Query) QueryResolver {
+			return QueryResolverFunc(resolveApolloServiceQuery)
+		})
+	}
+
 	for _, q := range s.Queries(schema.HTTPQuery) {
 		rf.WithQueryResolver(q, func(q schema.Query) QueryResolver {
 			return NewHTTPQueryResolver(&http.Client{
@@ -398,6 +417,13 @@ func New(s schema.Schema, resolverFactory ResolverFactory) *RequestResolver {
 	}
 }
 
+// WithResponseCache makes the resolver cache the responses of the queries with a @cacheControl
+// directive in the given cache.
+func (r *RequestResolver) WithResponseCache(cache *ResponseCache) *RequestResolver {
+	r.cache = cache
+	return r
+}
+
 // Resolve processes r.GqlReq and returns a GraphQL response.
 // r.GqlReq should be set with a request before Resolve is called
 // and a schema and backend Dgraph should have been added.
@@ -437,6 +463,14 @@ func (r *RequestResolver) Resolve(ctx context.Context, gqlReq *schema.Request) *
 	if err != nil {
 		return schema.ErrorResponse(err)
 	}
+	// The Dgraph requests of the operation are logged with its name when they're slow.
+	ctx = x.WithGraphQLOperation(ctx, op.Name())
+
+	resp.Extensions.Cost = op.Complexity().Cost
+	if err := schema.CheckCost(op.Complexity()); err != nil {
+		resp.WithError(err)
+		return resp
+	}
 
 	if glog.V(3) {
 		// don't log the introspection queries they are sent too frequently
@@ -453,7 +487,7 @@ func (r *RequestResolver) Resolve(ctx context.Context, gqlReq *schema.Request) *
 	}
 
 	// resolveQueries will resolve user's queries.
-	resolveQueries := func() {
+	resolveQueries := func(ctx context.Context) {
 		// Queries run in parallel and are independent of each other: e.g.
 		// an error in one query, doesn't affect the others.
 
@@ -499,7 +533,7 @@ func (r *RequestResolver) Resolve(ctx context.Context, gqlReq *schema.Request) *
 			resp.Header.Set(schema.CacheControlHeader, op.CacheControl())
 			resp.Header.Set("Vary", "Accept-Encoding")
 		}
-		resolveQueries()
+		r.resolveCachedQueries(ctx, gqlReq, op, resp, resolveQueries)
 	case op.IsMutation():
 		// A mutation operation can contain any number of mutation fields.  Those should be executed
 		// serially.
@@ -530,12 +564,47 @@ func (r *RequestResolver) Resolve(ctx context.Context, gqlReq *schema.Request) *
 			addResult(resp, res)
 		}
 	case op.IsSubscription():
-		resolveQueries()
+		resolveQueries(ctx)
 	}
 
 	return resp
 }
 
+// resolveCachedQueries resolves the queries of op with resolveQueries, unless their response
+// is in the response cache. Only the responses without errors are cached.
+func (r *RequestResolver) resolveCachedQueries(ctx context.Context, gqlReq *schema.Request,
+	op schema.Operation, resp *schema.Response, resolveQueries func(context.Context)) {
+
+	if r.cache == nil || op.CacheControl() == "" {
+		resolveQueries(ctx)
+		return
+	}
+	maxAge, err := authorization.ParseMaxAge(op.CacheControl())
+	if err != nil || maxAge <= 0 {
+		resolveQueries(ctx)
+		return
+	}
+	key, err := responseCacheKey(ctx, gqlReq)
+	if err != nil {
+		resolveQueries(ctx)
+		return
+	}
+	if data, ok := r.cache.get(key); ok {
+		resp.Data.Write(data)
+		return
+	}
+
+	// Take the sequence number before running the queries, so that the changes made while
+	// they run invalidate their response.
+	seq := r.cache.currentSeq()
+	ctx, rp := WithReadPredicates(ctx)
+	resolveQueries(ctx)
+	if preds, ok := rp.List(); ok && len(resp.Errors) == 0 {
+		data := append([]byte{}, resp.Data.Bytes()...)
+		r.cache.set(key, data, preds, seq, time.Duration(maxAge)*time.Second)
+	}
+}
+
 // ValidateSubscription will check the given subscription query is valid or not.
 func (r *RequestResolver) ValidateSubscription(req *schema.Request) error {
 	if r.schema == nil {
@@ -574,6 +643,17 @@ func validateCustomFieldsRecursively(field schema.Field) error {
 	return nil
 }
 
+// selectsCustomFields returns whether any of the fields selected by the given field, at any
+// depth, is resolved by @custom or @lambda.
+func selectsCustomFields(field schema.Field) bool {
+	for _, f := range field.SelectionSet() {
+		if has, _ := f.HasCustomDirective(); has || selectsCustomFields(f) {
+			return true
+		}
+	}
+	return false
+}
+
 func addResult(resp *schema.Response, res *Resolved) {
 	// Errors should report the "path" into the result where the error was found.
 	//
@@ -2056,6 +2136,8 @@ func (hr *httpResolver) Resolve(ctx context.Context, field schema.Field) *Resolv
 	stop := x.SpanTimer(span, "resolveHTTP")
 	defer stop()
 
+	// The response of the remote endpoint doesn't depend on the predicates.
+	recordUnknownReadPredicates(ctx)
 	resolved := hr.rewriteAndExecute(ctx, field)
 	hr.resultCompleter.Complete(ctx, resolved)
 	return resolved
@@ -0,0 +1,319 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resolve
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/dgraph/gql"
+	"github.com/dgraph-io/dgraph/graphql/authorization"
+	"github.com/dgraph-io/dgraph/graphql/schema"
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/dgraph-io/ristretto"
+	"github.com/pkg/errors"
+)
+
+const readPredicatesKey resolveCtxKey = "readPredicates"
+
+// ResponseCache caches the responses of the queries with a @cacheControl directive, for at most
+// their maxAge. The responses are keyed by the operation, its variables and the claims of the
+// JWT, and a cached response is dropped as soon as a commit writes one of the predicates that
+// its queries read.
+//
+// The changes are tracked lazily: every change increments a sequence number, and a cached
+// response is only valid if none of the predicates it read changed after the sequence number
+// at which its queries started.
+type ResponseCache struct {
+	cache *ristretto.Cache
+
+	sync.RWMutex
+	seq uint64
+	// changed is the sequence number of the last change of each predicate.
+	changed map[string]uint64
+	// remoteChanged is the sequence number of the last commit that could have written the
+	// predicates served by the other groups.
+	remoteChanged uint64
+	// resetSeq is the sequence number of the last change to all the predicates.
+	resetSeq uint64
+
+	// servesPredicate tells if a predicate is served by this Alpha's group.
+	servesPredicate func(string) bool
+}
+
+type cachedResponse struct {
+	data   []byte
+	preds  []string
+	remote bool
+	seq    uint64
+}
+
+// NewResponseCache returns a ResponseCache that holds at most maxBytes of responses. It's
+// notified of the commits to the data by the worker.
+func NewResponseCache(maxBytes int64) (*ResponseCache, error) {
+	c, err := newResponseCache(maxBytes, worker.ServesPredicate)
+	if err != nil {
+		return nil, err
+	}
+	worker.AddCommitWatcher(c)
+	return c, nil
+}
+
+func newResponseCache(maxBytes int64, servesPredicate func(string) bool) (*ResponseCache, error) {
+	cache, err := ristretto.NewCache(&ristretto.Config{
+		// Assume 1KB per response to size the admission counters.
+		NumCounters: 10 * (maxBytes >> 10),
+		MaxCost:     maxBytes,
+		BufferItems: 64,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "while creating the GraphQL response cache")
+	}
+	return &ResponseCache{
+		cache:           cache,
+		changed:         make(map[string]uint64),
+		servesPredicate: servesPredicate,
+	}, nil
+}
+
+// Changed implements worker.CommitWatcher.
+func (c *ResponseCache) Changed(preds []string, remote bool) {
+	c.Lock()
+	defer c.Unlock()
+	c.seq++
+	for _, pred := range preds {
+		c.changed[pred] = c.seq
+	}
+	if remote {
+		c.remoteChanged = c.seq
+	}
+}
+
+// Reset implements worker.CommitWatcher. It's also called when the GraphQL schema changes.
+func (c *ResponseCache) Reset() {
+	c.Lock()
+	defer c.Unlock()
+	c.seq++
+	c.resetSeq = c.seq
+	// The predicates changed before the reset can't invalidate anything anymore.
+	c.changed = make(map[string]uint64)
+}
+
+func (c *ResponseCache) currentSeq() uint64 {
+	c.RLock()
+	defer c.RUnlock()
+	return c.seq
+}
+
+func (c *ResponseCache) get(key string) ([]byte, bool) {
+	val, ok := c.cache.Get(key)
+	if !ok {
+		return nil, false
+	}
+	resp := val.(*cachedResponse)
+
+	c.RLock()
+	defer c.RUnlock()
+	if c.resetSeq > resp.seq {
+		return nil, false
+	}
+	for _, pred := range resp.preds {
+		if c.changed[pred] > resp.seq {
+			return nil, false
+		}
+	}
+	if c.remoteChanged > resp.seq {
+		// A predicate read by the queries may have moved away from this group since.
+		if resp.remote || !c.servesAll(resp.preds) {
+			return nil, false
+		}
+	}
+	return resp.data, true
+}
+
+// set caches the response of the queries that read preds, and started at sequence number seq.
+func (c *ResponseCache) set(key string, data []byte, preds []string, seq uint64,
+	maxAge time.Duration) {
+
+	resp := &cachedResponse{
+		data:   data,
+		preds:  preds,
+		remote: !c.servesAll(preds),
+		seq:    seq,
+	}
+	c.cache.SetWithTTL(key, resp, int64(len(key)+len(data)), maxAge)
+}
+
+func (c *ResponseCache) servesAll(preds []string) bool {
+	for _, pred := range preds {
+		if !c.servesPredicate(pred) {
+			return false
+		}
+	}
+	return true
+}
+
+// responseCacheKey returns the key of the response to the request, made of the operation, its
+// variables and the claims of the JWT. It returns an error if the JWT isn't valid.
+func responseCacheKey(ctx context.Context, req *schema.Request) (string, error) {
+	claims, err := authorization.ExtractCustomClaims(ctx)
+	if err != nil {
+		return "", err
+	}
+	b, err := json.Marshal(struct {
+		Query         string
+		OperationName string
+		Variables     map[string]interface{}
+		Claims        map[string]interface{}
+	}{req.Query, req.OperationName, req.Variables, claims.AuthVariables})
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(b)
+	return string(hash[:]), nil
+}
+
+// ReadPredicates collects the predicates read by the Dgraph queries of a GraphQL operation.
+type ReadPredicates struct {
+	sync.Mutex
+	preds map[string]struct{}
+	// unknown is set if the predicates of a query couldn't be found.
+	unknown bool
+}
+
+// WithReadPredicates returns a context in which the resolver collects the predicates read by
+// the Dgraph queries it runs.
+func WithReadPredicates(ctx context.Context) (context.Context, *ReadPredicates) {
+	rp := &ReadPredicates{preds: make(map[string]struct{})}
+	return context.WithValue(ctx, readPredicatesKey, rp), rp
+}
+
+// recordReadPredicates adds the predicates read by the queries to the ones collected in ctx, if
+// any.
+func recordReadPredicates(ctx context.Context, queries []*gql.GraphQuery) {
+	rp, ok := ctx.Value(readPredicatesKey).(*ReadPredicates)
+	if !ok {
+		return
+	}
+	rp.Lock()
+	defer rp.Unlock()
+	rp.addQueries(queries)
+}
+
+// RecordReadPredicatesOfDQL adds the predicates read by the DQL query to the ones collected in
+// ctx, if any.
+func RecordReadPredicatesOfDQL(ctx context.Context, query string, vars map[string]string) {
+	rp, ok := ctx.Value(readPredicatesKey).(*ReadPredicates)
+	if !ok {
+		return
+	}
+	res, err := gql.Parse(gql.Request{Str: query, Variables: vars})
+	rp.Lock()
+	defer rp.Unlock()
+	if err != nil {
+		rp.unknown = true
+		return
+	}
+	rp.addQueries(res.Query)
+}
+
+// recordUnknownReadPredicates records that the response doesn't only depend on the predicates
+// read, e.g. because of the fields resolved by @custom or @lambda, if the predicates are
+// collected in ctx.
+func recordUnknownReadPredicates(ctx context.Context) {
+	rp, ok := ctx.Value(readPredicatesKey).(*ReadPredicates)
+	if !ok {
+		return
+	}
+	rp.Lock()
+	defer rp.Unlock()
+	rp.unknown = true
+}
+
+// List returns the predicates collected, and false if some couldn't be found.
+func (rp *ReadPredicates) List() ([]string, bool) {
+	rp.Lock()
+	defer rp.Unlock()
+	preds := make([]string, 0, len(rp.preds))
+	for pred := range rp.preds {
+		preds = append(preds, pred)
+	}
+	return preds, !rp.unknown
+}
+
+func (rp *ReadPredicates) add(attr string) {
+	// The counts and aggregates of the rewritten queries are written as functions, e.g.
+	// count(uid), min(Post.score) or max(val(scoreVar)).
+	if i := strings.IndexByte(attr, '('); i >= 0 && strings.HasSuffix(attr, ")") {
+		attr = attr[i+1 : len(attr)-1]
+		if strings.HasPrefix(attr, "val(") {
+			return
+		}
+	}
+	switch attr {
+	case "", "uid", "val", "var", "expand":
+	default:
+		// The reverse edges of a predicate are changed by the commits to the predicate.
+		rp.preds[strings.TrimPrefix(attr, "~")] = struct{}{}
+	}
+}
+
+func (rp *ReadPredicates) addFunction(f *gql.Function) {
+	if f == nil {
+		return
+	}
+	if f.Name == "type" {
+		rp.add("dgraph.type")
+	}
+	rp.add(f.Attr)
+}
+
+func (rp *ReadPredicates) addFilter(f *gql.FilterTree) {
+	if f == nil {
+		return
+	}
+	rp.addFunction(f.Func)
+	for _, child := range f.Child {
+		rp.addFilter(child)
+	}
+}
+
+func (rp *ReadPredicates) addQueries(queries []*gql.GraphQuery) {
+	for _, gq := range queries {
+		if gq == nil {
+			continue
+		}
+		if gq.Expand != "" {
+			// The predicates expanded depend on the types of the nodes read.
+			rp.unknown = true
+		}
+		rp.add(gq.Attr)
+		for _, attr := range gq.GroupbyAttrs {
+			rp.add(attr.Attr)
+		}
+		rp.addFunction(gq.Func)
+		rp.addFilter(gq.Filter)
+		for _, order := range gq.Order {
+			rp.add(order.Attr)
+		}
+		rp.addQueries(gq.Children)
+	}
+}
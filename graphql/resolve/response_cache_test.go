@@ -0,0 +1,210 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resolve
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	dgoapi "github.com/dgraph-io/dgo/v200/protos/api"
+	"github.com/dgraph-io/dgraph/gql"
+	"github.com/dgraph-io/dgraph/graphql/schema"
+	"github.com/dgraph-io/dgraph/graphql/test"
+	"github.com/stretchr/testify/require"
+)
+
+type countingExecutor struct {
+	executor
+	queries int
+}
+
+func (ex *countingExecutor) Execute(ctx context.Context, req *dgoapi.Request) (
+	*dgoapi.Response, error) {
+	ex.queries++
+	return ex.executor.Execute(ctx, req)
+}
+
+func TestResponseCacheInvalidation(t *testing.T) {
+	local := map[string]bool{"Author.name": true, "dgraph.type": true}
+	c, err := newResponseCache(1<<20, func(pred string) bool { return local[pred] })
+	require.NoError(t, err)
+
+	set := func(key string, preds ...string) {
+		c.set(key, []byte(key), preds, c.currentSeq(), time.Minute)
+		c.cache.Wait()
+	}
+	cached := func(key string) bool {
+		_, ok := c.get(key)
+		return ok
+	}
+
+	set("name", "Author.name", "dgraph.type")
+	set("title", "Post.title")
+	require.True(t, cached("name"))
+	require.True(t, cached("title"))
+
+	c.Changed([]string{"Post.text"}, false)
+	require.True(t, cached("name"))
+	require.True(t, cached("title"))
+
+	// Post.title isn't served by this group, so any commit to the other groups drops it.
+	c.Changed(nil, true)
+	require.True(t, cached("name"))
+	require.False(t, cached("title"))
+
+	c.Changed([]string{"Author.name"}, false)
+	require.False(t, cached("name"))
+
+	// The changes made while the queries run invalidate their response.
+	seq := c.currentSeq()
+	c.Changed([]string{"Author.name"}, false)
+	c.set("name", []byte("name"), []string{"Author.name"}, seq, time.Minute)
+	c.cache.Wait()
+	require.False(t, cached("name"))
+
+	set("name", "Author.name")
+	c.Reset()
+	require.False(t, cached("name"))
+}
+
+func TestReadPredicates(t *testing.T) {
+	res, err := gql.Parse(gql.Request{Str: `query {
+		q(func: type(Author)) @filter(eq(Author.name, "A")) {
+			Author.posts (orderasc: Post.title) {
+				uid
+				Post.text
+			}
+			count(~Post.coauthors)
+		}
+		r(func: has(~Comment.author)) {
+			~Post.author {
+				Post.title
+			}
+		}
+	}`})
+	require.NoError(t, err)
+
+	ctx, rp := WithReadPredicates(context.Background())
+	recordReadPredicates(ctx, res.Query)
+	preds, ok := rp.List()
+	require.True(t, ok)
+	sort.Strings(preds)
+	require.Equal(t, []string{"Author.name", "Author.posts", "Comment.author", "Post.author",
+		"Post.coauthors", "Post.text", "Post.title", "dgraph.type"}, preds)
+
+	RecordReadPredicatesOfDQL(ctx, "query {", nil)
+	_, ok = rp.List()
+	require.False(t, ok)
+
+	// The predicates read by expand() aren't known.
+	ctx, rp = WithReadPredicates(context.Background())
+	RecordReadPredicatesOfDQL(ctx, `{ q(func: uid(0x1)) { expand(_all_) } }`, nil)
+	preds, ok = rp.List()
+	require.False(t, ok)
+	require.Empty(t, preds)
+	ctx, rp = WithReadPredicates(context.Background())
+	RecordReadPredicatesOfDQL(ctx, `{ q(func: uid(0x1)) { expand(Author) { Post.title } } }`,
+		nil)
+	preds, ok = rp.List()
+	require.False(t, ok)
+	require.Equal(t, []string{"Post.title"}, preds)
+}
+
+func TestReadPredicatesOfRewrittenQueries(t *testing.T) {
+	ctx, rp := WithReadPredicates(context.Background())
+	recordReadPredicates(ctx, []*gql.GraphQuery{
+		{
+			Alias:        "groupByPost",
+			IsGroupby:    true,
+			GroupbyAttrs: []gql.GroupByAttr{{Attr: "Post.category", Alias: "category"}},
+			Children: []*gql.GraphQuery{
+				{Alias: "count", Attr: "count(uid)"},
+				{Alias: "scoreMin", Attr: "min(Post.score)"},
+			},
+		},
+		{
+			Attr: "aggregatePost()",
+			Children: []*gql.GraphQuery{
+				{Alias: "scoreMax", Attr: "max(val(scoreVar))"},
+			},
+		},
+	})
+	preds, ok := rp.List()
+	require.True(t, ok)
+	sort.Strings(preds)
+	require.Equal(t, []string{"Post.category", "Post.score"}, preds)
+}
+
+func TestResolverCachesResponses(t *testing.T) {
+	gqlSchema := test.LoadSchemaFromString(t, testGQLSchema)
+	ex := &countingExecutor{
+		executor: executor{resp: `{ "getAuthor": [ { "uid": "0x1", "name": "A.N. Author" } ] }`},
+	}
+	c, err := newResponseCache(1<<20, func(string) bool { return true })
+	require.NoError(t, err)
+	resolver := New(gqlSchema, NewResolverFactory(nil, nil).WithConventionResolvers(gqlSchema,
+		&ResolverFns{Qrw: NewQueryRewriter(), Ex: ex})).WithResponseCache(c)
+
+	resolve := func(query string) string {
+		resp := resolver.Resolve(context.Background(), &schema.Request{Query: query})
+		require.Nil(t, resp.Errors)
+		c.cache.Wait()
+		return resp.Data.String()
+	}
+	cachedQuery := `query @cacheControl(maxAge: 60) { getAuthor(id: "0x1") { name } }`
+
+	expected := `{"getAuthor": {"name": "A.N. Author"}}`
+	require.JSONEq(t, expected, resolve(cachedQuery))
+	require.JSONEq(t, expected, resolve(cachedQuery))
+	require.Equal(t, 1, ex.queries)
+
+	// Only the queries with a @cacheControl directive are cached.
+	resolve(`query { getAuthor(id: "0x1") { name } }`)
+	resolve(`query { getAuthor(id: "0x1") { name } }`)
+	require.Equal(t, 3, ex.queries)
+
+	c.Changed([]string{"Post.title"}, false)
+	require.JSONEq(t, expected, resolve(cachedQuery))
+	require.Equal(t, 3, ex.queries)
+
+	c.Changed([]string{"Author.name"}, false)
+	require.JSONEq(t, expected, resolve(cachedQuery))
+	require.Equal(t, 4, ex.queries)
+}
+
+func TestReadPredicatesOfCustomFields(t *testing.T) {
+	gqlSchema := test.LoadSchemaFromFile(t, "schema.graphql")
+	resolver := New(gqlSchema, NewResolverFactory(nil, nil).WithConventionResolvers(gqlSchema,
+		&ResolverFns{Qrw: NewQueryRewriter(), Ex: &executor{resp: `{ "queryComment": [] }`}}))
+
+	tcases := map[string]bool{
+		`query { queryComment { title } }`:                        true,
+		`query { queryComment { title content } }`:                false,
+		`query { queryComment { relatedUsers { name } } }`:        false,
+		`query { myFavoriteMovies(id: "0x1", name: "x") { id } }`: false,
+	}
+	for query, known := range tcases {
+		t.Run(query, func(t *testing.T) {
+			ctx, rp := WithReadPredicates(context.Background())
+			resolver.Resolve(ctx, &schema.Request{Query: query})
+			_, ok := rp.List()
+			require.Equal(t, known, ok)
+		})
+	}
+}
@@ -52,6 +52,7 @@ const (
 	generateQueryField      = "query"
 	generatePasswordField   = "password"
 	generateAggregateField  = "aggregate"
+	generateGroupByField    = "groupBy"
 	generateMutationArg     = "mutation"
 	generateAddField        = "add"
 	generateUpdateField     = "update"
@@ -64,6 +65,21 @@ const (
 	cacheControlDirective = "cacheControl"
 	CacheControlHeader    = "Cache-Control"
 
+	asOfDirective = "asOf"
+	asOfArg       = "ts"
+
+	costDirective = "cost"
+	costWeightArg = "weight"
+
+	// Apollo Federation directives and the fields they add to the Query type.
+	apolloKeyDirective      = "key"
+	apolloKeyArg            = "fields"
+	apolloExternalDirective = "external"
+	apolloExtendsDirective  = "extends"
+	apolloEntitiesQuery     = "_entities"
+	apolloServiceQuery      = "_service"
+	apolloEntityUnion       = "_Entity"
+
 	// custom directive args and fields
 	dqlArg      = "dql"
 	httpArg     = "http"
@@ -170,6 +186,12 @@ enum Mode {
 	SINGLE
 }
 
+enum DateTimeInterval {
+	day
+	month
+	year
+}
+
 input CustomHTTP {
 	url: String!
 	method: HTTPMethod!
@@ -252,6 +274,7 @@ input GenerateQueryParams {
 	query: Boolean
 	password: Boolean
 	aggregate: Boolean
+	groupBy: Boolean
 }
 
 input GenerateMutationParams {
@@ -276,7 +299,9 @@ directive @custom(http: CustomHTTP, dql: String) on FIELD_DEFINITION
 directive @remote on OBJECT | INTERFACE | UNION | INPUT_OBJECT | ENUM
 directive @cascade(fields: [String]) on FIELD
 directive @lambda on FIELD_DEFINITION
+directive @cost(weight: Int!) on FIELD_DEFINITION
 directive @cacheControl(maxAge: Int!) on QUERY
+directive @asOf(ts: String!) on QUERY
 directive @generate(
 	query: GenerateQueryParams,
 	mutation: GenerateMutationParams,
@@ -346,6 +371,22 @@ input StringHashFilter {
 	eq: String
 	in: [String]
 }
+`
+
+	// apolloSchemaExtras is added to the input schema along with schemaExtras, but it's only
+	// printed in the generated schema if a type has a @key directive, so that the Apollo
+	// Federation types and directives are only there for the schemas that use them.
+	apolloSchemaExtras = `
+scalar _Any
+scalar _FieldSet
+
+type _Service {
+	sdl: String
+}
+
+directive @external on FIELD_DEFINITION
+directive @key(fields: _FieldSet!) on OBJECT
+directive @extends on OBJECT
 `
 )
 
@@ -511,6 +552,11 @@ var directiveValidators = map[string]directiveValidator{
 	deprecatedDirective:   ValidatorNoOp,
 	lambdaDirective:       lambdaDirectiveValidation,
 	generateDirective:     ValidatorNoOp,
+	costDirective:         costValidation,
+
+	apolloKeyDirective:      ValidatorNoOp, // Validated by apolloKeyValidation
+	apolloExtendsDirective:  ValidatorNoOp, // Validated by apolloKeyValidation
+	apolloExternalDirective: apolloExternalValidation,
 }
 
 // directiveLocationMap stores the directives and their locations for the ones which can be
@@ -528,6 +574,11 @@ var directiveLocationMap = map[string]map[ast.DefinitionKind]bool{
 		ast.InputObject: true, ast.Enum: true},
 	cascadeDirective:  nil,
 	generateDirective: {ast.Object: true, ast.Interface: true},
+	costDirective:     nil,
+
+	apolloKeyDirective:      {ast.Object: true},
+	apolloExtendsDirective:  {ast.Object: true},
+	apolloExternalDirective: nil,
 }
 
 // Struct to store parameters of @generate directive
@@ -536,6 +587,7 @@ type GenerateDirectiveParams struct {
 	generateFilterQuery    bool
 	generatePasswordQuery  bool
 	generateAggregateQuery bool
+	generateGroupByQuery   bool
 	generateAddMutation    bool
 	generateUpdateMutation bool
 	generateDeleteMutation bool
@@ -548,6 +600,7 @@ func parseGenerateDirectiveParams(defn *ast.Definition) *GenerateDirectiveParams
 		generateFilterQuery:    true,
 		generatePasswordQuery:  true,
 		generateAggregateQuery: true,
+		generateGroupByQuery:   false,
 		generateAddMutation:    true,
 		generateUpdateMutation: true,
 		generateDeleteMutation: true,
@@ -577,6 +630,11 @@ func parseGenerateDirectiveParams(defn *ast.Definition) *GenerateDirectiveParams
 					ret.generateAggregateQuery = aggregateFieldVal.(bool)
 				}
 			}
+			if groupByField := queryArg.Value.Children.ForName(generateGroupByField); groupByField != nil {
+				if groupByFieldVal, err := groupByField.Value(nil); err == nil {
+					ret.generateGroupByQuery = groupByFieldVal.(bool)
+				}
+			}
 		}
 
 		if mutationArg := dir.Arguments.ForName(generateMutationArg); mutationArg != nil {
@@ -628,10 +686,10 @@ func copyAstFieldDef(src *ast.FieldDefinition) *ast.FieldDefinition {
 	return dst
 }
 
-// expandSchema adds schemaExtras to the doc and adds any fields inherited from interfaces into
+// expandSchema adds schemaExtras and apolloSchemaExtras to the doc and adds any fields inherited from interfaces into
 // implementing types
 func expandSchema(doc *ast.SchemaDocument) *gqlerror.Error {
-	docExtras, gqlErr := parser.ParseSchema(&ast.Source{Input: schemaExtras})
+	docExtras, gqlErr := parser.ParseSchema(&ast.Source{Input: schemaExtras + apolloSchemaExtras})
 	if gqlErr != nil {
 		x.Panic(gqlErr)
 	}
@@ -896,12 +954,17 @@ func completeSchema(sch *ast.Schema, definitions []string) {
 		addTypeOrderable(sch, defn)
 		addFieldFilters(sch, defn)
 		addAggregationResultType(sch, defn)
+		if params.generateGroupByQuery {
+			addGroupByTypes(sch, defn)
+		}
 		addQueries(sch, defn, params)
 		addTypeHasFilter(sch, defn)
 		// We need to call this at last as aggregateFields
 		// should not be part of HasFilter or UpdatePayloadType etc.
 		addAggregateFields(sch, defn)
 	}
+
+	addApolloQueries(sch, definitions)
 }
 
 func cleanupInput(sch *ast.Schema, def *ast.Definition, seen map[string]bool) {
@@ -1450,6 +1513,18 @@ func isSummable(fld *ast.FieldDefinition) bool {
 	return summable[fld.Type.NamedType] && !hasCustomOrLambda(fld)
 }
 
+// Returns true if the nodes of a type can be grouped by the field, i.e. if it's a single value of
+// a scalar type other than ID, or of an enum.
+func isGroupable(sch *ast.Schema, fld *ast.FieldDefinition) bool {
+	if fld.Type.NamedType == "" || hasCustomOrLambda(fld) {
+		return false
+	}
+	if typ := sch.Types[fld.Type.NamedType]; typ != nil && typ.Kind == ast.Enum {
+		return true
+	}
+	return orderable[fld.Type.NamedType] || fld.Type.NamedType == "Boolean"
+}
+
 func hasID(defn *ast.Definition) bool {
 	return fieldAny(defn.Fields, isID)
 }
@@ -1729,6 +1804,80 @@ func addAggregationResultType(schema *ast.Schema, defn *ast.Definition) {
 	}
 }
 
+// addGroupByTypes adds the types used by the groupBy query of defn, if it has fields that its
+// nodes can be grouped by. For a type Post, with fields category: String and score: Int, these
+// are
+//
+//	enum PostGroupable { category score }
+//	input PostGroupBy { field: PostGroupable!, interval: DateTimeInterval }
+//	type PostGroupByResult { category: String, score: Int, count: Int, scoreMin: Int, ... }
+//	input PostGroupByHaving { count: IntFilter, scoreMin: IntFilter, ..., scoreAvg: FloatFilter }
+//
+// The result type has the fields of PostAggregateResult, which are computed for every group.
+func addGroupByTypes(schema *ast.Schema, defn *ast.Definition) {
+	groupableName := defn.Name + "Groupable"
+	groupable := &ast.Definition{
+		Kind: ast.Enum,
+		Name: groupableName,
+	}
+	var resultFields ast.FieldList
+	for _, fld := range defn.Fields {
+		if isGroupable(schema, fld) {
+			groupable.EnumValues = append(groupable.EnumValues,
+				&ast.EnumValueDefinition{Name: fld.Name})
+			resultFields = append(resultFields, &ast.FieldDefinition{
+				Name: fld.Name,
+				Type: &ast.Type{NamedType: fld.Type.NamedType},
+			})
+		}
+	}
+	if len(resultFields) == 0 {
+		return
+	}
+	schema.Types[groupableName] = groupable
+
+	schema.Types[defn.Name+"GroupBy"] = &ast.Definition{
+		Kind: ast.InputObject,
+		Name: defn.Name + "GroupBy",
+		Fields: ast.FieldList{
+			{Name: "field", Type: &ast.Type{NamedType: groupableName, NonNull: true}},
+			{Name: "interval", Type: &ast.Type{NamedType: "DateTimeInterval"}},
+		},
+	}
+
+	for _, fld := range schema.Types[defn.Name+"AggregateResult"].Fields {
+		resultFields = append(resultFields, &ast.FieldDefinition{
+			Name: fld.Name,
+			Type: &ast.Type{NamedType: fld.Type.NamedType},
+		})
+	}
+	schema.Types[defn.Name+"GroupByResult"] = &ast.Definition{
+		Kind:   ast.Object,
+		Name:   defn.Name + "GroupByResult",
+		Fields: resultFields,
+	}
+
+	// The groups can be filtered by their count, and by the aggregates of the fields that can
+	// be summed.
+	having := ast.FieldList{{Name: "count", Type: &ast.Type{NamedType: "IntFilter"}}}
+	for _, fld := range defn.Fields {
+		if !isSummable(fld) {
+			continue
+		}
+		filter := &ast.Type{NamedType: fld.Type.NamedType + "Filter"}
+		having = append(having,
+			&ast.FieldDefinition{Name: fld.Name + "Min", Type: filter},
+			&ast.FieldDefinition{Name: fld.Name + "Max", Type: filter},
+			&ast.FieldDefinition{Name: fld.Name + "Sum", Type: filter},
+			&ast.FieldDefinition{Name: fld.Name + "Avg", Type: &ast.Type{NamedType: "FloatFilter"}})
+	}
+	schema.Types[defn.Name+"GroupByHaving"] = &ast.Definition{
+		Kind:   ast.InputObject,
+		Name:   defn.Name + "GroupByHaving",
+		Fields: having,
+	}
+}
+
 func addGetQuery(schema *ast.Schema, defn *ast.Definition, generateSubscription bool) {
 	hasIDField := hasID(defn)
 	hasXIDField := hasXID(defn)
@@ -1810,6 +1959,35 @@ func addAggregationQuery(schema *ast.Schema, defn *ast.Definition, generateSubsc
 
 }
 
+func addGroupByQuery(schema *ast.Schema, defn *ast.Definition) {
+	if schema.Types[defn.Name+"GroupByResult"] == nil {
+		return
+	}
+	qry := &ast.FieldDefinition{
+		Name: "groupBy" + defn.Name,
+		Type: &ast.Type{
+			Elem: &ast.Type{
+				NamedType: defn.Name + "GroupByResult",
+			},
+		},
+	}
+	addFilterArgumentForField(schema, qry, defn.Name)
+	qry.Arguments = append(qry.Arguments,
+		&ast.ArgumentDefinition{
+			Name: "by",
+			Type: &ast.Type{
+				Elem:    &ast.Type{NamedType: defn.Name + "GroupBy", NonNull: true},
+				NonNull: true,
+			},
+		},
+		&ast.ArgumentDefinition{
+			Name: "having",
+			Type: &ast.Type{NamedType: defn.Name + "GroupByHaving"},
+		})
+
+	schema.Query.Fields = append(schema.Query.Fields, qry)
+}
+
 func addPasswordQuery(schema *ast.Schema, defn *ast.Definition) {
 	hasIDField := hasID(defn)
 	hasXIDField := hasXID(defn)
@@ -1864,6 +2042,51 @@ func addQueries(schema *ast.Schema, defn *ast.Definition, params *GenerateDirect
 	if params.generateAggregateQuery {
 		addAggregationQuery(schema, defn, params.generateSubscription)
 	}
+
+	if params.generateGroupByQuery {
+		addGroupByQuery(schema, defn)
+	}
+}
+
+// addApolloQueries adds the _Entity union of the types with a @key directive, and the
+// _entities and _service queries that an Apollo Federation gateway uses to query the schema.
+// Nothing is added if no type has a @key directive.
+func addApolloQueries(schema *ast.Schema, definitions []string) {
+	var entities []string
+	for _, key := range definitions {
+		defn := schema.Types[key]
+		if defn.Kind == ast.Object && defn.Directives.ForName(apolloKeyDirective) != nil {
+			entities = append(entities, defn.Name)
+		}
+	}
+	if len(entities) == 0 {
+		return
+	}
+
+	schema.Types[apolloEntityUnion] = &ast.Definition{
+		Kind:  ast.Union,
+		Name:  apolloEntityUnion,
+		Types: entities,
+	}
+	schema.Query.Fields = append(schema.Query.Fields,
+		&ast.FieldDefinition{
+			Name: apolloEntitiesQuery,
+			Arguments: []*ast.ArgumentDefinition{{
+				Name: "representations",
+				Type: &ast.Type{
+					Elem:    &ast.Type{NamedType: "_Any", NonNull: true},
+					NonNull: true,
+				},
+			}},
+			Type: &ast.Type{
+				Elem:    &ast.Type{NamedType: apolloEntityUnion},
+				NonNull: true,
+			},
+		},
+		&ast.FieldDefinition{
+			Name: apolloServiceQuery,
+			Type: &ast.Type{NamedType: "_Service", NonNull: true},
+		})
 }
 
 func addAddMutation(schema *ast.Schema, defn *ast.Definition) {
@@ -1886,6 +2109,14 @@ func addAddMutation(schema *ast.Schema, defn *ast.Definition) {
 			},
 		},
 	}
+	// Objects with an @id field can be upserted: an object whose @id value already exists
+	// updates the existing node instead of failing the mutation.
+	if hasXID(defn) {
+		add.Arguments = append(add.Arguments, &ast.ArgumentDefinition{
+			Name: UpsertArgName,
+			Type: &ast.Type{NamedType: "Boolean"},
+		})
+	}
 	schema.Mutation.Fields = append(schema.Mutation.Fields, add)
 
 }
@@ -2275,7 +2506,7 @@ func Stringify(schema *ast.Schema, originalTypes []string) string {
 	// schemaExtras gets added to the result as a string, but we need to mark
 	// off all it's contents as printed, so nothing in there gets printed with
 	// the generated definitions.
-	docExtras, gqlErr := parser.ParseSchema(&ast.Source{Input: schemaExtras})
+	docExtras, gqlErr := parser.ParseSchema(&ast.Source{Input: schemaExtras + apolloSchemaExtras})
 	if gqlErr != nil {
 		x.Panic(gqlErr)
 	}
@@ -2304,12 +2535,14 @@ func Stringify(schema *ast.Schema, originalTypes []string) string {
 	sort.Strings(typeNames)
 
 	// Now consider the types generated by completeSchema, which can only be
-	// types, inputs and enums
+	// types, inputs, enums and the _Entity union
 	for _, typName := range typeNames {
 		typ := schema.Types[typName]
 		switch typ.Kind {
 		case ast.Object:
 			x.Check2(object.WriteString(generateObjectString(typ) + "\n"))
+		case ast.Union:
+			x.Check2(object.WriteString(generateUnionString(typ) + "\n"))
 		case ast.InputObject:
 			x.Check2(input.WriteString(generateInputString(typ) + "\n"))
 		case ast.Enum:
@@ -2323,6 +2556,9 @@ func Stringify(schema *ast.Schema, originalTypes []string) string {
 	x.Check2(sch.WriteString(
 		"#######################\n# Extended Definitions\n#######################\n"))
 	x.Check2(sch.WriteString(schemaExtras))
+	if schema.Types[apolloEntityUnion] != nil {
+		x.Check2(sch.WriteString(apolloSchemaExtras))
+	}
 	x.Check2(sch.WriteString("\n"))
 	if object.Len() > 0 {
 		x.Check2(sch.WriteString(
@@ -2361,6 +2597,97 @@ func Stringify(schema *ast.Schema, originalTypes []string) string {
 	return sch.String()
 }
 
+// apolloServiceDirectives are the directives kept in the SDL of the _service query. The gateway
+// doesn't know about the other directives.
+var apolloServiceDirectives = map[string]bool{
+	apolloKeyDirective:      true,
+	apolloExtendsDirective:  true,
+	apolloExternalDirective: true,
+	deprecatedDirective:     true,
+}
+
+// apolloServiceSDL returns the SDL of the schema that's returned by the _service query to an
+// Apollo Federation gateway. It has the types, queries and mutations of the schema with only the
+// directives in apolloServiceDirectives, and without the definitions that the gateway adds
+// itself, i.e. the _entities and _service queries and their types.
+func apolloServiceSDL(schema *ast.Schema) string {
+	var sdl strings.Builder
+
+	typeNames := make([]string, 0, len(schema.Types))
+	for typName, typ := range schema.Types {
+		if typ.BuiltIn || isQueryOrMutation(typName) || typName == "Subscription" {
+			continue
+		}
+		switch typName {
+		case "_Any", "_FieldSet", "_Service", apolloEntityUnion:
+			continue
+		}
+		typeNames = append(typeNames, typName)
+	}
+	sort.Strings(typeNames)
+
+	for _, typName := range typeNames {
+		typ := apolloServiceDefinition(schema.Types[typName])
+		switch typ.Kind {
+		case ast.Scalar:
+			x.Check2(sdl.WriteString(fmt.Sprintf("%sscalar %s\n",
+				generateDescription(typ.Description), typ.Name)))
+		case ast.Interface:
+			x.Check2(sdl.WriteString(generateInterfaceString(typ)))
+		case ast.Object:
+			x.Check2(sdl.WriteString(generateObjectString(typ)))
+		case ast.Union:
+			x.Check2(sdl.WriteString(generateUnionString(typ)))
+		case ast.Enum:
+			x.Check2(sdl.WriteString(generateEnumString(typ)))
+		case ast.InputObject:
+			x.Check2(sdl.WriteString(generateInputString(typ)))
+		}
+		x.Check2(sdl.WriteString("\n"))
+	}
+
+	query := apolloServiceDefinition(schema.Query)
+	fields := query.Fields[:0]
+	for _, fld := range query.Fields {
+		if fld.Name != apolloEntitiesQuery && fld.Name != apolloServiceQuery {
+			fields = append(fields, fld)
+		}
+	}
+	query.Fields = fields
+	if len(query.Fields) > 0 {
+		x.Check2(sdl.WriteString(generateObjectString(query) + "\n"))
+	}
+	if schema.Mutation != nil && len(schema.Mutation.Fields) > 0 {
+		x.Check2(sdl.WriteString(generateObjectString(apolloServiceDefinition(schema.Mutation))))
+	}
+
+	return sdl.String()
+}
+
+// apolloServiceDefinition returns a copy of the definition with only the directives in
+// apolloServiceDirectives.
+func apolloServiceDefinition(defn *ast.Definition) *ast.Definition {
+	filter := func(dirs ast.DirectiveList) ast.DirectiveList {
+		var kept ast.DirectiveList
+		for _, dir := range dirs {
+			if apolloServiceDirectives[dir.Name] {
+				kept = append(kept, dir)
+			}
+		}
+		return kept
+	}
+
+	cp := *defn
+	cp.Directives = filter(defn.Directives)
+	cp.Fields = make(ast.FieldList, 0, len(defn.Fields))
+	for _, fld := range defn.Fields {
+		fldCp := *fld
+		fldCp.Directives = filter(fld.Directives)
+		cp.Fields = append(cp.Fields, &fldCp)
+	}
+	return &cp
+}
+
 func isIDField(defn *ast.Definition, fld *ast.FieldDefinition) bool {
 	return fld.Type.Name() == idTypeFor(defn)
 }
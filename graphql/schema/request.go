@@ -108,6 +108,7 @@ func (s *schema) Operation(req *Request) (Operation, error) {
 	for _, s := range op.SelectionSet {
 		recursivelyExpandFragmentSelections(s.(*ast.Field), operation)
 	}
+	operation.complexity = operationComplexity(operation)
 
 	return operation, nil
 }
@@ -169,7 +169,9 @@ func (r *Response) Output() interface{} {
 // Extensions represents GraphQL extensions
 type Extensions struct {
 	TouchedUids uint64 `json:"touched_uids,omitempty"`
-	Tracing     *Trace `json:"tracing,omitempty"`
+	// Cost is the cost of the operation, as calculated by Operation.Complexity().
+	Cost    uint64 `json:"cost,omitempty"`
+	Tracing *Trace `json:"tracing,omitempty"`
 }
 
 // GetTouchedUids returns TouchedUids
@@ -39,7 +39,7 @@ func init() {
 	schemaValidations = append(schemaValidations, dgraphDirectivePredicateValidation)
 	typeValidations = append(typeValidations, idCountCheck, dgraphDirectiveTypeValidation,
 		passwordDirectiveValidation, conflictingDirectiveValidation, nonIdFieldsCheck,
-		remoteTypeValidation, generateDirectiveValidation)
+		remoteTypeValidation, generateDirectiveValidation, apolloKeyValidation)
 	fieldValidations = append(fieldValidations, listValidityCheck, fieldArgumentCheck,
 		fieldNameCheck, isValidFieldForList, hasAuthDirective)
 
@@ -47,6 +47,7 @@ func init() {
 	validator.AddRule("Check arguments of cascade directive", directiveArgumentsCheck)
 	validator.AddRule("Check range for Int type", intRangeCheck)
 	validator.AddRule("Input Coercion to List", listInputCoercion)
+	validator.AddRule("Check complexity of the operation", complexityCheck)
 
 }
 
@@ -252,6 +253,8 @@ func dgraphDirectivePredicateValidation(gqlSch *ast.Schema, definitions []string
 func typeNameValidation(schema *ast.SchemaDocument) gqlerror.List {
 	var errs []*gqlerror.Error
 	forbiddenTypeNames := map[string]bool{
+		// The union of the entities generated for Apollo Federation
+		apolloEntityUnion: true,
 		// The static types that we define in schemaExtras
 		"Int64":                true,
 		"DateTime":             true,
@@ -1241,6 +1244,93 @@ func lambdaDirectiveValidation(sch *ast.Schema,
 	return errs
 }
 
+func apolloExternalValidation(sch *ast.Schema,
+	typ *ast.Definition,
+	field *ast.FieldDefinition,
+	dir *ast.Directive,
+	secrets map[string]x.SensitiveByteSlice) gqlerror.List {
+	key := typ.Directives.ForName(apolloKeyDirective)
+	if typ.Directives.ForName(apolloExtendsDirective) == nil || key == nil ||
+		key.Arguments.ForName(apolloKeyArg) == nil ||
+		strings.TrimSpace(key.Arguments.ForName(apolloKeyArg).Value.Raw) != field.Name {
+		return []*gqlerror.Error{gqlerror.ErrorPosf(dir.Position,
+			"Type %s; Field %s: has the @external directive, but only the @key field of a "+
+				"type with the @extends directive can be external.", typ.Name, field.Name)}
+	}
+	return nil
+}
+
+func costValidation(sch *ast.Schema,
+	typ *ast.Definition,
+	field *ast.FieldDefinition,
+	dir *ast.Directive,
+	secrets map[string]x.SensitiveByteSlice) gqlerror.List {
+	arg := dir.Arguments.ForName(costWeightArg)
+	if arg == nil {
+		return nil
+	}
+	if w, err := strconv.ParseInt(arg.Value.Raw, 10, 32); err != nil || w < 0 {
+		return []*gqlerror.Error{gqlerror.ErrorPosf(dir.Position,
+			"Type %s; Field %s: the weight of @cost must be a non-negative Int, not %s.",
+			typ.Name, field.Name, arg.Value.Raw)}
+	}
+	return nil
+}
+
+// apolloKeyValidation checks the @key and @extends directives of a type. The key of an entity is
+// a single field, which is either the ID field of the type or a field with the @id directive, so
+// that the entities can be looked up by it.
+func apolloKeyValidation(schema *ast.Schema, typ *ast.Definition) gqlerror.List {
+	extends := typ.Directives.ForName(apolloExtendsDirective)
+	var keys []*ast.Directive
+	for _, dir := range typ.Directives {
+		if dir.Name == apolloKeyDirective {
+			keys = append(keys, dir)
+		}
+	}
+	if len(keys) == 0 {
+		if extends != nil {
+			return []*gqlerror.Error{gqlerror.ErrorPosf(extends.Position,
+				"Type %s; has the @extends directive, but no @key directive.", typ.Name)}
+		}
+		return nil
+	}
+	if len(keys) > 1 {
+		return []*gqlerror.Error{gqlerror.ErrorPosf(keys[1].Position,
+			"Type %s; has more than one @key directive, but a type can only have one key.",
+			typ.Name)}
+	}
+
+	dir := keys[0]
+	if typ.Directives.ForName(remoteDirective) != nil {
+		return []*gqlerror.Error{gqlerror.ErrorPosf(dir.Position,
+			"Type %s; has the @key directive, but @remote types can't be entities.", typ.Name)}
+	}
+	arg := dir.Arguments.ForName(apolloKeyArg)
+	if arg == nil || arg.Value.Kind != ast.StringValue {
+		return []*gqlerror.Error{gqlerror.ErrorPosf(dir.Position,
+			"Type %s; the fields argument of @key must be a String.", typ.Name)}
+	}
+	field := typ.Fields.ForName(strings.TrimSpace(arg.Value.Raw))
+	if field == nil {
+		return []*gqlerror.Error{gqlerror.ErrorPosf(arg.Position,
+			"Type %s; @key uses the field %s, but the type has no such field. Only a single "+
+				"field of the type can be used as the key.", typ.Name, arg.Value.Raw)}
+	}
+	if !isIDField(typ, field) && !hasIDDirective(field) {
+		return []*gqlerror.Error{gqlerror.ErrorPosf(arg.Position,
+			"Type %s; Field %s: is used by @key, but it's neither of type ID nor has the @id "+
+				"directive.", typ.Name, field.Name)}
+	}
+	if extends != nil && (field.Directives.ForName(apolloExternalDirective) == nil ||
+		!hasIDDirective(field)) {
+		return []*gqlerror.Error{gqlerror.ErrorPosf(arg.Position,
+			"Type %s; Field %s: is the key of a type with the @extends directive, so it must "+
+				"have the @external and @id directives.", typ.Name, field.Name)}
+	}
+	return nil
+}
+
 func generateDirectiveValidation(schema *ast.Schema, typ *ast.Definition) gqlerror.List {
 	dir := typ.Directives.ForName(generateDirective)
 	if dir == nil {
@@ -1293,6 +1383,15 @@ func generateDirectiveValidation(schema *ast.Schema, typ *ast.Definition) gqlerr
 					"only be true/false, found: `%s",
 				typ.Name, aggregateField.Raw))
 		}
+
+		groupByField := queryArg.Value.Children.ForName(generateGroupByField)
+		if groupByField != nil && groupByField.Kind != ast.BooleanValue {
+			errs = append(errs, gqlerror.ErrorPosf(
+				groupByField.Position,
+				"Type %s; groupBy field inside query argument of @generate directive can "+
+					"only be true/false, found: `%s",
+				typ.Name, groupByField.Raw))
+		}
 	}
 
 	mutationArg := dir.Arguments.ForName(generateMutationArg)
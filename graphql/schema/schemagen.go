@@ -222,12 +222,10 @@ func NewHandler(input string, validateOnly bool) (Handler, error) {
 		return nil, gqlerror.Errorf("No query or mutation found in the generated schema")
 	}
 
-	// If Dgraph.Authorization header is parsed successfully and JWKUrl is present
-	// then initialise the http client and Fetch the JWKs from the JWKUrl
-	if metaInfo != nil && metaInfo.JWKUrl != "" {
-		metaInfo.InitHttpClient()
-		fetchErr := metaInfo.FetchJWKs()
-		if fetchErr != nil {
+	// If Dgraph.Authorization header is parsed successfully and JWKUrls are present
+	// then initialise the http clients and Fetch the JWKs from the JWKUrls
+	if metaInfo != nil {
+		if fetchErr := metaInfo.FetchAllJWKs(); fetchErr != nil {
 			return nil, fetchErr
 		}
 	}
@@ -18,12 +18,195 @@ package schema
 
 import (
 	"errors"
+	"fmt"
+	"math"
 	"strconv"
+	"strings"
 
 	"github.com/dgraph-io/gqlparser/v2/ast"
+	"github.com/dgraph-io/gqlparser/v2/gqlerror"
 	"github.com/dgraph-io/gqlparser/v2/validator"
+
+	"github.com/dgraph-io/dgraph/x"
 )
 
+// DefaultListSize is the number of elements assumed for the lists whose size isn't limited by a
+// first argument, when computing the cost of an operation.
+const DefaultListSize = 100
+
+// Complexity measures the size of an operation, to reject the operations that could take too many
+// resources to resolve.
+type Complexity struct {
+	// Depth is the largest number of nested fields.
+	Depth int
+	// Aliases is the number of aliased fields.
+	Aliases int
+	// Cost estimates the work to resolve the operation. Each field costs its weight, given by the
+	// @cost directive in the schema, or 1 for the fields of an object type and 0 for the others.
+	// The cost of a field is multiplied by the size of the lists it is nested in, given by their
+	// first argument, or x.Config.GraphqlListSize if they have none.
+	Cost uint64
+}
+
+// operationComplexity returns the complexity of the operation, with the values of its variables.
+func operationComplexity(op *operation) Complexity {
+	return newComplexityCounter(op.inSchema.schema, op.vars).count(op.op)
+}
+
+// complexityCheck rejects the operations that are deeper or have more aliases than the limits in
+// x.Config. Their cost depends on the values of the variables, which aren't known yet when the
+// operations are validated, it's checked by CheckCost once they are.
+func complexityCheck(observers *validator.Events, addError validator.AddErrFunc) {
+	observers.OnOperation(func(walker *validator.Walker, op *ast.OperationDefinition) {
+		maxDepth, maxAliases := x.Config.GraphqlMaxDepth, x.Config.GraphqlMaxAliases
+		if maxDepth <= 0 && maxAliases <= 0 {
+			return
+		}
+		c := newComplexityCounter(walker.Schema, nil).count(op)
+
+		if maxDepth > 0 && c.Depth > maxDepth {
+			addError(validator.Message("Operation has depth %d, more than the maximum depth %d.",
+				c.Depth, maxDepth), validator.At(op.Position))
+		}
+		if maxAliases > 0 && c.Aliases > maxAliases {
+			addError(validator.Message("Operation has %d aliases, more than the maximum of %d "+
+				"aliases.", c.Aliases, maxAliases), validator.At(op.Position))
+		}
+	})
+}
+
+// CheckCost returns an error if the operation costs more than the limit in x.Config. Its
+// complexity must be computed with the values of its variables, see Operation.Complexity.
+func CheckCost(c Complexity) error {
+	maxCost := x.Config.GraphqlMaxCost
+	if maxCost == 0 || c.Cost <= maxCost {
+		return nil
+	}
+	return &gqlerror.Error{
+		Message: fmt.Sprintf("Operation has cost %d, more than the maximum cost %d.", c.Cost,
+			maxCost),
+		Extensions: map[string]interface{}{"cost": c.Cost, "maxCost": maxCost},
+	}
+}
+
+// complexityCounter computes the complexity of the selection sets of an operation.
+type complexityCounter struct {
+	schema   *ast.Schema
+	vars     map[string]interface{}
+	listSize uint64
+	// frags holds the fragments being counted, to stop at the cycles of fragments which are
+	// reported by the other validation rules.
+	frags map[string]bool
+	c     Complexity
+}
+
+func newComplexityCounter(sch *ast.Schema, vars map[string]interface{}) *complexityCounter {
+	listSize := x.Config.GraphqlListSize
+	if listSize == 0 {
+		listSize = DefaultListSize
+	}
+	return &complexityCounter{schema: sch, vars: vars, listSize: listSize,
+		frags: make(map[string]bool)}
+}
+
+func (cc *complexityCounter) count(op *ast.OperationDefinition) Complexity {
+	cc.c.Cost = cc.selection(op.SelectionSet, 1, 1)
+	return cc.c
+}
+
+// selection adds the depth and the aliases of the selection set to cc.c, and returns its cost.
+// The fields of the selection set are at the given depth, and are resolved mult times.
+func (cc *complexityCounter) selection(sels ast.SelectionSet, depth int, mult uint64) uint64 {
+	var cost uint64
+	for _, sel := range sels {
+		var f *ast.Field
+		switch sel := sel.(type) {
+		case *ast.Field:
+			f = sel
+		case *ast.InlineFragment:
+			cost = addCost(cost, cc.selection(sel.SelectionSet, depth, mult))
+			continue
+		case *ast.FragmentSpread:
+			if sel.Definition == nil || cc.frags[sel.Name] {
+				continue
+			}
+			cc.frags[sel.Name] = true
+			cost = addCost(cost, cc.selection(sel.Definition.SelectionSet, depth, mult))
+			delete(cc.frags, sel.Name)
+			continue
+		}
+		// The introspection fields and __typename are cheap, whatever their depth.
+		if f.Definition == nil || strings.HasPrefix(f.Name, "__") {
+			continue
+		}
+		if depth > cc.c.Depth {
+			cc.c.Depth = depth
+		}
+		if f.Alias != "" && f.Alias != f.Name {
+			cc.c.Aliases++
+		}
+
+		cost = addCost(cost, mulCost(mult, cc.fieldWeight(f)))
+		if len(f.SelectionSet) == 0 {
+			continue
+		}
+		childMult := mult
+		if f.Definition.Type.Elem != nil {
+			childMult = mulCost(mult, cc.fieldListSize(f))
+		}
+		cost = addCost(cost, cc.selection(f.SelectionSet, depth+1, childMult))
+	}
+	return cost
+}
+
+// fieldWeight returns the cost of resolving the field once.
+func (cc *complexityCounter) fieldWeight(f *ast.Field) uint64 {
+	if dir := f.Definition.Directives.ForName(costDirective); dir != nil {
+		if arg := dir.Arguments.ForName(costWeightArg); arg != nil {
+			if w, err := strconv.ParseUint(arg.Value.Raw, 10, 64); err == nil {
+				return w
+			}
+		}
+	}
+	if typ := cc.schema.Types[f.Definition.Type.Name()]; typ != nil &&
+		(typ.Kind == ast.Object || typ.Kind == ast.Interface || typ.Kind == ast.Union) {
+		return 1
+	}
+	return 0
+}
+
+// fieldListSize returns the number of elements the list field can return.
+func (cc *complexityCounter) fieldListSize(f *ast.Field) uint64 {
+	arg := f.Arguments.ForName("first")
+	if arg == nil {
+		return cc.listSize
+	}
+	val, err := arg.Value.Value(cc.vars)
+	if err != nil || val == nil {
+		return cc.listSize
+	}
+	first, err := strconv.ParseInt(fmt.Sprint(val), 10, 64)
+	if err != nil || first < 0 {
+		return cc.listSize
+	}
+	return uint64(first)
+}
+
+// addCost and mulCost saturate instead of overflowing, the costs can be made up by clients.
+func addCost(a, b uint64) uint64 {
+	if a > math.MaxUint64-b {
+		return math.MaxUint64
+	}
+	return a + b
+}
+
+func mulCost(a, b uint64) uint64 {
+	if a != 0 && b > math.MaxUint64/a {
+		return math.MaxUint64
+	}
+	return a * b
+}
+
 func listInputCoercion(observers *validator.Events, addError validator.AddErrFunc) {
 	observers.OnValue(func(walker *validator.Walker, value *ast.Value) {
 		if value.Definition == nil || value.ExpectedType == nil {
@@ -76,10 +76,13 @@ const (
 	GetQuery             QueryType    = "get"
 	FilterQuery          QueryType    = "query"
 	AggregateQuery       QueryType    = "aggregate"
+	GroupByQuery         QueryType    = "groupBy"
 	SchemaQuery          QueryType    = "schema"
 	PasswordQuery        QueryType    = "checkPassword"
 	HTTPQuery            QueryType    = "http"
 	DQLQuery             QueryType    = "dql"
+	EntitiesQuery        QueryType    = "entities"
+	ApolloServiceQuery   QueryType    = "apolloService"
 	NotSupportedQuery    QueryType    = "notsupported"
 	AddMutation          MutationType = "add"
 	UpdateMutation       MutationType = "update"
@@ -88,6 +91,7 @@ const (
 	NotSupportedMutation MutationType = "notsupported"
 	IDType                            = "ID"
 	InputArgName                      = "input"
+	UpsertArgName                     = "upsert"
 	FilterArgName                     = "filter"
 )
 
@@ -96,11 +100,15 @@ type Schema interface {
 	Operation(r *Request) (Operation, error)
 	Queries(t QueryType) []string
 	Mutations(t MutationType) []string
+	// ServiceSDL is the SDL returned by the _service query to an Apollo Federation gateway.
+	ServiceSDL() string
 }
 
 // An Operation is a single valid GraphQL operation.  It contains either
 // Queries or Mutations, but not both.  Subscriptions are not yet supported.
 type Operation interface {
+	// Name is the name of the operation, or "" if it's anonymous.
+	Name() string
 	Queries() []Query
 	Mutations() []Mutation
 	Schema() Schema
@@ -108,6 +116,8 @@ type Operation interface {
 	IsMutation() bool
 	IsSubscription() bool
 	CacheControl() string
+	AsOf() string
+	Complexity() Complexity
 }
 
 // A Field is one field from an Operation.
@@ -161,6 +171,8 @@ type Query interface {
 	Field
 	QueryType() QueryType
 	DQLQuery() string
+	RepresentationsArg() ([]*EntityRepresentations, error)
+	GroupByArg() ([]GroupByKey, error)
 	Rename(newName string)
 	AuthFor(typ Type, jwtVars map[string]interface{}) Query
 }
@@ -173,6 +185,7 @@ type Type interface {
 	Fields() []FieldDefinition
 	IDField() FieldDefinition
 	XIDField() FieldDefinition
+	KeyField() FieldDefinition
 	InterfaceImplHasAuthRules() bool
 	PasswordField() FieldDefinition
 	Name() string
@@ -212,6 +225,24 @@ type FieldDefinition interface {
 	ForwardEdge() FieldDefinition
 }
 
+// EntityRepresentations are the representations of the entities of a type in the argument of an
+// Apollo _entities query, i.e. the values of the key field of the entities to look up.
+type EntityRepresentations struct {
+	TypeDefn Type
+	KeyField FieldDefinition
+	// KeyVals are the values of the key field, in the order of the representations.
+	KeyVals []interface{}
+	// Positions are the indexes of the representations in the argument of the query.
+	Positions []int
+}
+
+// GroupByKey is a field that the groups of a groupBy query are keyed by. If Interval is set, the
+// DateTime values of the field are bucketed into that interval, i.e. day, month or year.
+type GroupByKey struct {
+	Field    FieldDefinition
+	Interval string
+}
+
 type astType struct {
 	typ             *ast.Type
 	inSchema        *schema
@@ -256,6 +287,7 @@ type operation struct {
 	// interface to its typeCondition. It is used during completion to find out if a field should
 	// be included in GraphQL response or not.
 	interfaceImplFragFields map[*ast.Field]string
+	complexity              Complexity
 
 	// The fields below are used by schema introspection queries.
 	query    string
@@ -295,6 +327,10 @@ func (s *schema) Queries(t QueryType) []string {
 	return result
 }
 
+func (s *schema) ServiceSDL() string {
+	return apolloServiceSDL(s.schema)
+}
+
 func (s *schema) Mutations(t MutationType) []string {
 	if s.schema.Mutation == nil {
 		return nil
@@ -308,6 +344,10 @@ func (s *schema) Mutations(t MutationType) []string {
 	return result
 }
 
+func (o *operation) Name() string {
+	return o.op.Name
+}
+
 func (o *operation) IsQuery() bool {
 	return o.op.Operation == ast.Query
 }
@@ -352,6 +392,11 @@ func (o *operation) Mutations() (ms []Mutation) {
 	return
 }
 
+// Complexity returns the depth, the number of aliases and the cost of the operation.
+func (o *operation) Complexity() Complexity {
+	return o.complexity
+}
+
 func (o *operation) CacheControl() string {
 	if o.op.Directives.ForName(cacheControlDirective) == nil {
 		return ""
@@ -359,6 +404,17 @@ func (o *operation) CacheControl() string {
 	return "public,max-age=" + o.op.Directives.ForName(cacheControlDirective).Arguments[0].Value.Raw
 }
 
+// AsOf returns the point in time the queries of the operation read at, given by the @asOf
+// directive, or "" if they read the latest state.
+func (o *operation) AsOf() string {
+	dir := o.op.Directives.ForName(asOfDirective)
+	if dir == nil {
+		return ""
+	}
+	ts, _ := dir.ArgumentMap(o.vars)[asOfArg].(string)
+	return ts
+}
+
 // parentInterface returns the name of an interface that a field belonging to a type definition
 // typDef inherited from. If there is no such interface, then it returns an empty string.
 //
@@ -1472,12 +1528,18 @@ func (f *field) ConstructedFor() Type {
 }
 
 func (q *query) ConstructedFor() Type {
-	if q.QueryType() != AggregateQuery {
+	var typeName string
+	switch q.QueryType() {
+	case AggregateQuery:
+		fieldName := q.Type().Name()
+		typeName = fieldName[:len(fieldName)-len("AggregateResult")]
+	case GroupByQuery:
+		// Its type is [<Type>GroupByResult]
+		fieldName := q.Type().Name()
+		typeName = fieldName[:len(fieldName)-len("GroupByResult")]
+	default:
 		return q.Type()
 	}
-	// Its of type AggregateQuery
-	fieldName := q.Type().Name()
-	typeName := fieldName[:len(fieldName)-15]
 	return &astType{
 		typ: &ast.Type{
 			NamedType: typeName,
@@ -1552,6 +1614,94 @@ func (q *query) QueryType() QueryType {
 	return queryType(q.Name(), q.op.inSchema.customDirectives["Query"][q.Name()])
 }
 
+// RepresentationsArg parses the representations argument of the _entities query, and groups
+// them by type, in the order the types first appear. All the representations must have a value
+// for the key field of their type.
+func (q *query) RepresentationsArg() ([]*EntityRepresentations, error) {
+	representations, ok := q.ArgValue("representations").([]interface{})
+	if !ok || len(representations) == 0 {
+		return nil, errors.New("expected at least one representation in the `representations` " +
+			"argument")
+	}
+
+	var groups []*EntityRepresentations
+	byType := make(map[string]*EntityRepresentations)
+	for i, r := range representations {
+		rep, ok := r.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("representation %d isn't an object", i)
+		}
+		name, ok := rep[Typename].(string)
+		if !ok {
+			return nil, errors.Errorf("representation %d has no %s", i, Typename)
+		}
+
+		reps := byType[name]
+		if reps == nil {
+			defn := q.op.inSchema.schema.Types[name]
+			if defn == nil || defn.Directives.ForName(apolloKeyDirective) == nil {
+				return nil, errors.Errorf("type %s isn't an entity, it has no @key directive", name)
+			}
+			reps = &EntityRepresentations{
+				TypeDefn: &astType{
+					typ:             &ast.Type{NamedType: name},
+					inSchema:        q.op.inSchema,
+					dgraphPredicate: q.op.inSchema.dgraphPredicate,
+				},
+			}
+			reps.KeyField = reps.TypeDefn.KeyField()
+			byType[name] = reps
+			groups = append(groups, reps)
+		}
+
+		val, ok := rep[reps.KeyField.Name()]
+		if !ok || val == nil {
+			return nil, errors.Errorf("representation %d has no value for the key field %s", i,
+				reps.KeyField.Name())
+		}
+		reps.KeyVals = append(reps.KeyVals, val)
+		reps.Positions = append(reps.Positions, i)
+	}
+	return groups, nil
+}
+
+// GroupByArg parses the by argument of a groupBy query. A field can only be used once, and an
+// interval can only be given for a DateTime field.
+func (q *query) GroupByArg() ([]GroupByKey, error) {
+	by, _ := q.ArgValue("by").([]interface{})
+	if len(by) == 0 {
+		return nil, errors.New("expected at least one field in the `by` argument")
+	}
+
+	typ := q.ConstructedFor()
+	fields := make(map[string]FieldDefinition)
+	for _, fld := range typ.Fields() {
+		fields[fld.Name()] = fld
+	}
+	keys := make([]GroupByKey, 0, len(by))
+	seen := make(map[string]bool, len(by))
+	for _, b := range by {
+		arg, _ := b.(map[string]interface{})
+		name, _ := arg["field"].(string)
+		fld := fields[name]
+		if fld == nil {
+			return nil, errors.Errorf("%s can't be grouped by %s", typ.Name(), name)
+		}
+		if seen[name] {
+			return nil, errors.Errorf("%s can only be used once in the `by` argument", name)
+		}
+		seen[name] = true
+
+		interval, _ := arg["interval"].(string)
+		if interval != "" && fld.Type().Name() != "DateTime" {
+			return nil, errors.Errorf("%s isn't a DateTime, so it can't be grouped by %s",
+				name, interval)
+		}
+		keys = append(keys, GroupByKey{Field: fld, Interval: interval})
+	}
+	return keys, nil
+}
+
 func (q *query) DQLQuery() string {
 	if customDir := q.op.inSchema.customDirectives["Query"][q.Name()]; customDir != nil {
 		if dqlArgument := customDir.Arguments.ForName(dqlArg); dqlArgument != nil {
@@ -1568,6 +1718,10 @@ func queryType(name string, custom *ast.Directive) QueryType {
 			return DQLQuery
 		}
 		return HTTPQuery
+	case name == apolloEntitiesQuery:
+		return EntitiesQuery
+	case name == apolloServiceQuery:
+		return ApolloServiceQuery
 	case strings.HasPrefix(name, "get"):
 		return GetQuery
 	case name == "__schema" || name == "__type" || name == "__typename":
@@ -1578,6 +1732,8 @@ func queryType(name string, custom *ast.Directive) QueryType {
 		return PasswordQuery
 	case strings.HasPrefix(name, "aggregate"):
 		return AggregateQuery
+	case strings.HasPrefix(name, "groupBy"):
+		return GroupByQuery
 	default:
 		return NotSupportedQuery
 	}
@@ -2059,6 +2215,30 @@ func (t *astType) IDField() FieldDefinition {
 	return nil
 }
 
+// KeyField returns the field used as the key of the type by its Apollo @key directive, or nil if
+// the type has no @key.
+func (t *astType) KeyField() FieldDefinition {
+	def := t.inSchema.schema.Types[t.Name()]
+	if def == nil {
+		return nil
+	}
+	key := def.Directives.ForName(apolloKeyDirective)
+	if key == nil || key.Arguments.ForName(apolloKeyArg) == nil {
+		return nil
+	}
+
+	fd := def.Fields.ForName(strings.TrimSpace(key.Arguments.ForName(apolloKeyArg).Value.Raw))
+	if fd == nil {
+		return nil
+	}
+	return &fieldDefinition{
+		fieldDef:        fd,
+		inSchema:        t.inSchema,
+		parentType:      t,
+		dgraphPredicate: t.dgraphPredicate,
+	}
+}
+
 func (t *astType) PasswordField() FieldDefinition {
 	def := t.inSchema.schema.Types[t.Name()]
 	if def.Kind != ast.Object && def.Kind != ast.Interface {
@@ -22,7 +22,9 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/dgraph-io/dgraph/x"
 	"github.com/dgraph-io/gqlparser/v2/ast"
+	"github.com/dgraph-io/gqlparser/v2/gqlerror"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
@@ -1273,3 +1275,130 @@ func TestParseSecrets(t *testing.T) {
 		})
 	}
 }
+
+func TestOperationAsOf(t *testing.T) {
+	schHandler, errs := NewHandler(`
+	type User {
+		id: ID!
+		name: String!
+	}`, false)
+	require.NoError(t, errs)
+	sch, err := FromString(schHandler.GQLSchema())
+	require.NoError(t, err)
+
+	tcases := []struct {
+		query string
+		vars  map[string]interface{}
+		asOf  string
+	}{
+		{`query { queryUser { name } }`, nil, ""},
+		{`query @asOf(ts: "2020-10-01T10:00:00Z") { queryUser { name } }`, nil,
+			"2020-10-01T10:00:00Z"},
+		{`query($ts: String!) @asOf(ts: $ts) { queryUser { name } }`,
+			map[string]interface{}{"ts": "0x2710"}, "0x2710"},
+	}
+	for _, tcase := range tcases {
+		op, err := sch.Operation(&Request{Query: tcase.query, Variables: tcase.vars})
+		require.NoError(t, err, tcase.query)
+		require.Equal(t, tcase.asOf, op.AsOf())
+	}
+
+	// The directive can only be used on queries.
+	_, err = sch.Operation(&Request{
+		Query: `mutation @asOf(ts: "10") { deleteUser(filter: {}) { msg } }`})
+	require.Error(t, err)
+}
+
+func TestOperationComplexity(t *testing.T) {
+	schHandler, errs := NewHandler(`
+	type Author {
+		id: ID!
+		name: String!
+		posts: [Post] @cost(weight: 2)
+	}
+	type Post {
+		id: ID!
+		title: String!
+		text: String @cost(weight: 5)
+	}`, false)
+	require.NoError(t, errs)
+	sch, err := FromString(schHandler.GQLSchema())
+	require.NoError(t, err)
+
+	tcases := []struct {
+		query      string
+		vars       map[string]interface{}
+		complexity Complexity
+	}{
+		{`query { queryAuthor { name } }`, nil, Complexity{Depth: 2, Cost: 1}},
+		{`query { queryAuthor(first: 10) { posts(first: 3) { text } } }`, nil,
+			Complexity{Depth: 3, Cost: 1 + 10*2 + 30*5}},
+		{`query($n: Int) { queryAuthor(first: $n) { posts { title } } }`,
+			map[string]interface{}{"n": json.Number("4")}, Complexity{Depth: 3, Cost: 1 + 4*2}},
+		{`query { getAuthor(id: "0x1") { posts { text } } }`, nil,
+			Complexity{Depth: 3, Cost: 1 + 2 + DefaultListSize*5}},
+		{`query { a: queryAuthor(first: 1) { name } b: queryAuthor(first: 1) { n: name } }`,
+			nil, Complexity{Depth: 2, Aliases: 3, Cost: 2}},
+		{`query { __schema { types { name fields { name } } } }`, nil, Complexity{}},
+	}
+	for _, tcase := range tcases {
+		op, err := sch.Operation(&Request{Query: tcase.query, Variables: tcase.vars})
+		require.NoError(t, err, tcase.query)
+		require.Equal(t, tcase.complexity, op.Complexity(), tcase.query)
+	}
+}
+
+func TestComplexityCheck(t *testing.T) {
+	schHandler, errs := NewHandler(`
+	type Author {
+		id: ID!
+		name: String!
+		posts: [Post] @cost(weight: 2)
+	}
+	type Post {
+		id: ID!
+		text: String @cost(weight: 5)
+	}`, false)
+	require.NoError(t, errs)
+	sch, err := FromString(schHandler.GQLSchema())
+	require.NoError(t, err)
+
+	defer func(c x.Options) { x.Config = c }(x.Config)
+	query := `query($n: Int = 2) {
+		a: queryAuthor(first: $n) { ...posts }
+		b: queryAuthor(first: 1) { name }
+	}
+	fragment posts on Author { posts(first: 3) { text } }`
+	x.Config.GraphqlMaxDepth, x.Config.GraphqlMaxAliases, x.Config.GraphqlMaxCost = 3, 2, 36
+	_, err = sch.Operation(&Request{Query: query,
+		Variables: map[string]interface{}{"n": json.Number("2")}})
+	require.NoError(t, err)
+
+	x.Config.GraphqlMaxDepth, x.Config.GraphqlMaxAliases = 2, 1
+	_, err = sch.Operation(&Request{Query: query,
+		Variables: map[string]interface{}{"n": json.Number("2")}})
+	require.Error(t, err)
+	errList, ok := err.(gqlerror.List)
+	require.True(t, ok)
+	require.Len(t, errList, 2)
+	require.Equal(t, "Operation has depth 3, more than the maximum depth 2.", errList[0].Message)
+	require.Equal(t, "Operation has 2 aliases, more than the maximum of 1 aliases.",
+		errList[1].Message)
+
+	// The cost is checked with the values of the variables.
+	x.Config.GraphqlMaxDepth, x.Config.GraphqlMaxAliases, x.Config.GraphqlMaxCost = 0, 0, 30
+	op, err := sch.Operation(&Request{Query: query,
+		Variables: map[string]interface{}{"n": json.Number("2")}})
+	require.NoError(t, err)
+	err = CheckCost(op.Complexity())
+	gqlErr, ok := err.(*gqlerror.Error)
+	require.True(t, ok)
+	require.Equal(t, "Operation has cost 36, more than the maximum cost 30.", gqlErr.Message)
+	require.Equal(t, map[string]interface{}{"cost": uint64(36), "maxCost": uint64(30)},
+		gqlErr.Extensions)
+
+	op, err = sch.Operation(&Request{Query: query,
+		Variables: map[string]interface{}{"n": json.Number("1")}})
+	require.NoError(t, err)
+	require.NoError(t, CheckCost(op.Complexity()))
+}
@@ -0,0 +1,87 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package subscription
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/dgraph-io/dgo/v200/protos/api"
+	"github.com/dgraph-io/dgraph/edgraph"
+	"github.com/dgraph-io/dgraph/graphql/resolve"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/dgryski/go-farm"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// NewDQLPoller returns a Poller for the subscriptions to DQL queries. They don't depend on the
+// GraphQL schema, so the poller has no epoch and no resolver.
+func NewDQLPoller() *Poller {
+	return NewPoller(nil, nil)
+}
+
+// AddDQLSubscriber subscribes to the read-only DQL query of the request. The subscriber gets
+// the *api.Response of the query, and a new one every time the result of the query changes. If
+// the query fails while polling, the subscriber gets the error instead.
+//
+// The subscriptions to the same query, with the same variables and access JWT, share a polling
+// goroutine.
+func (p *Poller) AddDQLSubscriber(ctx context.Context,
+	req *api.Request) (*SubscriberResponse, error) {
+
+	if len(req.Mutations) > 0 {
+		return nil, errors.New("Subscriptions can't have mutations")
+	}
+	if req.StartTs != 0 {
+		return nil, errors.New("Subscriptions can't be run at a given startTs")
+	}
+
+	// The query is run again long after the request, so only keep what authorizes it.
+	md, _ := metadata.FromIncomingContext(ctx)
+	md = md.Copy()
+	pr, hasPeer := peer.FromContext(ctx)
+
+	buf, err := json.Marshal(struct {
+		Query     string
+		Vars      map[string]string
+		AccessJwt []string
+		AuthToken []string
+	}{req.Query, req.Vars, md.Get("accessJwt"), md.Get("auth-token")})
+	x.Check(err)
+	bucketID := farm.Fingerprint64(buf)
+
+	run := func(ctx context.Context) ([]byte, interface{}, error) {
+		ctx = metadata.NewIncomingContext(ctx, md)
+		if hasPeer {
+			ctx = peer.NewContext(ctx, pr)
+		}
+		resolve.RecordReadPredicatesOfDQL(ctx, req.Query, req.Vars)
+		resp, err := (&edgraph.Server{}).Query(ctx, &api.Request{
+			Query:    req.Query,
+			Vars:     req.Vars,
+			ReadOnly: true,
+		})
+		if err != nil {
+			return nil, err, err
+		}
+		return resp.Json, resp, nil
+	}
+	return p.addSubscriber(bucketID, 0, time.Time{}, run)
+}
@@ -27,27 +27,41 @@ import (
 	"github.com/dgraph-io/dgraph/graphql/authorization"
 	"github.com/dgraph-io/dgraph/graphql/resolve"
 	"github.com/dgraph-io/dgraph/graphql/schema"
+	"github.com/dgraph-io/dgraph/worker"
 	"github.com/dgraph-io/dgraph/x"
 	"github.com/dgryski/go-farm"
 	"github.com/golang/glog"
 )
 
-// Poller is used to poll user subscription query.
+// Poller is used to poll user subscription query. A polling goroutine only runs its query again
+// once a commit changes the predicates that the query read, or on every poll if they aren't
+// known.
 type Poller struct {
 	sync.RWMutex
 	resolver       *resolve.RequestResolver
 	pollRegistry   map[uint64]map[uint64]subscriber
 	subscriptionID uint64
 	globalEpoch    *uint64
+
+	// watchMu protects watched. It's separate from the mutex of the registry so that the
+	// commits are never blocked by the updates sent to the subscribers.
+	watchMu sync.RWMutex
+	watched map[uint64]*watch
+	// servesPredicate tells if a predicate is served by this Alpha's group.
+	servesPredicate func(string) bool
 }
 
 // NewPoller returns Poller.
 func NewPoller(globalEpoch *uint64, resolver *resolve.RequestResolver) *Poller {
-	return &Poller{
-		resolver:     resolver,
-		pollRegistry: make(map[uint64]map[uint64]subscriber),
-		globalEpoch:  globalEpoch,
+	p := &Poller{
+		resolver:        resolver,
+		pollRegistry:    make(map[uint64]map[uint64]subscriber),
+		globalEpoch:     globalEpoch,
+		watched:         make(map[uint64]*watch),
+		servesPredicate: worker.ServesPredicate,
 	}
+	worker.AddCommitWatcher(p)
+	return p
 }
 
 // SubscriberResponse holds the meta data about subscriber.
@@ -90,31 +104,64 @@ func (p *Poller) AddSubscriber(
 	} else {
 		bucketID = farm.Fingerprint64(buf)
 	}
+	run := func(ctx context.Context) ([]byte, interface{}, error) {
+		ctx = context.WithValue(ctx, authorization.AuthVariables, customClaims.AuthVariables)
+		res := resolver.Resolve(ctx, req)
+		if len(res.Errors) != 0 {
+			return res.Data.Bytes(), res.Output(), res.Errors
+		}
+		return res.Data.Bytes(), res.Output(), nil
+	}
+	return p.addSubscriber(bucketID, localEpoch, customClaims.StandardClaims.ExpiresAt.Time, run)
+}
+
+// resolveFunc runs the query of a subscription. It returns the result of the query, which
+// tells if it changed, and the update to send to the subscribers.
+type resolveFunc func(ctx context.Context) (result []byte, update interface{}, err error)
+
+// addSubscriber adds a subscriber to the bucket, and starts a polling goroutine for the bucket
+// if there is none.
+func (p *Poller) addSubscriber(bucketID, localEpoch uint64, expiry time.Time,
+	run resolveFunc) (*SubscriberResponse, error) {
+
 	p.Lock()
 	defer p.Unlock()
 
-	ctx := context.WithValue(context.Background(), authorization.AuthVariables, customClaims.AuthVariables)
-	res := resolver.Resolve(ctx, req)
-	if len(res.Errors) != 0 {
-		return nil, res.Errors
+	subscriptions, ok := p.pollRegistry[bucketID]
+	var result []byte
+	var update interface{}
+	var err error
+	w := newWatch()
+	if ok {
+		result, update, err = run(context.Background())
+	} else {
+		// Watch the changes before running the query, so that none is missed.
+		p.watchMu.Lock()
+		p.watched[bucketID] = w
+		p.watchMu.Unlock()
+		result, update, err = p.resolveWatched(run, w)
+	}
+	if err != nil {
+		if !ok {
+			p.unwatch(bucketID, w)
+		}
+		return nil, err
 	}
 
-	prevHash := farm.Fingerprint64(res.Data.Bytes())
+	prevHash := farm.Fingerprint64(result)
 
 	updateCh := make(chan interface{}, 10)
-	updateCh <- res.Output()
+	updateCh <- update
 
 	subscriptionID := p.subscriptionID
 	// Increment ID for next subscription.
 	p.subscriptionID++
-	subscriptions, ok := p.pollRegistry[bucketID]
 	if !ok {
 		subscriptions = make(map[uint64]subscriber)
 	}
 	glog.Infof("Subscription polling is started for the ID %d", subscriptionID)
 
-	subscriptions[subscriptionID] = subscriber{
-		expiry: customClaims.StandardClaims.ExpiresAt.Time, updateCh: updateCh}
+	subscriptions[subscriptionID] = subscriber{expiry: expiry, updateCh: updateCh}
 	p.pollRegistry[bucketID] = subscriptions
 
 	if ok {
@@ -130,11 +177,11 @@ func (p *Poller) AddSubscriber(
 	// There is no goroutine running to check updates for this query. So, run one to publish
 	// the updates.
 	pollR := &pollRequest{
-		bucketID:      bucketID,
-		prevHash:      prevHash,
-		graphqlReq:    req,
-		authVariables: customClaims.AuthVariables,
-		localEpoch:    localEpoch,
+		bucketID:   bucketID,
+		prevHash:   prevHash,
+		localEpoch: localEpoch,
+		watch:      w,
+		run:        run,
 	}
 	go p.poll(pollR)
 
@@ -146,37 +193,40 @@ func (p *Poller) AddSubscriber(
 }
 
 type pollRequest struct {
-	prevHash      uint64
-	graphqlReq    *schema.Request
-	bucketID      uint64
-	localEpoch    uint64
-	authVariables map[string]interface{}
+	prevHash   uint64
+	bucketID   uint64
+	localEpoch uint64
+	watch      *watch
+	run        resolveFunc
 }
 
 func (p *Poller) poll(req *pollRequest) {
-	p.RLock()
-	resolver := p.resolver
-	p.RUnlock()
+	defer p.unwatch(req.bucketID, req.watch)
 
 	pollID := uint64(0)
+	lastRun := time.Now()
 	for {
 		pollID++
-		time.Sleep(x.Config.PollInterval)
+		changed := p.waitForChanges(req.watch, lastRun)
 
-		globalEpoch := atomic.LoadUint64(p.globalEpoch)
-		if req.localEpoch != globalEpoch || globalEpoch == math.MaxUint64 {
+		if p.schemaChanged(req.localEpoch) {
 			// There is a schema change since local epoch is diffrent from global schema epoch.
 			// We'll terminate all the subscription for this bucket. So, that all client can
 			// reconnect and listen for new schema.
 			p.terminateSubscriptions(req.bucketID)
 		}
 
-		ctx := context.WithValue(context.Background(), authorization.AuthVariables, req.authVariables)
-		res := resolver.Resolve(ctx, req.graphqlReq)
-
-		currentHash := farm.Fingerprint64(res.Data.Bytes())
+		var update interface{}
+		var currentHash uint64
+		if changed {
+			lastRun = time.Now()
+			var result []byte
+			result, update, _ = p.resolveWatched(req.run, req.watch)
+			currentHash = farm.Fingerprint64(result)
+			changed = req.prevHash != currentHash
+		}
 
-		if req.prevHash == currentHash {
+		if !changed {
 			if pollID%2 != 0 {
 				// Don't update if there is no change in response.
 				continue
@@ -218,12 +268,22 @@ func (p *Poller) poll(req *pollRequest) {
 
 		}
 		for _, subscriber := range subscribers {
-			subscriber.updateCh <- res.Output()
+			subscriber.updateCh <- update
 		}
 		p.Unlock()
 	}
 }
 
+// schemaChanged returns whether the GraphQL schema changed since the epoch. The subscriptions to
+// DQL queries don't depend on the GraphQL schema, and their poller has no epoch.
+func (p *Poller) schemaChanged(localEpoch uint64) bool {
+	if p.globalEpoch == nil {
+		return false
+	}
+	globalEpoch := atomic.LoadUint64(p.globalEpoch)
+	return localEpoch != globalEpoch || globalEpoch == math.MaxUint64
+}
+
 // UpdateResolver will update the resolver.
 func (p *Poller) UpdateResolver(resolver *resolve.RequestResolver) {
 	p.Lock()
@@ -0,0 +1,156 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package subscription
+
+import (
+	"context"
+	"time"
+
+	"github.com/dgraph-io/dgraph/graphql/resolve"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// watch tracks the predicates read by the query of a polling goroutine, so that the query only
+// runs again once a commit changes them.
+type watch struct {
+	// changed is signalled when the result of the query may have changed.
+	changed chan struct{}
+
+	// The fields below are protected by Poller.watchMu.
+	preds map[string]struct{}
+	// remote is set if the query read predicates that aren't served by this Alpha's group.
+	remote bool
+	// tracked is false if the predicates read by the query aren't known. The query then runs
+	// on every poll.
+	tracked bool
+	// resolving is set while the query runs. The changes made meanwhile might not be part of
+	// its result.
+	resolving bool
+}
+
+func newWatch() *watch {
+	return &watch{changed: make(chan struct{}, 1)}
+}
+
+func (w *watch) signal() {
+	select {
+	case w.changed <- struct{}{}:
+	default:
+	}
+}
+
+// dependsOn returns whether the result of the query depends on the changed predicates.
+func (w *watch) dependsOn(preds []string, remote bool, servesPredicate func(string) bool) bool {
+	if !w.tracked || w.resolving {
+		return true
+	}
+	for _, pred := range preds {
+		if _, ok := w.preds[pred]; ok {
+			return true
+		}
+	}
+	if !remote {
+		return false
+	}
+	if w.remote {
+		return true
+	}
+	// A predicate read by the query may have moved away from this group since it ran.
+	for pred := range w.preds {
+		if !servesPredicate(pred) {
+			return true
+		}
+	}
+	return false
+}
+
+// Changed implements worker.CommitWatcher. It signals the polling goroutines whose query read
+// one of the predicates.
+func (p *Poller) Changed(preds []string, remote bool) {
+	p.watchMu.RLock()
+	defer p.watchMu.RUnlock()
+	for _, w := range p.watched {
+		if w.dependsOn(preds, remote, p.servesPredicate) {
+			w.signal()
+		}
+	}
+}
+
+// Reset implements worker.CommitWatcher. It signals all the polling goroutines.
+func (p *Poller) Reset() {
+	p.watchMu.RLock()
+	defer p.watchMu.RUnlock()
+	for _, w := range p.watched {
+		w.signal()
+	}
+}
+
+func (p *Poller) unwatch(bucketID uint64, w *watch) {
+	p.watchMu.Lock()
+	defer p.watchMu.Unlock()
+	// A new goroutine may already poll the bucket.
+	if p.watched[bucketID] == w {
+		delete(p.watched, bucketID)
+	}
+}
+
+// resolveWatched runs the query of the subscription, and records the predicates it read.
+func (p *Poller) resolveWatched(run resolveFunc, w *watch) ([]byte, interface{}, error) {
+	p.watchMu.Lock()
+	w.resolving = true
+	p.watchMu.Unlock()
+
+	ctx, rp := resolve.WithReadPredicates(context.Background())
+	result, update, err := run(ctx)
+
+	preds, ok := rp.List()
+	set := make(map[string]struct{}, len(preds))
+	remote := false
+	for _, pred := range preds {
+		set[pred] = struct{}{}
+		remote = remote || !p.servesPredicate(pred)
+	}
+
+	p.watchMu.Lock()
+	defer p.watchMu.Unlock()
+	w.resolving = false
+	// The query runs again on the next poll if it failed.
+	w.tracked = ok && err == nil
+	w.preds = set
+	w.remote = remote
+	return result, update, err
+}
+
+// waitForChanges waits for the next poll, and returns whether the query has to run again: once
+// a commit may have changed its result, or on every poll if the predicates it reads aren't
+// known. The query doesn't run more than once per poll interval.
+func (p *Poller) waitForChanges(w *watch, lastRun time.Time) bool {
+	timer := time.NewTimer(x.Config.PollInterval)
+	defer timer.Stop()
+
+	select {
+	case <-w.changed:
+		if wait := x.Config.PollInterval - time.Since(lastRun); wait > 0 {
+			time.Sleep(wait)
+		}
+		return true
+	case <-timer.C:
+		p.watchMu.RLock()
+		defer p.watchMu.RUnlock()
+		return !w.tracked
+	}
+}
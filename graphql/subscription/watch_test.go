@@ -0,0 +1,174 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package subscription
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/dgraph/graphql/resolve"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchDependsOn(t *testing.T) {
+	local := map[string]bool{"Author.name": true, "dgraph.type": true}
+	servesPredicate := func(pred string) bool { return local[pred] }
+
+	w := newWatch()
+	// The predicates read by the query aren't known yet.
+	require.True(t, w.dependsOn([]string{"Post.title"}, false, servesPredicate))
+
+	w.tracked = true
+	w.preds = map[string]struct{}{"Author.name": {}, "dgraph.type": {}}
+	require.True(t, w.dependsOn([]string{"Author.name"}, false, servesPredicate))
+	require.False(t, w.dependsOn([]string{"Post.title"}, false, servesPredicate))
+	require.False(t, w.dependsOn(nil, true, servesPredicate))
+
+	// Author.name moved to another group.
+	local["Author.name"] = false
+	require.True(t, w.dependsOn(nil, true, servesPredicate))
+
+	w.remote = true
+	require.True(t, w.dependsOn(nil, true, servesPredicate))
+
+	w.resolving = true
+	require.True(t, w.dependsOn([]string{"Post.title"}, false, servesPredicate))
+}
+
+func TestPollerSignalsWatches(t *testing.T) {
+	p := &Poller{
+		watched:         make(map[uint64]*watch),
+		servesPredicate: func(string) bool { return true },
+	}
+	author, post := newWatch(), newWatch()
+	author.tracked, post.tracked = true, true
+	author.preds = map[string]struct{}{"Author.name": {}}
+	post.preds = map[string]struct{}{"Post.title": {}}
+	p.watched[1], p.watched[2] = author, post
+
+	p.Changed([]string{"Author.name"}, false)
+	p.Changed([]string{"Author.name"}, false)
+	require.Len(t, author.changed, 1)
+	require.Len(t, post.changed, 0)
+
+	p.Reset()
+	require.Len(t, post.changed, 1)
+
+	p.unwatch(1, newWatch())
+	require.Len(t, p.watched, 2)
+	p.unwatch(1, author)
+	require.Len(t, p.watched, 1)
+}
+
+func TestPollerSignalsWatchesOfReverseEdges(t *testing.T) {
+	p := &Poller{
+		watched:         make(map[uint64]*watch),
+		servesPredicate: func(string) bool { return true },
+	}
+	w := newWatch()
+	run := func(ctx context.Context) ([]byte, interface{}, error) {
+		resolve.RecordReadPredicatesOfDQL(ctx,
+			`{ q(func: uid(0x1)) { ~Post.author { Post.title } } }`, nil)
+		return nil, nil, nil
+	}
+	_, _, err := p.resolveWatched(run, w)
+	require.NoError(t, err)
+	require.True(t, w.tracked)
+	require.Equal(t, map[string]struct{}{"Post.author": {}, "Post.title": {}}, w.preds)
+	p.watched[1] = w
+
+	// The commits report the reverse edges under their predicate.
+	p.Changed([]string{"Author.name"}, false)
+	require.Len(t, w.changed, 0)
+	p.Changed([]string{"Post.author"}, false)
+	require.Len(t, w.changed, 1)
+}
+
+func TestWatchOfDQLQueryWithExpand(t *testing.T) {
+	p := &Poller{
+		watched:         make(map[uint64]*watch),
+		servesPredicate: func(string) bool { return true },
+	}
+	w := newWatch()
+	run := func(ctx context.Context) ([]byte, interface{}, error) {
+		resolve.RecordReadPredicatesOfDQL(ctx,
+			`{ q(func: has(~follows)) { expand(_all_) { name } } }`, nil)
+		return nil, nil, nil
+	}
+	_, _, err := p.resolveWatched(run, w)
+	require.NoError(t, err)
+	// The predicates expanded aren't known, so the query runs on every poll.
+	require.False(t, w.tracked)
+	require.Equal(t, map[string]struct{}{"follows": {}, "name": {}}, w.preds)
+	require.True(t, w.dependsOn([]string{"age"}, false, p.servesPredicate))
+}
+
+func TestPollerRunsQueryOnChanges(t *testing.T) {
+	x.Config.PollInterval = 10 * time.Millisecond
+	p := &Poller{
+		pollRegistry:    make(map[uint64]map[uint64]subscriber),
+		watched:         make(map[uint64]*watch),
+		servesPredicate: func(string) bool { return true },
+	}
+
+	var mu sync.Mutex
+	name, runs := "A", 0
+	run := func(ctx context.Context) ([]byte, interface{}, error) {
+		resolve.RecordReadPredicatesOfDQL(ctx, `{ q(func: has(name)) { name } }`, nil)
+		mu.Lock()
+		defer mu.Unlock()
+		runs++
+		return []byte(name), name, nil
+	}
+	numRuns := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return runs
+	}
+
+	sub, err := p.addSubscriber(1, 0, time.Time{}, run)
+	require.NoError(t, err)
+	require.Equal(t, "A", <-sub.UpdateCh)
+	other, err := p.addSubscriber(1, 0, time.Time{}, run)
+	require.NoError(t, err)
+	require.Equal(t, "A", <-other.UpdateCh)
+	require.Equal(t, 2, numRuns())
+
+	// The query doesn't read age, so it doesn't run again.
+	p.Changed([]string{"age"}, false)
+	time.Sleep(50 * time.Millisecond)
+	require.Equal(t, 2, numRuns())
+
+	// The commit doesn't change the result, so nothing is sent.
+	p.Changed([]string{"name"}, false)
+	time.Sleep(50 * time.Millisecond)
+	require.Equal(t, 3, numRuns())
+	require.Len(t, sub.UpdateCh, 0)
+
+	mu.Lock()
+	name = "B"
+	mu.Unlock()
+	p.Changed([]string{"name"}, false)
+	require.Equal(t, "B", <-sub.UpdateCh)
+	require.Equal(t, "B", <-other.UpdateCh)
+
+	p.TerminateSubscription(1, sub.SubscriptionID)
+	p.TerminateSubscription(1, other.SubscriptionID)
+}
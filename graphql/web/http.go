@@ -66,6 +66,8 @@ type graphqlHandler struct {
 	resolver *resolve.RequestResolver
 	handler  http.Handler
 	poller   *subscription.Poller
+	// admin is true for the handler of /admin, which isn't restricted to trusted documents.
+	admin bool
 }
 
 // NewServer returns a new IServeGraphQL that can serve the given resolvers
@@ -73,6 +75,7 @@ func NewServer(schemaEpoch *uint64, resolver *resolve.RequestResolver, admin boo
 	gh := &graphqlHandler{
 		resolver: resolver,
 		poller:   subscription.NewPoller(schemaEpoch, resolver),
+		admin:    admin,
 	}
 	gh.handler = recoveryHandler(commonHeaders(admin, gh.Handler()))
 	return gh
@@ -169,6 +172,11 @@ func (gs *graphqlSubscription) Subscribe(
 		Query:         document,
 		Variables:     variableValues,
 	}
+	if gs.graphqlHandler.onlyTrustedDocuments() {
+		if err := edgraph.ProcessTrustedDocument(ctx, req); err != nil {
+			return nil, err
+		}
+	}
 
 	res, err := gs.graphqlHandler.poller.AddSubscriber(req, customClaims)
 	if err != nil {
@@ -220,7 +228,12 @@ func (gh *graphqlHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err = edgraph.ProcessPersistedQuery(ctx, gqlReq); err != nil {
+	if gh.onlyTrustedDocuments() {
+		err = edgraph.ProcessTrustedDocument(ctx, gqlReq)
+	} else {
+		err = edgraph.ProcessPersistedQuery(ctx, gqlReq)
+	}
+	if err != nil {
 		write(w, schema.ErrorResponse(err), strings.Contains(r.Header.Get("Accept-Encoding"), "gzip"))
 		return
 	}
@@ -229,6 +242,12 @@ func (gh *graphqlHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	write(w, res, strings.Contains(r.Header.Get("Accept-Encoding"), "gzip"))
 }
 
+// onlyTrustedDocuments returns true if the handler only executes the trusted documents
+// registered through /admin.
+func (gh *graphqlHandler) onlyTrustedDocuments() bool {
+	return x.Config.GraphqlTrustedDocuments && !gh.admin
+}
+
 func (gh *graphqlHandler) isValid() bool {
 	return !(gh == nil || gh.resolver == nil)
 }
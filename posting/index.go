@@ -100,6 +100,16 @@ func (txn *Txn) addIndexMutations(ctx context.Context, info *indexMutationInfo)
 		// This data is not indexable
 		return err
 	}
+	for _, it := range info.tokenizers {
+		if it.Identifier() != tok.IdentHNSW {
+			continue
+		}
+		if info.op == pb.DirectedEdge_SET {
+			txn.addVector(attr, uid, info.val)
+		} else {
+			txn.deleteVector(attr, uid)
+		}
+	}
 
 	// Create a value token -> uid edge.
 	edge := &pb.DirectedEdge{
@@ -777,6 +787,7 @@ func (rb *IndexRebuild) DropIndexes(ctx context.Context) error {
 	prefixes = append(prefixes, prefixesToDropReverseEdges(ctx, rb)...)
 	prefixes = append(prefixes, prefixesToDropCountIndex(ctx, rb)...)
 	glog.Infof("Deleting indexes for %s", rb.Attr)
+	dropVectorIndex(rb.Attr)
 	return pstore.DropPrefix(prefixes...)
 }
 
@@ -974,7 +985,15 @@ func rebuildTokIndex(ctx context.Context, rb *IndexRebuild) error {
 			}
 		})
 	}
-	return builder.Run(ctx)
+	if err := builder.Run(ctx); err != nil {
+		return err
+	}
+	for _, t := range tokenizers {
+		if t.Identifier() == tok.IdentHNSW {
+			return rebuildVectorIndex(rb.Attr, rb.StartTs)
+		}
+	}
+	return nil
 }
 
 func (rb *IndexRebuild) needsCountIndexRebuild() indexOp {
@@ -1230,11 +1249,13 @@ func rebuildListType(ctx context.Context, rb *IndexRebuild) error {
 
 // DeleteAll deletes all entries in the posting list.
 func DeleteAll() error {
+	dropAllVectorIndexes()
 	return pstore.DropAll()
 }
 
 // DeleteData deletes all data but leaves types and schema intact.
 func DeleteData() error {
+	dropAllVectorIndexes()
 	return pstore.DropPrefix([]byte{x.DefaultPrefix})
 }
 
@@ -1242,6 +1263,7 @@ func DeleteData() error {
 func DeletePredicate(ctx context.Context, attr string) error {
 	glog.Infof("Dropping predicate: [%s]", attr)
 	prefix := x.PredicatePrefix(attr)
+	dropVectorIndex(attr)
 	if err := pstore.DropPrefix(prefix); err != nil {
 		return err
 	}
@@ -538,3 +538,36 @@ func TestNeedsListTypeRebuild(t *testing.T) {
 	require.False(t, rebuild)
 	require.Error(t, err)
 }
+
+func TestVectorIndexDeleteAndOverwrite(t *testing.T) {
+	require.NoError(t, schema.ParseBytes([]byte("embedding: float32vector @index(hnsw) ."), 1))
+	defer dropVectorIndex("embedding")
+
+	setVector := func(uid uint64, op uint32, vec string, startTs, commitTs uint64) {
+		l, err := GetNoStore(x.DataKey("embedding", uid), startTs)
+		require.NoError(t, err)
+		edge := &pb.DirectedEdge{
+			Value:     []byte(vec),
+			ValueType: pb.Posting_STRING,
+			Attr:      "embedding",
+			Entity:    uid,
+		}
+		addMutation(t, l, edge, op, startTs, commitTs, true)
+	}
+	setVector(1, Set, "[0, 0]", 1, 2)
+	setVector(2, Set, "[1, 1]", 3, 4)
+
+	index, err := VectorIndex("embedding")
+	require.NoError(t, err)
+	got, err := index.Search([]float32{0, 0}, 2, 10)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1, 2}, got)
+
+	// The overwritten and the deleted vectors are no longer found.
+	setVector(1, Set, "[5, 5]", 5, 6)
+	setVector(2, Del, "[1, 1]", 7, 8)
+	got, err = index.Search([]float32{0, 0}, 2, 10)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1}, got)
+	require.Equal(t, 1, index.Len())
+}
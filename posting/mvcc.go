@@ -254,6 +254,7 @@ func (txn *Txn) CommitToDisk(writer *TxnWriter, commitTs uint64) error {
 			return err
 		}
 	}
+	txn.applyVectors()
 	return nil
 }
 
@@ -278,6 +279,28 @@ func (txn *Txn) RemoveCachedKeys() {
 	}
 }
 
+// Predicates returns the predicates written by this txn.
+func (txn *Txn) Predicates() []string {
+	if txn == nil || txn.cache == nil {
+		return nil
+	}
+	txn.cache.RLock()
+	defer txn.cache.RUnlock()
+	preds := make(map[string]struct{})
+	for key := range txn.cache.deltas {
+		pk, err := x.Parse([]byte(key))
+		if err != nil || len(pk.Attr) == 0 {
+			continue
+		}
+		preds[pk.Attr] = struct{}{}
+	}
+	out := make([]string, 0, len(preds))
+	for pred := range preds {
+		out = append(out, pred)
+	}
+	return out
+}
+
 func WaitForCache() {
 	// TODO Investigate if this is needed and why Jepsen tests fail with the cache enabled.
 	// lCache.Wait()
@@ -395,10 +418,17 @@ func ReadPostingList(key []byte, it *badger.Iterator) (*List, error) {
 }
 
 func getNew(key []byte, pstore *badger.DB, readTs uint64) (*List, error) {
-	cachedVal, ok := lCache.Get(key)
-	if ok {
+	// Reads older than the immutable layer of the cached list, e.g. once the list was rolled
+	// up, read the older versions from disk, like the point-in-time reads of the versions only
+	// kept for the retention period. The lists they read aren't cached.
+	useCache := !isHistoricalRead(readTs)
+	if cachedVal, ok := lCache.Get(key); ok && useCache {
 		l, ok := cachedVal.(*List)
-		if ok && l != nil {
+		switch {
+		case !ok || l == nil:
+		case readTs < l.minTs:
+			useCache = false
+		default:
 			// No need to clone the immutable layer or the key since mutations will not modify it.
 			lCopy := &List{
 				minTs: l.minTs,
@@ -434,6 +464,8 @@ func getNew(key []byte, pstore *badger.DB, readTs uint64) (*List, error) {
 	if err != nil {
 		return l, err
 	}
-	lCache.Set(key, l, 0)
+	if useCache {
+		lCache.Set(key, l, 0)
+	}
 	return l, nil
 }
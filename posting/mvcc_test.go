@@ -22,6 +22,7 @@ import (
 
 	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/x"
+	"github.com/dgraph-io/ristretto"
 	"github.com/stretchr/testify/require"
 )
 
@@ -61,6 +62,48 @@ func TestRollupTimestamp(t *testing.T) {
 	require.Equal(t, uint64(10), kvs[0].Version)
 }
 
+func TestReadOlderThanRollup(t *testing.T) {
+	key := x.DataKey("rollupread", 1)
+	addEdgeToUID(t, "rollupread", 1, 2, 1, 2)
+	addEdgeToUID(t, "rollupread", 1, 3, 3, 4)
+
+	// The tests don't use the cache of the lists otherwise.
+	cache, err := ristretto.NewCache(&ristretto.Config{
+		NumCounters: 1000,
+		MaxCost:     1 << 20,
+		BufferItems: 64,
+	})
+	require.NoError(t, err)
+	lCache = cache
+	defer func() {
+		lCache = nil
+		cache.Close()
+	}()
+
+	// Roll up the list, as the incremental rollups do, and cache the rolled up list.
+	writer := NewTxnWriter(pstore)
+	require.NoError(t, IncrRollup.rollUpKey(writer, key))
+	require.NoError(t, writer.Flush())
+	l, err := getNew(key, pstore, math.MaxUint64)
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), l.minTs)
+	lCache.Wait()
+	_, ok := lCache.Get(key)
+	require.True(t, ok)
+
+	// A read older than the rollup reads the versions below it from disk.
+	l, err = getNew(key, pstore, 3)
+	require.NoError(t, err)
+	uidList, err := l.Uids(ListOptions{ReadTs: 3})
+	require.NoError(t, err)
+	require.Equal(t, []uint64{2}, uidList.Uids)
+
+	// The cached list is still the rolled up one.
+	cachedVal, ok := lCache.Get(key)
+	require.True(t, ok)
+	require.Equal(t, uint64(4), cachedVal.(*List).minTs)
+}
+
 func TestPostingListRead(t *testing.T) {
 	key := x.DataKey("emptypl", 1)
 
@@ -61,6 +61,10 @@ type Txn struct {
 	lastUpdate time.Time
 
 	cache *LocalCache // This pointer does not get modified.
+
+	// Values set on predicates indexed with the hnsw tokenizer, added to their HNSW graphs
+	// on commit.
+	vectors []pendingVector
 }
 
 // NewTxn returns a new Txn instance.
@@ -238,6 +242,7 @@ func (o *oracle) ProcessDelta(delta *pb.OracleDelta) {
 		delete(o.waiters, startTs)
 	}
 	x.AssertTrue(atomic.CompareAndSwapUint64(&o.maxAssigned, curMax, delta.MaxAssigned))
+	history.record(delta.MaxAssigned, time.Now())
 	ostats.Record(context.Background(),
 		x.MaxAssignedTs.M(int64(delta.MaxAssigned))) // Can't access o.MaxAssigned without atomics.
 }
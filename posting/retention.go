@@ -0,0 +1,268 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package posting
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+)
+
+// Rollups write complete posting lists with the discard bit set, so Badger drops the versions
+// below them once they are older than its discard ts. The discard ts is advanced after every
+// snapshot, which only leaves the versions needed by the pending transactions. With a
+// retention period, the discard ts is held back to the max ts assigned at the start of the
+// period, so that point-in-time queries can read the state at any time within the period.
+//
+// To map times to timestamps, the max assigned ts is recorded as the oracle advances, once
+// per second. With a retention period, the record is also appended to a file in the postings
+// directory, so that it survives restarts.
+
+var history = &tsHistory{}
+
+// historyFile is the name of the file holding the history, in the postings directory.
+const historyFile = "ts_history"
+
+// tsPointSize is the size of a point in the history file: the ts, then the time in
+// nanoseconds since the epoch.
+const tsPointSize = 16
+
+type tsPoint struct {
+	ts uint64
+	at time.Time
+}
+
+type tsHistory struct {
+	sync.RWMutex
+	retention  time.Duration
+	discardTs  uint64
+	snapshotTs uint64
+	points     []tsPoint
+
+	// The history file, if the history is persisted, and the number of points it holds.
+	file       *os.File
+	filePoints int
+}
+
+// SetRetention sets the period for which the old versions of the posting lists are kept. Zero
+// disables the retention, the versions are discarded as soon as they aren't needed. Otherwise,
+// the history recorded before the alpha restarted is read back.
+func SetRetention(d time.Duration) error {
+	history.Lock()
+	defer history.Unlock()
+	history.retention = d
+	if d == 0 {
+		return nil
+	}
+	return history.open(filepath.Join(pstore.Opts().Dir, historyFile), time.Now())
+}
+
+// open reads the points recorded in the history file, from the start of the retention period
+// at the given time, and keeps the file to append the next ones.
+func (h *tsHistory) open(path string, now time.Time) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "while reading history file %s", path)
+	}
+	cutoff := now.Add(-h.retention)
+	var points []tsPoint
+	for ; len(data) >= tsPointSize; data = data[tsPointSize:] {
+		p := tsPoint{
+			ts: binary.BigEndian.Uint64(data),
+			at: time.Unix(0, int64(binary.BigEndian.Uint64(data[8:]))),
+		}
+		// Keep the last point before the retention period, like record does.
+		if len(points) == 1 && !p.at.After(cutoff) {
+			points = points[:0]
+		}
+		points = append(points, p)
+	}
+	h.points = points
+	return h.rewrite(path)
+}
+
+// rewrite writes the points in memory to a new history file, which replaces the one at path.
+// Must be called with the lock held.
+func (h *tsHistory) rewrite(path string) error {
+	buf := make([]byte, 0, len(h.points)*tsPointSize)
+	for _, p := range h.points {
+		buf = appendTsPoint(buf, p)
+	}
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, buf, 0600); err != nil {
+		return errors.Wrapf(err, "while writing history file %s", tmp)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return errors.Wrapf(err, "while renaming history file %s", tmp)
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "while opening history file %s", path)
+	}
+	if h.file != nil {
+		_ = h.file.Close()
+	}
+	h.file, h.filePoints = f, len(h.points)
+	return nil
+}
+
+func appendTsPoint(buf []byte, p tsPoint) []byte {
+	var b [tsPointSize]byte
+	binary.BigEndian.PutUint64(b[:], p.ts)
+	binary.BigEndian.PutUint64(b[8:], uint64(p.at.UnixNano()))
+	return append(buf, b[:]...)
+}
+
+// persist appends the last point of the previous second to the history file, once a point is
+// recorded for the next second, and compacts the file once most of its points are before the
+// retention period. Must be called with the lock held.
+func (h *tsHistory) persist(p tsPoint) {
+	if h.file == nil {
+		return
+	}
+	var err error
+	if h.filePoints > 2*len(h.points)+60 {
+		err = h.rewrite(h.file.Name())
+	} else if _, err = h.file.Write(appendTsPoint(nil, p)); err == nil {
+		h.filePoints++
+	}
+	if err != nil {
+		glog.Warningf("Unable to persist the history of timestamps: %v", err)
+	}
+}
+
+// record notes that ts was the max assigned ts at the given time.
+func (h *tsHistory) record(ts uint64, at time.Time) {
+	h.Lock()
+	defer h.Unlock()
+	sec := at.Truncate(time.Second)
+	if n := len(h.points); n > 0 && h.points[n-1].at.Truncate(time.Second).Equal(sec) {
+		h.points[n-1] = tsPoint{ts: ts, at: at}
+		return
+	}
+	if n := len(h.points); n > 0 {
+		h.persist(h.points[n-1])
+	}
+	h.points = append(h.points, tsPoint{ts: ts, at: at})
+
+	// Drop the points before the retention period, but the last one.
+	cutoff := at.Add(-h.retention)
+	i := sort.Search(len(h.points), func(i int) bool { return h.points[i].at.After(cutoff) })
+	if i > 1 {
+		h.points = append(h.points[:0], h.points[i-1:]...)
+	}
+}
+
+// tsAt returns the max ts assigned at or before t, or false if the history doesn't go back
+// to t.
+func (h *tsHistory) tsAt(t time.Time) (uint64, bool) {
+	h.RLock()
+	defer h.RUnlock()
+	i := sort.Search(len(h.points), func(i int) bool { return h.points[i].at.After(t) })
+	if i == 0 {
+		return 0, false
+	}
+	return h.points[i-1].ts, true
+}
+
+// retainedTs returns the oldest ts that must stay readable at the given time.
+func (h *tsHistory) retainedTs(now time.Time) uint64 {
+	h.RLock()
+	retention := h.retention
+	h.RUnlock()
+	if retention == 0 {
+		return math.MaxUint64
+	}
+	// Nothing can be discarded until the history covers the whole period.
+	ts, _ := h.tsAt(now.Add(-retention))
+	return ts
+}
+
+// isHistoricalRead tells if a read at readTs can read versions that are only kept for the
+// retention period, below the ones rolled up by the last snapshot.
+func isHistoricalRead(readTs uint64) bool {
+	history.RLock()
+	defer history.RUnlock()
+	return history.retention > 0 && readTs < history.snapshotTs
+}
+
+// SetDiscardTs lets Badger discard the versions below ts, or below the start of the retention
+// period if it's older.
+func SetDiscardTs(ts uint64) {
+	snapshotTs := ts
+	if retained := history.retainedTs(time.Now()); retained < ts {
+		ts = retained
+	}
+	history.Lock()
+	defer history.Unlock()
+	if snapshotTs > history.snapshotTs {
+		history.snapshotTs = snapshotTs
+	}
+	if ts <= history.discardTs {
+		return
+	}
+	history.discardTs = ts
+	pstore.SetDiscardTs(ts)
+}
+
+// DiscardTs returns the ts below which versions may have been discarded.
+func DiscardTs() uint64 {
+	history.RLock()
+	defer history.RUnlock()
+	return history.discardTs
+}
+
+// ReadTsAt returns the ts a point-in-time query reads at. The point in time is either a
+// timestamp or a time in RFC3339 format, in which case the query reads at the max ts assigned
+// at that time.
+func ReadTsAt(at string) (uint64, error) {
+	ts, err := strconv.ParseUint(at, 0, 64)
+	if err != nil {
+		t, err := time.Parse(time.RFC3339Nano, at)
+		if err != nil {
+			return 0, errors.Errorf("Invalid point in time %q, expected a timestamp or"+
+				" a time in RFC3339 format", at)
+		}
+		if t.After(time.Now()) {
+			return 0, errors.Errorf("Point in time %s is in the future", at)
+		}
+		var ok bool
+		if ts, ok = history.tsAt(t); !ok {
+			return 0, errors.Errorf("Point in time %s is older than the retained history", at)
+		}
+	}
+
+	switch {
+	case ts == 0:
+		return 0, errors.Errorf("Point in time %q must be greater than zero", at)
+	case ts > Oracle().MaxAssigned():
+		return 0, errors.Errorf("Timestamp %d hasn't been assigned yet", ts)
+	case ts < DiscardTs():
+		return 0, errors.Errorf("Timestamp %d is older than the retained versions,"+
+			" which start at %d", ts, DiscardTs())
+	}
+	return ts, nil
+}
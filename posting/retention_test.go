@@ -0,0 +1,143 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package posting
+
+import (
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+)
+
+func TestTsHistory(t *testing.T) {
+	h := &tsHistory{retention: time.Minute}
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	// Two updates per second for 90 seconds.
+	for i := 0; i < 180; i++ {
+		h.record(uint64(10+i), start.Add(time.Duration(i)*500*time.Millisecond))
+	}
+	// One point per second is kept, from the last one before the retention period.
+	require.Len(t, h.points, 62)
+
+	ts, ok := h.tsAt(start.Add(30 * time.Second))
+	require.True(t, ok)
+	require.Equal(t, uint64(69), ts)
+	ts, ok = h.tsAt(start.Add(time.Hour))
+	require.True(t, ok)
+	require.Equal(t, uint64(189), ts)
+	_, ok = h.tsAt(start.Add(10 * time.Second))
+	require.False(t, ok)
+
+	require.Equal(t, uint64(69), h.retainedTs(start.Add(90*time.Second)))
+	// The history doesn't cover the retention period yet.
+	require.Equal(t, uint64(0), h.retainedTs(start.Add(60*time.Second)))
+	h.retention = 0
+	require.Equal(t, uint64(math.MaxUint64), h.retainedTs(start.Add(90*time.Second)))
+}
+
+func TestTsHistoryPersisted(t *testing.T) {
+	dir, err := ioutil.TempDir("", "history")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, historyFile)
+
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	h := &tsHistory{retention: time.Minute}
+	require.NoError(t, h.open(path, start))
+	for i := 0; i < 180; i++ {
+		h.record(uint64(10+i), start.Add(time.Duration(i)*500*time.Millisecond))
+	}
+
+	// The last point of each second is read back once the next second has started, from the
+	// last one before the retention period.
+	h = &tsHistory{retention: time.Minute}
+	require.NoError(t, h.open(path, start.Add(90*time.Second)))
+	require.Len(t, h.points, 60)
+	ts, ok := h.tsAt(start.Add(30 * time.Second))
+	require.True(t, ok)
+	require.Equal(t, uint64(69), ts)
+	ts, ok = h.tsAt(start.Add(time.Hour))
+	require.True(t, ok)
+	require.Equal(t, uint64(187), ts)
+
+	// The file only holds the points read back.
+	fi, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, int64(60*tsPointSize), fi.Size())
+}
+
+func TestIsHistoricalRead(t *testing.T) {
+	history.Lock()
+	history.snapshotTs = 100
+	history.Unlock()
+	defer func() {
+		history.Lock()
+		history.retention, history.snapshotTs = 0, 0
+		history.Unlock()
+	}()
+	// Without retention, the versions below the snapshot are discarded anyway.
+	require.False(t, isHistoricalRead(50))
+
+	history.Lock()
+	history.retention = time.Hour
+	history.Unlock()
+	require.True(t, isHistoricalRead(50))
+	require.False(t, isHistoricalRead(100))
+}
+
+func TestReadTsAt(t *testing.T) {
+	maxAssigned := Oracle().MaxAssigned() + 1000
+	Oracle().ProcessDelta(&pb.OracleDelta{MaxAssigned: maxAssigned})
+
+	ts, err := ReadTsAt("0x10")
+	require.NoError(t, err)
+	require.Equal(t, uint64(16), ts)
+	ts, err = ReadTsAt(time.Now().Format(time.RFC3339Nano))
+	require.NoError(t, err)
+	require.Equal(t, maxAssigned, ts)
+
+	for _, at := range []string{
+		"0",
+		"yesterday",
+		strconv.FormatUint(maxAssigned+1, 10),
+		time.Now().Add(time.Hour).Format(time.RFC3339),
+		time.Now().Add(-time.Hour).Format(time.RFC3339),
+	} {
+		_, err := ReadTsAt(at)
+		require.Error(t, err, at)
+	}
+
+	history.Lock()
+	history.discardTs = 20
+	history.Unlock()
+	defer func() {
+		history.Lock()
+		history.discardTs = 0
+		history.Unlock()
+	}()
+	_, err = ReadTsAt("19")
+	require.Error(t, err)
+	_, err = ReadTsAt("20")
+	require.NoError(t, err)
+}
@@ -0,0 +1,205 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package posting
+
+import (
+	"bytes"
+	"math"
+	"sync"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/golang/glog"
+
+	"github.com/dgraph-io/dgraph/tok/hnsw"
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// The HNSW graphs of the predicates indexed with the hnsw tokenizer are held in memory. A
+// graph is built when the index is rebuilt, or from the committed values the first time it's
+// needed after a restart, and the values set or deleted by a transaction are applied to it
+// once the transaction commits. The graph only holds the latest values, so the callers must
+// check the candidates returned by the graph against the values visible at their read
+// timestamp.
+
+type vectorIndex struct {
+	*hnsw.Index
+	once sync.Once
+	err  error
+}
+
+var vectorIndexes = struct {
+	sync.Mutex
+	m map[string]*vectorIndex
+}{m: make(map[string]*vectorIndex)}
+
+// VectorIndex returns the HNSW graph of the given predicate. If the graph isn't in memory
+// yet, it's built from all the committed values.
+func VectorIndex(attr string) (*hnsw.Index, error) {
+	vectorIndexes.Lock()
+	vi, ok := vectorIndexes.m[attr]
+	if !ok {
+		// Register the graph before building it, so that it gets the values committed meanwhile.
+		// Those that are also read by the build are inserted twice, which replaces them.
+		vi = &vectorIndex{Index: hnsw.New()}
+		vectorIndexes.m[attr] = vi
+	}
+	vectorIndexes.Unlock()
+
+	vi.once.Do(func() {
+		vi.err = buildVectorIndex(attr, math.MaxUint64, vi.Index)
+	})
+	if vi.err != nil {
+		vectorIndexes.Lock()
+		if vectorIndexes.m[attr] == vi {
+			delete(vectorIndexes.m, attr)
+		}
+		vectorIndexes.Unlock()
+		return nil, vi.err
+	}
+	return vi.Index, nil
+}
+
+// rebuildVectorIndex builds the HNSW graph of attr from the values visible at readTs, the
+// timestamp the index is rebuilt at, and replaces the graph in memory with it.
+func rebuildVectorIndex(attr string, readTs uint64) error {
+	vi := &vectorIndex{Index: hnsw.New()}
+	vi.once.Do(func() {
+		vi.err = buildVectorIndex(attr, readTs, vi.Index)
+	})
+	if vi.err != nil {
+		return vi.err
+	}
+	vectorIndexes.Lock()
+	defer vectorIndexes.Unlock()
+	vectorIndexes.m[attr] = vi
+	return nil
+}
+
+func buildVectorIndex(attr string, readTs uint64, index *hnsw.Index) error {
+	glog.Infof("Building HNSW index for attr %s at ts %d", attr, readTs)
+	txn := pstore.NewTransactionAt(readTs, false)
+	defer txn.Discard()
+
+	pk := x.ParsedKey{Attr: attr}
+	itOpt := badger.DefaultIteratorOptions
+	itOpt.AllVersions = true
+	itOpt.Prefix = pk.DataPrefix()
+	it := txn.NewIterator(itOpt)
+	defer it.Close()
+
+	var prevKey []byte
+	for it.Seek(itOpt.Prefix); it.Valid(); {
+		item := it.Item()
+		if bytes.Equal(item.Key(), prevKey) {
+			it.Next()
+			continue
+		}
+		prevKey = append(prevKey[:0], item.Key()...)
+
+		pk, err := x.Parse(item.Key())
+		if err != nil {
+			return err
+		}
+		if pk.HasStartUid {
+			continue
+		}
+		l, err := ReadPostingList(item.KeyCopy(nil), it)
+		if err != nil {
+			return err
+		}
+		val, err := l.Value(readTs)
+		switch {
+		case err == ErrNoValue:
+			continue
+		case err != nil:
+			return err
+		}
+		insertVector(index, attr, pk.Uid, val)
+	}
+	glog.Infof("Built HNSW index for attr %s with %d vectors", attr, index.Len())
+	return nil
+}
+
+// pendingVector is a value set or deleted by a transaction on a predicate indexed with the
+// hnsw tokenizer. It's applied to the HNSW graph once the transaction commits.
+type pendingVector struct {
+	attr string
+	uid  uint64
+	val  types.Val
+	del  bool
+}
+
+func (txn *Txn) addVector(attr string, uid uint64, val types.Val) {
+	txn.Lock()
+	defer txn.Unlock()
+	txn.vectors = append(txn.vectors, pendingVector{attr: attr, uid: uid, val: val})
+}
+
+func (txn *Txn) deleteVector(attr string, uid uint64) {
+	txn.Lock()
+	defer txn.Unlock()
+	txn.vectors = append(txn.vectors, pendingVector{attr: attr, uid: uid, del: true})
+}
+
+// applyVectors applies the values set or deleted by the committed transaction to the HNSW
+// graphs that are in memory, in the order of the mutations. A value that's replaced is deleted
+// before the new one is added.
+func (txn *Txn) applyVectors() {
+	txn.Lock()
+	vectors := txn.vectors
+	txn.vectors = nil
+	txn.Unlock()
+
+	for _, v := range vectors {
+		vectorIndexes.Lock()
+		vi, ok := vectorIndexes.m[v.attr]
+		vectorIndexes.Unlock()
+		switch {
+		case !ok:
+		case v.del:
+			vi.Index.Delete(v.uid)
+		default:
+			insertVector(vi.Index, v.attr, v.uid, v.val)
+		}
+	}
+}
+
+func insertVector(index *hnsw.Index, attr string, uid uint64, val types.Val) {
+	vec, err := types.Convert(val, types.VFloatID)
+	if err == nil {
+		err = index.Insert(uid, vec.Value.([]float32))
+	}
+	if err != nil {
+		// The value stays in the store but can't be found by similar_to.
+		glog.Warningf("Unable to add vector of uid %#x to HNSW index of %s: %v", uid, attr, err)
+	}
+}
+
+// dropVectorIndex removes the HNSW graph of attr from memory, it's built again when needed.
+func dropVectorIndex(attr string) {
+	vectorIndexes.Lock()
+	defer vectorIndexes.Unlock()
+	delete(vectorIndexes.m, attr)
+}
+
+// dropAllVectorIndexes removes all the HNSW graphs from memory.
+func dropAllVectorIndexes() {
+	vectorIndexes.Lock()
+	defer vectorIndexes.Unlock()
+	vectorIndexes.m = make(map[string]*vectorIndex)
+}
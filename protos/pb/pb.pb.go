@@ -133,6 +133,7 @@ const (
 	Posting_PASSWORD Posting_ValType = 8
 	Posting_STRING   Posting_ValType = 9
 	Posting_OBJECT   Posting_ValType = 10
+	Posting_VFLOAT   Posting_ValType = 11
 )
 
 var Posting_ValType_name = map[int32]string{
@@ -147,6 +148,7 @@ var Posting_ValType_name = map[int32]string{
 	8:  "PASSWORD",
 	9:  "STRING",
 	10: "OBJECT",
+	11: "VFLOAT",
 }
 
 var Posting_ValType_value = map[string]int32{
@@ -161,6 +163,7 @@ var Posting_ValType_value = map[string]int32{
 	"PASSWORD": 8,
 	"STRING":   9,
 	"OBJECT":   10,
+	"VFLOAT":   11,
 }
 
 func (x Posting_ValType) String() string {
@@ -1748,6 +1751,11 @@ type Tablet struct {
 	ReadOnly             bool     `protobuf:"varint,9,opt,name=read_only,json=readOnly,proto3" json:"readOnly,omitempty"`
 	MoveTs               uint64   `protobuf:"varint,10,opt,name=move_ts,json=moveTs,proto3" json:"moveTs,omitempty"`
 	UncompressedBytes    int64    `protobuf:"varint,11,opt,name=uncompressed_bytes,json=uncompressedBytes,proto3" json:"uncompressed_bytes,omitempty"`
+	NumUids              uint64   `protobuf:"varint,12,opt,name=num_uids,json=numUids,proto3" json:"num_uids,omitempty"`
+	NumTokens            uint64   `protobuf:"varint,13,opt,name=num_tokens,json=numTokens,proto3" json:"num_tokens,omitempty"`
+	NumIndexUids         uint64   `protobuf:"varint,14,opt,name=num_index_uids,json=numIndexUids,proto3" json:"num_index_uids,omitempty"`
+	TopTokenHashes       []uint64 `protobuf:"varint,15,rep,packed,name=top_token_hashes,json=topTokenHashes,proto3" json:"top_token_hashes,omitempty"`
+	TopTokenCounts       []uint64 `protobuf:"varint,16,rep,packed,name=top_token_counts,json=topTokenCounts,proto3" json:"top_token_counts,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1842,6 +1850,41 @@ func (m *Tablet) GetUncompressedBytes() int64 {
 	return 0
 }
 
+func (m *Tablet) GetNumUids() uint64 {
+	if m != nil {
+		return m.NumUids
+	}
+	return 0
+}
+
+func (m *Tablet) GetNumTokens() uint64 {
+	if m != nil {
+		return m.NumTokens
+	}
+	return 0
+}
+
+func (m *Tablet) GetNumIndexUids() uint64 {
+	if m != nil {
+		return m.NumIndexUids
+	}
+	return 0
+}
+
+func (m *Tablet) GetTopTokenHashes() []uint64 {
+	if m != nil {
+		return m.TopTokenHashes
+	}
+	return nil
+}
+
+func (m *Tablet) GetTopTokenCounts() []uint64 {
+	if m != nil {
+		return m.TopTokenCounts
+	}
+	return nil
+}
+
 type DirectedEdge struct {
 	Entity               uint64          `protobuf:"fixed64,1,opt,name=entity,proto3" json:"entity,omitempty"`
 	Attr                 string          `protobuf:"bytes,2,opt,name=attr,proto3" json:"attr,omitempty"`
@@ -2436,6 +2479,7 @@ type Proposal struct {
 	Index                uint64           `protobuf:"varint,10,opt,name=index,proto3" json:"index,omitempty"`
 	ExpectedChecksum     uint64           `protobuf:"varint,11,opt,name=expected_checksum,json=expectedChecksum,proto3" json:"expected_checksum,omitempty"`
 	Restore              *RestoreRequest  `protobuf:"bytes,12,opt,name=restore,proto3" json:"restore,omitempty"`
+	CdcState             *CDCState        `protobuf:"bytes,13,opt,name=cdc_state,json=cdcState,proto3" json:"cdc_state,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
 	XXX_unrecognized     []byte           `json:"-"`
 	XXX_sizecache        int32            `json:"-"`
@@ -2537,6 +2581,13 @@ func (m *Proposal) GetRestore() *RestoreRequest {
 	return nil
 }
 
+func (m *Proposal) GetCdcState() *CDCState {
+	if m != nil {
+		return m.CdcState
+	}
+	return nil
+}
+
 type KVS struct {
 	Data []byte `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"`
 	// done used to indicate if the stream of KVS is over.
@@ -4665,6 +4716,7 @@ type ExportRequest struct {
 	SecretKey            string   `protobuf:"bytes,7,opt,name=secret_key,json=secretKey,proto3" json:"secret_key,omitempty"`
 	SessionToken         string   `protobuf:"bytes,8,opt,name=session_token,json=sessionToken,proto3" json:"session_token,omitempty"`
 	Anonymous            bool     `protobuf:"varint,9,opt,name=anonymous,proto3" json:"anonymous,omitempty"`
+	Namespace            uint64   `protobuf:"varint,10,opt,name=namespace,proto3" json:"namespace,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -4766,6 +4818,13 @@ func (m *ExportRequest) GetAnonymous() bool {
 	return false
 }
 
+func (m *ExportRequest) GetNamespace() uint64 {
+	if m != nil {
+		return m.Namespace
+	}
+	return 0
+}
+
 type ExportResponse struct {
 	// 0 indicates a success, and a non-zero code indicates failure
 	Code                 int32    `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
@@ -5116,6 +5175,55 @@ func (m *UpdateGraphQLSchemaResponse) GetUid() uint64 {
 	return 0
 }
 
+// CDCState is proposed by the leader of a group once the change events of the transactions
+// committed up to sent_ts were handed over to the CDC sink.
+type CDCState struct {
+	SentTs               uint64   `protobuf:"varint,1,opt,name=sent_ts,json=sentTs,proto3" json:"sent_ts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CDCState) Reset()         { *m = CDCState{} }
+func (m *CDCState) String() string { return proto.CompactTextString(m) }
+func (*CDCState) ProtoMessage()    {}
+func (*CDCState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{64}
+}
+func (m *CDCState) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CDCState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CDCState.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CDCState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CDCState.Merge(m, src)
+}
+func (m *CDCState) XXX_Size() int {
+	return m.Size()
+}
+func (m *CDCState) XXX_DiscardUnknown() {
+	xxx_messageInfo_CDCState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CDCState proto.InternalMessageInfo
+
+func (m *CDCState) GetSentTs() uint64 {
+	if m != nil {
+		return m.SentTs
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterEnum("pb.DirectedEdge_Op", DirectedEdge_Op_name, DirectedEdge_Op_value)
 	proto.RegisterEnum("pb.Mutations_DropOp", Mutations_DropOp_name, Mutations_DropOp_value)
@@ -5196,316 +5304,324 @@ func init() {
 	proto.RegisterType((*BackupPostingList)(nil), "pb.BackupPostingList")
 	proto.RegisterType((*UpdateGraphQLSchemaRequest)(nil), "pb.UpdateGraphQLSchemaRequest")
 	proto.RegisterType((*UpdateGraphQLSchemaResponse)(nil), "pb.UpdateGraphQLSchemaResponse")
+	proto.RegisterType((*CDCState)(nil), "pb.CDCState")
 }
 
 func init() { proto.RegisterFile("pb.proto", fileDescriptor_f80abaa17e25ccc8) }
 
 var fileDescriptor_f80abaa17e25ccc8 = []byte{
-	// 4853 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x7b, 0xcd, 0x6f, 0x1c, 0x57,
-	0x72, 0xb8, 0xa6, 0xe7, 0xb3, 0x6b, 0x66, 0xa8, 0xd1, 0x93, 0x56, 0x9e, 0x1d, 0xd9, 0x22, 0xdd,
-	0xb2, 0xd6, 0x5c, 0xcb, 0xa2, 0x24, 0x7a, 0x7f, 0xf8, 0xad, 0xbd, 0x08, 0x10, 0x52, 0x1c, 0xca,
-	0xb4, 0x28, 0x92, 0xdb, 0x1c, 0x6a, 0x3f, 0x0e, 0x19, 0x34, 0xbb, 0x1f, 0xc9, 0x5e, 0xf6, 0x74,
-	0xf7, 0x76, 0xf7, 0x70, 0x49, 0xdf, 0x82, 0x00, 0x41, 0x0e, 0xc9, 0x29, 0x87, 0xec, 0x29, 0x01,
-	0xf2, 0x0f, 0x04, 0xc9, 0x29, 0x08, 0x90, 0x4b, 0x10, 0x04, 0x41, 0x0e, 0x41, 0xfe, 0x81, 0x28,
-	0x81, 0x93, 0x93, 0x80, 0x5c, 0x12, 0x20, 0x40, 0x6e, 0x41, 0x55, 0xbd, 0xfe, 0x1a, 0x0e, 0x25,
-	0x7b, 0x81, 0x3d, 0xe4, 0x34, 0xaf, 0xaa, 0xde, 0x67, 0x55, 0xbd, 0xfa, 0x7a, 0x3d, 0xd0, 0x0a,
-	0x0f, 0x57, 0xc2, 0x28, 0x48, 0x02, 0xa1, 0x85, 0x87, 0x03, 0xdd, 0x0a, 0x5d, 0x06, 0x07, 0x1f,
-	0x1d, 0xbb, 0xc9, 0xc9, 0xf4, 0x70, 0xc5, 0x0e, 0x26, 0x8f, 0x9c, 0xe3, 0xc8, 0x0a, 0x4f, 0x1e,
-	0xba, 0xc1, 0xa3, 0x43, 0xcb, 0x39, 0x96, 0xd1, 0xa3, 0xb3, 0xd5, 0x47, 0xe1, 0xe1, 0xa3, 0x74,
-	0xe8, 0xe0, 0x61, 0xa1, 0xef, 0x71, 0x70, 0x1c, 0x3c, 0x22, 0xf4, 0xe1, 0xf4, 0x88, 0x20, 0x02,
-	0xa8, 0xc5, 0xdd, 0x8d, 0x01, 0xd4, 0xb6, 0xdd, 0x38, 0x11, 0x02, 0x6a, 0x53, 0xd7, 0x89, 0xfb,
-	0x95, 0xa5, 0xea, 0x72, 0xc3, 0xa4, 0xb6, 0xf1, 0x02, 0xf4, 0x91, 0x15, 0x9f, 0xbe, 0xb4, 0xbc,
-	0xa9, 0x14, 0x3d, 0xa8, 0x9e, 0x59, 0x5e, 0xbf, 0xb2, 0x54, 0x59, 0xee, 0x98, 0xd8, 0x14, 0x2b,
-	0xd0, 0x3a, 0xb3, 0xbc, 0x71, 0x72, 0x11, 0xca, 0xbe, 0xb6, 0x54, 0x59, 0x5e, 0x58, 0xbd, 0xb9,
-	0x12, 0x1e, 0xae, 0xec, 0x05, 0x71, 0xe2, 0xfa, 0xc7, 0x2b, 0x2f, 0x2d, 0x6f, 0x74, 0x11, 0x4a,
-	0xb3, 0x79, 0xc6, 0x0d, 0x63, 0x17, 0xda, 0xfb, 0x91, 0xbd, 0x39, 0xf5, 0xed, 0xc4, 0x0d, 0x7c,
-	0x5c, 0xd1, 0xb7, 0x26, 0x92, 0x66, 0xd4, 0x4d, 0x6a, 0x23, 0xce, 0x8a, 0x8e, 0xe3, 0x7e, 0x75,
-	0xa9, 0x8a, 0x38, 0x6c, 0x8b, 0x3e, 0x34, 0xdd, 0xf8, 0x69, 0x30, 0xf5, 0x93, 0x7e, 0x6d, 0xa9,
-	0xb2, 0xdc, 0x32, 0x53, 0xd0, 0xf8, 0x93, 0x2a, 0xd4, 0x7f, 0x38, 0x95, 0xd1, 0x05, 0x8d, 0x4b,
-	0x92, 0x28, 0x9d, 0x0b, 0xdb, 0xe2, 0x16, 0xd4, 0x3d, 0xcb, 0x3f, 0x8e, 0xfb, 0x1a, 0x4d, 0xc6,
-	0x80, 0xb8, 0x03, 0xba, 0x75, 0x94, 0xc8, 0x68, 0x3c, 0x75, 0x9d, 0x7e, 0x75, 0xa9, 0xb2, 0xdc,
-	0x30, 0x5b, 0x84, 0x38, 0x70, 0x1d, 0xf1, 0x6d, 0x68, 0x39, 0xc1, 0xd8, 0x2e, 0xae, 0xe5, 0x04,
-	0xb4, 0x96, 0xb8, 0x07, 0xad, 0xa9, 0xeb, 0x8c, 0x3d, 0x37, 0x4e, 0xfa, 0xf5, 0xa5, 0xca, 0x72,
-	0x7b, 0xb5, 0x85, 0x87, 0x45, 0xde, 0x99, 0xcd, 0xa9, 0xeb, 0x10, 0x13, 0x3f, 0x82, 0x56, 0x1c,
-	0xd9, 0xe3, 0xa3, 0xa9, 0x6f, 0xf7, 0x1b, 0xd4, 0xe9, 0x3a, 0x76, 0x2a, 0x9c, 0xda, 0x6c, 0xc6,
-	0x0c, 0xe0, 0xb1, 0x22, 0x79, 0x26, 0xa3, 0x58, 0xf6, 0x9b, 0xbc, 0x94, 0x02, 0xc5, 0x63, 0x68,
-	0x1f, 0x59, 0xb6, 0x4c, 0xc6, 0xa1, 0x15, 0x59, 0x93, 0x7e, 0x2b, 0x9f, 0x68, 0x13, 0xd1, 0x7b,
-	0x88, 0x8d, 0x4d, 0x38, 0xca, 0x00, 0xf1, 0x09, 0x74, 0x09, 0x8a, 0xc7, 0x47, 0xae, 0x97, 0xc8,
-	0xa8, 0xaf, 0xd3, 0x98, 0x05, 0x1a, 0x43, 0x98, 0x51, 0x24, 0xa5, 0xd9, 0xe1, 0x4e, 0x8c, 0x11,
-	0xef, 0x01, 0xc8, 0xf3, 0xd0, 0xf2, 0x9d, 0xb1, 0xe5, 0x79, 0x7d, 0xa0, 0x3d, 0xe8, 0x8c, 0x59,
-	0xf3, 0x3c, 0xf1, 0x0e, 0xee, 0xcf, 0x72, 0xc6, 0x49, 0xdc, 0xef, 0x2e, 0x55, 0x96, 0x6b, 0x66,
-	0x03, 0xc1, 0x51, 0x8c, 0x7c, 0xb5, 0x2d, 0xfb, 0x44, 0xf6, 0x17, 0x96, 0x2a, 0xcb, 0x75, 0x93,
-	0x01, 0xc4, 0x1e, 0xb9, 0x51, 0x9c, 0xf4, 0xaf, 0x33, 0x96, 0x00, 0x63, 0x15, 0x74, 0xd2, 0x1e,
-	0xe2, 0xce, 0x7d, 0x68, 0x9c, 0x21, 0xc0, 0x4a, 0xd6, 0x5e, 0xed, 0xe2, 0xf6, 0x32, 0x05, 0x33,
-	0x15, 0xd1, 0xb8, 0x0b, 0xad, 0x6d, 0xcb, 0x3f, 0x4e, 0xb5, 0x12, 0xc5, 0x46, 0x03, 0x74, 0x93,
-	0xda, 0xc6, 0x2f, 0x35, 0x68, 0x98, 0x32, 0x9e, 0x7a, 0x89, 0xf8, 0x10, 0x00, 0x85, 0x32, 0xb1,
-	0x92, 0xc8, 0x3d, 0x57, 0xb3, 0xe6, 0x62, 0xd1, 0xa7, 0xae, 0xf3, 0x82, 0x48, 0xe2, 0x31, 0x74,
-	0x68, 0xf6, 0xb4, 0xab, 0x96, 0x6f, 0x20, 0xdb, 0x9f, 0xd9, 0xa6, 0x2e, 0x6a, 0xc4, 0x6d, 0x68,
-	0x90, 0x1e, 0xb0, 0x2e, 0x76, 0x4d, 0x05, 0x89, 0xfb, 0xb0, 0xe0, 0xfa, 0x09, 0xca, 0xc9, 0x4e,
-	0xc6, 0x8e, 0x8c, 0x53, 0x45, 0xe9, 0x66, 0xd8, 0x0d, 0x19, 0x27, 0xe2, 0x09, 0x30, 0xb3, 0xd3,
-	0x05, 0xeb, 0xb4, 0xe0, 0x42, 0x26, 0xc4, 0x98, 0x57, 0xa4, 0x3e, 0x6a, 0xc5, 0x87, 0xd0, 0xc6,
-	0xf3, 0xa5, 0x23, 0x1a, 0x34, 0xa2, 0x43, 0xa7, 0x51, 0xec, 0x30, 0x01, 0x3b, 0xa8, 0xee, 0xc8,
-	0x1a, 0x54, 0x46, 0x56, 0x1e, 0x6a, 0x1b, 0x43, 0xa8, 0xef, 0x46, 0x8e, 0x8c, 0xe6, 0xde, 0x07,
-	0x01, 0x35, 0x47, 0xc6, 0x36, 0x5d, 0xd5, 0x96, 0x49, 0xed, 0xfc, 0x8e, 0x54, 0x0b, 0x77, 0xc4,
-	0xf8, 0xe3, 0x0a, 0xb4, 0xf7, 0x83, 0x28, 0x79, 0x21, 0xe3, 0xd8, 0x3a, 0x96, 0x62, 0x11, 0xea,
-	0x01, 0x4e, 0xab, 0x38, 0xac, 0xe3, 0x9e, 0x68, 0x1d, 0x93, 0xf1, 0x33, 0x72, 0xd0, 0xae, 0x96,
-	0x03, 0xea, 0x0e, 0xdd, 0xae, 0xaa, 0xd2, 0x1d, 0xba, 0x5b, 0xb7, 0xa1, 0x11, 0x1c, 0x1d, 0xc5,
-	0x92, 0x79, 0x59, 0x37, 0x15, 0x74, 0xa5, 0x0a, 0x1a, 0xff, 0x0f, 0x00, 0xf7, 0xf7, 0x0d, 0xb5,
-	0xc0, 0x38, 0x81, 0xb6, 0x69, 0x1d, 0x25, 0x4f, 0x03, 0x3f, 0x91, 0xe7, 0x89, 0x58, 0x00, 0xcd,
-	0x75, 0x88, 0x45, 0x0d, 0x53, 0x73, 0x1d, 0xdc, 0xdc, 0x71, 0x14, 0x4c, 0x43, 0xe2, 0x50, 0xd7,
-	0x64, 0x80, 0x58, 0xe9, 0x38, 0x11, 0xed, 0x18, 0x59, 0xe9, 0x38, 0x91, 0x58, 0x84, 0x76, 0xec,
-	0x5b, 0x61, 0x7c, 0x12, 0x24, 0xb8, 0xb9, 0x1a, 0x6d, 0x0e, 0x52, 0xd4, 0x28, 0x36, 0xfe, 0x43,
-	0x83, 0xc6, 0x0b, 0x39, 0x39, 0x94, 0xd1, 0xa5, 0x55, 0x1e, 0x43, 0x8b, 0x26, 0x1e, 0xbb, 0x0e,
-	0x2f, 0xb4, 0xfe, 0xad, 0xd7, 0xaf, 0x16, 0x6f, 0x10, 0x6e, 0xcb, 0xf9, 0x38, 0x98, 0xb8, 0x89,
-	0x9c, 0x84, 0xc9, 0x85, 0xd9, 0x54, 0xa8, 0xb9, 0x3b, 0xb8, 0x0d, 0x0d, 0x4f, 0x5a, 0x28, 0x13,
-	0x56, 0x3f, 0x05, 0x89, 0x87, 0xd0, 0xb4, 0x26, 0x63, 0x47, 0x5a, 0x0e, 0x59, 0xa9, 0xd6, 0xfa,
-	0xad, 0xd7, 0xaf, 0x16, 0x7b, 0xd6, 0x64, 0x43, 0x5a, 0xc5, 0xb9, 0x1b, 0x8c, 0x11, 0x9f, 0xa2,
-	0xce, 0xc5, 0xc9, 0x78, 0x1a, 0x3a, 0x56, 0x22, 0xc9, 0x66, 0xd5, 0xd6, 0xfb, 0xaf, 0x5f, 0x2d,
-	0xde, 0x42, 0xf4, 0x01, 0x61, 0x0b, 0xc3, 0x20, 0xc7, 0x8a, 0x2d, 0xb8, 0x61, 0x7b, 0xd3, 0x18,
-	0x4d, 0xa9, 0xeb, 0x1f, 0x05, 0xe3, 0xc0, 0xf7, 0x2e, 0x48, 0x4c, 0xad, 0xf5, 0xf7, 0x5e, 0xbf,
-	0x5a, 0xfc, 0xb6, 0x22, 0x6e, 0xf9, 0x47, 0xc1, 0xae, 0xef, 0x5d, 0x14, 0x66, 0xb9, 0x3e, 0x43,
-	0x12, 0xbf, 0x09, 0x0b, 0x47, 0x41, 0x64, 0xcb, 0x71, 0xc6, 0x98, 0x05, 0x9a, 0x67, 0xf0, 0xfa,
-	0xd5, 0xe2, 0x6d, 0xa2, 0x3c, 0xbb, 0xc4, 0x9d, 0x4e, 0x11, 0x6f, 0xfc, 0xb3, 0x06, 0x75, 0x6a,
-	0x8b, 0xc7, 0xd0, 0x9c, 0x10, 0xe3, 0x53, 0x2b, 0x73, 0x1b, 0x35, 0x81, 0x68, 0x2b, 0x2c, 0x91,
-	0x78, 0xe8, 0x27, 0xd1, 0x85, 0x99, 0x76, 0xc3, 0x11, 0x89, 0x75, 0xe8, 0xc9, 0x24, 0x56, 0x9a,
-	0x5b, 0x18, 0x31, 0x62, 0x82, 0x1a, 0xa1, 0xba, 0xcd, 0x8a, 0xbf, 0x3a, 0x2b, 0x7e, 0x31, 0x80,
-	0x96, 0x7d, 0x22, 0xed, 0xd3, 0x78, 0x3a, 0x51, 0xca, 0x91, 0xc1, 0xe2, 0x1e, 0x74, 0xa9, 0x1d,
-	0x06, 0xae, 0x4f, 0xc3, 0xeb, 0xd4, 0xa1, 0x93, 0x23, 0x47, 0xf1, 0x60, 0x13, 0x3a, 0xc5, 0xcd,
-	0xa2, 0xf3, 0x3d, 0x95, 0x17, 0xa4, 0x45, 0x35, 0x13, 0x9b, 0x62, 0x09, 0xea, 0x64, 0xae, 0x48,
-	0x87, 0xda, 0xab, 0x80, 0x7b, 0xe6, 0x21, 0x26, 0x13, 0x3e, 0xd3, 0xbe, 0x5f, 0xc1, 0x79, 0x8a,
-	0x47, 0x28, 0xce, 0xa3, 0x5f, 0x3d, 0x0f, 0x0f, 0x29, 0xcc, 0x63, 0x04, 0xd0, 0xdc, 0x76, 0x6d,
-	0xe9, 0xc7, 0xe4, 0xa2, 0xa7, 0xb1, 0xcc, 0x4c, 0x0b, 0xb6, 0xf1, 0xbc, 0x13, 0xeb, 0x7c, 0x27,
-	0x70, 0x64, 0x4c, 0xf3, 0xd4, 0xcc, 0x0c, 0x46, 0x9a, 0x3c, 0x0f, 0xdd, 0xe8, 0x62, 0xc4, 0x9c,
-	0xaa, 0x9a, 0x19, 0x8c, 0x3e, 0x50, 0xfa, 0xb8, 0x98, 0x93, 0xba, 0x5b, 0x05, 0x1a, 0x7f, 0x53,
-	0x85, 0xce, 0x4f, 0x65, 0x14, 0xec, 0x45, 0x41, 0x18, 0xc4, 0x96, 0x27, 0xd6, 0xca, 0x3c, 0x67,
-	0xd9, 0x2e, 0xe1, 0x6e, 0x8b, 0xdd, 0x56, 0xf6, 0x33, 0x21, 0xb0, 0xcc, 0x8a, 0x52, 0x31, 0xa0,
-	0xc1, 0x32, 0x9f, 0xc3, 0x33, 0x45, 0xc1, 0x3e, 0x2c, 0x65, 0xda, 0x6b, 0x99, 0x1f, 0x8a, 0x22,
-	0xee, 0x02, 0x4c, 0xac, 0xf3, 0x6d, 0x69, 0xc5, 0x72, 0xcb, 0x49, 0x2f, 0x7f, 0x8e, 0x51, 0xdc,
-	0x18, 0x9d, 0xfb, 0xa3, 0x54, 0xb8, 0x19, 0x2c, 0xde, 0x05, 0x7d, 0x62, 0x9d, 0xa3, 0x15, 0xda,
-	0x72, 0xf8, 0xba, 0x99, 0x39, 0x42, 0xbc, 0x0f, 0xd5, 0xe4, 0xdc, 0x27, 0x93, 0x8e, 0x1e, 0x1f,
-	0x03, 0xc0, 0xd1, 0xb9, 0xaf, 0xec, 0x95, 0x89, 0x34, 0x94, 0xa0, 0xed, 0x3a, 0xe4, 0xe0, 0x75,
-	0x13, 0x9b, 0xe2, 0x3e, 0x34, 0x3d, 0x96, 0x0d, 0x39, 0xf1, 0xf6, 0x6a, 0x9b, 0x6d, 0x1f, 0xa1,
-	0xcc, 0x94, 0x26, 0x3e, 0x86, 0x56, 0xca, 0x8b, 0x7e, 0x9b, 0xfa, 0xf5, 0x52, 0xee, 0xa5, 0x4c,
-	0x33, 0xb3, 0x1e, 0x83, 0xdf, 0x80, 0xeb, 0x33, 0xac, 0x2c, 0xea, 0x4e, 0x97, 0x75, 0xe7, 0x56,
-	0x51, 0x77, 0x6a, 0x05, 0x7d, 0xf9, 0xa2, 0xd6, 0x6a, 0xf5, 0x74, 0xe3, 0x5f, 0xaa, 0x70, 0x5d,
-	0xa9, 0xf1, 0x89, 0x1b, 0xee, 0x27, 0x68, 0x36, 0xfa, 0xd0, 0x24, 0xa3, 0xaf, 0x34, 0xa8, 0x66,
-	0xa6, 0xa0, 0xf8, 0xff, 0xd0, 0xa0, 0xfb, 0x9f, 0x5e, 0xc3, 0xc5, 0x5c, 0x3c, 0xd9, 0x70, 0xbe,
-	0x96, 0x4a, 0xb6, 0xaa, 0xbb, 0xf8, 0x1e, 0xd4, 0xbf, 0x94, 0x51, 0xc0, 0x4e, 0xac, 0xbd, 0x7a,
-	0x77, 0xde, 0x38, 0x3c, 0xa6, 0x1a, 0xc6, 0x9d, 0x7f, 0x8d, 0x52, 0xfc, 0x00, 0xdd, 0xd6, 0x24,
-	0x38, 0x93, 0x4e, 0xbf, 0x49, 0x3b, 0x2a, 0x2a, 0x5a, 0x4a, 0x4a, 0x05, 0xd9, 0x9a, 0x2b, 0x48,
-	0xfd, 0x6a, 0x41, 0x0e, 0x36, 0xa0, 0x5d, 0xe0, 0xc2, 0x1c, 0xb1, 0x2c, 0x96, 0xaf, 0xb4, 0x9e,
-	0x99, 0xb3, 0xa2, 0x65, 0xd8, 0x00, 0xc8, 0x79, 0xf2, 0xab, 0xda, 0x17, 0xe3, 0xb7, 0x2b, 0x70,
-	0xfd, 0x69, 0xe0, 0xfb, 0x92, 0x82, 0x5b, 0x96, 0x70, 0x7e, 0xcd, 0x2a, 0x57, 0x5e, 0xb3, 0xef,
-	0x42, 0x3d, 0xc6, 0xce, 0x6a, 0xf6, 0x9b, 0x73, 0x44, 0x66, 0x72, 0x0f, 0x34, 0xb6, 0x13, 0xeb,
-	0x7c, 0x1c, 0x4a, 0xdf, 0x71, 0xfd, 0xe3, 0xd4, 0xd8, 0x4e, 0xac, 0xf3, 0x3d, 0xc6, 0x18, 0x7f,
-	0xa9, 0x01, 0x7c, 0x2e, 0x2d, 0x2f, 0x39, 0x41, 0x87, 0x82, 0x72, 0x73, 0xfd, 0x38, 0xb1, 0x7c,
-	0x3b, 0x4d, 0x2d, 0x32, 0x18, 0x95, 0x0f, 0xbd, 0xa7, 0x8c, 0xd9, 0x4c, 0xe9, 0x66, 0x0a, 0xa2,
-	0x3f, 0xc5, 0xe5, 0xa6, 0xb1, 0xf2, 0xb2, 0x0a, 0xca, 0x63, 0x82, 0x1a, 0xa1, 0x55, 0x4c, 0xd0,
-	0x87, 0x26, 0x86, 0xea, 0x6e, 0xe0, 0x93, 0x6a, 0xe8, 0x66, 0x0a, 0xe2, 0x3c, 0xd3, 0x30, 0x71,
-	0x27, 0xec, 0x4b, 0xab, 0xa6, 0x82, 0x70, 0x57, 0xe8, 0x3b, 0x87, 0xf6, 0x49, 0x40, 0xd7, 0xbb,
-	0x6a, 0x66, 0x30, 0xce, 0x16, 0xf8, 0xc7, 0x01, 0x9e, 0xae, 0x45, 0x61, 0x58, 0x0a, 0xf2, 0x59,
-	0x1c, 0x79, 0x8e, 0x24, 0x9d, 0x48, 0x19, 0x8c, 0x7c, 0x91, 0x72, 0x7c, 0x24, 0xad, 0x64, 0x1a,
-	0xc9, 0xb8, 0x0f, 0x44, 0x06, 0x29, 0x37, 0x15, 0x46, 0xbc, 0x0f, 0x1d, 0x64, 0x9c, 0x15, 0xc7,
-	0xee, 0xb1, 0x2f, 0x1d, 0xba, 0xf4, 0x35, 0x13, 0x99, 0xb9, 0xa6, 0x50, 0xc6, 0x5f, 0x6b, 0xd0,
-	0x60, 0xe3, 0x56, 0x0a, 0x4b, 0x2a, 0x5f, 0x2b, 0x2c, 0x79, 0x17, 0xf4, 0x30, 0x92, 0x8e, 0x6b,
-	0xa7, 0x72, 0xd4, 0xcd, 0x1c, 0x41, 0xf9, 0x00, 0x7a, 0x68, 0xe2, 0x67, 0xcb, 0x64, 0x40, 0x18,
-	0xd0, 0x0d, 0xfc, 0xb1, 0xe3, 0xc6, 0xa7, 0xe3, 0xc3, 0x8b, 0x44, 0xc6, 0x8a, 0x17, 0xed, 0xc0,
-	0xdf, 0x70, 0xe3, 0xd3, 0x75, 0x44, 0x21, 0x0b, 0xf9, 0x8e, 0xd0, 0xdd, 0x68, 0x99, 0x0a, 0x12,
-	0x9f, 0x80, 0x4e, 0xd1, 0x20, 0x05, 0x1a, 0x3a, 0x05, 0x08, 0xb7, 0x5f, 0xbf, 0x5a, 0x14, 0x88,
-	0x9c, 0x89, 0x30, 0x5a, 0x29, 0x0e, 0xe3, 0x21, 0x1c, 0x8c, 0x2e, 0x03, 0x28, 0xb8, 0xa1, 0x78,
-	0x08, 0x51, 0xa3, 0xb8, 0x18, 0x0f, 0x31, 0x46, 0x3c, 0x04, 0x31, 0xf5, 0xed, 0x60, 0x12, 0xa2,
-	0x52, 0x48, 0x47, 0x6d, 0xb2, 0x4d, 0x9b, 0xbc, 0x51, 0xa4, 0xd0, 0x56, 0x8d, 0x7f, 0xd4, 0xa0,
-	0xb3, 0xe1, 0x46, 0xd2, 0x4e, 0xa4, 0x33, 0x74, 0x8e, 0x25, 0xee, 0x5d, 0xfa, 0x89, 0x9b, 0x5c,
-	0xa8, 0x80, 0x4f, 0x41, 0x59, 0x3c, 0xae, 0x95, 0xf3, 0x53, 0xbe, 0x61, 0x55, 0x4a, 0xa9, 0x19,
-	0x10, 0xab, 0x00, 0x9c, 0xa9, 0x50, 0x5a, 0x5d, 0xbb, 0x3a, 0xad, 0xd6, 0xa9, 0x1b, 0x36, 0x31,
-	0x6d, 0xe5, 0x31, 0x2e, 0x47, 0x7d, 0x0d, 0xca, 0xb9, 0xa7, 0x68, 0xc5, 0x28, 0xc0, 0x3f, 0x94,
-	0x1e, 0xa9, 0x23, 0x05, 0xf8, 0x87, 0xd2, 0xcb, 0xd2, 0xaa, 0x26, 0x6f, 0x07, 0xdb, 0xe2, 0x1e,
-	0x68, 0x41, 0x48, 0x2c, 0x57, 0x0b, 0x16, 0x0f, 0xb6, 0xb2, 0x1b, 0x9a, 0x5a, 0x10, 0xe2, 0xdd,
-	0xe6, 0x1c, 0x92, 0xd4, 0x11, 0xef, 0x36, 0xfa, 0x28, 0xca, 0x68, 0x4c, 0x45, 0x11, 0x06, 0x74,
-	0x2c, 0xcf, 0x0b, 0x7e, 0x21, 0x9d, 0xbd, 0x48, 0x3a, 0xa9, 0x66, 0x96, 0x70, 0xc6, 0x6d, 0xd0,
-	0x76, 0x43, 0xd1, 0x84, 0xea, 0xfe, 0x70, 0xd4, 0xbb, 0x86, 0x8d, 0x8d, 0xe1, 0x76, 0xaf, 0x62,
-	0x7c, 0xa5, 0x81, 0xfe, 0x62, 0x9a, 0x58, 0x68, 0x4d, 0x62, 0x3c, 0x57, 0x59, 0x27, 0x73, 0xe5,
-	0xfb, 0x36, 0xb4, 0xe2, 0xc4, 0x8a, 0x28, 0x16, 0x60, 0xef, 0xd3, 0x24, 0x78, 0x14, 0x8b, 0xef,
-	0x40, 0x5d, 0x3a, 0xc7, 0x32, 0x75, 0x07, 0xbd, 0xd9, 0xb3, 0x98, 0x4c, 0x16, 0xcb, 0xd0, 0x88,
-	0xed, 0x13, 0x39, 0xb1, 0xfa, 0xb5, 0xbc, 0xe3, 0x3e, 0x61, 0x38, 0xc4, 0x35, 0x15, 0x5d, 0x7c,
-	0x00, 0x75, 0x94, 0x46, 0xac, 0x72, 0x32, 0xca, 0xe2, 0x90, 0xf1, 0xaa, 0x1b, 0x13, 0x51, 0xd5,
-	0x9c, 0x28, 0x08, 0xc7, 0x41, 0x48, 0x7c, 0x5d, 0x58, 0xbd, 0x45, 0x56, 0x2d, 0x3d, 0xcd, 0xca,
-	0x46, 0x14, 0x84, 0xbb, 0xa1, 0xd9, 0x70, 0xe8, 0x17, 0xd3, 0x6f, 0xea, 0xce, 0x3a, 0xc0, 0x6e,
-	0x40, 0x47, 0x0c, 0x97, 0x5b, 0x96, 0xa1, 0x35, 0x91, 0x89, 0xe5, 0x58, 0x89, 0xa5, 0xbc, 0x41,
-	0x87, 0x8d, 0x24, 0xe3, 0xcc, 0x8c, 0x6a, 0x3c, 0x82, 0x06, 0x4f, 0x2d, 0x5a, 0x50, 0xdb, 0xd9,
-	0xdd, 0x19, 0x32, 0x43, 0xd7, 0xb6, 0xb7, 0x7b, 0x15, 0x44, 0x6d, 0xac, 0x8d, 0xd6, 0x7a, 0x1a,
-	0xb6, 0x46, 0x3f, 0xd9, 0x1b, 0xf6, 0xaa, 0xc6, 0x3f, 0x54, 0xa0, 0x95, 0xce, 0x23, 0x3e, 0x03,
-	0xc0, 0x4b, 0x3b, 0x3e, 0x71, 0xfd, 0x2c, 0xac, 0xba, 0x53, 0x5c, 0x69, 0x05, 0x25, 0xf6, 0x39,
-	0x52, 0xd9, 0x7d, 0xd2, 0x1d, 0x27, 0x78, 0xb0, 0x0f, 0x0b, 0x65, 0xe2, 0x9c, 0xf8, 0xf2, 0x41,
-	0xd1, 0x8f, 0x2c, 0xac, 0x7e, 0xab, 0x34, 0x35, 0x8e, 0x24, 0x65, 0x2e, 0xb8, 0x94, 0x87, 0xd0,
-	0x4a, 0xd1, 0xa2, 0x0d, 0xcd, 0x8d, 0xe1, 0xe6, 0xda, 0xc1, 0x36, 0x2a, 0x09, 0x40, 0x63, 0x7f,
-	0x6b, 0xe7, 0xd9, 0xf6, 0x90, 0x8f, 0xb5, 0xbd, 0xb5, 0x3f, 0xea, 0x69, 0xc6, 0x1f, 0x56, 0xa0,
-	0x95, 0x46, 0x2a, 0xe2, 0xbb, 0x18, 0x5c, 0x50, 0xb0, 0xa4, 0x7c, 0x0f, 0x55, 0x4d, 0x0a, 0x39,
-	0x9f, 0x99, 0xd2, 0xf1, 0x62, 0x90, 0x29, 0x4d, 0x63, 0x17, 0x02, 0x8a, 0x19, 0x67, 0xb5, 0x54,
-	0xf4, 0xc0, 0xe4, 0x39, 0xf0, 0xa5, 0x0a, 0x53, 0xa9, 0x4d, 0x3a, 0xe8, 0xfa, 0xb6, 0xcc, 0x83,
-	0xf8, 0x26, 0xc1, 0xa3, 0xd8, 0x48, 0x38, 0x7a, 0xcd, 0x36, 0x96, 0xad, 0x56, 0x29, 0xae, 0x76,
-	0x29, 0x15, 0xd0, 0x2e, 0xa7, 0x02, 0xb9, 0xab, 0xac, 0xbf, 0xcd, 0x55, 0x1a, 0x7f, 0x5e, 0x83,
-	0x05, 0x53, 0xc6, 0x49, 0x10, 0x49, 0x53, 0xfe, 0x7c, 0x2a, 0xe3, 0xe4, 0x4d, 0x57, 0xe8, 0x3d,
-	0x80, 0x88, 0x3b, 0xe7, 0x4b, 0xeb, 0x0a, 0xc3, 0x39, 0x8c, 0x17, 0xd8, 0xa4, 0xbb, 0xca, 0x27,
-	0x66, 0xb0, 0xb8, 0x03, 0xfa, 0xa1, 0x65, 0x9f, 0xf2, 0xb4, 0xec, 0x19, 0x5b, 0x8c, 0xe0, 0x79,
-	0x2d, 0xdb, 0x96, 0x71, 0x3c, 0x46, 0x55, 0x60, 0xff, 0xa8, 0x33, 0xe6, 0xb9, 0xbc, 0x40, 0x72,
-	0x2c, 0xed, 0x48, 0x26, 0x44, 0x66, 0xb3, 0xa4, 0x33, 0x06, 0xc9, 0xf7, 0xa0, 0x1b, 0xcb, 0x18,
-	0x7d, 0xe9, 0x38, 0x09, 0x4e, 0xa5, 0xaf, 0x6c, 0x54, 0x47, 0x21, 0x47, 0x88, 0x43, 0xd7, 0x63,
-	0xf9, 0x81, 0x7f, 0x31, 0x09, 0xa6, 0xb1, 0xf2, 0x12, 0x39, 0x42, 0xac, 0xc0, 0x4d, 0xe9, 0xdb,
-	0xd1, 0x45, 0x88, 0x7b, 0xc5, 0x55, 0xc6, 0x47, 0xae, 0x27, 0x55, 0xc8, 0x7c, 0x23, 0x27, 0x3d,
-	0x97, 0x17, 0x9b, 0xae, 0x27, 0x71, 0x47, 0x67, 0xd6, 0xd4, 0x4b, 0xc6, 0x94, 0x65, 0x03, 0xef,
-	0x88, 0x30, 0x6b, 0x98, 0x6a, 0x7f, 0x04, 0x37, 0x98, 0x1c, 0x05, 0x9e, 0x74, 0x1d, 0x9e, 0xac,
-	0x4d, 0xbd, 0xae, 0x13, 0xc1, 0x24, 0x3c, 0x4d, 0xb5, 0x02, 0x37, 0xb9, 0x2f, 0x1f, 0x28, 0xed,
-	0xdd, 0xe1, 0xa5, 0x89, 0xb4, 0xaf, 0x28, 0xe5, 0xa5, 0x43, 0x2b, 0x39, 0xa1, 0xec, 0x39, 0x5d,
-	0x7a, 0xcf, 0x4a, 0x4e, 0xd0, 0xc7, 0x33, 0xf9, 0xc8, 0x95, 0x1e, 0x67, 0xc5, 0xba, 0xc9, 0x23,
-	0x36, 0x11, 0x83, 0x3e, 0x5e, 0x75, 0x08, 0xa2, 0x89, 0xc5, 0xc5, 0x37, 0xdd, 0xe4, 0x41, 0x9b,
-	0x84, 0xc2, 0x25, 0x94, 0xac, 0xfc, 0xe9, 0xa4, 0xdf, 0x63, 0x31, 0x33, 0x66, 0x67, 0x3a, 0x31,
-	0xfe, 0x53, 0x83, 0x56, 0x96, 0x64, 0x3d, 0x00, 0x7d, 0x92, 0xda, 0x2b, 0x15, 0x9a, 0x75, 0x4b,
-	0x46, 0xcc, 0xcc, 0xe9, 0xe2, 0x3d, 0xd0, 0x4e, 0xcf, 0x94, 0xed, 0xec, 0xae, 0x70, 0x31, 0x3a,
-	0x3c, 0x5c, 0x5d, 0x79, 0xfe, 0xd2, 0xd4, 0x4e, 0xcf, 0xbe, 0x81, 0xde, 0x8a, 0x0f, 0xe1, 0xba,
-	0xed, 0x49, 0xcb, 0x1f, 0xe7, 0xf1, 0x04, 0xeb, 0xc5, 0x02, 0xa1, 0xf7, 0xb2, 0xa0, 0xe2, 0x3e,
-	0xd4, 0x1d, 0xe9, 0x25, 0x56, 0xb1, 0x26, 0xba, 0x1b, 0x59, 0xb6, 0x27, 0x37, 0x10, 0x6d, 0x32,
-	0x15, 0x6d, 0x67, 0x96, 0xea, 0x14, 0x6c, 0xe7, 0xe5, 0x34, 0x27, 0xbf, 0x97, 0x50, 0xbc, 0x97,
-	0x0f, 0xe0, 0x86, 0x3c, 0x0f, 0xc9, 0x61, 0x8c, 0xb3, 0x3c, 0x9e, 0xc3, 0xa7, 0x5e, 0x4a, 0x78,
-	0x9a, 0xe6, 0xf3, 0x1f, 0xa3, 0xc9, 0xa0, 0x4b, 0x43, 0x62, 0x6e, 0xaf, 0x0a, 0xb2, 0x39, 0xa5,
-	0x6b, 0x68, 0xa6, 0x5d, 0xbe, 0xa8, 0xb5, 0x9a, 0xbd, 0x96, 0x61, 0x43, 0xf5, 0xf9, 0xcb, 0x7d,
-	0x32, 0x2a, 0x68, 0xdf, 0xeb, 0x14, 0x00, 0x50, 0x3b, 0x33, 0x34, 0x5a, 0xc1, 0xd0, 0xdc, 0x65,
-	0x1b, 0x4d, 0x3c, 0x48, 0x4b, 0x75, 0x05, 0x0c, 0x9e, 0x82, 0xfd, 0x53, 0x8d, 0xab, 0x78, 0x04,
-	0x18, 0xff, 0x5d, 0x85, 0xa6, 0x0a, 0x1a, 0xd0, 0x2e, 0x4f, 0xb3, 0x2a, 0x14, 0x36, 0xcb, 0xb9,
-	0x5b, 0x16, 0x7d, 0x14, 0x4b, 0xfa, 0xd5, 0xb7, 0x97, 0xf4, 0xc5, 0x67, 0xd0, 0x09, 0x99, 0x56,
-	0x8c, 0x57, 0xde, 0x29, 0x8e, 0x51, 0xbf, 0x34, 0xae, 0x1d, 0xe6, 0x00, 0x9a, 0x26, 0xaa, 0x77,
-	0x26, 0xd6, 0xb1, 0xe2, 0x40, 0x13, 0xe1, 0x91, 0x75, 0x7c, 0x45, 0xd4, 0xf2, 0x75, 0x82, 0x8f,
-	0x05, 0x8a, 0x62, 0x3a, 0x64, 0xe9, 0x30, 0x60, 0x29, 0xc6, 0x09, 0xdd, 0x72, 0x9c, 0x70, 0x07,
-	0x74, 0x3b, 0x98, 0x4c, 0x5c, 0xa2, 0x2d, 0xa8, 0x2a, 0x0d, 0x21, 0x46, 0xb1, 0xf1, 0xbb, 0x15,
-	0x68, 0xaa, 0xd3, 0x5e, 0xf2, 0x42, 0xeb, 0x5b, 0x3b, 0x6b, 0xe6, 0x4f, 0x7a, 0x15, 0xf4, 0xb2,
-	0x5b, 0x3b, 0xa3, 0x9e, 0x26, 0x74, 0xa8, 0x6f, 0x6e, 0xef, 0xae, 0x8d, 0x7a, 0x55, 0xf4, 0x4c,
-	0xeb, 0xbb, 0xbb, 0xdb, 0xbd, 0x9a, 0xe8, 0x40, 0x6b, 0x63, 0x6d, 0x34, 0x1c, 0x6d, 0xbd, 0x18,
-	0xf6, 0xea, 0xd8, 0xf7, 0xd9, 0x70, 0xb7, 0xd7, 0xc0, 0xc6, 0xc1, 0xd6, 0x46, 0xaf, 0x89, 0xf4,
-	0xbd, 0xb5, 0xfd, 0xfd, 0x1f, 0xed, 0x9a, 0x1b, 0xbd, 0x16, 0x79, 0xb7, 0x91, 0xb9, 0xb5, 0xf3,
-	0xac, 0xa7, 0x63, 0x7b, 0x77, 0xfd, 0x8b, 0xe1, 0xd3, 0x51, 0x0f, 0x8c, 0x27, 0xd0, 0x2e, 0x70,
-	0x10, 0x47, 0x9b, 0xc3, 0xcd, 0xde, 0x35, 0x5c, 0xf2, 0xe5, 0xda, 0xf6, 0x01, 0x3a, 0xc3, 0x05,
-	0x00, 0x6a, 0x8e, 0xb7, 0xd7, 0x76, 0x9e, 0xf5, 0x34, 0xe3, 0x87, 0xd0, 0x3a, 0x70, 0x9d, 0x75,
-	0x2f, 0xb0, 0x4f, 0x51, 0x9d, 0x0e, 0xad, 0x58, 0x2a, 0xbf, 0x43, 0x6d, 0x0c, 0x52, 0xe9, 0x9e,
-	0xc4, 0x4a, 0xf6, 0x0a, 0x42, 0x5e, 0xf9, 0xd3, 0xc9, 0x98, 0x9e, 0x81, 0xaa, 0xec, 0x2b, 0xfc,
-	0xe9, 0xe4, 0xc0, 0x75, 0x62, 0xe3, 0x14, 0x9a, 0x07, 0xae, 0xb3, 0x67, 0xd9, 0xa7, 0x64, 0x4f,
-	0x70, 0xea, 0x71, 0xec, 0x7e, 0x29, 0x95, 0x4f, 0xd1, 0x09, 0xb3, 0xef, 0x7e, 0x29, 0xc5, 0x07,
-	0xd0, 0x20, 0x20, 0xcd, 0xe2, 0xe9, 0xe6, 0xa5, 0xdb, 0x31, 0x15, 0x8d, 0x5e, 0x61, 0x3c, 0x2f,
-	0xb0, 0xc7, 0x91, 0x3c, 0xea, 0xbf, 0xc3, 0xbc, 0x27, 0x84, 0x29, 0x8f, 0x8c, 0xdf, 0xaf, 0x64,
-	0x67, 0xa6, 0x47, 0x80, 0x45, 0xa8, 0x85, 0x96, 0x7d, 0xaa, 0x5c, 0x7a, 0x5b, 0x4d, 0x88, 0x9b,
-	0x31, 0x89, 0x20, 0x3e, 0x84, 0x96, 0x52, 0xac, 0x74, 0xd5, 0x76, 0x41, 0x03, 0xcd, 0x8c, 0x58,
-	0x16, 0x79, 0xb5, 0x2c, 0x72, 0x4a, 0x01, 0x43, 0xcf, 0x4d, 0xf8, 0x1a, 0xd5, 0x4c, 0x05, 0x19,
-	0xdf, 0x03, 0xc8, 0xdf, 0x5d, 0xe6, 0x44, 0x38, 0xb7, 0xa0, 0x6e, 0x79, 0xae, 0x95, 0xa6, 0x94,
-	0x0c, 0x18, 0x3b, 0xd0, 0x2e, 0xbc, 0xd6, 0x20, 0x6f, 0x2d, 0xcf, 0x43, 0x67, 0x14, 0xd3, 0xd8,
-	0x96, 0xd9, 0xb4, 0x3c, 0xef, 0xb9, 0xbc, 0x88, 0x31, 0xba, 0xe4, 0x87, 0x1e, 0x6d, 0xe6, 0x8d,
-	0x80, 0x86, 0x9a, 0x4c, 0x34, 0x3e, 0x86, 0xc6, 0x66, 0x1a, 0x5f, 0xa7, 0xd7, 0xa0, 0x72, 0xd5,
-	0x35, 0x30, 0x3e, 0x55, 0x7b, 0xa6, 0x67, 0x06, 0xf1, 0x40, 0x3d, 0x28, 0xc5, 0xfc, 0x7c, 0x55,
-	0xc9, 0x8b, 0x12, 0xdc, 0x49, 0xbd, 0x25, 0x51, 0x67, 0x63, 0x03, 0x5a, 0x6f, 0x7c, 0xa2, 0x53,
-	0x0c, 0xd0, 0x72, 0x06, 0xcc, 0x79, 0xb4, 0x33, 0x7e, 0x06, 0x90, 0x3f, 0x3c, 0xa9, 0x5b, 0xc9,
-	0xb3, 0xe0, 0xad, 0xfc, 0x08, 0x5a, 0xf6, 0x89, 0xeb, 0x39, 0x91, 0xf4, 0x4b, 0xa7, 0xce, 0x9f,
-	0xaa, 0x32, 0xba, 0x58, 0x82, 0x1a, 0xbd, 0xa7, 0x55, 0x73, 0x43, 0x9e, 0x3d, 0xa6, 0x11, 0xc5,
-	0x38, 0x87, 0x2e, 0x87, 0xed, 0x5f, 0x23, 0xe8, 0x29, 0x9b, 0x52, 0xed, 0x92, 0x29, 0xbd, 0x0d,
-	0x0d, 0xf2, 0xb5, 0xe9, 0x69, 0x14, 0x74, 0x85, 0x89, 0xfd, 0x1d, 0x0d, 0x80, 0x97, 0xde, 0x09,
-	0x1c, 0x59, 0xce, 0x88, 0x2b, 0xb3, 0x19, 0xb1, 0x80, 0x5a, 0xf6, 0x54, 0xaa, 0x9b, 0xd4, 0xce,
-	0xfd, 0x8f, 0xca, 0x92, 0xd9, 0xff, 0xbc, 0x0b, 0x3a, 0xc5, 0x3e, 0xee, 0x97, 0x54, 0xdf, 0xc7,
-	0x05, 0x73, 0x44, 0xf1, 0xe1, 0xb0, 0x5e, 0x7e, 0x38, 0xcc, 0x5e, 0x57, 0x1a, 0x3c, 0x1b, 0xbf,
-	0xae, 0xcc, 0x79, 0x28, 0xe2, 0x32, 0x45, 0x2c, 0xa3, 0x24, 0xcd, 0xb1, 0x19, 0xca, 0x12, 0x43,
-	0x5d, 0xf5, 0xb5, 0xb8, 0xd0, 0xe0, 0x07, 0x63, 0x3b, 0xf0, 0x8f, 0x3c, 0xd7, 0x4e, 0xd4, 0x43,
-	0x21, 0xf8, 0xc1, 0x53, 0x85, 0x31, 0x3e, 0x83, 0x4e, 0xca, 0x7f, 0x7a, 0x8f, 0xf9, 0x28, 0x4b,
-	0xac, 0x2a, 0xb9, 0x6c, 0x73, 0x36, 0xad, 0x6b, 0xfd, 0x4a, 0x9a, 0x5a, 0x19, 0xff, 0x55, 0x4d,
-	0x07, 0xab, 0x67, 0x85, 0x37, 0xf3, 0xb0, 0x9c, 0x1d, 0x6b, 0x5f, 0x2b, 0x3b, 0xfe, 0x3e, 0xe8,
-	0x0e, 0xa5, 0x7f, 0xee, 0x59, 0xea, 0xd4, 0x06, 0xb3, 0xa9, 0x9e, 0x4a, 0x10, 0xdd, 0x33, 0x69,
-	0xe6, 0x9d, 0xdf, 0x22, 0x87, 0x8c, 0xdb, 0xf5, 0x79, 0xdc, 0x6e, 0xfc, 0x8a, 0xdc, 0x7e, 0x1f,
-	0x3a, 0x7e, 0xe0, 0x8f, 0xfd, 0xa9, 0xe7, 0x59, 0x87, 0x9e, 0x54, 0xec, 0x6e, 0xfb, 0x81, 0xbf,
-	0xa3, 0x50, 0x18, 0x90, 0x16, 0xbb, 0xf0, 0xa5, 0x6e, 0x53, 0xbf, 0xeb, 0x85, 0x7e, 0x74, 0xf5,
-	0x97, 0xa1, 0x17, 0x1c, 0xfe, 0x4c, 0xda, 0x09, 0x71, 0x6c, 0x4c, 0xb7, 0x99, 0xa3, 0xd1, 0x05,
-	0xc6, 0x23, 0x8b, 0x76, 0xf0, 0x5e, 0xcf, 0x88, 0xb9, 0x7b, 0x49, 0xcc, 0x9f, 0x82, 0x9e, 0x71,
-	0xa9, 0x90, 0x6a, 0xea, 0x50, 0xdf, 0xda, 0xd9, 0x18, 0xfe, 0xb8, 0x57, 0x41, 0x47, 0x69, 0x0e,
-	0x5f, 0x0e, 0xcd, 0xfd, 0x61, 0x4f, 0x43, 0x27, 0xb6, 0x31, 0xdc, 0x1e, 0x8e, 0x86, 0xbd, 0x2a,
-	0x47, 0x3d, 0x54, 0xf7, 0xf7, 0x5c, 0xdb, 0x4d, 0x8c, 0x7d, 0x80, 0x3c, 0x7f, 0x46, 0xab, 0x9c,
-	0x6f, 0x4e, 0x95, 0xec, 0x92, 0x74, 0x5b, 0xcb, 0xd9, 0x85, 0xd4, 0xae, 0xca, 0xd2, 0x99, 0x6e,
-	0xac, 0x82, 0xfe, 0xc2, 0x0a, 0x3f, 0xe7, 0x77, 0xb0, 0xfb, 0xb0, 0x10, 0x5a, 0x51, 0xe2, 0xa6,
-	0x29, 0x00, 0x1b, 0xcb, 0x8e, 0xd9, 0xcd, 0xb0, 0x68, 0x7b, 0x8d, 0xbf, 0xa8, 0xc0, 0xad, 0x17,
-	0xc1, 0x99, 0xcc, 0x42, 0xcc, 0x3d, 0xeb, 0xc2, 0x0b, 0x2c, 0xe7, 0x2d, 0x6a, 0x88, 0x39, 0x4c,
-	0x30, 0xa5, 0x17, 0xab, 0xf4, 0x15, 0xcf, 0xd4, 0x19, 0xf3, 0x4c, 0x7d, 0x46, 0x20, 0xe3, 0x84,
-	0x88, 0xca, 0x91, 0x22, 0x8c, 0xa4, 0x6f, 0x41, 0x23, 0x39, 0xf7, 0xf3, 0x47, 0xc3, 0x7a, 0x42,
-	0x05, 0xe5, 0xb9, 0x11, 0x67, 0x7d, 0x7e, 0xc4, 0x69, 0x3c, 0x05, 0x7d, 0x74, 0x4e, 0xc5, 0xd6,
-	0x69, 0x5c, 0x0a, 0x70, 0x2a, 0x6f, 0x08, 0x70, 0xb4, 0x99, 0x00, 0xe7, 0xdf, 0x2b, 0xd0, 0x2e,
-	0x84, 0xce, 0xe2, 0x7d, 0xa8, 0x25, 0xe7, 0x7e, 0xf9, 0x69, 0x3e, 0x5d, 0xc4, 0x24, 0xd2, 0xa5,
-	0x82, 0xa2, 0x76, 0xa9, 0xa0, 0x28, 0xb6, 0xe1, 0x3a, 0x5b, 0xde, 0xf4, 0x10, 0x69, 0x15, 0xe6,
-	0xde, 0x4c, 0xa8, 0xce, 0x05, 0xe9, 0xf4, 0x48, 0xaa, 0xb4, 0xb0, 0x70, 0x5c, 0x42, 0x0e, 0xd6,
-	0xe0, 0xe6, 0x9c, 0x6e, 0xdf, 0xe4, 0x21, 0xc2, 0x58, 0x84, 0xee, 0xe8, 0xdc, 0x1f, 0xb9, 0x13,
-	0x19, 0x27, 0xd6, 0x24, 0xa4, 0x00, 0x51, 0x79, 0xce, 0x9a, 0xa9, 0x25, 0xb1, 0xf1, 0x1d, 0xe8,
-	0xec, 0x49, 0x19, 0x99, 0x32, 0x0e, 0x03, 0x9f, 0x83, 0x23, 0x55, 0x08, 0x66, 0x37, 0xad, 0x20,
-	0xe3, 0xb7, 0x40, 0x37, 0xad, 0xa3, 0x64, 0xdd, 0x4a, 0xec, 0x93, 0x6f, 0x52, 0x67, 0xf8, 0x0e,
-	0x34, 0x43, 0xd6, 0x29, 0x95, 0x50, 0x75, 0xc8, 0x5d, 0x2b, 0x3d, 0x33, 0x53, 0xa2, 0xf1, 0x04,
-	0x6e, 0xee, 0x4f, 0x0f, 0x63, 0x3b, 0x72, 0x29, 0x37, 0x4d, 0x5d, 0xd9, 0x00, 0x5a, 0x61, 0x24,
-	0x8f, 0xdc, 0x73, 0x99, 0x6a, 0x70, 0x06, 0x1b, 0x3f, 0x80, 0x5b, 0xe5, 0x21, 0xea, 0x08, 0xf7,
-	0xa0, 0x7a, 0x7a, 0x16, 0xab, 0x9d, 0xdd, 0x28, 0x65, 0x66, 0xf4, 0x22, 0x8e, 0x54, 0xc3, 0x84,
-	0xea, 0xce, 0x74, 0x52, 0xfc, 0xaa, 0xa7, 0xc6, 0x5f, 0xf5, 0xdc, 0x29, 0x96, 0x59, 0x39, 0x0b,
-	0xc9, 0xcb, 0xa9, 0xef, 0x82, 0x7e, 0x14, 0x44, 0xbf, 0xb0, 0x22, 0x47, 0x3a, 0xca, 0x67, 0xe5,
-	0x08, 0xe3, 0xa7, 0xd0, 0x4e, 0x35, 0x61, 0xcb, 0xa1, 0xd7, 0x3d, 0x52, 0xc5, 0x2d, 0xa7, 0xa4,
-	0x99, 0x5c, 0x95, 0x94, 0xbe, 0xb3, 0x95, 0xaa, 0x10, 0x03, 0xe5, 0x95, 0xd5, 0x93, 0x4b, 0xba,
-	0xb2, 0xb1, 0x09, 0x9d, 0x34, 0x7f, 0x7b, 0x21, 0x13, 0x8b, 0x94, 0xdb, 0x73, 0xa5, 0x5f, 0x50,
-	0xfc, 0x16, 0x23, 0x46, 0xe5, 0xc2, 0xa1, 0x56, 0x0a, 0x00, 0x8c, 0x15, 0x68, 0xa8, 0x9b, 0x23,
-	0xa0, 0x66, 0x07, 0x0e, 0xdf, 0xee, 0xba, 0x49, 0x6d, 0x64, 0xc7, 0x24, 0x3e, 0x4e, 0x83, 0x9b,
-	0x49, 0x7c, 0x6c, 0xfc, 0x95, 0x06, 0xdd, 0x75, 0xca, 0x96, 0x53, 0x91, 0x14, 0x6a, 0x44, 0x95,
-	0x52, 0x8d, 0xa8, 0x58, 0x0f, 0xd2, 0x4a, 0xf5, 0xa0, 0xd2, 0x86, 0xaa, 0xe5, 0x88, 0xe4, 0x1d,
-	0x68, 0x4e, 0x7d, 0xf7, 0x3c, 0x35, 0x09, 0xba, 0xd9, 0x40, 0x70, 0x14, 0x8b, 0x25, 0x68, 0xa3,
-	0xd5, 0x70, 0x7d, 0xae, 0xc1, 0x70, 0x21, 0xa5, 0x88, 0x9a, 0xa9, 0xb4, 0x34, 0xde, 0x5c, 0x69,
-	0x69, 0xbe, 0xb5, 0xd2, 0xd2, 0x7a, 0x5b, 0xa5, 0x45, 0x9f, 0xad, 0xb4, 0x94, 0xa3, 0x29, 0x98,
-	0x8d, 0xa6, 0x8c, 0x6d, 0x58, 0x48, 0x79, 0xa7, 0x74, 0xf3, 0x33, 0xb8, 0xae, 0x8a, 0xa4, 0x32,
-	0x52, 0x75, 0x06, 0xb6, 0x38, 0x37, 0xa8, 0x4c, 0x4b, 0x75, 0x4c, 0x45, 0x31, 0x17, 0x9c, 0x22,
-	0x18, 0x1b, 0xbf, 0x57, 0x81, 0x6e, 0xa9, 0x87, 0x78, 0x92, 0x97, 0x5c, 0x2b, 0xe4, 0xd8, 0xfb,
-	0x97, 0x66, 0x79, 0x73, 0xd9, 0x55, 0x9b, 0x29, 0xbb, 0x1a, 0xf7, 0xb3, 0x62, 0xaa, 0x2a, 0xa1,
-	0x5e, 0xcb, 0x4a, 0xa8, 0x54, 0x75, 0x5c, 0x1b, 0x8d, 0xcc, 0x9e, 0x66, 0xfc, 0x91, 0x06, 0xdd,
-	0xe1, 0x79, 0x48, 0xdf, 0xa0, 0xbc, 0x35, 0xe6, 0x2c, 0x28, 0x8c, 0x56, 0x52, 0x98, 0x82, 0xe8,
-	0xab, 0xea, 0xb5, 0x88, 0x45, 0x8f, 0x51, 0x28, 0x17, 0x74, 0x94, 0x4a, 0x30, 0xf4, 0x7f, 0x40,
-	0x25, 0x50, 0xe4, 0x29, 0x63, 0x94, 0xc8, 0xbf, 0xd6, 0x3d, 0xe3, 0xef, 0xc7, 0xbc, 0xac, 0xbc,
-	0xc1, 0x80, 0xf1, 0x07, 0x1a, 0xe8, 0xac, 0x41, 0xb8, 0xbd, 0xef, 0xaa, 0x08, 0xba, 0x92, 0x97,
-	0x92, 0x33, 0xe2, 0xca, 0x73, 0x79, 0x41, 0x91, 0x1f, 0x07, 0xd6, 0xf3, 0x1e, 0x5c, 0x54, 0x11,
-	0x84, 0xf3, 0x3e, 0x2a, 0x82, 0xdc, 0x01, 0x9d, 0x9d, 0xe7, 0xd4, 0x4d, 0x9f, 0x80, 0xd9, 0x9b,
-	0x1e, 0xb8, 0xf4, 0xd9, 0x4d, 0x22, 0xa3, 0x89, 0xe2, 0x32, 0xb5, 0xcb, 0x11, 0x76, 0x57, 0xc5,
-	0x7c, 0xc6, 0x09, 0x34, 0xd5, 0xea, 0x18, 0x02, 0x1d, 0xec, 0x3c, 0xdf, 0xd9, 0xfd, 0xd1, 0x4e,
-	0x49, 0x73, 0xb2, 0x20, 0x49, 0x2b, 0x06, 0x49, 0x55, 0xc4, 0x3f, 0xdd, 0x3d, 0xd8, 0x19, 0xf5,
-	0x6a, 0xa2, 0x0b, 0x3a, 0x35, 0xc7, 0xe6, 0xf0, 0x65, 0xaf, 0x4e, 0xf5, 0x80, 0xa7, 0x9f, 0x0f,
-	0x5f, 0xac, 0xf5, 0x1a, 0x59, 0xe9, 0xbe, 0x69, 0xfc, 0x69, 0x05, 0x6e, 0xf0, 0x91, 0x8b, 0x09,
-	0x72, 0xf1, 0xdb, 0xcd, 0x1a, 0x7f, 0xbb, 0xf9, 0xeb, 0xcd, 0x89, 0x71, 0xd0, 0xd4, 0x4d, 0x9f,
-	0xc7, 0xb8, 0x78, 0xd3, 0x9a, 0xba, 0xea, 0x55, 0xec, 0xef, 0x2a, 0x30, 0xe0, 0xd8, 0xec, 0x59,
-	0x64, 0x85, 0x27, 0x3f, 0xdc, 0xbe, 0x94, 0x9d, 0x5d, 0x15, 0xb1, 0xdc, 0x87, 0x05, 0xfa, 0xba,
-	0xf5, 0xe7, 0xde, 0x58, 0x65, 0x10, 0x2c, 0xbf, 0xae, 0xc2, 0xf2, 0x44, 0xe2, 0x13, 0xe8, 0xf0,
-	0x57, 0xb0, 0x54, 0x30, 0x2c, 0x3d, 0xf4, 0x94, 0x22, 0xc3, 0x36, 0xf7, 0xa2, 0x27, 0x27, 0xf1,
-	0x24, 0x1b, 0x94, 0x27, 0x72, 0x97, 0xdf, 0x72, 0xd4, 0x90, 0x11, 0xa5, 0x77, 0x8f, 0xe0, 0xce,
-	0xdc, 0x73, 0x28, 0xc5, 0x2e, 0x14, 0xd5, 0x58, 0x9f, 0x56, 0xff, 0xb6, 0x02, 0x35, 0x8c, 0x02,
-	0xc4, 0x43, 0xd0, 0x3f, 0x97, 0x56, 0x94, 0x1c, 0x4a, 0x2b, 0x11, 0x25, 0x8f, 0x3f, 0xa0, 0x15,
-	0xf3, 0xf7, 0x6a, 0xe3, 0xda, 0xe3, 0x8a, 0x58, 0xe1, 0x0f, 0xd3, 0xd2, 0xef, 0xed, 0xba, 0x69,
-	0x34, 0x41, 0xd1, 0xc6, 0xa0, 0x34, 0xde, 0xb8, 0xb6, 0x4c, 0xfd, 0xbf, 0x08, 0x5c, 0xff, 0x29,
-	0x7f, 0x47, 0x25, 0x66, 0xa3, 0x8f, 0xd9, 0x11, 0xe2, 0x21, 0x34, 0xb6, 0x62, 0x0c, 0x73, 0x2e,
-	0x77, 0x25, 0xae, 0x15, 0x23, 0x20, 0xe3, 0xda, 0xea, 0x9f, 0x55, 0xa1, 0xf6, 0x53, 0x19, 0x05,
-	0xe2, 0x63, 0x68, 0xaa, 0xd7, 0x7d, 0x51, 0x78, 0xc5, 0x1f, 0x50, 0xc6, 0x35, 0xf3, 0xec, 0x4f,
-	0xab, 0xf4, 0x98, 0x5d, 0x79, 0x99, 0x57, 0xe4, 0x1f, 0x1f, 0x5c, 0xda, 0xd4, 0xa7, 0xd0, 0xdb,
-	0x4f, 0x22, 0x69, 0x4d, 0x0a, 0xdd, 0xcb, 0xac, 0x9a, 0x57, 0x33, 0x26, 0x7e, 0x3d, 0x80, 0x06,
-	0xc7, 0x92, 0x33, 0x03, 0x66, 0x0b, 0xc2, 0xd4, 0xf9, 0x43, 0x68, 0xef, 0x9f, 0x04, 0x53, 0xcf,
-	0xd9, 0x97, 0xd1, 0x99, 0x14, 0x85, 0x2f, 0x7a, 0x06, 0x85, 0xb6, 0x71, 0x4d, 0x2c, 0x03, 0x70,
-	0xf8, 0x72, 0x80, 0x17, 0xa8, 0x89, 0xb4, 0x9d, 0xe9, 0x84, 0x27, 0x2d, 0xc4, 0x35, 0xdc, 0xb3,
-	0x10, 0x52, 0xbe, 0xa9, 0xe7, 0x27, 0xd0, 0x7d, 0x4a, 0x97, 0x69, 0x37, 0x5a, 0x3b, 0x0c, 0xa2,
-	0x44, 0xcc, 0x7e, 0xd5, 0x33, 0x98, 0x45, 0x18, 0xd7, 0xc4, 0x63, 0x68, 0x8d, 0xa2, 0x0b, 0xee,
-	0x7f, 0x43, 0x45, 0xe2, 0xf9, 0x7a, 0x73, 0x4e, 0xb9, 0xfa, 0x3f, 0x35, 0x68, 0xfc, 0x28, 0x88,
-	0x4e, 0x65, 0x84, 0xd9, 0x37, 0x95, 0xeb, 0x95, 0x1a, 0x65, 0xa5, 0xfb, 0x79, 0x0b, 0x7d, 0x00,
-	0x3a, 0x31, 0x65, 0x64, 0xc5, 0xa7, 0x2c, 0x2a, 0xfa, 0x9c, 0x9a, 0xf9, 0xc2, 0xd9, 0x3c, 0xc9,
-	0x75, 0x81, 0x05, 0x95, 0x3d, 0x67, 0x95, 0xca, 0xe9, 0x03, 0x3a, 0xff, 0xf3, 0x97, 0xfb, 0xa8,
-	0x9a, 0x8f, 0x2b, 0x68, 0xa5, 0xf7, 0xf9, 0xa4, 0xd8, 0x29, 0xff, 0x8c, 0x94, 0x35, 0x3f, 0xff,
-	0x6e, 0xd3, 0xb8, 0x26, 0x1e, 0x41, 0x43, 0x5d, 0xe9, 0x1b, 0xf9, 0xe5, 0x55, 0x76, 0x62, 0xd0,
-	0x2b, 0xa2, 0xd4, 0x80, 0x27, 0xd0, 0x60, 0xf3, 0xc7, 0x03, 0x4a, 0x81, 0xd9, 0x40, 0x14, 0x51,
-	0xa9, 0x32, 0x8b, 0x07, 0xd0, 0x54, 0xc5, 0x78, 0x31, 0xa7, 0x32, 0xcf, 0x47, 0xe5, 0x88, 0x90,
-	0xe7, 0x67, 0xef, 0xc5, 0xf3, 0x97, 0x5c, 0x3c, 0xcf, 0x5f, 0x76, 0x6e, 0xac, 0xf5, 0xa6, 0xb4,
-	0xa5, 0x5b, 0x48, 0x22, 0x45, 0xca, 0x91, 0x39, 0x57, 0xf7, 0x53, 0xe8, 0x96, 0x12, 0x4e, 0x41,
-	0x21, 0xcb, 0xbc, 0x1c, 0xf4, 0xd2, 0x85, 0xf9, 0x01, 0xe8, 0x2a, 0xde, 0x3f, 0x94, 0x82, 0x6a,
-	0xec, 0x73, 0x32, 0x86, 0xc1, 0xe5, 0x80, 0x9f, 0x6e, 0xc1, 0x8f, 0xe1, 0xe6, 0x1c, 0x5b, 0x26,
-	0xe8, 0x63, 0xa9, 0xab, 0x8d, 0xf5, 0x60, 0xf1, 0x4a, 0x7a, 0xca, 0x80, 0xf5, 0xde, 0xdf, 0x7f,
-	0x75, 0xb7, 0xf2, 0x4f, 0x5f, 0xdd, 0xad, 0xfc, 0xeb, 0x57, 0x77, 0x2b, 0xbf, 0xfc, 0xb7, 0xbb,
-	0xd7, 0x0e, 0x1b, 0xf4, 0xd7, 0x82, 0x4f, 0xfe, 0x37, 0x00, 0x00, 0xff, 0xff, 0x22, 0xa8, 0x8d,
-	0xae, 0xd0, 0x30, 0x00, 0x00,
+	// 4976 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x3b, 0x4d, 0x6f, 0x1c, 0x57,
+	0x72, 0x9a, 0xef, 0xe9, 0x9a, 0x0f, 0x8e, 0x9e, 0xb4, 0xf2, 0x78, 0x6c, 0x8b, 0x74, 0xcb, 0xb2,
+	0x69, 0xcb, 0xa2, 0x64, 0x7a, 0x83, 0xac, 0xbd, 0x58, 0x20, 0xfc, 0x18, 0x4a, 0xb4, 0x28, 0x92,
+	0x6e, 0x8e, 0xe4, 0xdd, 0x3d, 0x64, 0xd0, 0xec, 0x7e, 0x24, 0x7b, 0xd9, 0xd3, 0xdd, 0xdb, 0xdd,
+	0xc3, 0x25, 0x7d, 0x4b, 0x72, 0xc9, 0x21, 0xb9, 0x24, 0x97, 0xbd, 0x24, 0x01, 0xf2, 0x07, 0x82,
+	0x04, 0x08, 0x10, 0xe4, 0x18, 0x04, 0x41, 0x90, 0x43, 0x90, 0x3f, 0x10, 0x25, 0x70, 0x72, 0x12,
+	0x90, 0x43, 0x90, 0x53, 0x6e, 0x41, 0x55, 0xbd, 0xfe, 0x1a, 0x0e, 0x25, 0x7b, 0x81, 0x3d, 0xe4,
+	0x34, 0xaf, 0xaa, 0xde, 0x67, 0xbd, 0x7a, 0xf5, 0xd9, 0x03, 0xcd, 0xe0, 0x70, 0x25, 0x08, 0xfd,
+	0xd8, 0x17, 0xe5, 0xe0, 0x70, 0xa0, 0x99, 0x81, 0xc3, 0xe0, 0xe0, 0xa3, 0x63, 0x27, 0x3e, 0x99,
+	0x1e, 0xae, 0x58, 0xfe, 0xe4, 0x81, 0x7d, 0x1c, 0x9a, 0xc1, 0xc9, 0x7d, 0xc7, 0x7f, 0x70, 0x68,
+	0xda, 0xc7, 0x32, 0x7c, 0x70, 0xb6, 0xfa, 0x20, 0x38, 0x7c, 0x90, 0x0c, 0x1d, 0xdc, 0xcf, 0xf5,
+	0x3d, 0xf6, 0x8f, 0xfd, 0x07, 0x84, 0x3e, 0x9c, 0x1e, 0x11, 0x44, 0x00, 0xb5, 0xb8, 0xbb, 0x3e,
+	0x80, 0xea, 0x8e, 0x13, 0xc5, 0x42, 0x40, 0x75, 0xea, 0xd8, 0x51, 0xbf, 0xb4, 0x54, 0x59, 0xae,
+	0x1b, 0xd4, 0xd6, 0x9f, 0x82, 0x36, 0x32, 0xa3, 0xd3, 0xe7, 0xa6, 0x3b, 0x95, 0xa2, 0x07, 0x95,
+	0x33, 0xd3, 0xed, 0x97, 0x96, 0x4a, 0xcb, 0x6d, 0x03, 0x9b, 0x62, 0x05, 0x9a, 0x67, 0xa6, 0x3b,
+	0x8e, 0x2f, 0x02, 0xd9, 0x2f, 0x2f, 0x95, 0x96, 0xbb, 0xab, 0x37, 0x56, 0x82, 0xc3, 0x95, 0x7d,
+	0x3f, 0x8a, 0x1d, 0xef, 0x78, 0xe5, 0xb9, 0xe9, 0x8e, 0x2e, 0x02, 0x69, 0x34, 0xce, 0xb8, 0xa1,
+	0xef, 0x41, 0xeb, 0x20, 0xb4, 0xb6, 0xa6, 0x9e, 0x15, 0x3b, 0xbe, 0x87, 0x2b, 0x7a, 0xe6, 0x44,
+	0xd2, 0x8c, 0x9a, 0x41, 0x6d, 0xc4, 0x99, 0xe1, 0x71, 0xd4, 0xaf, 0x2c, 0x55, 0x10, 0x87, 0x6d,
+	0xd1, 0x87, 0x86, 0x13, 0x6d, 0xf8, 0x53, 0x2f, 0xee, 0x57, 0x97, 0x4a, 0xcb, 0x4d, 0x23, 0x01,
+	0xf5, 0x3f, 0xab, 0x40, 0xed, 0xcb, 0xa9, 0x0c, 0x2f, 0x68, 0x5c, 0x1c, 0x87, 0xc9, 0x5c, 0xd8,
+	0x16, 0x37, 0xa1, 0xe6, 0x9a, 0xde, 0x71, 0xd4, 0x2f, 0xd3, 0x64, 0x0c, 0x88, 0xb7, 0x40, 0x33,
+	0x8f, 0x62, 0x19, 0x8e, 0xa7, 0x8e, 0xdd, 0xaf, 0x2c, 0x95, 0x96, 0xeb, 0x46, 0x93, 0x10, 0xcf,
+	0x1c, 0x5b, 0xbc, 0x09, 0x4d, 0xdb, 0x1f, 0x5b, 0xf9, 0xb5, 0x6c, 0x9f, 0xd6, 0x12, 0x77, 0xa0,
+	0x39, 0x75, 0xec, 0xb1, 0xeb, 0x44, 0x71, 0xbf, 0xb6, 0x54, 0x5a, 0x6e, 0xad, 0x36, 0xf1, 0xb0,
+	0xc8, 0x3b, 0xa3, 0x31, 0x75, 0x6c, 0x6c, 0x88, 0x8f, 0xa0, 0x19, 0x85, 0xd6, 0xf8, 0x68, 0xea,
+	0x59, 0xfd, 0x3a, 0x75, 0x5a, 0xc0, 0x4e, 0xb9, 0x53, 0x1b, 0x8d, 0x88, 0x01, 0x3c, 0x56, 0x28,
+	0xcf, 0x64, 0x18, 0xc9, 0x7e, 0x83, 0x97, 0x52, 0xa0, 0x78, 0x08, 0xad, 0x23, 0xd3, 0x92, 0xf1,
+	0x38, 0x30, 0x43, 0x73, 0xd2, 0x6f, 0x66, 0x13, 0x6d, 0x21, 0x7a, 0x1f, 0xb1, 0x91, 0x01, 0x47,
+	0x29, 0x20, 0x3e, 0x85, 0x0e, 0x41, 0xd1, 0xf8, 0xc8, 0x71, 0x63, 0x19, 0xf6, 0x35, 0x1a, 0xd3,
+	0xa5, 0x31, 0x84, 0x19, 0x85, 0x52, 0x1a, 0x6d, 0xee, 0xc4, 0x18, 0xf1, 0x0e, 0x80, 0x3c, 0x0f,
+	0x4c, 0xcf, 0x1e, 0x9b, 0xae, 0xdb, 0x07, 0xda, 0x83, 0xc6, 0x98, 0x35, 0xd7, 0x15, 0x6f, 0xe0,
+	0xfe, 0x4c, 0x7b, 0x1c, 0x47, 0xfd, 0xce, 0x52, 0x69, 0xb9, 0x6a, 0xd4, 0x11, 0x1c, 0x45, 0xc8,
+	0x57, 0xcb, 0xb4, 0x4e, 0x64, 0xbf, 0xbb, 0x54, 0x5a, 0xae, 0x19, 0x0c, 0x20, 0xf6, 0xc8, 0x09,
+	0xa3, 0xb8, 0xbf, 0xc0, 0x58, 0x02, 0xf4, 0x55, 0xd0, 0x48, 0x7a, 0x88, 0x3b, 0x77, 0xa1, 0x7e,
+	0x86, 0x00, 0x0b, 0x59, 0x6b, 0xb5, 0x83, 0xdb, 0x4b, 0x05, 0xcc, 0x50, 0x44, 0xfd, 0x36, 0x34,
+	0x77, 0x4c, 0xef, 0x38, 0x91, 0x4a, 0xbc, 0x36, 0x1a, 0xa0, 0x19, 0xd4, 0xd6, 0x7f, 0x59, 0x86,
+	0xba, 0x21, 0xa3, 0xa9, 0x1b, 0x8b, 0x0f, 0x00, 0xf0, 0x52, 0x26, 0x66, 0x1c, 0x3a, 0xe7, 0x6a,
+	0xd6, 0xec, 0x5a, 0xb4, 0xa9, 0x63, 0x3f, 0x25, 0x92, 0x78, 0x08, 0x6d, 0x9a, 0x3d, 0xe9, 0x5a,
+	0xce, 0x36, 0x90, 0xee, 0xcf, 0x68, 0x51, 0x17, 0x35, 0xe2, 0x16, 0xd4, 0x49, 0x0e, 0x58, 0x16,
+	0x3b, 0x86, 0x82, 0xc4, 0x5d, 0xe8, 0x3a, 0x5e, 0x8c, 0xf7, 0x64, 0xc5, 0x63, 0x5b, 0x46, 0x89,
+	0xa0, 0x74, 0x52, 0xec, 0xa6, 0x8c, 0x62, 0xf1, 0x09, 0x30, 0xb3, 0x93, 0x05, 0x6b, 0x4b, 0x95,
+	0xf4, 0x42, 0xe8, 0x12, 0x78, 0x45, 0xea, 0xa3, 0x56, 0xbc, 0x0f, 0x2d, 0x3c, 0x5f, 0x32, 0xa2,
+	0x4e, 0x23, 0xda, 0x74, 0x1a, 0xc5, 0x0e, 0x03, 0xb0, 0x83, 0xea, 0x8e, 0xac, 0x41, 0x61, 0x64,
+	0xe1, 0xa1, 0xb6, 0x3e, 0x84, 0xda, 0x5e, 0x68, 0xcb, 0x70, 0xee, 0x7b, 0x10, 0x50, 0xb5, 0x65,
+	0x64, 0xd1, 0x53, 0x6d, 0x1a, 0xd4, 0xce, 0xde, 0x48, 0x25, 0xf7, 0x46, 0xf4, 0x3f, 0x2d, 0x41,
+	0xeb, 0xc0, 0x0f, 0xe3, 0xa7, 0x32, 0x8a, 0xcc, 0x63, 0x29, 0x16, 0xa1, 0xe6, 0xe3, 0xb4, 0x8a,
+	0xc3, 0x1a, 0xee, 0x89, 0xd6, 0x31, 0x18, 0x3f, 0x73, 0x0f, 0xe5, 0xab, 0xef, 0x01, 0x65, 0x87,
+	0x5e, 0x57, 0x45, 0xc9, 0x0e, 0x02, 0xc8, 0x6b, 0xff, 0xe8, 0x28, 0x92, 0xcc, 0xcb, 0x9a, 0xa1,
+	0xa0, 0x2b, 0x45, 0x50, 0xff, 0x0d, 0x00, 0xdc, 0xdf, 0x77, 0x94, 0x02, 0xfd, 0x04, 0x5a, 0x86,
+	0x79, 0x14, 0x6f, 0xf8, 0x5e, 0x2c, 0xcf, 0x63, 0xd1, 0x85, 0xb2, 0x63, 0x13, 0x8b, 0xea, 0x46,
+	0xd9, 0xb1, 0x71, 0x73, 0xc7, 0xa1, 0x3f, 0x0d, 0x88, 0x43, 0x1d, 0x83, 0x01, 0x62, 0xa5, 0x6d,
+	0x87, 0xfd, 0x8a, 0x62, 0xa5, 0x6d, 0x87, 0x62, 0x11, 0x5a, 0x91, 0x67, 0x06, 0xd1, 0x89, 0x1f,
+	0xe3, 0xe6, 0xaa, 0xb4, 0x39, 0x48, 0x50, 0xa3, 0x48, 0xff, 0xaf, 0x32, 0xd4, 0x9f, 0xca, 0xc9,
+	0xa1, 0x0c, 0x2f, 0xad, 0xf2, 0x10, 0x9a, 0x34, 0xf1, 0xd8, 0xb1, 0x79, 0xa1, 0xf5, 0xef, 0xbd,
+	0x7c, 0xb1, 0x78, 0x9d, 0x70, 0xdb, 0xf6, 0xc7, 0xfe, 0xc4, 0x89, 0xe5, 0x24, 0x88, 0x2f, 0x8c,
+	0x86, 0x42, 0xcd, 0xdd, 0xc1, 0x2d, 0xa8, 0xbb, 0xd2, 0xc4, 0x3b, 0x61, 0xf1, 0x53, 0x90, 0xb8,
+	0x0f, 0x0d, 0x73, 0x32, 0xb6, 0xa5, 0x69, 0x93, 0x96, 0x6a, 0xae, 0xdf, 0x7c, 0xf9, 0x62, 0xb1,
+	0x67, 0x4e, 0x36, 0xa5, 0x99, 0x9f, 0xbb, 0xce, 0x18, 0xf1, 0x19, 0xca, 0x5c, 0x14, 0x8f, 0xa7,
+	0x81, 0x6d, 0xc6, 0x92, 0x74, 0x56, 0x75, 0xbd, 0xff, 0xf2, 0xc5, 0xe2, 0x4d, 0x44, 0x3f, 0x23,
+	0x6c, 0x6e, 0x18, 0x64, 0x58, 0xb1, 0x0d, 0xd7, 0x2d, 0x77, 0x1a, 0xa1, 0x2a, 0x75, 0xbc, 0x23,
+	0x7f, 0xec, 0x7b, 0xee, 0x05, 0x5d, 0x53, 0x73, 0xfd, 0x9d, 0x97, 0x2f, 0x16, 0xdf, 0x54, 0xc4,
+	0x6d, 0xef, 0xc8, 0xdf, 0xf3, 0xdc, 0x8b, 0xdc, 0x2c, 0x0b, 0x33, 0x24, 0xf1, 0x5b, 0xd0, 0x3d,
+	0xf2, 0x43, 0x4b, 0x8e, 0x53, 0xc6, 0x74, 0x69, 0x9e, 0xc1, 0xcb, 0x17, 0x8b, 0xb7, 0x88, 0xf2,
+	0xe8, 0x12, 0x77, 0xda, 0x79, 0xbc, 0xfe, 0xaf, 0x65, 0xa8, 0x51, 0x5b, 0x3c, 0x84, 0xc6, 0x84,
+	0x18, 0x9f, 0x68, 0x99, 0x5b, 0x28, 0x09, 0x44, 0x5b, 0xe1, 0x1b, 0x89, 0x86, 0x5e, 0x1c, 0x5e,
+	0x18, 0x49, 0x37, 0x1c, 0x11, 0x9b, 0x87, 0xae, 0x8c, 0xa3, 0x7e, 0x79, 0x76, 0xc4, 0x88, 0x09,
+	0x6a, 0x84, 0xea, 0x36, 0x7b, 0xfd, 0x95, 0xd9, 0xeb, 0x17, 0x03, 0x68, 0x5a, 0x27, 0xd2, 0x3a,
+	0x8d, 0xa6, 0x13, 0x25, 0x1c, 0x29, 0x2c, 0xee, 0x40, 0x87, 0xda, 0x81, 0xef, 0x78, 0x34, 0xbc,
+	0x46, 0x1d, 0xda, 0x19, 0x72, 0x14, 0x0d, 0xb6, 0xa0, 0x9d, 0xdf, 0x2c, 0x1a, 0xdf, 0x53, 0x79,
+	0x41, 0x52, 0x54, 0x35, 0xb0, 0x29, 0x96, 0xa0, 0x46, 0xea, 0x8a, 0x64, 0xa8, 0xb5, 0x0a, 0xb8,
+	0x67, 0x1e, 0x62, 0x30, 0xe1, 0xf3, 0xf2, 0x0f, 0x4a, 0x38, 0x4f, 0xfe, 0x08, 0xf9, 0x79, 0xb4,
+	0xab, 0xe7, 0xe1, 0x21, 0xb9, 0x79, 0x74, 0x1f, 0x1a, 0x3b, 0x8e, 0x25, 0xbd, 0x88, 0x4c, 0xf4,
+	0x34, 0x92, 0xa9, 0x6a, 0xc1, 0x36, 0x9e, 0x77, 0x62, 0x9e, 0xef, 0xfa, 0xb6, 0x8c, 0x68, 0x9e,
+	0xaa, 0x91, 0xc2, 0x48, 0x93, 0xe7, 0x81, 0x13, 0x5e, 0x8c, 0x98, 0x53, 0x15, 0x23, 0x85, 0xd1,
+	0x06, 0x4a, 0x0f, 0x17, 0xb3, 0x13, 0x73, 0xab, 0x40, 0xfd, 0xef, 0x2a, 0xd0, 0xfe, 0xa9, 0x0c,
+	0xfd, 0xfd, 0xd0, 0x0f, 0xfc, 0xc8, 0x74, 0xc5, 0x5a, 0x91, 0xe7, 0x7c, 0xb7, 0x4b, 0xb8, 0xdb,
+	0x7c, 0xb7, 0x95, 0x83, 0xf4, 0x12, 0xf8, 0xce, 0xf2, 0xb7, 0xa2, 0x43, 0x9d, 0xef, 0x7c, 0x0e,
+	0xcf, 0x14, 0x05, 0xfb, 0xf0, 0x2d, 0xf7, 0x2b, 0x59, 0x1f, 0xc5, 0x0f, 0x45, 0x11, 0xb7, 0x01,
+	0x26, 0xe6, 0xf9, 0x8e, 0x34, 0x23, 0xb9, 0x6d, 0x27, 0x8f, 0x3f, 0xc3, 0x28, 0x6e, 0x8c, 0xce,
+	0xbd, 0x51, 0x72, 0xb9, 0x29, 0x2c, 0xde, 0x06, 0x6d, 0x62, 0x9e, 0xa3, 0x16, 0xda, 0xb6, 0xf9,
+	0xb9, 0x19, 0x19, 0x42, 0xbc, 0x0b, 0x95, 0xf8, 0xdc, 0xeb, 0x37, 0x94, 0xc5, 0x47, 0x07, 0x70,
+	0x74, 0xee, 0x29, 0x7d, 0x65, 0x20, 0x0d, 0x6f, 0xd0, 0x72, 0x6c, 0x32, 0xf0, 0x9a, 0x81, 0x4d,
+	0x71, 0x17, 0x1a, 0x2e, 0xdf, 0x0d, 0x19, 0xf1, 0xd6, 0x6a, 0x8b, 0x75, 0x1f, 0xa1, 0x8c, 0x84,
+	0x26, 0x3e, 0x86, 0x66, 0xc2, 0x8b, 0x7e, 0x8b, 0xfa, 0xf5, 0x12, 0xee, 0x25, 0x4c, 0x33, 0xd2,
+	0x1e, 0x83, 0x1f, 0xc1, 0xc2, 0x0c, 0x2b, 0xf3, 0xb2, 0xd3, 0x61, 0xd9, 0xb9, 0x99, 0x97, 0x9d,
+	0x6a, 0x4e, 0x5e, 0xbe, 0xa8, 0x36, 0x9b, 0x3d, 0x4d, 0xff, 0xb7, 0x0a, 0x2c, 0x28, 0x31, 0x3e,
+	0x71, 0x82, 0x83, 0x18, 0xd5, 0x46, 0x1f, 0x1a, 0xa4, 0xf4, 0x95, 0x04, 0x55, 0x8d, 0x04, 0x14,
+	0xbf, 0x09, 0x75, 0x7a, 0xff, 0xc9, 0x33, 0x5c, 0xcc, 0xae, 0x27, 0x1d, 0xce, 0xcf, 0x52, 0xdd,
+	0xad, 0xea, 0x2e, 0xbe, 0x0f, 0xb5, 0xaf, 0x65, 0xe8, 0xb3, 0x11, 0x6b, 0xad, 0xde, 0x9e, 0x37,
+	0x0e, 0x8f, 0xa9, 0x86, 0x71, 0xe7, 0x5f, 0xe3, 0x2d, 0xbe, 0x87, 0x66, 0x6b, 0xe2, 0x9f, 0x49,
+	0xbb, 0xdf, 0x58, 0xaa, 0x24, 0x42, 0xa4, 0x04, 0x2d, 0x21, 0x25, 0x17, 0xd9, 0x9c, 0x7b, 0x91,
+	0xda, 0xd5, 0x17, 0x39, 0xd8, 0x84, 0x56, 0x8e, 0x0b, 0x73, 0xae, 0x65, 0xb1, 0xf8, 0xa4, 0xb5,
+	0x54, 0x9d, 0xe5, 0x35, 0xc3, 0x26, 0x40, 0xc6, 0x93, 0x5f, 0x55, 0xbf, 0xe8, 0xbf, 0x53, 0x82,
+	0x85, 0x0d, 0xdf, 0xf3, 0x24, 0x39, 0xb7, 0x7c, 0xc3, 0xd9, 0x33, 0x2b, 0x5d, 0xf9, 0xcc, 0x3e,
+	0x84, 0x5a, 0x84, 0x9d, 0xd5, 0xec, 0x37, 0xe6, 0x5c, 0x99, 0xc1, 0x3d, 0x50, 0xd9, 0x4e, 0xcc,
+	0xf3, 0x71, 0x20, 0x3d, 0xdb, 0xf1, 0x8e, 0x13, 0x65, 0x3b, 0x31, 0xcf, 0xf7, 0x19, 0xa3, 0xff,
+	0x4d, 0x19, 0xe0, 0xb1, 0x34, 0xdd, 0xf8, 0x04, 0x0d, 0x0a, 0xde, 0x9b, 0xe3, 0x45, 0xb1, 0xe9,
+	0x59, 0x49, 0x68, 0x91, 0xc2, 0x28, 0x7c, 0x68, 0x3d, 0x65, 0xc4, 0x6a, 0x4a, 0x33, 0x12, 0x10,
+	0xed, 0x29, 0x2e, 0x37, 0x8d, 0x94, 0x95, 0x55, 0x50, 0xe6, 0x13, 0x54, 0x09, 0xcd, 0x00, 0xce,
+	0x83, 0xae, 0xba, 0xe3, 0x7b, 0x24, 0x1a, 0x9a, 0x91, 0x80, 0x38, 0xcf, 0x34, 0x88, 0x9d, 0x09,
+	0xdb, 0xd2, 0x8a, 0xa1, 0x20, 0xdc, 0x15, 0xda, 0xce, 0xa1, 0x75, 0xe2, 0xd3, 0xf3, 0xae, 0x18,
+	0x29, 0x8c, 0xb3, 0xf9, 0xde, 0xb1, 0x8f, 0xa7, 0x6b, 0x92, 0x1b, 0x96, 0x80, 0x7c, 0x16, 0x5b,
+	0x9e, 0x23, 0x49, 0x23, 0x52, 0x0a, 0x23, 0x5f, 0xa4, 0x1c, 0x1f, 0x49, 0x33, 0x9e, 0x86, 0x32,
+	0xea, 0x03, 0x91, 0x41, 0xca, 0x2d, 0x85, 0x11, 0xef, 0x42, 0x1b, 0x19, 0x67, 0x46, 0x91, 0x73,
+	0xec, 0x49, 0x9b, 0x1e, 0x7d, 0xd5, 0x40, 0x66, 0xae, 0x29, 0x94, 0xfe, 0xdf, 0x15, 0xa8, 0xb3,
+	0x72, 0x2b, 0xb8, 0x25, 0xa5, 0x6f, 0xe5, 0x96, 0xbc, 0x0d, 0x5a, 0x10, 0x4a, 0xdb, 0xb1, 0x92,
+	0x7b, 0xd4, 0x8c, 0x0c, 0x41, 0xf1, 0x00, 0x5a, 0x68, 0xe2, 0x67, 0xd3, 0x60, 0x40, 0xe8, 0xd0,
+	0xf1, 0xbd, 0xb1, 0xed, 0x44, 0xa7, 0xe3, 0xc3, 0x8b, 0x58, 0x46, 0x8a, 0x17, 0x2d, 0xdf, 0xdb,
+	0x74, 0xa2, 0xd3, 0x75, 0x44, 0x21, 0x0b, 0xf9, 0x8d, 0xd0, 0xdb, 0x68, 0x1a, 0x0a, 0x12, 0x9f,
+	0x82, 0x46, 0xde, 0x20, 0x39, 0x1a, 0x1a, 0x39, 0x08, 0xb7, 0x5e, 0xbe, 0x58, 0x14, 0x88, 0x9c,
+	0xf1, 0x30, 0x9a, 0x09, 0x0e, 0xfd, 0x21, 0x1c, 0x8c, 0x26, 0x03, 0xc8, 0xb9, 0x21, 0x7f, 0x08,
+	0x51, 0xa3, 0x28, 0xef, 0x0f, 0x31, 0x46, 0xdc, 0x07, 0x31, 0xf5, 0x2c, 0x7f, 0x12, 0xa0, 0x50,
+	0x48, 0x5b, 0x6d, 0xb2, 0x45, 0x9b, 0xbc, 0x9e, 0xa7, 0xf0, 0x56, 0xdf, 0x84, 0xa6, 0x37, 0x9d,
+	0x8c, 0x29, 0x70, 0x6e, 0xb3, 0x36, 0xf3, 0xa6, 0x93, 0x67, 0x8e, 0x1d, 0x61, 0x74, 0x85, 0xa4,
+	0xd8, 0x3f, 0x95, 0x5e, 0xe2, 0xbe, 0x6a, 0xde, 0x74, 0x32, 0x22, 0x84, 0x78, 0x0f, 0xba, 0x48,
+	0xa6, 0xdb, 0xe4, 0xf1, 0x5d, 0x76, 0x03, 0xbc, 0xe9, 0x64, 0x1b, 0x91, 0x34, 0xc9, 0x32, 0xf4,
+	0x62, 0x3f, 0xe0, 0x49, 0xc6, 0x27, 0x66, 0x74, 0x22, 0xa3, 0xfe, 0xc2, 0x52, 0x65, 0xb9, 0x6a,
+	0x74, 0x63, 0x3f, 0xa0, 0xa9, 0x1e, 0x13, 0xb6, 0xd8, 0x53, 0x05, 0x2e, 0xbd, 0x62, 0x4f, 0x8a,
+	0x63, 0x23, 0xfd, 0x9f, 0xcb, 0xd0, 0xde, 0x74, 0x42, 0x69, 0xc5, 0xd2, 0x1e, 0xda, 0xc7, 0x12,
+	0xf9, 0x2d, 0xbd, 0xd8, 0x89, 0x2f, 0x94, 0x93, 0xaa, 0xa0, 0x34, 0x86, 0x28, 0x17, 0x63, 0x6a,
+	0xd6, 0x0a, 0x15, 0x4a, 0x03, 0x30, 0x20, 0x56, 0x01, 0xa8, 0xc1, 0xa9, 0x80, 0xea, 0xd5, 0xa9,
+	0x00, 0x8d, 0xba, 0x61, 0x13, 0x59, 0xc7, 0x63, 0x1c, 0xf6, 0x54, 0xeb, 0x94, 0x27, 0x98, 0xa2,
+	0xe6, 0xa5, 0xa0, 0xe4, 0x50, 0xba, 0xf4, 0x84, 0x28, 0x28, 0x39, 0x94, 0x6e, 0x1a, 0x0a, 0x36,
+	0x78, 0x3b, 0xd8, 0x16, 0x77, 0xa0, 0xec, 0x07, 0xfd, 0x66, 0xb6, 0x60, 0xfe, 0x60, 0x2b, 0x7b,
+	0x81, 0x51, 0xf6, 0x03, 0xd4, 0x47, 0x1c, 0xf7, 0xd2, 0x13, 0x42, 0x7d, 0x84, 0x76, 0x95, 0xa2,
+	0x30, 0x43, 0x51, 0x84, 0x0e, 0x6d, 0xd3, 0x75, 0xfd, 0x5f, 0x48, 0x7b, 0x3f, 0x94, 0x76, 0xf2,
+	0x9a, 0x0a, 0x38, 0xfd, 0x16, 0x94, 0xf7, 0x02, 0xd1, 0x80, 0xca, 0xc1, 0x70, 0xd4, 0xbb, 0x86,
+	0x8d, 0xcd, 0xe1, 0x4e, 0xaf, 0xa4, 0x7f, 0x53, 0x06, 0xed, 0xe9, 0x34, 0x36, 0x51, 0x03, 0x92,
+	0x48, 0x14, 0xdf, 0x51, 0xf6, 0x60, 0xde, 0x84, 0x66, 0x14, 0x9b, 0x21, 0xf9, 0x2f, 0x6c, 0x31,
+	0x1b, 0x04, 0x8f, 0x22, 0xf1, 0x3e, 0xd4, 0xa4, 0x7d, 0x2c, 0x13, 0x13, 0xd6, 0x9b, 0x3d, 0x8b,
+	0xc1, 0x64, 0xb1, 0x0c, 0xf5, 0xc8, 0x3a, 0x91, 0x13, 0xb3, 0x5f, 0xcd, 0x3a, 0x1e, 0x10, 0x86,
+	0xdd, 0x72, 0x43, 0xd1, 0xc5, 0x7b, 0x50, 0xc3, 0xdb, 0x88, 0xfa, 0xf5, 0x2c, 0xf2, 0x44, 0xc6,
+	0xab, 0x6e, 0x4c, 0xc4, 0xe7, 0x61, 0x87, 0x7e, 0x30, 0xf6, 0x03, 0xe2, 0x6b, 0x77, 0xf5, 0x26,
+	0x69, 0xe2, 0xe4, 0x34, 0x2b, 0x9b, 0xa1, 0x1f, 0xec, 0x05, 0x46, 0xdd, 0xa6, 0x5f, 0x14, 0x6a,
+	0xea, 0xce, 0x32, 0xc0, 0xa6, 0x4b, 0x43, 0x0c, 0xa7, 0x88, 0x96, 0xa1, 0x39, 0x91, 0xb1, 0x69,
+	0x9b, 0xb1, 0xa9, 0x2c, 0x18, 0x85, 0xaf, 0x4f, 0x15, 0xce, 0x48, 0xa9, 0xfa, 0x03, 0xa8, 0xf3,
+	0xd4, 0xa2, 0x09, 0xd5, 0xdd, 0xbd, 0xdd, 0x21, 0x33, 0x74, 0x6d, 0x67, 0xa7, 0x57, 0x42, 0xd4,
+	0xe6, 0xda, 0x68, 0xad, 0x57, 0xc6, 0xd6, 0xe8, 0x27, 0xfb, 0xc3, 0x5e, 0x45, 0xff, 0xa7, 0x12,
+	0x34, 0x93, 0x79, 0xc4, 0xe7, 0x00, 0xa8, 0x68, 0xc6, 0x27, 0x8e, 0x97, 0xba, 0x82, 0x6f, 0xe5,
+	0x57, 0x5a, 0xc1, 0x1b, 0x7b, 0x8c, 0x54, 0x36, 0xf9, 0x5a, 0x90, 0xc0, 0x83, 0x03, 0xe8, 0x16,
+	0x89, 0x73, 0x7c, 0xe2, 0x7b, 0x79, 0xdb, 0xd7, 0x5d, 0xfd, 0x5e, 0x61, 0x6a, 0x1c, 0x49, 0xc2,
+	0x9c, 0x33, 0x83, 0xf7, 0xa1, 0x99, 0xa0, 0x45, 0x0b, 0x1a, 0x9b, 0xc3, 0xad, 0xb5, 0x67, 0x3b,
+	0x28, 0x24, 0x00, 0xf5, 0x83, 0xed, 0xdd, 0x47, 0x3b, 0x43, 0x3e, 0xd6, 0xce, 0xf6, 0xc1, 0xa8,
+	0x57, 0xd6, 0xff, 0xb8, 0x04, 0xcd, 0xc4, 0xbb, 0x12, 0x1f, 0xa2, 0x43, 0x44, 0x0e, 0x5e, 0xbf,
+	0x94, 0x65, 0x7a, 0x72, 0x71, 0xaa, 0x91, 0xd0, 0xf1, 0x61, 0x90, 0xc2, 0x48, 0xfc, 0x2d, 0x02,
+	0xf2, 0x51, 0x72, 0xa5, 0x90, 0xa8, 0xc1, 0x80, 0xdf, 0xf7, 0xa4, 0x72, 0xad, 0xa9, 0x4d, 0x32,
+	0xe8, 0x78, 0x96, 0xcc, 0x02, 0x8f, 0x06, 0xc1, 0xa3, 0x48, 0x8f, 0xd9, 0xe3, 0x4e, 0x37, 0x96,
+	0xae, 0x56, 0xca, 0xaf, 0x76, 0x29, 0x7c, 0x29, 0x5f, 0x0e, 0x5f, 0x32, 0xf3, 0x5e, 0x7b, 0x9d,
+	0x79, 0xd7, 0xff, 0xb2, 0x0a, 0x5d, 0x43, 0x46, 0xb1, 0x1f, 0x4a, 0x43, 0xfe, 0x7c, 0x2a, 0xa3,
+	0xf8, 0x55, 0x4f, 0xe8, 0x1d, 0x80, 0x90, 0x3b, 0x67, 0x4b, 0x6b, 0x0a, 0xc3, 0x71, 0x97, 0xeb,
+	0x5b, 0x24, 0xbb, 0xca, 0x8e, 0xa7, 0x30, 0x26, 0xfe, 0x0e, 0x4d, 0xeb, 0x94, 0xa7, 0x65, 0x6b,
+	0xde, 0x64, 0x04, 0xcf, 0x6b, 0x5a, 0x96, 0x8c, 0xa2, 0x31, 0x8a, 0x02, 0xdb, 0x74, 0x8d, 0x31,
+	0x4f, 0xe4, 0x05, 0x92, 0x23, 0x69, 0x85, 0x32, 0x26, 0x32, 0xab, 0x25, 0x8d, 0x31, 0x48, 0xbe,
+	0x03, 0x9d, 0x48, 0x46, 0x68, 0xff, 0x59, 0x01, 0x2b, 0x1d, 0xd5, 0x56, 0x48, 0xd2, 0xbe, 0x68,
+	0x2e, 0x4d, 0xcf, 0xf7, 0x2e, 0x26, 0xfe, 0x34, 0x52, 0x96, 0x2d, 0x43, 0x88, 0x15, 0xb8, 0x21,
+	0x3d, 0x2b, 0xbc, 0x08, 0x70, 0xaf, 0xb8, 0x0a, 0x66, 0xf2, 0xa4, 0x72, 0xf3, 0xaf, 0x67, 0xa4,
+	0x27, 0xf2, 0x62, 0xcb, 0x71, 0x25, 0xee, 0xe8, 0xcc, 0x9c, 0xba, 0xf1, 0x98, 0x32, 0x03, 0xc0,
+	0x3b, 0x22, 0xcc, 0x1a, 0xa6, 0x07, 0x3e, 0x82, 0xeb, 0x4c, 0x0e, 0x7d, 0x57, 0x3a, 0x36, 0x4f,
+	0xd6, 0xa2, 0x5e, 0x0b, 0x44, 0x30, 0x08, 0x4f, 0x53, 0xad, 0xc0, 0x0d, 0xee, 0xcb, 0x07, 0x4a,
+	0x7a, 0xb7, 0x79, 0x69, 0x22, 0x1d, 0x28, 0x4a, 0x71, 0xe9, 0xc0, 0x8c, 0x4f, 0xfa, 0x9d, 0xdc,
+	0xd2, 0xfb, 0x66, 0x7c, 0x82, 0x7e, 0x09, 0x93, 0x8f, 0x1c, 0xe9, 0x72, 0x24, 0xaf, 0x19, 0x3c,
+	0x62, 0x0b, 0x31, 0xe8, 0x97, 0xa8, 0x0e, 0x7e, 0x38, 0x31, 0x39, 0x61, 0xa8, 0x19, 0x3c, 0x68,
+	0x8b, 0x50, 0xb8, 0x84, 0xba, 0x2b, 0x6f, 0x3a, 0xe9, 0xf7, 0xf8, 0x9a, 0x19, 0xb3, 0x3b, 0x9d,
+	0xe8, 0x7f, 0x52, 0x81, 0x66, 0x1a, 0x18, 0xde, 0x03, 0x6d, 0x92, 0xe8, 0x2b, 0xe5, 0x4e, 0x76,
+	0x0a, 0x4a, 0xcc, 0xc8, 0xe8, 0xe2, 0x1d, 0x28, 0x9f, 0x9e, 0x29, 0xdd, 0xd9, 0x59, 0xe1, 0x04,
+	0x7a, 0x70, 0xb8, 0xba, 0xf2, 0xe4, 0xb9, 0x51, 0x3e, 0x3d, 0xfb, 0x0e, 0x72, 0x2b, 0x3e, 0x80,
+	0x05, 0xcb, 0x95, 0xa6, 0x37, 0xce, 0x7c, 0x20, 0x96, 0x8b, 0x2e, 0xa1, 0xf7, 0x13, 0xac, 0xb8,
+	0x0b, 0x35, 0x5b, 0xba, 0xb1, 0x99, 0xcf, 0xe3, 0xee, 0x85, 0xa6, 0xe5, 0xca, 0x4d, 0x44, 0x1b,
+	0x4c, 0x45, 0xdd, 0x99, 0x86, 0x67, 0x39, 0xdd, 0x79, 0x39, 0x34, 0xcb, 0xde, 0x25, 0xe4, 0xdf,
+	0xe5, 0x3d, 0xb8, 0x2e, 0xcf, 0x03, 0x32, 0x18, 0xe3, 0x34, 0xf7, 0xc0, 0x2e, 0x5f, 0x2f, 0x21,
+	0x6c, 0x28, 0xbc, 0xf8, 0x18, 0x1a, 0xea, 0xd1, 0xd0, 0x35, 0xb7, 0x56, 0x05, 0xe9, 0x9c, 0xc2,
+	0x33, 0x34, 0x92, 0x2e, 0xe2, 0x43, 0xd0, 0x2c, 0xdb, 0x1a, 0x33, 0x67, 0x3a, 0xd9, 0xde, 0x36,
+	0x36, 0x37, 0x98, 0x25, 0x4d, 0xcb, 0xb6, 0xa8, 0xf5, 0x45, 0xb5, 0xd9, 0xe8, 0x35, 0x75, 0x0b,
+	0x2a, 0x4f, 0x9e, 0x1f, 0x90, 0xfe, 0x41, 0x53, 0x50, 0x23, 0x5f, 0x81, 0xda, 0xa9, 0x4e, 0x2a,
+	0xe7, 0x74, 0xd2, 0x6d, 0x56, 0xe7, 0xc4, 0xae, 0x24, 0x13, 0x99, 0xc3, 0xe0, 0x81, 0xd9, 0x94,
+	0x55, 0x89, 0xc4, 0x80, 0xfe, 0xbb, 0x55, 0x68, 0x28, 0xff, 0x02, 0x55, 0xf8, 0x34, 0x4d, 0xb2,
+	0x61, 0xb3, 0x18, 0x9a, 0xa6, 0x8e, 0x4a, 0xbe, 0x62, 0x51, 0x79, 0x7d, 0xc5, 0x42, 0x7c, 0x0e,
+	0xed, 0x80, 0x69, 0x79, 0xd7, 0xe6, 0x8d, 0xfc, 0x18, 0xf5, 0x4b, 0xe3, 0x5a, 0x41, 0x06, 0xa0,
+	0x16, 0xa3, 0x74, 0x6e, 0x6c, 0x1e, 0x2b, 0x0e, 0x34, 0x10, 0x1e, 0x99, 0xc7, 0x57, 0x38, 0x38,
+	0xdf, 0xc6, 0x4f, 0xe9, 0x92, 0xc3, 0xd3, 0x26, 0xa5, 0x88, 0xbe, 0x4d, 0xde, 0xa5, 0xe8, 0x14,
+	0x5d, 0x8a, 0xb7, 0x40, 0xb3, 0xfc, 0xc9, 0xc4, 0x21, 0x5a, 0x57, 0x25, 0xa1, 0x08, 0x31, 0x8a,
+	0xf4, 0x3f, 0x2a, 0x41, 0x43, 0x9d, 0xf6, 0x92, 0xc1, 0x5a, 0xdf, 0xde, 0x5d, 0x33, 0x7e, 0xd2,
+	0x2b, 0xa1, 0x41, 0xde, 0xde, 0x1d, 0xf5, 0xca, 0x42, 0x83, 0xda, 0xd6, 0xce, 0xde, 0xda, 0xa8,
+	0x57, 0x41, 0x23, 0xb6, 0xbe, 0xb7, 0xb7, 0xd3, 0xab, 0x8a, 0x36, 0x34, 0x37, 0xd7, 0x46, 0xc3,
+	0xd1, 0xf6, 0xd3, 0x61, 0xaf, 0x86, 0x7d, 0x1f, 0x0d, 0xf7, 0x7a, 0x75, 0x6c, 0x3c, 0xdb, 0xde,
+	0xec, 0x35, 0x90, 0xbe, 0xbf, 0x76, 0x70, 0xf0, 0xd5, 0x9e, 0xb1, 0xd9, 0x6b, 0x92, 0x21, 0x1c,
+	0x19, 0xdb, 0xbb, 0x8f, 0x7a, 0x1a, 0xb6, 0xf7, 0xd6, 0xbf, 0x18, 0x6e, 0x8c, 0x7a, 0x80, 0xed,
+	0xe7, 0x3c, 0x77, 0x4b, 0xff, 0x04, 0x5a, 0x39, 0x6e, 0xe2, 0x4c, 0xc6, 0x70, 0xab, 0x77, 0x0d,
+	0x97, 0x7f, 0xbe, 0xb6, 0xf3, 0x0c, 0x6d, 0x68, 0x17, 0x80, 0x9a, 0xe3, 0x9d, 0xb5, 0xdd, 0x47,
+	0xbd, 0xb2, 0xfe, 0x25, 0x34, 0x9f, 0x39, 0xf6, 0xba, 0xeb, 0x5b, 0xa7, 0x28, 0x5a, 0x87, 0x66,
+	0x24, 0x95, 0xb9, 0xa2, 0x36, 0xfa, 0xb6, 0xf4, 0xbc, 0x22, 0x25, 0x07, 0x0a, 0x2a, 0x38, 0xee,
+	0x15, 0x36, 0x31, 0xca, 0x71, 0xd7, 0x4f, 0xa1, 0xf1, 0xcc, 0xb1, 0xf7, 0x4d, 0xeb, 0x94, 0xd4,
+	0x10, 0x4e, 0x3d, 0x8e, 0x9c, 0xaf, 0xa5, 0x32, 0x45, 0x1a, 0x61, 0x0e, 0x9c, 0xaf, 0xa5, 0x78,
+	0x0f, 0xea, 0x04, 0x24, 0x09, 0x0b, 0x7a, 0x14, 0xc9, 0x76, 0x0c, 0x45, 0xa3, 0x82, 0x93, 0xeb,
+	0xfa, 0xd6, 0x38, 0x94, 0x47, 0xfd, 0x37, 0xf8, 0x1e, 0x08, 0x61, 0xc8, 0x23, 0xfd, 0x0f, 0x4a,
+	0xe9, 0x99, 0xa9, 0xde, 0xb1, 0x08, 0xd5, 0xc0, 0xb4, 0x4e, 0xfb, 0xa5, 0x2c, 0xfe, 0x57, 0x9b,
+	0x31, 0x88, 0x20, 0x3e, 0x80, 0xa6, 0x12, 0xb2, 0x64, 0xd5, 0x56, 0x4e, 0x1a, 0x8d, 0x94, 0x58,
+	0xbc, 0xfe, 0x4a, 0xf1, 0xfa, 0x29, 0xda, 0x0d, 0x5c, 0x27, 0xe6, 0x27, 0x55, 0x35, 0x14, 0xa4,
+	0x7f, 0x1f, 0x20, 0x2b, 0x31, 0xcd, 0x71, 0x8c, 0x6e, 0x42, 0xcd, 0x74, 0x1d, 0x33, 0x89, 0x9e,
+	0x19, 0xd0, 0x77, 0xa1, 0x95, 0x8d, 0x22, 0xde, 0x9a, 0xae, 0x8b, 0x36, 0x2c, 0xa2, 0xb1, 0x4d,
+	0xa3, 0x61, 0xba, 0xee, 0x13, 0x79, 0x81, 0x51, 0x4f, 0x8d, 0x6b, 0x5a, 0xe5, 0x99, 0x72, 0x08,
+	0x0d, 0x35, 0x98, 0xa8, 0x7f, 0x0c, 0xf5, 0xad, 0xc4, 0x2d, 0x4f, 0x9e, 0x44, 0xe9, 0xaa, 0x27,
+	0xa1, 0x7f, 0x06, 0x90, 0x55, 0x54, 0xc4, 0x3d, 0x55, 0x3b, 0x8b, 0xb8, 0x52, 0x57, 0xca, 0xf2,
+	0x2f, 0xdc, 0x49, 0x95, 0xcd, 0xa8, 0xb3, 0xbe, 0x09, 0xcd, 0x57, 0x56, 0x23, 0x15, 0x03, 0xca,
+	0x19, 0x03, 0xe6, 0xd4, 0x27, 0xf5, 0x9f, 0x01, 0x64, 0x35, 0x36, 0xf5, 0x42, 0x79, 0x16, 0x7c,
+	0xa1, 0x1f, 0x61, 0x2a, 0xd8, 0x71, 0xed, 0x50, 0x7a, 0x85, 0x53, 0xa7, 0x23, 0x8c, 0x94, 0x2e,
+	0x96, 0xa0, 0x4a, 0xa5, 0xc3, 0x4a, 0xa6, 0x63, 0x93, 0xfd, 0x19, 0x44, 0xd1, 0xcf, 0xa1, 0xc3,
+	0xde, 0xfe, 0xb7, 0xf0, 0x95, 0x8a, 0x6a, 0xb5, 0x7c, 0x49, 0xad, 0xde, 0x82, 0x3a, 0x99, 0xe8,
+	0xe4, 0x34, 0x0a, 0xba, 0x42, 0xdd, 0xfe, 0x5e, 0x19, 0x80, 0x97, 0xc6, 0xb4, 0x6e, 0x31, 0xf8,
+	0x2f, 0xcd, 0x06, 0xff, 0x02, 0xaa, 0x69, 0x55, 0x58, 0x33, 0xa8, 0x9d, 0x99, 0x2d, 0x95, 0x10,
+	0x20, 0x00, 0xe7, 0x21, 0x97, 0xc9, 0xf9, 0x5a, 0x86, 0x6a, 0xc1, 0x0c, 0x91, 0xaf, 0x91, 0xd6,
+	0x8a, 0x35, 0xd2, 0xb4, 0x90, 0x54, 0xe7, 0xd9, 0x08, 0x98, 0x57, 0x13, 0xe3, 0x8c, 0x4c, 0x24,
+	0xc3, 0x38, 0x49, 0x27, 0x30, 0x94, 0xc6, 0x93, 0x9a, 0xea, 0x6b, 0x72, 0x4e, 0xc5, 0xc3, 0xfa,
+	0xaf, 0x77, 0xe4, 0x3a, 0x56, 0xac, 0x6a, 0xa2, 0xe0, 0xf9, 0x1b, 0x0a, 0xa3, 0x7f, 0x0e, 0xed,
+	0x84, 0xff, 0x54, 0x7a, 0xfa, 0x28, 0x8d, 0xc7, 0x4a, 0xd9, 0xdd, 0x66, 0x6c, 0x5a, 0x2f, 0xf7,
+	0x4b, 0x49, 0x44, 0xa6, 0xff, 0x4f, 0x25, 0x19, 0xac, 0x2a, 0x28, 0xaf, 0xe6, 0x61, 0x31, 0xa8,
+	0x2e, 0x7f, 0xab, 0xa0, 0xfa, 0x07, 0xa0, 0xd9, 0x14, 0x35, 0x3a, 0x67, 0x89, 0x81, 0x1b, 0xcc,
+	0x46, 0x88, 0x2a, 0xae, 0x74, 0xce, 0xa4, 0x91, 0x75, 0x7e, 0xcd, 0x3d, 0xa4, 0xdc, 0xae, 0xcd,
+	0xe3, 0x76, 0xfd, 0x57, 0xe4, 0xf6, 0xbb, 0xd0, 0xf6, 0x7c, 0x6f, 0xec, 0x4d, 0x5d, 0x17, 0x73,
+	0x50, 0x8a, 0xdd, 0x2d, 0xcf, 0xf7, 0x76, 0x15, 0x0a, 0xfd, 0xd8, 0x7c, 0x17, 0x7e, 0xd4, 0x2d,
+	0xea, 0xb7, 0x90, 0xeb, 0x47, 0x4f, 0x7f, 0x19, 0x7a, 0xfe, 0xe1, 0xcf, 0xb0, 0x2c, 0x8b, 0x1c,
+	0x1b, 0xd3, 0x6b, 0x66, 0x27, 0xb6, 0xcb, 0x78, 0x64, 0xd1, 0x2e, 0xbe, 0xeb, 0x99, 0x6b, 0xee,
+	0x5c, 0xba, 0xe6, 0xcf, 0x40, 0x4b, 0xb9, 0x94, 0x8b, 0x50, 0x35, 0xa8, 0x6d, 0xef, 0x6e, 0x0e,
+	0x7f, 0xdc, 0x2b, 0xa1, 0xd1, 0x34, 0x86, 0xcf, 0x87, 0xc6, 0xc1, 0xb0, 0x57, 0x46, 0x23, 0xb6,
+	0x39, 0xdc, 0x19, 0x8e, 0x86, 0xbd, 0x0a, 0x7b, 0x40, 0x54, 0xe2, 0x70, 0x1d, 0xcb, 0x89, 0xf5,
+	0x03, 0x80, 0x2c, 0xec, 0x46, 0xad, 0x9c, 0x6d, 0x4e, 0x65, 0x27, 0xe3, 0x64, 0x5b, 0xcb, 0xe9,
+	0x83, 0x2c, 0x5f, 0x15, 0xdc, 0x33, 0x1d, 0xcb, 0xea, 0x4f, 0xcd, 0xe0, 0x31, 0x97, 0xfc, 0xee,
+	0x42, 0x37, 0x30, 0xc3, 0xd8, 0x49, 0x22, 0x07, 0x56, 0x96, 0x6d, 0xa3, 0x93, 0x62, 0x51, 0xf7,
+	0xea, 0x7f, 0x55, 0x82, 0x9b, 0x4f, 0xfd, 0x33, 0x99, 0x7a, 0xa6, 0xfb, 0xe6, 0x85, 0xeb, 0x9b,
+	0xf6, 0x6b, 0xc4, 0x10, 0x43, 0x1f, 0x7f, 0x4a, 0xc5, 0xb9, 0xa4, 0x60, 0x69, 0x68, 0x8c, 0x79,
+	0xa4, 0xbe, 0x98, 0x90, 0x51, 0x4c, 0x44, 0x65, 0x48, 0x11, 0x46, 0xd2, 0xf7, 0xa0, 0x1e, 0x9f,
+	0x7b, 0x59, 0x7d, 0xb4, 0x16, 0x53, 0xee, 0x7c, 0xae, 0xa3, 0x5a, 0x9b, 0xef, 0xa8, 0xea, 0x1b,
+	0xa0, 0x8d, 0xce, 0x29, 0xaf, 0x3c, 0x8d, 0x0a, 0xce, 0x4e, 0xe9, 0x15, 0xce, 0x4e, 0x79, 0xc6,
+	0xd9, 0xf9, 0xcf, 0x12, 0xb4, 0x72, 0x1e, 0xb7, 0x78, 0x17, 0xaa, 0xf1, 0xb9, 0x57, 0xfc, 0x0a,
+	0x21, 0x59, 0xc4, 0x20, 0xd2, 0xa5, 0xdc, 0x69, 0xf9, 0x52, 0xee, 0x54, 0xec, 0xc0, 0x02, 0x6b,
+	0xde, 0xe4, 0x10, 0x49, 0xf2, 0xe6, 0xce, 0x8c, 0x87, 0xcf, 0xb9, 0xf7, 0xe4, 0x48, 0x2a, 0x23,
+	0xd1, 0x3d, 0x2e, 0x20, 0x07, 0x6b, 0x70, 0x63, 0x4e, 0xb7, 0xef, 0x52, 0x73, 0xd1, 0x17, 0xa1,
+	0x83, 0xd5, 0x09, 0x67, 0x22, 0xa3, 0xd8, 0x9c, 0x04, 0xe4, 0x2c, 0x2a, 0xcb, 0x59, 0x35, 0xca,
+	0x71, 0xa4, 0xbf, 0x0f, 0xed, 0x7d, 0x29, 0x43, 0x43, 0x46, 0x81, 0xef, 0xb1, 0x73, 0xa4, 0x72,
+	0xde, 0x6c, 0xa6, 0x15, 0xa4, 0xff, 0x36, 0x68, 0x98, 0x7e, 0x58, 0x37, 0x63, 0xeb, 0xe4, 0xbb,
+	0xa4, 0x27, 0xde, 0x87, 0x46, 0xc0, 0x32, 0xa5, 0xe2, 0xb0, 0x36, 0x99, 0x6b, 0x25, 0x67, 0x46,
+	0x42, 0xd4, 0x3f, 0x81, 0x1b, 0x07, 0xd3, 0xc3, 0xc8, 0x0a, 0x1d, 0x0a, 0x69, 0x13, 0x53, 0x36,
+	0x80, 0x66, 0x10, 0xca, 0x23, 0xe7, 0x5c, 0x26, 0x12, 0x9c, 0xc2, 0xfa, 0x0f, 0xe1, 0x66, 0x71,
+	0x88, 0x3a, 0xc2, 0x1d, 0xa8, 0x9c, 0x9e, 0x45, 0x6a, 0x67, 0xd7, 0x0b, 0x01, 0x1d, 0x15, 0xff,
+	0x91, 0xaa, 0x1b, 0x50, 0xd9, 0x9d, 0x4e, 0xf2, 0x1f, 0x30, 0x55, 0xf9, 0x03, 0xa6, 0xb7, 0xf2,
+	0x19, 0x65, 0x8e, 0x48, 0xb2, 0xcc, 0xf1, 0xdb, 0xa0, 0x1d, 0xf9, 0xe1, 0x2f, 0xcc, 0xd0, 0x96,
+	0xb6, 0xb2, 0x59, 0x19, 0x42, 0xff, 0x29, 0xb4, 0x12, 0x49, 0xd8, 0xb6, 0xa9, 0x90, 0x49, 0xa2,
+	0xb8, 0x6d, 0x17, 0x24, 0x93, 0x93, 0x99, 0xd2, 0xb3, 0xb7, 0x13, 0x11, 0x62, 0xa0, 0xb8, 0xb2,
+	0xaa, 0x2e, 0x25, 0x2b, 0xeb, 0x5b, 0xd0, 0x4e, 0xc2, 0x3e, 0xcc, 0x3a, 0x91, 0x70, 0xbb, 0x8e,
+	0xf4, 0x72, 0x82, 0xdf, 0x64, 0xc4, 0xa8, 0x98, 0x6f, 0x2c, 0x17, 0x1c, 0x00, 0x7d, 0x05, 0xea,
+	0xea, 0xe5, 0x08, 0xa8, 0x5a, 0xbe, 0xcd, 0xaf, 0xbb, 0x66, 0x50, 0x1b, 0xd9, 0x31, 0x89, 0x8e,
+	0x13, 0xe7, 0x66, 0x12, 0x1d, 0xeb, 0x7f, 0x5b, 0x86, 0xce, 0x3a, 0x05, 0xd9, 0xc9, 0x95, 0xe4,
+	0x52, 0x4b, 0xa5, 0x42, 0x6a, 0x29, 0x9f, 0x46, 0x2a, 0x17, 0xd2, 0x48, 0x85, 0x0d, 0x55, 0x8a,
+	0x1e, 0xc9, 0x1b, 0xd0, 0x98, 0x7a, 0xce, 0x79, 0xa2, 0x12, 0x34, 0xa3, 0x8e, 0xe0, 0x28, 0x12,
+	0x4b, 0xd0, 0x42, 0xad, 0xe1, 0x78, 0x9c, 0xba, 0xe1, 0xfc, 0x4b, 0x1e, 0x35, 0x93, 0xa0, 0xa9,
+	0xbf, 0x3a, 0x41, 0xd3, 0x78, 0x6d, 0x82, 0xa6, 0xf9, 0xba, 0x04, 0x8d, 0x36, 0x9b, 0xa0, 0x29,
+	0x7a, 0x53, 0x30, 0xeb, 0x4d, 0xe9, 0x3b, 0xd0, 0x4d, 0x78, 0xa7, 0x64, 0xf3, 0x73, 0x58, 0x50,
+	0xb9, 0x55, 0x19, 0xaa, 0xf4, 0x04, 0x6b, 0x9c, 0xeb, 0x94, 0xdd, 0xa5, 0xf4, 0xa7, 0xa2, 0x18,
+	0x5d, 0x3b, 0x0f, 0x46, 0xfa, 0xef, 0x97, 0xa0, 0x53, 0xe8, 0x21, 0x3e, 0xc9, 0x32, 0xb5, 0x25,
+	0x32, 0xec, 0xfd, 0x4b, 0xb3, 0xbc, 0x3a, 0x5b, 0x5b, 0x9e, 0xc9, 0xd6, 0xea, 0x77, 0xd3, 0x1c,
+	0xac, 0xca, 0xbc, 0x5e, 0x4b, 0x33, 0xaf, 0x94, 0xac, 0x5c, 0x1b, 0x8d, 0x8c, 0x5e, 0x59, 0xff,
+	0xeb, 0x32, 0x74, 0x86, 0xe7, 0x01, 0x7d, 0x6e, 0xf3, 0x5a, 0x9f, 0x33, 0x27, 0x30, 0xe5, 0x82,
+	0xc0, 0xe4, 0xae, 0xbe, 0xa2, 0x0a, 0x63, 0x7c, 0xf5, 0xe8, 0x85, 0x72, 0x1e, 0x48, 0x89, 0x04,
+	0x43, 0xff, 0x1f, 0x44, 0xe2, 0x6d, 0xd0, 0xd0, 0x8e, 0x47, 0x81, 0x69, 0x49, 0x95, 0x8c, 0xc9,
+	0x10, 0x28, 0x10, 0x09, 0xdb, 0x94, 0x40, 0x7c, 0xab, 0x57, 0xc8, 0x1f, 0xd2, 0xb9, 0x69, 0x22,
+	0x84, 0x01, 0xfd, 0x0f, 0xcb, 0xa0, 0xb1, 0x7c, 0xe1, 0xe6, 0x3f, 0x54, 0xfe, 0x75, 0x29, 0xcb,
+	0x4f, 0xa7, 0xc4, 0x95, 0x27, 0xf2, 0x82, 0xfc, 0x42, 0xea, 0x32, 0xb7, 0x8a, 0xa3, 0xd2, 0x25,
+	0x1c, 0x15, 0x62, 0x13, 0x55, 0x0c, 0x9b, 0xd6, 0xa9, 0x93, 0xd4, 0xc2, 0xd9, 0xd6, 0xe2, 0x57,
+	0x91, 0xe8, 0xcd, 0xcb, 0x70, 0xa2, 0xee, 0x80, 0xda, 0x45, 0xff, 0xbb, 0xa3, 0x3c, 0x42, 0xfd,
+	0x04, 0x1a, 0x6a, 0x75, 0x74, 0x90, 0x9e, 0xed, 0x3e, 0xd9, 0xdd, 0xfb, 0x6a, 0xb7, 0x20, 0x57,
+	0xa9, 0x0b, 0x55, 0xce, 0xbb, 0x50, 0x15, 0xc4, 0x6f, 0xec, 0x3d, 0xdb, 0x1d, 0xf5, 0xaa, 0xa2,
+	0x03, 0x1a, 0x35, 0xc7, 0xc6, 0xf0, 0x79, 0xaf, 0x46, 0x99, 0x83, 0x8d, 0xc7, 0xc3, 0xa7, 0x6b,
+	0xbd, 0x7a, 0x5a, 0x0f, 0x68, 0xe8, 0x7f, 0x5e, 0x82, 0xeb, 0x7c, 0xe4, 0x7c, 0xf8, 0x9c, 0xff,
+	0x88, 0xb5, 0xca, 0x1f, 0xb1, 0xfe, 0x7a, 0x23, 0x66, 0x1c, 0x34, 0x75, 0x92, 0x3a, 0x21, 0xa7,
+	0x79, 0xf0, 0x3b, 0x51, 0x2a, 0x0f, 0xea, 0xff, 0x50, 0x82, 0x01, 0x7b, 0x6e, 0x8f, 0xf0, 0x9b,
+	0xdd, 0x2f, 0x77, 0x2e, 0xc5, 0x6e, 0x57, 0xf9, 0x33, 0x77, 0xa1, 0x4b, 0x9f, 0xf9, 0xfe, 0xdc,
+	0x1d, 0xab, 0xf8, 0x82, 0xef, 0xaf, 0xa3, 0xb0, 0x3c, 0x91, 0xf8, 0x14, 0xda, 0xfc, 0x39, 0x30,
+	0x65, 0x21, 0x0b, 0xd5, 0xa3, 0x82, 0xdf, 0xd8, 0xe2, 0x5e, 0x54, 0xc7, 0xc2, 0x4f, 0x13, 0xd5,
+	0xa0, 0x2c, 0xcc, 0xbb, 0x5c, 0x20, 0x52, 0x43, 0x46, 0x14, 0xfc, 0x3d, 0x80, 0xb7, 0xe6, 0x9e,
+	0x43, 0x09, 0x76, 0x2e, 0xfd, 0xc6, 0xf2, 0xa4, 0xdf, 0x81, 0x66, 0x92, 0x1d, 0xc4, 0xa7, 0x1f,
+	0x15, 0x8c, 0x57, 0x3d, 0x22, 0xd3, 0xb5, 0xfa, 0xf7, 0x25, 0xa8, 0xa2, 0x23, 0x21, 0xee, 0x83,
+	0xf6, 0x58, 0x9a, 0x61, 0x7c, 0x28, 0xcd, 0x58, 0x14, 0x9c, 0x86, 0x01, 0x6d, 0x2b, 0xab, 0xee,
+	0xeb, 0xd7, 0x1e, 0x96, 0xc4, 0x0a, 0x7f, 0xc6, 0x97, 0x7c, 0x9d, 0xd8, 0x49, 0x1c, 0x12, 0x72,
+	0x58, 0x06, 0x85, 0xf1, 0xfa, 0xb5, 0x65, 0xea, 0xff, 0x85, 0xef, 0x78, 0x1b, 0xfc, 0xd5, 0x99,
+	0x98, 0x75, 0x60, 0x66, 0x47, 0x88, 0xfb, 0x50, 0xdf, 0x8e, 0xf6, 0xe5, 0xbc, 0xae, 0xc4, 0xda,
+	0xbc, 0x13, 0xa5, 0x5f, 0x5b, 0xfd, 0x8b, 0x0a, 0x54, 0xb1, 0x70, 0x82, 0x59, 0x55, 0xf5, 0x2d,
+	0x84, 0xc8, 0x7d, 0xf3, 0x30, 0xa0, 0xa0, 0x6d, 0xe6, 0x23, 0x09, 0x5a, 0xa5, 0xc7, 0x3c, 0xcd,
+	0x12, 0xcc, 0x22, 0xfb, 0x54, 0xe3, 0xd2, 0xa6, 0x3e, 0x83, 0xde, 0x41, 0x1c, 0x4a, 0x73, 0x92,
+	0xeb, 0x5e, 0x64, 0xd5, 0xbc, 0x6c, 0x35, 0xf1, 0xeb, 0x1e, 0xd4, 0xd9, 0x1d, 0x9d, 0x19, 0x30,
+	0x9b, 0x8a, 0xa6, 0xce, 0x1f, 0x40, 0xeb, 0xe0, 0xc4, 0x9f, 0xba, 0xf6, 0x81, 0x0c, 0xcf, 0xa4,
+	0xc8, 0x7d, 0xff, 0x34, 0xc8, 0xb5, 0xf5, 0x6b, 0x62, 0x19, 0x80, 0x3d, 0x20, 0xaa, 0x54, 0x37,
+	0x90, 0xb6, 0x3b, 0x9d, 0xf0, 0xa4, 0x39, 0xd7, 0x88, 0x7b, 0xe6, 0xbc, 0xd2, 0x57, 0xf5, 0xfc,
+	0x14, 0x3a, 0x1b, 0xf4, 0xe2, 0xf6, 0xc2, 0xb5, 0x43, 0x3f, 0x8c, 0xc5, 0xec, 0x37, 0x50, 0x83,
+	0x59, 0x84, 0x7e, 0x0d, 0xbf, 0x5c, 0x18, 0x85, 0x17, 0xdc, 0xff, 0xba, 0x72, 0xe6, 0xb3, 0xf5,
+	0xe6, 0x9c, 0x72, 0xf5, 0x7f, 0xab, 0x50, 0xff, 0xca, 0x0f, 0x4f, 0x25, 0x16, 0x4a, 0xea, 0x54,
+	0x28, 0x50, 0x62, 0x94, 0x16, 0x0d, 0xe6, 0x2d, 0xf4, 0x1e, 0x68, 0xc4, 0x14, 0xfc, 0x64, 0x99,
+	0xaf, 0x8a, 0x3e, 0x3e, 0x67, 0xbe, 0x70, 0x42, 0x80, 0xee, 0xb5, 0xcb, 0x17, 0x95, 0x16, 0xd2,
+	0x0a, 0x89, 0xfc, 0x01, 0x9d, 0xff, 0xc9, 0xf3, 0x03, 0x14, 0xcd, 0x87, 0x25, 0x54, 0xe5, 0x07,
+	0x7c, 0x52, 0xec, 0x94, 0x7d, 0x74, 0x3b, 0xe8, 0x26, 0x88, 0x74, 0xe6, 0x07, 0x50, 0x57, 0xef,
+	0xfe, 0x7a, 0xf6, 0xc2, 0x95, 0x32, 0x19, 0xf4, 0xf2, 0x28, 0x35, 0xe0, 0x13, 0xa8, 0xb3, 0x8e,
+	0xe4, 0x01, 0x05, 0xdf, 0x6e, 0x20, 0xf2, 0xa8, 0x44, 0x98, 0xc5, 0x3d, 0x68, 0xa8, 0x32, 0x80,
+	0x98, 0x53, 0x13, 0xe0, 0xa3, 0xb2, 0x53, 0xc9, 0xf3, 0xb3, 0x89, 0xe3, 0xf9, 0x0b, 0x5e, 0xc2,
+	0x40, 0xe4, 0x51, 0xe9, 0xfc, 0xf7, 0xa1, 0x67, 0x48, 0x4b, 0x3a, 0xb9, 0x38, 0x54, 0x24, 0x1c,
+	0x99, 0xf3, 0x74, 0x3f, 0x83, 0x4e, 0x21, 0x66, 0x15, 0xe4, 0xf5, 0xcc, 0x0b, 0x63, 0x2f, 0x3d,
+	0x98, 0x1f, 0x82, 0xa6, 0x42, 0x86, 0x43, 0x29, 0x28, 0x65, 0x3f, 0x27, 0xe8, 0x18, 0x5c, 0x8e,
+	0x19, 0xe8, 0x15, 0xfc, 0x18, 0x6e, 0xcc, 0x51, 0x78, 0x82, 0x3e, 0x2d, 0xbb, 0x5a, 0xa3, 0x0f,
+	0x16, 0xaf, 0xa4, 0xa7, 0xda, 0xe2, 0x47, 0xd0, 0xc9, 0xef, 0x23, 0x12, 0x1f, 0xe7, 0xf7, 0xc9,
+	0x87, 0x48, 0xa6, 0xeb, 0x28, 0x28, 0x19, 0xfc, 0xb0, 0xb4, 0xde, 0xfb, 0xc7, 0x6f, 0x6e, 0x97,
+	0xfe, 0xe5, 0x9b, 0xdb, 0xa5, 0x7f, 0xff, 0xe6, 0x76, 0xe9, 0x97, 0xff, 0x71, 0xfb, 0xda, 0x61,
+	0x9d, 0xfe, 0xc7, 0xf1, 0xe9, 0xff, 0x0d, 0x00, 0xf2, 0x48, 0xe6, 0x26, 0x3d, 0x32, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -6739,6 +6855,109 @@ var _Worker_serviceDesc = grpc.ServiceDesc{
 	Metadata: "pb.proto",
 }
 
+// SubscriptionsClient is the client API for Subscriptions service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type SubscriptionsClient interface {
+	// Subscribe streams the response to a read-only DQL query, and a new one every time its
+	// result changes.
+	Subscribe(ctx context.Context, in *api.Request, opts ...grpc.CallOption) (Subscriptions_SubscribeClient, error)
+}
+
+type subscriptionsClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewSubscriptionsClient(cc *grpc.ClientConn) SubscriptionsClient {
+	return &subscriptionsClient{cc}
+}
+
+func (c *subscriptionsClient) Subscribe(ctx context.Context, in *api.Request, opts ...grpc.CallOption) (Subscriptions_SubscribeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Subscriptions_serviceDesc.Streams[0], "/pb.Subscriptions/Subscribe", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &subscriptionsSubscribeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Subscriptions_SubscribeClient interface {
+	Recv() (*api.Response, error)
+	grpc.ClientStream
+}
+
+type subscriptionsSubscribeClient struct {
+	grpc.ClientStream
+}
+
+func (x *subscriptionsSubscribeClient) Recv() (*api.Response, error) {
+	m := new(api.Response)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// SubscriptionsServer is the server API for Subscriptions service.
+type SubscriptionsServer interface {
+	// Subscribe streams the response to a read-only DQL query, and a new one every time its
+	// result changes.
+	Subscribe(*api.Request, Subscriptions_SubscribeServer) error
+}
+
+// UnimplementedSubscriptionsServer can be embedded to have forward compatible implementations.
+type UnimplementedSubscriptionsServer struct {
+}
+
+func (*UnimplementedSubscriptionsServer) Subscribe(req *api.Request, srv Subscriptions_SubscribeServer) error {
+	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
+
+func RegisterSubscriptionsServer(s *grpc.Server, srv SubscriptionsServer) {
+	s.RegisterService(&_Subscriptions_serviceDesc, srv)
+}
+
+func _Subscriptions_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(api.Request)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SubscriptionsServer).Subscribe(m, &subscriptionsSubscribeServer{stream})
+}
+
+type Subscriptions_SubscribeServer interface {
+	Send(*api.Response) error
+	grpc.ServerStream
+}
+
+type subscriptionsSubscribeServer struct {
+	grpc.ServerStream
+}
+
+func (x *subscriptionsSubscribeServer) Send(m *api.Response) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _Subscriptions_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.Subscriptions",
+	HandlerType: (*SubscriptionsServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Subscribe",
+			Handler:       _Subscriptions_Subscribe_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "pb.proto",
+}
+
 func (m *List) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -8093,6 +8312,59 @@ func (m *Tablet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.TopTokenCounts) > 0 {
+		dAtA20 := make([]byte, len(m.TopTokenCounts)*10)
+		var j19 int
+		for _, num := range m.TopTokenCounts {
+			for num >= 1<<7 {
+				dAtA20[j19] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j19++
+			}
+			dAtA20[j19] = uint8(num)
+			j19++
+		}
+		i -= j19
+		copy(dAtA[i:], dAtA20[:j19])
+		i = encodeVarintPb(dAtA, i, uint64(j19))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x82
+	}
+	if len(m.TopTokenHashes) > 0 {
+		dAtA22 := make([]byte, len(m.TopTokenHashes)*10)
+		var j21 int
+		for _, num := range m.TopTokenHashes {
+			for num >= 1<<7 {
+				dAtA22[j21] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j21++
+			}
+			dAtA22[j21] = uint8(num)
+			j21++
+		}
+		i -= j21
+		copy(dAtA[i:], dAtA22[:j21])
+		i = encodeVarintPb(dAtA, i, uint64(j21))
+		i--
+		dAtA[i] = 0x7a
+	}
+	if m.NumIndexUids != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.NumIndexUids))
+		i--
+		dAtA[i] = 0x70
+	}
+	if m.NumTokens != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.NumTokens))
+		i--
+		dAtA[i] = 0x68
+	}
+	if m.NumUids != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.NumUids))
+		i--
+		dAtA[i] = 0x60
+	}
 	if m.UncompressedBytes != 0 {
 		i = encodeVarintPb(dAtA, i, uint64(m.UncompressedBytes))
 		i--
@@ -8675,6 +8947,18 @@ func (m *Proposal) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.CdcState != nil {
+		{
+			size, err := m.CdcState.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPb(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x6a
+	}
 	if m.Restore != nil {
 		{
 			size, err := m.Restore.MarshalToSizedBuffer(dAtA[:i])
@@ -9046,20 +9330,20 @@ func (m *PostingList) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	if len(m.Splits) > 0 {
-		dAtA28 := make([]byte, len(m.Splits)*10)
-		var j27 int
+		dAtA33 := make([]byte, len(m.Splits)*10)
+		var j32 int
 		for _, num := range m.Splits {
 			for num >= 1<<7 {
-				dAtA28[j27] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA33[j32] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j27++
+				j32++
 			}
-			dAtA28[j27] = uint8(num)
-			j27++
+			dAtA33[j32] = uint8(num)
+			j32++
 		}
-		i -= j27
-		copy(dAtA[i:], dAtA28[:j27])
-		i = encodeVarintPb(dAtA, i, uint64(j27))
+		i -= j32
+		copy(dAtA[i:], dAtA33[:j32])
+		i = encodeVarintPb(dAtA, i, uint64(j32))
 		i--
 		dAtA[i] = 0x22
 	}
@@ -9992,20 +10276,20 @@ func (m *TxnTimestamps) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	if len(m.Ts) > 0 {
-		dAtA32 := make([]byte, len(m.Ts)*10)
-		var j31 int
+		dAtA37 := make([]byte, len(m.Ts)*10)
+		var j36 int
 		for _, num := range m.Ts {
 			for num >= 1<<7 {
-				dAtA32[j31] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA37[j36] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j31++
+				j36++
 			}
-			dAtA32[j31] = uint8(num)
-			j31++
+			dAtA37[j36] = uint8(num)
+			j36++
 		}
-		i -= j31
-		copy(dAtA[i:], dAtA32[:j31])
-		i = encodeVarintPb(dAtA, i, uint64(j31))
+		i -= j36
+		copy(dAtA[i:], dAtA37[:j36])
+		i = encodeVarintPb(dAtA, i, uint64(j36))
 		i--
 		dAtA[i] = 0xa
 	}
@@ -10545,6 +10829,11 @@ func (m *ExportRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.Namespace != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.Namespace))
+		i--
+		dAtA[i] = 0x50
+	}
 	if m.Anonymous {
 		i--
 		if m.Anonymous {
@@ -10749,20 +11038,20 @@ func (m *BackupPostingList) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		dAtA[i] = 0x2a
 	}
 	if len(m.Splits) > 0 {
-		dAtA37 := make([]byte, len(m.Splits)*10)
-		var j36 int
+		dAtA42 := make([]byte, len(m.Splits)*10)
+		var j41 int
 		for _, num := range m.Splits {
 			for num >= 1<<7 {
-				dAtA37[j36] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA42[j41] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j36++
+				j41++
 			}
-			dAtA37[j36] = uint8(num)
-			j36++
+			dAtA42[j41] = uint8(num)
+			j41++
 		}
-		i -= j36
-		copy(dAtA[i:], dAtA37[:j36])
-		i = encodeVarintPb(dAtA, i, uint64(j36))
+		i -= j41
+		copy(dAtA[i:], dAtA42[:j41])
+		i = encodeVarintPb(dAtA, i, uint64(j41))
 		i--
 		dAtA[i] = 0x22
 	}
@@ -10786,20 +11075,20 @@ func (m *BackupPostingList) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		}
 	}
 	if len(m.Uids) > 0 {
-		dAtA39 := make([]byte, len(m.Uids)*10)
-		var j38 int
+		dAtA44 := make([]byte, len(m.Uids)*10)
+		var j43 int
 		for _, num := range m.Uids {
 			for num >= 1<<7 {
-				dAtA39[j38] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA44[j43] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j38++
+				j43++
 			}
-			dAtA39[j38] = uint8(num)
-			j38++
+			dAtA44[j43] = uint8(num)
+			j43++
 		}
-		i -= j38
-		copy(dAtA[i:], dAtA39[:j38])
-		i = encodeVarintPb(dAtA, i, uint64(j38))
+		i -= j43
+		copy(dAtA[i:], dAtA44[:j43])
+		i = encodeVarintPb(dAtA, i, uint64(j43))
 		i--
 		dAtA[i] = 0xa
 	}
@@ -10905,6 +11194,38 @@ func (m *UpdateGraphQLSchemaResponse) MarshalToSizedBuffer(dAtA []byte) (int, er
 	return len(dAtA) - i, nil
 }
 
+func (m *CDCState) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CDCState) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CDCState) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.SentTs != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.SentTs))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintPb(dAtA []byte, offset int, v uint64) int {
 	offset -= sovPb(v)
 	base := offset
@@ -11559,6 +11880,29 @@ func (m *Tablet) Size() (n int) {
 	if m.UncompressedBytes != 0 {
 		n += 1 + sovPb(uint64(m.UncompressedBytes))
 	}
+	if m.NumUids != 0 {
+		n += 1 + sovPb(uint64(m.NumUids))
+	}
+	if m.NumTokens != 0 {
+		n += 1 + sovPb(uint64(m.NumTokens))
+	}
+	if m.NumIndexUids != 0 {
+		n += 1 + sovPb(uint64(m.NumIndexUids))
+	}
+	if len(m.TopTokenHashes) > 0 {
+		l = 0
+		for _, e := range m.TopTokenHashes {
+			l += sovPb(uint64(e))
+		}
+		n += 1 + sovPb(uint64(l)) + l
+	}
+	if len(m.TopTokenCounts) > 0 {
+		l = 0
+		for _, e := range m.TopTokenCounts {
+			l += sovPb(uint64(e))
+		}
+		n += 2 + sovPb(uint64(l)) + l
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -11848,6 +12192,10 @@ func (m *Proposal) Size() (n int) {
 		l = m.Restore.Size()
 		n += 1 + l + sovPb(uint64(l))
 	}
+	if m.CdcState != nil {
+		l = m.CdcState.Size()
+		n += 1 + l + sovPb(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -12698,6 +13046,9 @@ func (m *ExportRequest) Size() (n int) {
 	if m.Anonymous {
 		n += 2
 	}
+	if m.Namespace != 0 {
+		n += 1 + sovPb(uint64(m.Namespace))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -12846,6 +13197,21 @@ func (m *UpdateGraphQLSchemaResponse) Size() (n int) {
 	return n
 }
 
+func (m *CDCState) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.SentTs != 0 {
+		n += 1 + sovPb(uint64(m.SentTs))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func sovPb(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -16917,19 +17283,228 @@ func (m *Tablet) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPb(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthPb
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthPb
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NumUids", wireType)
 			}
-			if (iNdEx + skippy) > l {
+			m.NumUids = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NumUids |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NumTokens", wireType)
+			}
+			m.NumTokens = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NumTokens |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NumIndexUids", wireType)
+			}
+			m.NumIndexUids = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NumIndexUids |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 15:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.TopTokenHashes = append(m.TopTokenHashes, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthPb
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthPb
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.TopTokenHashes) == 0 {
+					m.TopTokenHashes = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.TopTokenHashes = append(m.TopTokenHashes, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field TopTokenHashes", wireType)
+			}
+		case 16:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.TopTokenCounts = append(m.TopTokenCounts, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthPb
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthPb
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.TopTokenCounts) == 0 {
+					m.TopTokenCounts = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.TopTokenCounts = append(m.TopTokenCounts, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field TopTokenCounts", wireType)
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
 			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
@@ -18821,6 +19396,42 @@ func (m *Proposal) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CdcState", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.CdcState == nil {
+				m.CdcState = &CDCState{}
+			}
+			if err := m.CdcState.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -23740,6 +24351,25 @@ func (m *ExportRequest) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.Anonymous = bool(v != 0)
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+			}
+			m.Namespace = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Namespace |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -24635,6 +25265,79 @@ func (m *UpdateGraphQLSchemaResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *CDCState) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CDCState: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CDCState: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SentTs", wireType)
+			}
+			m.SentTs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SentTs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipPb(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
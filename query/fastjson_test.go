@@ -2,6 +2,7 @@ package query
 
 import (
 	"bytes"
+	"context"
 	"math"
 	"testing"
 
@@ -35,11 +36,28 @@ func subgraphWithSingleResultAndSingleValue(val *pb.TaskValue) *SubGraph {
 }
 
 func assertJSON(t *testing.T, expected string, sg *SubGraph) {
-	buf, err := ToJson(&Latency{}, []*SubGraph{sg})
+	buf, err := ToJson(context.Background(), &Latency{}, []*SubGraph{sg})
 	require.Nil(t, err)
 	require.Equal(t, expected, string(buf))
 }
 
+func TestToJsonMemoryLimit(t *testing.T) {
+	sg := subgraphWithSingleResultAndSingleValue(task.FromString("ABC"))
+	ctx, _ := WithMemoryLimit(context.Background(), 1)
+	_, err := ToJson(ctx, &Latency{}, []*SubGraph{sg})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "more than the limit of 1 bytes")
+
+	sg = subgraphWithSingleResultAndSingleValue(task.FromString("ABC"))
+	ctx, mem := WithMemoryLimit(context.Background(), 1<<20)
+	require.NoError(t, mem.add(100))
+	buf, err := ToJson(ctx, &Latency{}, []*SubGraph{sg})
+	require.NoError(t, err)
+	require.Equal(t, `{"query":[{"val":"ABC"}]}`, string(buf))
+	require.EqualError(t, mem.add(1<<20), "Query uses 1048676 bytes of memory, "+
+		"more than the limit of 1048576 bytes")
+}
+
 func TestSubgraphToFastJSON(t *testing.T) {
 	t.Run("With a string result", func(t *testing.T) {
 		sg := subgraphWithSingleResultAndSingleValue(task.FromString("ABC"))
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"sort"
 	"strconv"
+	"time"
 
 	"github.com/dgraph-io/dgraph/algo"
 	"github.com/dgraph-io/dgraph/protos/pb"
@@ -238,7 +239,8 @@ func (sg *SubGraph) formResult(ul *pb.List) (*groupResults, error) {
 				if err != nil {
 					continue
 				}
-				dedupMap.addValue(attr, val, srcUid)
+				dedupMap.addValue(attr, truncateToInterval(val, child.Params.GroupbyInterval),
+					srcUid)
 			}
 		}
 	}
@@ -316,7 +318,8 @@ func (sg *SubGraph) fillGroupedVars(doneVars map[string]varValue, path []*SubGra
 				if err != nil {
 					continue
 				}
-				dedupMap.addValue(attr, val, srcUid)
+				dedupMap.addValue(attr, truncateToInterval(val, child.Params.GroupbyInterval),
+					srcUid)
 			}
 		}
 	}
@@ -390,6 +393,26 @@ func (sg *SubGraph) processGroupBy(doneVars map[string]varValue, path []*SubGrap
 	return nil
 }
 
+// truncateToInterval truncates a DateTime value to the start of its day, month or year, in its
+// own time zone. The other values are returned as they are.
+func truncateToInterval(val types.Val, interval string) types.Val {
+	t, ok := val.Value.(time.Time)
+	if !ok || val.Tid != types.DateTimeID {
+		return val
+	}
+	switch interval {
+	case "day":
+		t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	case "month":
+		t = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	case "year":
+		t = time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, t.Location())
+	default:
+		return val
+	}
+	return types.Val{Tid: types.DateTimeID, Value: t}
+}
+
 func groupLess(a, b *groupResult) bool {
 	switch {
 	case len(a.uids) < len(b.uids):
@@ -0,0 +1,73 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+)
+
+// MemoryUsage tracks the memory taken by the results of a query, i.e. the results of its tasks
+// and its encoded response, against the limit of the query.
+//
+// The limit is a soft one: the result of a task is built by the Alpha serving its predicate,
+// which may be another one, and is only counted once it's received. A query thus takes at most
+// the limit plus the size of the results of the tasks it's running at once, before it fails.
+type MemoryUsage struct {
+	// used is accessed atomically, as the SubGraphs of a query are processed concurrently.
+	used  int64
+	limit int64
+}
+
+// WithMemoryLimit returns a context in which the memory taken by the results of the query is
+// tracked. The query fails once it takes more than limit bytes, 0 means no limit.
+func WithMemoryLimit(ctx context.Context, limit int64) (context.Context, *MemoryUsage) {
+	mem := &MemoryUsage{limit: limit}
+	return context.WithValue(ctx, memoryKey, mem), mem
+}
+
+func memoryUsage(ctx context.Context) *MemoryUsage {
+	mem, _ := ctx.Value(memoryKey).(*MemoryUsage)
+	return mem
+}
+
+// Used returns the number of bytes taken by the results of the query so far.
+func (mem *MemoryUsage) Used() int64 {
+	if mem == nil {
+		return 0
+	}
+	return atomic.LoadInt64(&mem.used)
+}
+
+// add records n more bytes taken by the query, and returns an error if it goes over its limit.
+func (mem *MemoryUsage) add(n int64) error {
+	if mem == nil {
+		return nil
+	}
+	return mem.check(atomic.AddInt64(&mem.used, n))
+}
+
+// check returns an error if the query would go over its limit with a total of used bytes.
+func (mem *MemoryUsage) check(used int64) error {
+	if mem == nil || mem.limit <= 0 || used <= mem.limit {
+		return nil
+	}
+	return errors.Errorf("Query uses %d bytes of memory, more than the limit of %d bytes",
+		used, mem.limit)
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/dgraph-io/dgraph/types"
 	"github.com/golang/glog"
 	"github.com/pkg/errors"
+	geom "github.com/twpayne/go-geom"
 )
 
 type mathTree struct {
@@ -210,6 +211,34 @@ func processTernary(mNode *mathTree) error {
 	return nil
 }
 
+// processGeoDistance handles geo_distance, which computes the distance in meters from the geo
+// values of its first child to the point held by its second child.
+func processGeoDistance(mNode *mathTree) error {
+	if len(mNode.Child) != 2 {
+		return errors.Errorf("Function %v expects 2 argument. But got: %v", mNode.Fn,
+			len(mNode.Child))
+	}
+	pt, ok := mNode.Child[1].Const.Value.(*geom.Point)
+	if !ok {
+		return errors.Errorf("Expected a point as the second argument of %v", mNode.Fn)
+	}
+	destMap := make(map[uint64]types.Val)
+	for k, val := range mNode.Child[0].Val {
+		g, ok := val.Value.(geom.T)
+		if val.Tid != types.GeoID || !ok {
+			return errors.Errorf("Expected geo values in %v but got %v", mNode.Fn,
+				val.Tid.Name())
+		}
+		dist, err := types.GeoDistance(g, pt)
+		if err != nil {
+			return err
+		}
+		destMap[k] = types.Val{Tid: types.FloatID, Value: dist}
+	}
+	mNode.Val = destMap
+	return nil
+}
+
 func evalMathTree(mNode *mathTree) error {
 	if mNode.Const.Value != nil {
 		return nil
@@ -231,6 +260,10 @@ func evalMathTree(mNode *mathTree) error {
 	}
 
 	aggName := mNode.Fn
+	if aggName == "geo_distance" {
+		return processGeoDistance(mNode)
+	}
+
 	if isUnary(aggName) {
 		if len(mNode.Child) != 1 {
 			return errors.Errorf("Function %v expects 1 argument. But got: %v", aggName,
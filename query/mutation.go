@@ -73,7 +73,7 @@ func expandEdges(ctx context.Context, m *pb.Mutations) ([]*pb.DirectedEdge, erro
 			if err != nil {
 				return nil, err
 			}
-			preds = append(preds, getPredicatesFromTypes(types)...)
+			preds = append(preds, getPredicatesFromTypes(ctx, types)...)
 			preds = append(preds, x.StarAllPredicates()...)
 			// AllowedPreds are used only with ACL. Do not delete all predicates but
 			// delete predicates to which the mutation has access
@@ -18,6 +18,7 @@ package query
 
 import (
 	"bytes"
+	"context"
 	"encoding/binary"
 	"encoding/json"
 	"fmt"
@@ -44,8 +45,9 @@ import (
 	"github.com/dgraph-io/ristretto/z"
 )
 
-// ToJson converts the list of subgraph into a JSON response by calling toFastJSON.
-func ToJson(l *Latency, sgl []*SubGraph) ([]byte, error) {
+// ToJson converts the list of subgraph into a JSON response by calling toFastJSON. The encoding
+// fails if the response doesn't fit in the memory limit of the query in ctx, see WithMemoryLimit.
+func ToJson(ctx context.Context, l *Latency, sgl []*SubGraph) ([]byte, error) {
 	sgr := &SubGraph{}
 	for _, sg := range sgl {
 		if sg.Params.Alias == "var" || sg.Params.Alias == "shortest" {
@@ -56,7 +58,7 @@ func ToJson(l *Latency, sgl []*SubGraph) ([]byte, error) {
 		}
 		sgr.Children = append(sgr.Children, sg)
 	}
-	data, err := sgr.toFastJSON(l)
+	data, err := sgr.toFastJSON(l, memoryUsage(ctx))
 	if err != nil {
 		glog.Errorf("while running ToJson: %v\n", err)
 	}
@@ -90,6 +92,9 @@ type encoder struct {
 	// Allocator for nodes.
 	alloc *z.Allocator
 
+	// mem is the memory used by the query so far, which curSize must fit in along with it.
+	mem *MemoryUsage
+
 	// Cache uid attribute, which is very commonly used.
 	uidAttr uint16
 }
@@ -249,11 +254,12 @@ func (enc *encoder) setScalarVal(fj fastJsonNode, sv []byte) error {
 
 	// Also increase curSize.
 	enc.curSize += uint64(len(sv))
-	if size := uint64(enc.alloc.Size()) + enc.curSize; size > maxEncodedSize {
+	size := uint64(enc.alloc.Size()) + enc.curSize
+	if size > maxEncodedSize {
 		return fmt.Errorf("estimated response size: %d is bigger than threshold: %d",
 			size, maxEncodedSize)
 	}
-	return nil
+	return enc.mem.check(enc.mem.Used() + int64(size))
 }
 
 func (enc *encoder) setList(fj fastJsonNode, list bool) {
@@ -590,6 +596,8 @@ func valToBytes(v types.Val) ([]byte, error) {
 		return []byte(fmt.Sprintf("\"%#x\"", v.Value)), nil
 	case types.PasswordID:
 		return []byte(fmt.Sprintf("%q", v.Value.(string))), nil
+	case types.VFloatID:
+		return json.Marshal(v.Value.([]float32))
 	default:
 		return nil, errors.New("Unsupported types.Val.Tid")
 	}
@@ -1043,15 +1051,17 @@ type Extensions struct {
 	Latency *api.Latency    `json:"server_latency,omitempty"`
 	Txn     *api.TxnContext `json:"txn,omitempty"`
 	Metrics *api.Metrics    `json:"metrics,omitempty"`
+	Plan    []*PlanNode     `json:"plan,omitempty"`
 }
 
-func (sg *SubGraph) toFastJSON(l *Latency) ([]byte, error) {
+func (sg *SubGraph) toFastJSON(l *Latency, mem *MemoryUsage) ([]byte, error) {
 	encodingStart := time.Now()
 	defer func() {
 		l.Json = time.Since(encodingStart)
 	}()
 
 	enc := newEncoder()
+	enc.mem = mem
 	defer func() {
 		enc.alloc.Release()
 	}()
@@ -1086,6 +1096,9 @@ func (sg *SubGraph) toFastJSON(l *Latency) ([]byte, error) {
 		return nil, fmt.Errorf("while writing to buffer. Encoded response size: %d"+
 			" is bigger than threshold: %d", bufw.Len(), maxEncodedSize)
 	}
+	if err := mem.check(mem.Used() + int64(bufw.Len())); err != nil {
+		return nil, err
+	}
 
 	// Put encoder's arena back to arena pool.
 	arenaPool.Put(enc.arena)
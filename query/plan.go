@@ -0,0 +1,159 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/worker"
+)
+
+// PlanNode describes how a SubGraph of a query is executed. The plans are returned for the
+// queries run with the explain or profile option.
+type PlanNode struct {
+	Attr  string `json:"attr,omitempty"`
+	Alias string `json:"alias,omitempty"`
+	// Func is the function of a query block, or of a filter.
+	Func string `json:"func,omitempty"`
+	// UsesIndex is set if Func is evaluated using the index of Attr.
+	UsesIndex bool `json:"uses_index,omitempty"`
+	// Group is the group serving Attr, if it's known yet.
+	Group uint32 `json:"group,omitempty"`
+	// FilterOp is the operator applied to the Filters: and, or, or not.
+	FilterOp string      `json:"filter_op,omitempty"`
+	Filters  []*PlanNode `json:"filters,omitempty"`
+	// Stats are only reported when profiling, as the query isn't run when it's only explained.
+	Stats    *PlanStats  `json:"stats,omitempty"`
+	Children []*PlanNode `json:"children,omitempty"`
+}
+
+// PlanStats are the statistics of the execution of a SubGraph.
+type PlanStats struct {
+	// UidsIn is the number of uids the SubGraph started from.
+	UidsIn int `json:"uids_in"`
+	// UidsOut is the number of uids the SubGraph resulted in, after the filters and pagination.
+	UidsOut int `json:"uids_out"`
+	// TaskNs is the time spent processing the task of the SubGraph, on this Alpha or on the
+	// one serving its predicate.
+	TaskNs uint64 `json:"task_ns"`
+}
+
+// IsExplain tells if the query is only planned, and not run. Its plan is returned instead of
+// its results.
+func IsExplain(ctx context.Context) bool {
+	return hasOption(ctx, ExplainKey, "explain")
+}
+
+// IsProfile tells if the query is run and profiled. Its plan, with the statistics of the
+// execution, is returned along with its results.
+func IsProfile(ctx context.Context) bool {
+	return hasOption(ctx, ProfileKey, "profile")
+}
+
+// BuildPlans returns the plans of the query blocks sgs, with their statistics if the query was
+// profiled.
+func BuildPlans(ctx context.Context, sgs []*SubGraph) []*PlanNode {
+	groupOf := func(attr string) uint32 {
+		return worker.PredicateGroup(ctx, attr)
+	}
+	withStats := IsProfile(ctx) && !IsExplain(ctx)
+
+	plans := make([]*PlanNode, 0, len(sgs))
+	for _, sg := range sgs {
+		plans = append(plans, buildPlan(sg, false, withStats, groupOf))
+	}
+	return plans
+}
+
+func buildPlan(sg *SubGraph, isFilter, withStats bool,
+	groupOf func(string) uint32) *PlanNode {
+
+	plan := &PlanNode{
+		Attr:     sg.Attr,
+		Alias:    sg.Params.Alias,
+		Func:     funcString(sg),
+		FilterOp: sg.FilterOp,
+	}
+	if sg.SrcFunc != nil {
+		plan.UsesIndex = worker.UsesIndex(
+			&pb.SrcFunction{Name: sg.SrcFunc.Name, IsCount: sg.SrcFunc.IsCount}, isFilter)
+	}
+	if attr := strings.TrimPrefix(sg.Attr, "~"); attr != "" && attr != "uid" {
+		plan.Group = groupOf(attr)
+	}
+	if withStats {
+		plan.Stats = &PlanStats{
+			UidsIn:  len(sg.SrcUIDs.GetUids()),
+			UidsOut: len(sg.DestUIDs.GetUids()),
+			TaskNs:  uint64(sg.taskLatency.Nanoseconds()),
+		}
+	}
+
+	for _, filter := range sg.Filters {
+		plan.Filters = append(plan.Filters, buildPlan(filter, true, withStats, groupOf))
+	}
+	for _, child := range sg.Children {
+		plan.Children = append(plan.Children, buildPlan(child, false, withStats, groupOf))
+	}
+	return plan
+}
+
+// funcString writes the function of sg like it's written in the query, e.g. eq(name, Alice).
+func funcString(sg *SubGraph) string {
+	fn := sg.SrcFunc
+	if fn == nil {
+		return ""
+	}
+
+	var args []string
+	if sg.Attr != "" && sg.Attr != "uid" {
+		attr := sg.Attr
+		if fn.IsCount {
+			attr = fmt.Sprintf("count(%s)", attr)
+		}
+		args = append(args, attr)
+	}
+	for _, arg := range fn.Args {
+		args = append(args, arg.Value)
+	}
+	return fmt.Sprintf("%s(%s)", fn.Name, strings.Join(args, ", "))
+}
+
+// Plans collects the plans of a request run with the explain or profile option.
+type Plans struct {
+	Nodes []*PlanNode
+}
+
+// WithPlans returns a context in which the plans of the query are collected, see RecordPlans.
+func WithPlans(ctx context.Context) (context.Context, *Plans) {
+	plans := &Plans{}
+	return context.WithValue(ctx, plansKey, plans), plans
+}
+
+// RecordPlans records the plans of the query in the Plans of ctx. It returns false if ctx
+// doesn't collect them.
+func RecordPlans(ctx context.Context, nodes []*PlanNode) bool {
+	plans, ok := ctx.Value(plansKey).(*Plans)
+	if !ok {
+		return false
+	}
+	plans.Nodes = nodes
+	return true
+}
@@ -0,0 +1,259 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/dgraph-io/dgraph/algo"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/worker"
+)
+
+// estimateFunc estimates the number of uids matched by the function of a SubGraph. It returns
+// false if it can't.
+type estimateFunc func(sg *SubGraph) (uint64, bool)
+
+// statsEstimate estimates the functions using the cardinality statistics of their predicates,
+// reported by the Alphas serving them.
+func statsEstimate(ctx context.Context) estimateFunc {
+	return func(sg *SubGraph) (uint64, bool) {
+		fn := sg.SrcFunc
+		switch {
+		case fn == nil || fn.IsCount || fn.IsValueVar || fn.IsLenVar:
+			return 0, false
+		case len(sg.Params.NeedsVar) > 0:
+			return 0, false
+		case fn.Name == "uid":
+			return uint64(len(sg.SrcUIDs.GetUids())), true
+		case sg.Attr == "" || strings.HasPrefix(sg.Attr, "~"):
+			return 0, false
+		}
+
+		args := make([]string, 0, len(fn.Args))
+		for _, arg := range fn.Args {
+			if arg.IsValueVar {
+				return 0, false
+			}
+			args = append(args, arg.Value)
+		}
+		var lang string
+		if len(sg.Params.Langs) > 0 {
+			lang = sg.Params.Langs[0]
+		}
+		return worker.EstimateCardinality(ctx, sg.Attr, fn.Name, args, lang)
+	}
+}
+
+// optimizeQuery plans the query block sg. The filters are run from the most selective to the
+// least selective one, and the most selective indexed function among the root function and the
+// filters anded with it becomes the root function.
+func optimizeQuery(sg *SubGraph, est estimateFunc) {
+	optimizeFilters(sg, est)
+	if pickRootFunc(sg, est) {
+		// The root function replaced a filter, which has to be planned again.
+		planFilter(sg.Filters[0], est)
+	}
+}
+
+func optimizeFilters(sg *SubGraph, est estimateFunc) {
+	for _, filter := range sg.Filters {
+		planFilter(filter, est)
+	}
+	for _, child := range sg.Children {
+		optimizeFilters(child, est)
+	}
+}
+
+// planFilter flattens the nested and and or filters of the filter tree, and orders them by their
+// estimated number of uids. It returns the estimate for the whole tree.
+//
+// The filters of an and run from the one matching the fewest uids, so that the next ones only
+// filter the uids that are left. The filters of an or run from the one matching the most uids,
+// so that the next ones only filter the uids that weren't matched yet.
+func planFilter(filter *SubGraph, est estimateFunc) (uint64, bool) {
+	switch filter.FilterOp {
+	case "and", "or":
+	case "not":
+		planFilter(filter.Filters[0], est)
+		return 0, false
+	default:
+		return est(filter)
+	}
+
+	var filters []*SubGraph
+	for _, child := range filter.Filters {
+		if child.FilterOp == filter.FilterOp && child.SrcFunc == nil {
+			filters = append(filters, child.Filters...)
+		} else {
+			filters = append(filters, child)
+		}
+	}
+	filter.Filters = filters
+
+	type estimate struct {
+		uids  uint64
+		known bool
+	}
+	estimates := make(map[*SubGraph]estimate, len(filters))
+	var numKnown int
+	var sum uint64
+	min := uint64(math.MaxUint64)
+	for _, child := range filters {
+		uids, ok := planFilter(child, est)
+		estimates[child] = estimate{uids, ok}
+		if !ok {
+			continue
+		}
+		numKnown++
+		if sum += uids; sum < uids {
+			sum = math.MaxUint64
+		}
+		if uids < min {
+			min = uids
+		}
+	}
+	if numKnown == 0 {
+		return 0, false
+	}
+
+	isAnd := filter.FilterOp == "and"
+	sort.SliceStable(filters, func(i, j int) bool {
+		ei, ej := estimates[filters[i]], estimates[filters[j]]
+		switch {
+		case ei.known != ej.known:
+			// The filters which can't be estimated are assumed to match a lot of uids.
+			return ei.known == isAnd
+		case isAnd:
+			return ei.uids < ej.uids
+		default:
+			return ei.uids > ej.uids
+		}
+	})
+	filter.filtersInOrder = len(filters) > 1
+
+	if isAnd {
+		return min, true
+	}
+	return sum, numKnown == len(filters)
+}
+
+// rootSwappable tells if the function of sg is evaluated the same way at the root and in a
+// filter, so that a root function and a filter anded with it can be swapped.
+func rootSwappable(sg *SubGraph) bool {
+	fn := sg.SrcFunc
+	switch {
+	case fn == nil || fn.IsCount || fn.IsValueVar || fn.IsLenVar:
+		return false
+	case len(sg.Params.NeedsVar) > 0 || len(sg.Params.Langs) > 0:
+		return false
+	case sg.Attr == "" || strings.HasPrefix(sg.Attr, "~"):
+		return false
+	}
+	switch fn.Name {
+	case "has", "eq", "anyofterms", "allofterms", "anyoftext", "alloftext":
+		return true
+	}
+	return false
+}
+
+// pickRootFunc swaps the root function of the query block sg with the filter anded with it
+// which is estimated to match the fewest uids, if it's estimated to match fewer uids than the
+// root function. It returns true if it did.
+func pickRootFunc(sg *SubGraph, est estimateFunc) bool {
+	switch {
+	case sg.Params.Recurse || sg.Params.Alias == "shortest" || sg.Params.IsEmpty:
+		return false
+	case len(sg.Filters) != 1 || !rootSwappable(sg):
+		return false
+	}
+	rootUids, ok := est(sg)
+	if !ok {
+		return false
+	}
+
+	var candidates []*SubGraph
+	switch top := sg.Filters[0]; top.FilterOp {
+	case "":
+		candidates = append(candidates, top)
+	case "and":
+		candidates = append(candidates, top.Filters...)
+	}
+	var best *SubGraph
+	bestUids := rootUids
+	for _, filter := range candidates {
+		if filter.FilterOp != "" || !rootSwappable(filter) {
+			continue
+		}
+		// The estimates only exist for indexed predicates, which the root function requires.
+		if uids, ok := est(filter); ok && uids < bestUids {
+			best, bestUids = filter, uids
+		}
+	}
+	if best == nil {
+		return false
+	}
+	sg.Attr, best.Attr = best.Attr, sg.Attr
+	sg.SrcFunc, best.SrcFunc = best.SrcFunc, sg.SrcFunc
+	return true
+}
+
+// runFiltersInOrder runs the filters of sg one after the other, in the order picked by
+// planFilter, and sets the DestUIDs of sg to the uids they keep.
+func (sg *SubGraph) runFiltersInOrder(ctx context.Context) error {
+	uids := sg.DestUIDs
+	var matched []*pb.List
+	for _, filter := range sg.Filters {
+		if len(uids.GetUids()) == 0 {
+			filter.DestUIDs = &pb.List{}
+			continue
+		}
+
+		isUidFuncWithoutVar := filter.SrcFunc != nil && filter.SrcFunc.Name == "uid" &&
+			len(filter.Params.NeedsVar) == 0
+		if isUidFuncWithoutVar {
+			// The user already gave us the list.
+			filter.DestUIDs = algo.IntersectSorted([]*pb.List{filter.SrcUIDs, uids})
+		} else {
+			filter.SrcUIDs = uids
+			// Passing the pointer is okay since the filter only reads.
+			filter.Params.ParentVars = sg.Params.ParentVars // Pass to the child.
+			filterChan := make(chan error, 1)
+			ProcessGraph(ctx, filter, sg, filterChan)
+			if err := <-filterChan; err != nil {
+				return err
+			}
+		}
+
+		if sg.FilterOp == "and" {
+			uids = algo.IntersectSorted([]*pb.List{uids, filter.DestUIDs})
+		} else {
+			matched = append(matched, filter.DestUIDs)
+			uids = algo.Difference(uids, filter.DestUIDs)
+		}
+	}
+
+	if sg.FilterOp == "and" {
+		sg.DestUIDs = uids
+	} else {
+		sg.DestUIDs = algo.MergeSorted(matched)
+	}
+	return nil
+}
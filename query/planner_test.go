@@ -0,0 +1,159 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dgraph-io/dgraph/gql"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/stretchr/testify/require"
+)
+
+func funcSubGraph(name, attr string, args ...string) *SubGraph {
+	fn := &Function{Name: name}
+	for _, arg := range args {
+		fn.Args = append(fn.Args, gql.Arg{Value: arg})
+	}
+	return &SubGraph{Attr: attr, SrcFunc: fn}
+}
+
+func uidSubGraph(uids ...uint64) *SubGraph {
+	return &SubGraph{SrcFunc: &Function{Name: "uid"}, SrcUIDs: &pb.List{Uids: uids}}
+}
+
+// attrEstimate estimates the functions by their predicate.
+func attrEstimate(uids map[string]uint64) estimateFunc {
+	return func(sg *SubGraph) (uint64, bool) {
+		n, ok := uids[sg.Attr]
+		return n, ok
+	}
+}
+
+func filterAttrs(sg *SubGraph) []string {
+	var attrs []string
+	for _, filter := range sg.Filters {
+		attrs = append(attrs, filter.Attr)
+	}
+	return attrs
+}
+
+func TestPlanFilterOrdersAnds(t *testing.T) {
+	est := attrEstimate(map[string]uint64{"country": 1000, "email": 1, "name": 500})
+	filter := &SubGraph{FilterOp: "and", Filters: []*SubGraph{
+		{FilterOp: "and", Filters: []*SubGraph{
+			funcSubGraph("eq", "country", "US"),
+			funcSubGraph("regexp", "bio", "/.*/"),
+		}},
+		funcSubGraph("has", "name"),
+		funcSubGraph("eq", "email", "a@b.c"),
+	}}
+
+	uids, ok := planFilter(filter, est)
+	require.True(t, ok)
+	require.Equal(t, uint64(1), uids)
+	// The nested and is flattened, and the filters that can't be estimated run last.
+	require.Equal(t, []string{"email", "name", "country", "bio"}, filterAttrs(filter))
+	require.True(t, filter.filtersInOrder)
+}
+
+func TestPlanFilterOrdersOrs(t *testing.T) {
+	est := attrEstimate(map[string]uint64{"country": 1000, "email": 1, "name": 500})
+	filter := &SubGraph{FilterOp: "or", Filters: []*SubGraph{
+		funcSubGraph("eq", "email", "a@b.c"),
+		{FilterOp: "or", Filters: []*SubGraph{
+			funcSubGraph("has", "name"),
+			funcSubGraph("eq", "country", "US"),
+		}},
+	}}
+
+	uids, ok := planFilter(filter, est)
+	require.True(t, ok)
+	require.Equal(t, uint64(1501), uids)
+	require.Equal(t, []string{"country", "name", "email"}, filterAttrs(filter))
+
+	filter.Filters = append(filter.Filters, funcSubGraph("regexp", "bio", "/.*/"))
+	_, ok = planFilter(filter, est)
+	require.False(t, ok)
+	require.Equal(t, []string{"bio", "country", "name", "email"}, filterAttrs(filter))
+}
+
+func TestPlanFilterKeepsUnknownOrder(t *testing.T) {
+	filter := &SubGraph{FilterOp: "and", Filters: []*SubGraph{
+		funcSubGraph("eq", "country", "US"),
+		funcSubGraph("eq", "email", "a@b.c"),
+	}}
+	_, ok := planFilter(filter, attrEstimate(nil))
+	require.False(t, ok)
+	require.Equal(t, []string{"country", "email"}, filterAttrs(filter))
+	require.False(t, filter.filtersInOrder)
+}
+
+func TestOptimizeQueryPicksRootFunc(t *testing.T) {
+	est := attrEstimate(map[string]uint64{"country": 1000, "email": 1, "name": 500})
+	sg := funcSubGraph("eq", "country", "US")
+	sg.Filters = []*SubGraph{{FilterOp: "and", Filters: []*SubGraph{
+		funcSubGraph("has", "name"),
+		funcSubGraph("eq", "email", "a@b.c"),
+	}}}
+
+	optimizeQuery(sg, est)
+	require.Equal(t, "email", sg.Attr)
+	require.Equal(t, "eq", sg.SrcFunc.Name)
+	require.Equal(t, "a@b.c", sg.SrcFunc.Args[0].Value)
+	require.Equal(t, []string{"name", "country"}, filterAttrs(sg.Filters[0]))
+
+	// The root function isn't swapped with a filter of an or.
+	sg = funcSubGraph("eq", "country", "US")
+	sg.Filters = []*SubGraph{{FilterOp: "or", Filters: []*SubGraph{
+		funcSubGraph("has", "name"),
+		funcSubGraph("eq", "email", "a@b.c"),
+	}}}
+	optimizeQuery(sg, est)
+	require.Equal(t, "country", sg.Attr)
+
+	// Nor with a filter using a language.
+	sg = funcSubGraph("eq", "country", "US")
+	filter := funcSubGraph("eq", "email", "a@b.c")
+	filter.Params.Langs = []string{"en"}
+	sg.Filters = []*SubGraph{filter}
+	optimizeQuery(sg, est)
+	require.Equal(t, "country", sg.Attr)
+}
+
+func TestRunFiltersInOrder(t *testing.T) {
+	sg := &SubGraph{
+		FilterOp:       "and",
+		Filters:        []*SubGraph{uidSubGraph(2, 3, 4), uidSubGraph(3, 4, 5)},
+		DestUIDs:       &pb.List{Uids: []uint64{1, 2, 3, 4}},
+		filtersInOrder: true,
+	}
+	require.NoError(t, sg.runFiltersInOrder(context.Background()))
+	require.Equal(t, []uint64{3, 4}, sg.DestUIDs.Uids)
+
+	sg = &SubGraph{
+		FilterOp:       "or",
+		Filters:        []*SubGraph{uidSubGraph(2, 3), uidSubGraph(3, 4, 5)},
+		DestUIDs:       &pb.List{Uids: []uint64{1, 2, 3, 4}},
+		filtersInOrder: true,
+	}
+	require.NoError(t, sg.runFiltersInOrder(context.Background()))
+	require.Equal(t, []uint64{2, 3, 4}, sg.DestUIDs.Uids)
+	// The second filter only ran on the uids that weren't matched yet.
+	require.Equal(t, []uint64{4}, sg.Filters[1].DestUIDs.Uids)
+}
@@ -191,6 +191,9 @@ type params struct {
 	IsGroupBy bool // True if @groupby is specified.
 	// GroupbyAttrs holds the list of attributes to group by.
 	GroupbyAttrs []gql.GroupByAttr
+	// GroupbyInterval is the interval that the DateTime values of a groupby attribute are
+	// truncated to.
+	GroupbyInterval string
 
 	// ParentIds is a stack that is maintained and passed down to children.
 	ParentIds []uint64
@@ -288,6 +291,16 @@ type SubGraph struct {
 	List     bool // whether predicate is of list type
 
 	pathMeta *pathMetadata
+
+	// taskLatency is the time spent processing the task of this SubGraph, on this Alpha or on
+	// the one serving its predicate.
+	taskLatency time.Duration
+	// filtersInOrder is set if the Filters run one after the other, in the order picked by the
+	// planner, see planFilter.
+	filtersInOrder bool
+	// similarOrder holds the uids found by similar_to at root, nearest first. The results of the
+	// root are kept in that order, unless the query orders them otherwise.
+	similarOrder []uint64
 }
 
 func (sg *SubGraph) recurse(set func(sg *SubGraph)) {
@@ -739,24 +752,38 @@ type ContextKey int
 const (
 	// DebugKey is the key used to toggle debug mode.
 	DebugKey ContextKey = iota
+	// ExplainKey is the key used to only plan the query, see IsExplain.
+	ExplainKey
+	// ProfileKey is the key used to profile the query, see IsProfile.
+	ProfileKey
+	// plansKey is the key of the Plans collected for the request, see WithPlans.
+	plansKey
+	// memoryKey is the key of the MemoryUsage of the request, see WithMemoryLimit.
+	memoryKey
 )
 
 func isDebug(ctx context.Context) bool {
-	var debug bool
+	return hasOption(ctx, DebugKey, "debug")
+}
 
-	// gRPC client passes information about debug as metadata.
+// hasOption tells if the boolean request option name is set, either in the gRPC metadata or
+// under key in ctx.
+func hasOption(ctx context.Context, key ContextKey, name string) bool {
+	var opt bool
+
+	// gRPC client passes information about the option as metadata.
 	if md, ok := metadata.FromIncomingContext(ctx); ok {
 		// md is a map[string][]string
-		if len(md["debug"]) > 0 {
+		if len(md[name]) > 0 {
 			// We ignore the error here, because in error case,
-			// debug would be false which is what we want.
-			debug, _ = strconv.ParseBool(md["debug"][0])
+			// opt would be false which is what we want.
+			opt, _ = strconv.ParseBool(md[name][0])
 		}
 	}
 
-	// HTTP passes information about debug as query parameter which is attached to context.
-	d, _ := ctx.Value(DebugKey).(bool)
-	return debug || d
+	// HTTP passes information about the option as query parameter which is attached to context.
+	o, _ := ctx.Value(key).(bool)
+	return opt || o
 }
 
 func (sg *SubGraph) populate(uids []uint64) error {
@@ -1301,7 +1328,7 @@ func (sg *SubGraph) updateFacetMatrix() {
 func (sg *SubGraph) updateUidMatrix() {
 	sg.updateFacetMatrix()
 	for _, l := range sg.uidMatrix {
-		if len(sg.Params.Order) > 0 || len(sg.Params.FacetsOrder) > 0 {
+		if len(sg.Params.Order) > 0 || len(sg.Params.FacetsOrder) > 0 || sg.similarOrder != nil {
 			// We can't do intersection directly as the list is not sorted by UIDs.
 			// So do filter.
 			algo.ApplyFilter(l, func(uid uint64, idx int) bool {
@@ -1905,7 +1932,7 @@ func expandSubgraph(ctx context.Context, sg *SubGraph) ([]*SubGraph, error) {
 				break
 			}
 
-			preds = getPredicatesFromTypes(typeNames)
+			preds = getPredicatesFromTypes(ctx, typeNames)
 			// We check if enterprise is enabled and only
 			// restrict preds to allowed preds if ACL is turned on.
 			if worker.EnterpriseEnabled() && sg.Params.AllowedPreds != nil {
@@ -1930,7 +1957,7 @@ func expandSubgraph(ctx context.Context, sg *SubGraph) ([]*SubGraph, error) {
 				preds = getPredsFromVals(child.ExpandPreds)
 			} else {
 				typeNames := strings.Split(child.Params.Expand, ",")
-				preds = getPredicatesFromTypes(typeNames)
+				preds = getPredicatesFromTypes(ctx, typeNames)
 			}
 		}
 		preds = uniquePreds(preds)
@@ -2090,7 +2117,9 @@ func ProcessGraph(ctx context.Context, sg, parent *SubGraph, rch chan error) {
 				rch <- err
 				return
 			}
+			taskStart := time.Now()
 			result, err := worker.ProcessTaskOverNetwork(ctx, taskQuery)
+			sg.taskLatency += time.Since(taskStart)
 			switch {
 			case err != nil && strings.Contains(err.Error(), worker.ErrNonExistentTabletMessage):
 				sg.UnknownAttr = true
@@ -2100,6 +2129,15 @@ func ProcessGraph(ctx context.Context, sg, parent *SubGraph, rch chan error) {
 				rch <- err
 				return
 			}
+			// The result is counted once it's built, see MemoryUsage.
+			if err := memoryUsage(ctx).add(int64(result.Size())); err != nil {
+				rch <- err
+				return
+			}
+
+			if sg.SrcFunc != nil && sg.SrcFunc.Name == "similar_to" {
+				sg.sortSimilarTo(result, parent == nil)
+			}
 
 			sg.uidMatrix = result.UidMatrix
 			sg.valueMatrix = result.ValueMatrix
@@ -2130,8 +2168,15 @@ func ProcessGraph(ctx context.Context, sg, parent *SubGraph, rch chan error) {
 		}
 	}
 
+	if sg.filtersInOrder {
+		if err = sg.runFiltersInOrder(ctx); err != nil {
+			rch <- err
+			return
+		}
+	}
+
 	// Run filters if any.
-	if len(sg.Filters) > 0 {
+	if len(sg.Filters) > 0 && !sg.filtersInOrder {
 		// Run all filters in parallel.
 		filterChan := make(chan error, len(sg.Filters))
 		for _, filter := range sg.Filters {
@@ -2191,7 +2236,10 @@ func ProcessGraph(ctx context.Context, sg, parent *SubGraph, rch chan error) {
 		}
 	}
 
-	if len(sg.Params.Order) == 0 && len(sg.Params.FacetsOrder) == 0 {
+	if sg.similarOrder != nil {
+		// The results of similar_to are ordered by similarity.
+		sg.applySimilarOrderAndPagination()
+	} else if len(sg.Params.Order) == 0 && len(sg.Params.FacetsOrder) == 0 {
 		// There is no ordering. Just apply pagination and return.
 		if err = sg.applyPagination(ctx); err != nil {
 			rch <- err
@@ -2240,9 +2288,10 @@ func ProcessGraph(ctx context.Context, sg, parent *SubGraph, rch chan error) {
 				Attr:   it.Attr,
 				ReadTs: sg.ReadTs,
 				Params: params{
-					Alias:        it.Alias,
-					IgnoreResult: true,
-					Langs:        it.Langs,
+					Alias:           it.Alias,
+					IgnoreResult:    true,
+					Langs:           it.Langs,
+					GroupbyInterval: it.Interval,
 				},
 			})
 		}
@@ -2387,6 +2436,33 @@ func (sg *SubGraph) applyOrderAndPagination(ctx context.Context) error {
 	return nil
 }
 
+// sortSimilarTo sorts by uid the lists of the result of similar_to, which come nearest first.
+// The order of similarity is kept for the results of the root, unless the query orders them
+// otherwise.
+func (sg *SubGraph) sortSimilarTo(result *pb.Result, isRoot bool) {
+	if isRoot && len(result.UidMatrix) == 1 &&
+		len(sg.Params.Order) == 0 && len(sg.Params.FacetsOrder) == 0 {
+		sg.similarOrder = append([]uint64{}, result.UidMatrix[0].Uids...)
+	}
+	for _, l := range result.UidMatrix {
+		sort.Slice(l.Uids, func(i, j int) bool { return l.Uids[i] < l.Uids[j] })
+	}
+}
+
+// applySimilarOrderAndPagination puts the results of similar_to at root back in the order of
+// similarity before applying pagination.
+func (sg *SubGraph) applySimilarOrderAndPagination() {
+	uids := make([]uint64, 0, len(sg.similarOrder))
+	for _, uid := range sg.similarOrder {
+		if algo.IndexOf(sg.DestUIDs, uid) >= 0 {
+			uids = append(uids, uid)
+		}
+	}
+	start, end := x.PageRange(sg.Params.Count, sg.Params.Offset, len(uids))
+	sg.uidMatrix = []*pb.List{{Uids: uids[start:end]}}
+	sg.updateDestUids()
+}
+
 func (sg *SubGraph) updateDestUids() {
 	// Update sg.destUID. Iterate over the UID matrix (which is not sorted by
 	// UID). For each element in UID matrix, we do a binary search in the
@@ -2547,7 +2623,7 @@ func isValidArg(a string) bool {
 func isValidFuncName(f string) bool {
 	switch f {
 	case "anyofterms", "allofterms", "val", "regexp", "anyoftext", "alloftext",
-		"has", "uid", "uid_in", "anyof", "allof", "type", "match":
+		"has", "uid", "uid_in", "anyof", "allof", "type", "match", "similar_to":
 		return true
 	}
 	return isInequalityFn(f) || types.IsGeoFunc(f)
@@ -2590,12 +2666,14 @@ func getNodeTypes(ctx context.Context, sg *SubGraph) ([]string, error) {
 	return getPredsFromVals(result.ValueMatrix), nil
 }
 
-// getPredicatesFromTypes returns the list of preds contained in the given types.
-func getPredicatesFromTypes(typeNames []string) []string {
+// getPredicatesFromTypes returns the list of preds contained in the given types of the
+// namespace of the request.
+func getPredicatesFromTypes(ctx context.Context, typeNames []string) []string {
 	var preds []string
 
+	ns := x.ExtractNamespace(ctx)
 	for _, typeName := range typeNames {
-		typeDef, ok := schema.State().GetType(typeName)
+		typeDef, ok := schema.State().GetType(x.NamespaceAttr(ns, typeName))
 		if !ok {
 			continue
 		}
@@ -2681,11 +2759,17 @@ func (req *Request) ProcessQuery(ctx context.Context) (err error) {
 			sg.ReadTs = req.ReadTs
 			sg.Cache = req.Cache
 		})
+		optimizeQuery(sg, statsEstimate(ctx))
 		span.Annotate(nil, "Query parsed")
 		req.Subgraphs = append(req.Subgraphs, sg)
 	}
 	req.Latency.Parsing += time.Since(loopStart)
 
+	if IsExplain(ctx) {
+		// The query is only planned, see BuildPlans.
+		return nil
+	}
+
 	execStart := time.Now()
 	hasExecuted := make([]bool, len(req.Subgraphs))
 	numQueriesDone := 0
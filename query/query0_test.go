@@ -1400,6 +1400,21 @@ func TestGroupByRoot(t *testing.T) {
 		js)
 }
 
+func TestGroupByRootInterval(t *testing.T) {
+	query := `
+	{
+		me(func: uid(1, 23, 24, 25, 31)) @groupby(year: dob(interval: year)) {
+				count(uid)
+				min(dob)
+		}
+	}
+	`
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t,
+		`{"data": {"me":[{"@groupby":[{"year":"1901-01-01T00:00:00Z","count":1,"min(dob)":"1901-01-15T00:00:00Z"},{"year":"1909-01-01T00:00:00Z","count":2,"min(dob)":"1909-01-10T00:00:00Z"},{"year":"1910-01-01T00:00:00Z","count":2,"min(dob)":"1910-01-01T00:00:00Z"}]}]}}`,
+		js)
+}
+
 func TestGroupByRootEmpty(t *testing.T) {
 	// Predicate agent doesn't exist.
 	query := `
@@ -127,7 +127,8 @@ func parseScalarPair(it *lex.ItemIterator, predicate string) (*pb.SchemaUpdate,
 		return nil, next.Errorf("Undefined Type")
 	}
 	if schema.List {
-		if uint32(t) == uint32(types.PasswordID) || uint32(t) == uint32(types.BoolID) {
+		if uint32(t) == uint32(types.PasswordID) || uint32(t) == uint32(types.BoolID) ||
+			uint32(t) == uint32(types.VFloatID) {
 			return nil, next.Errorf("Unsupported type for list: [%s].", types.TypeID(t).Name())
 		}
 	}
@@ -232,6 +232,20 @@ func TestParse4_NoError(t *testing.T) {
 	require.Nil(t, err)
 }
 
+func TestParseVectorIndex(t *testing.T) {
+	reset()
+	result, err := Parse("embedding: float32vector @index(hnsw) .")
+	require.NoError(t, err)
+	require.Equal(t, []string{"hnsw"}, result.Preds[0].Tokenizer)
+	require.Equal(t, pb.Posting_VFLOAT, result.Preds[0].ValueType)
+
+	_, err = Parse("embedding: [float32vector] @index(hnsw) .")
+	require.Contains(t, err.Error(), "Unsupported type for list: [float32vector]")
+	_, err = Parse("name: string @index(hnsw) .")
+	require.Contains(t, err.Error(),
+		"Tokenizer: hnsw isn't valid for predicate: name of type: string")
+}
+
 func TestParse5_Error(t *testing.T) {
 	reset()
 	result, err := Parse("value:default @index .")
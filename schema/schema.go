@@ -586,6 +586,18 @@ func initialTypesInternal(all bool) []*pb.TypeUpdate {
 				}, {
 					Predicate: "dgraph.graphql.p_sha256hash",
 					ValueType: pb.Posting_STRING,
+				}, {
+					Predicate: "dgraph.graphql.p_client",
+					ValueType: pb.Posting_STRING,
+				}, {
+					Predicate: "dgraph.graphql.p_name",
+					ValueType: pb.Posting_STRING,
+				}, {
+					Predicate: "dgraph.graphql.p_version",
+					ValueType: pb.Posting_STRING,
+				}, {
+					Predicate: "dgraph.graphql.p_usage",
+					ValueType: pb.Posting_INT,
 				},
 			},
 		})
@@ -700,6 +712,23 @@ func initialSchemaInternal(all bool) []*pb.SchemaUpdate {
 			ValueType: pb.Posting_STRING,
 			Directive: pb.SchemaUpdate_INDEX,
 			Tokenizer: []string{"exact"},
+		}, &pb.SchemaUpdate{
+			Predicate: "dgraph.graphql.p_client",
+			ValueType: pb.Posting_STRING,
+			Directive: pb.SchemaUpdate_INDEX,
+			Tokenizer: []string{"exact"},
+		}, &pb.SchemaUpdate{
+			Predicate: "dgraph.graphql.p_name",
+			ValueType: pb.Posting_STRING,
+		}, &pb.SchemaUpdate{
+			Predicate: "dgraph.graphql.p_version",
+			ValueType: pb.Posting_STRING,
+		}, &pb.SchemaUpdate{
+			Predicate: "dgraph.graphql.p_usage",
+			ValueType: pb.Posting_INT,
+		}, &pb.SchemaUpdate{
+			Predicate: "dgraph.task",
+			ValueType: pb.Posting_STRING,
 		})
 
 	if all || x.WorkerConfig.AclEnabled {
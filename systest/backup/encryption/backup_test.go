@@ -260,6 +260,7 @@ func runBackupInternal(t *testing.T, forceFull bool, numExpectedFiles,
 				code
 				message
 			}
+			taskId
 		}
 	}`
 
@@ -278,7 +279,15 @@ func runBackupInternal(t *testing.T, forceFull bool, numExpectedFiles,
 	require.NoError(t, err)
 	buf, err := ioutil.ReadAll(resp.Body)
 	require.NoError(t, err)
-	require.Contains(t, string(buf), "Backup completed.")
+	var data struct {
+		Data struct {
+			Backup struct {
+				TaskId string
+			}
+		}
+	}
+	require.NoError(t, json.Unmarshal(buf, &data))
+	testutil.WaitForTask(t, data.Data.Backup.TaskId, testutil.GetAlphaClientConfig(t))
 
 	// Verify that the right amount of files and directories were created.
 	copyToLocalFs(t)
@@ -329,7 +338,9 @@ func runRestore(t *testing.T, lastDir string, commitTs uint64) map[string]string
 	require.NoError(t, err)
 	require.ElementsMatch(t, []string{"dgraph.graphql.schema", "dgraph.cors", "dgraph.graphql.xid",
 		"dgraph.type", "movie", "dgraph.graphql.schema_history", "dgraph.graphql.schema_created_at",
-		"dgraph.graphql.p_query", "dgraph.graphql.p_sha256hash", "dgraph.drop.op"},
+		"dgraph.graphql.p_query", "dgraph.graphql.p_sha256hash", "dgraph.drop.op", "dgraph.task",
+		"dgraph.graphql.p_client", "dgraph.graphql.p_name", "dgraph.graphql.p_version",
+		"dgraph.graphql.p_usage"},
 		restoredPreds)
 
 	restoredTypes, err := testutil.GetTypeNames(pdir)
@@ -52,12 +52,9 @@ func TestExportSchemaToMinio(t *testing.T) {
 	result := requestExport(t)
 
 	require.Equal(t, "Success", getFromJSON(result, "data", "export", "response", "code").(string))
-	require.Equal(t, "Export completed.", getFromJSON(result, "data", "export", "response", "message").(string))
+	taskId := getFromJSON(result, "data", "export", "taskId").(string)
 
-	var files []string
-	for _, f := range getFromJSON(result, "data", "export", "exportedFiles").([]interface{}) {
-		files = append(files, f.(string))
-	}
+	files := testutil.WaitForTask(t, taskId, nil)
 	require.Equal(t, 3, len(files))
 
 	schemaFile := files[1]
@@ -78,11 +75,16 @@ func TestExportSchemaToMinio(t *testing.T) {
 
 var expectedSchema = `<movie>:string .` + " " + `
 <dgraph.cors>:[string] @index(exact) @upsert .` + " " + `
+<dgraph.task>:string .` + " " + `
 <dgraph.type>:[string] @index(exact) .` + " " + `
 <dgraph.drop.op>:string .` + " " + `
 <dgraph.graphql.xid>:string @index(exact) @upsert .` + " " + `
+<dgraph.graphql.p_name>:string .` + " " + `
 <dgraph.graphql.schema>:string .` + " " + `
 <dgraph.graphql.p_query>:string .` + " " + `
+<dgraph.graphql.p_usage>:int .` + " " + `
+<dgraph.graphql.p_client>:string @index(exact) .` + " " + `
+<dgraph.graphql.p_version>:string .` + " " + `
 <dgraph.graphql.p_sha256hash>:string @index(exact) .` + " " + `
 <dgraph.graphql.schema_history>:string .` + " " + `
 <dgraph.graphql.schema_created_at>:datetime .` + " " + `
@@ -100,6 +102,10 @@ type <dgraph.graphql.history> {
 type <dgraph.graphql.persisted_query> {
 	dgraph.graphql.p_query
 	dgraph.graphql.p_sha256hash
+	dgraph.graphql.p_client
+	dgraph.graphql.p_name
+	dgraph.graphql.p_version
+	dgraph.graphql.p_usage
 }
 `
 
@@ -139,7 +145,7 @@ func requestExport(t *testing.T) map[string]interface{} {
 				code
 				message
 			}
-			exportedFiles
+			taskId
 		}
 	}`
 
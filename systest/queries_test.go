@@ -384,6 +384,21 @@ func SchemaQueryTestPredicate1(t *testing.T, c *dgo.Dgraph) {
 	  {
 		"predicate": "dgraph.graphql.p_sha256hash"
 	  },
+	  {
+		"predicate": "dgraph.graphql.p_client"
+	  },
+	  {
+		"predicate": "dgraph.graphql.p_name"
+	  },
+	  {
+		"predicate": "dgraph.graphql.p_version"
+	  },
+	  {
+		"predicate": "dgraph.graphql.p_usage"
+	  },
+	  {
+		"predicate": "dgraph.task"
+	  },
 	  {
 	    "predicate": "dgraph.graphql.schema_history"
 	  },
@@ -131,6 +131,43 @@ func MakeGQLRequestWithAccessJwtAndTLS(t *testing.T, params *GraphQLParams, tls
 	return &gqlResp
 }
 
+// WaitForTask polls the admin task query until the export, backup or restore task with the
+// given ID is over. It requires the task to succeed and returns its output.
+func WaitForTask(t *testing.T, taskId string, tls *tls.Config) []string {
+	params := &GraphQLParams{
+		Query: `query task($id: String!) {
+			task(id: $id) {
+				status
+				error
+				output
+			}
+		}`,
+		Variables: map[string]interface{}{"id": taskId},
+	}
+	for i := 0; i < 600; i++ {
+		resp := MakeGQLRequestWithTLS(t, params, tls)
+		resp.RequireNoGraphQLErrors(t)
+
+		var data struct {
+			Task struct {
+				Status string
+				Error  string
+				Output []string
+			}
+		}
+		require.NoError(t, json.Unmarshal(resp.Data, &data))
+		switch data.Task.Status {
+		case "Success":
+			return data.Task.Output
+		case "Failed":
+			require.Failf(t, "task failed", "task %s failed: %s", taskId, data.Task.Error)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	require.Failf(t, "task is not over", "task %s is still running after a minute", taskId)
+	return nil
+}
+
 type clientCustomClaims struct {
 	Namespace     string
 	AuthVariables map[string]interface{}
@@ -40,10 +40,15 @@ const (
 {"predicate":"dgraph.drop.op", "type": "string"},
 {"predicate":"dgraph.graphql.p_query","type":"string"},
 {"predicate":"dgraph.graphql.p_sha256hash","type":"string","index":true,"tokenizer":["exact"]},
+{"predicate":"dgraph.graphql.p_client","type":"string","index":true,"tokenizer":["exact"]},
+{"predicate":"dgraph.graphql.p_name","type":"string"},
+{"predicate":"dgraph.graphql.p_version","type":"string"},
+{"predicate":"dgraph.graphql.p_usage","type":"int"},
 {"predicate":"dgraph.graphql.schema", "type": "string"},
 {"predicate":"dgraph.graphql.schema_history", "type": "string"},
 {"predicate":"dgraph.graphql.schema_created_at", "type": "datetime"},
-{"predicate":"dgraph.graphql.xid","type":"string","index":true,"tokenizer":["exact"],"upsert":true}
+{"predicate":"dgraph.graphql.xid","type":"string","index":true,"tokenizer":["exact"],"upsert":true},
+{"predicate":"dgraph.task","type":"string"}
 `
 	aclTypes = `
 {
@@ -65,7 +70,9 @@ const (
 	"fields": [{"name": "dgraph.graphql.schema_history"},{"name": "dgraph.graphql.schema_created_at"}],
 	"name": "dgraph.graphql.history"
 },{
-	"fields": [{"name": "dgraph.graphql.p_query"},{"name": "dgraph.graphql.p_sha256hash"}],
+	"fields": [{"name": "dgraph.graphql.p_query"},{"name": "dgraph.graphql.p_sha256hash"},
+		{"name": "dgraph.graphql.p_client"},{"name": "dgraph.graphql.p_name"},
+		{"name": "dgraph.graphql.p_version"},{"name": "dgraph.graphql.p_usage"}],
 	"name": "dgraph.graphql.persisted_query"
 }
 `
@@ -0,0 +1,332 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package hnsw implements a Hierarchical Navigable Small World graph, used to find the
+// approximate nearest neighbours of a vector. See https://arxiv.org/abs/1603.09320.
+package hnsw
+
+import (
+	"container/heap"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// defaultM is the number of neighbours a node is linked to on each level above zero.
+	defaultM = 16
+	// defaultEfConstruction is the number of candidates considered while inserting a node.
+	defaultEfConstruction = 200
+)
+
+type node struct {
+	vec []float32
+	// friends holds the neighbours of the node on each of its levels.
+	friends [][]uint64
+	// deleted marks a node whose vector was deleted. It's kept in the graph, so that the
+	// searches can still go through it, but it's never returned.
+	deleted bool
+}
+
+// Index is an HNSW graph of vectors identified by uid. It is safe for concurrent use.
+type Index struct {
+	sync.RWMutex
+	m              int
+	maxM0          int
+	efConstruction int
+	levelMult      float64
+	rand           *rand.Rand
+
+	dim      int
+	nodes    map[uint64]*node
+	deleted  int
+	entry    uint64
+	maxLevel int
+}
+
+// New returns an empty index.
+func New() *Index {
+	return &Index{
+		m:              defaultM,
+		maxM0:          2 * defaultM,
+		efConstruction: defaultEfConstruction,
+		levelMult:      1 / math.Log(defaultM),
+		rand:           rand.New(rand.NewSource(rand.Int63())),
+		nodes:          make(map[uint64]*node),
+	}
+}
+
+// Distance returns the squared euclidean distance between two vectors of the same length.
+func Distance(a, b []float32) float32 {
+	var d float32
+	for i := range a {
+		diff := a[i] - b[i]
+		d += diff * diff
+	}
+	return d
+}
+
+// Len returns the number of vectors in the index, not counting the deleted ones.
+func (h *Index) Len() int {
+	h.RLock()
+	defer h.RUnlock()
+	return len(h.nodes) - h.deleted
+}
+
+// Delete removes the vector of uid from the results of the searches, if it's in the index.
+func (h *Index) Delete(uid uint64) {
+	h.Lock()
+	defer h.Unlock()
+
+	if n, ok := h.nodes[uid]; ok && !n.deleted {
+		n.deleted = true
+		h.deleted++
+	}
+}
+
+// Insert adds the vector of uid to the index, replacing its previous vector if any.
+func (h *Index) Insert(uid uint64, vec []float32) error {
+	h.Lock()
+	defer h.Unlock()
+
+	if len(vec) == 0 {
+		return errors.Errorf("Can't index an empty vector")
+	}
+	if h.dim == 0 {
+		h.dim = len(vec)
+	}
+	if len(vec) != h.dim {
+		return errors.Errorf("Vector of length %d doesn't match the length %d of the index",
+			len(vec), h.dim)
+	}
+
+	level := int(-math.Log(1-h.rand.Float64()) * h.levelMult)
+	old, replace := h.nodes[uid]
+	if replace {
+		// Keep the levels of the node, only its position in the graph changes.
+		level = len(old.friends) - 1
+		if old.deleted {
+			h.deleted--
+		}
+	}
+	n := &node{vec: vec, friends: make([][]uint64, level+1)}
+	if len(h.nodes) == 0 || replace && len(h.nodes) == 1 {
+		h.nodes[uid] = n
+		h.entry, h.maxLevel = uid, level
+		return nil
+	}
+
+	// The neighbours are searched for while the previous node, if any, is still in the graph:
+	// the search goes through its links even if it's the entry point.
+	ep := []uint64{h.entry}
+	for l := h.maxLevel; l > level; l-- {
+		ep = h.searchLayer(vec, ep, 1, l)
+	}
+	for l := min(level, h.maxLevel); l >= 0; l-- {
+		cands := h.searchLayer(vec, ep, h.efConstruction, l)
+		n.friends[l] = h.closest(vec, without(cands, uid), h.m)
+		ep = cands
+	}
+
+	h.nodes[uid] = n
+	for l, friends := range n.friends {
+		for _, f := range friends {
+			h.link(f, uid, l)
+		}
+	}
+	if level > h.maxLevel {
+		h.entry, h.maxLevel = uid, level
+	}
+	return nil
+}
+
+// Search returns the uids of up to k vectors closest to vec, nearest first. ef is the
+// number of candidates considered, larger values give better results at the expense of
+// speed. The deleted vectors don't count, more candidates are considered until k vectors
+// that weren't deleted are found.
+func (h *Index) Search(vec []float32, k, ef int) ([]uint64, error) {
+	h.RLock()
+	defer h.RUnlock()
+
+	if len(h.nodes) == h.deleted {
+		return nil, nil
+	}
+	if len(vec) != h.dim {
+		return nil, errors.Errorf("Vector of length %d doesn't match the length %d of the index",
+			len(vec), h.dim)
+	}
+	if ef < k {
+		ef = k
+	}
+
+	ep := []uint64{h.entry}
+	for l := h.maxLevel; l > 0; l-- {
+		ep = h.searchLayer(vec, ep, 1, l)
+	}
+	for {
+		cands := h.searchLayer(vec, ep, ef, 0)
+		res := cands[:0:0]
+		for _, uid := range cands {
+			if !h.nodes[uid].deleted {
+				res = append(res, uid)
+			}
+		}
+		if len(res) >= k || len(cands) < ef || ef >= len(h.nodes) {
+			if len(res) > k {
+				res = res[:k]
+			}
+			return res, nil
+		}
+		ef *= 2
+	}
+}
+
+// link adds to as a neighbour of from on the given level, keeping only the closest
+// neighbours if from has too many.
+func (h *Index) link(from, to uint64, level int) {
+	n := h.nodes[from]
+	if level >= len(n.friends) {
+		return
+	}
+	for _, f := range n.friends[level] {
+		if f == to {
+			return
+		}
+	}
+	n.friends[level] = append(n.friends[level], to)
+
+	max := h.m
+	if level == 0 {
+		max = h.maxM0
+	}
+	if len(n.friends[level]) > max {
+		n.friends[level] = h.closest(n.vec, n.friends[level], max)
+	}
+}
+
+// closest returns the k uids closest to vec.
+func (h *Index) closest(vec []float32, uids []uint64, k int) []uint64 {
+	out := append([]uint64{}, uids...)
+	sort.Slice(out, func(i, j int) bool {
+		return Distance(vec, h.nodes[out[i]].vec) < Distance(vec, h.nodes[out[j]].vec)
+	})
+	if len(out) > k {
+		out = out[:k]
+	}
+	return out
+}
+
+// searchLayer returns up to ef uids closest to vec on the given level, nearest first,
+// starting the search from the entry points ep.
+func (h *Index) searchLayer(vec []float32, ep []uint64, ef, level int) []uint64 {
+	visited := make(map[uint64]struct{}, ef*4)
+	cands := &minHeap{}
+	res := &maxHeap{}
+	for _, uid := range ep {
+		if _, ok := visited[uid]; ok {
+			continue
+		}
+		visited[uid] = struct{}{}
+		c := candidate{uid: uid, dist: Distance(vec, h.nodes[uid].vec)}
+		heap.Push(cands, c)
+		heap.Push(res, c)
+	}
+	for res.Len() > ef {
+		heap.Pop(res)
+	}
+
+	for cands.Len() > 0 {
+		c := heap.Pop(cands).(candidate)
+		if res.Len() >= ef && c.dist > (*res)[0].dist {
+			break
+		}
+		n := h.nodes[c.uid]
+		if level >= len(n.friends) {
+			continue
+		}
+		for _, f := range n.friends[level] {
+			if _, ok := visited[f]; ok {
+				continue
+			}
+			visited[f] = struct{}{}
+			fc := candidate{uid: f, dist: Distance(vec, h.nodes[f].vec)}
+			if res.Len() < ef || fc.dist < (*res)[0].dist {
+				heap.Push(cands, fc)
+				heap.Push(res, fc)
+				if res.Len() > ef {
+					heap.Pop(res)
+				}
+			}
+		}
+	}
+
+	out := make([]uint64, res.Len())
+	for i := len(out) - 1; i >= 0; i-- {
+		out[i] = heap.Pop(res).(candidate).uid
+	}
+	return out
+}
+
+func without(uids []uint64, uid uint64) []uint64 {
+	out := uids[:0:0]
+	for _, u := range uids {
+		if u != uid {
+			out = append(out, u)
+		}
+	}
+	return out
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+type candidate struct {
+	uid  uint64
+	dist float32
+}
+
+type minHeap []candidate
+
+func (h minHeap) Len() int            { return len(h) }
+func (h minHeap) Less(i, j int) bool  { return h[i].dist < h[j].dist }
+func (h minHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *minHeap) Push(x interface{}) { *h = append(*h, x.(candidate)) }
+func (h *minHeap) Pop() interface{} {
+	old := *h
+	c := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return c
+}
+
+type maxHeap []candidate
+
+func (h maxHeap) Len() int            { return len(h) }
+func (h maxHeap) Less(i, j int) bool  { return h[i].dist > h[j].dist }
+func (h maxHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *maxHeap) Push(x interface{}) { *h = append(*h, x.(candidate)) }
+func (h *maxHeap) Pop() interface{} {
+	old := *h
+	c := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return c
+}
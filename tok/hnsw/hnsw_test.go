@@ -0,0 +1,186 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hnsw
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func randomVector(r *rand.Rand, dim int) []float32 {
+	vec := make([]float32, dim)
+	for i := range vec {
+		vec[i] = r.Float32()
+	}
+	return vec
+}
+
+func bruteForce(vecs map[uint64][]float32, q []float32, k int) []uint64 {
+	var uids []uint64
+	for uid := range vecs {
+		uids = append(uids, uid)
+	}
+	sort.Slice(uids, func(i, j int) bool {
+		return Distance(q, vecs[uids[i]]) < Distance(q, vecs[uids[j]])
+	})
+	return uids[:k]
+}
+
+func TestSearchRecall(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	h := New()
+	vecs := make(map[uint64][]float32)
+	for uid := uint64(1); uid <= 2000; uid++ {
+		vecs[uid] = randomVector(r, 16)
+		require.NoError(t, h.Insert(uid, vecs[uid]))
+	}
+	require.Equal(t, 2000, h.Len())
+
+	const k = 10
+	var found int
+	for i := 0; i < 50; i++ {
+		q := randomVector(r, 16)
+		got, err := h.Search(q, k, 64)
+		require.NoError(t, err)
+		require.Len(t, got, k)
+
+		want := make(map[uint64]bool)
+		for _, uid := range bruteForce(vecs, q, k) {
+			want[uid] = true
+		}
+		for _, uid := range got {
+			if want[uid] {
+				found++
+			}
+		}
+	}
+	require.True(t, found >= 50*k*9/10, "recall too low: %d/%d", found, 50*k)
+}
+
+func TestSearchOrder(t *testing.T) {
+	h := New()
+	require.NoError(t, h.Insert(1, []float32{0, 0}))
+	require.NoError(t, h.Insert(2, []float32{1, 1}))
+	require.NoError(t, h.Insert(3, []float32{5, 5}))
+
+	got, err := h.Search([]float32{0.9, 0.9}, 2, 10)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{2, 1}, got)
+
+	// Replacing the vector moves the node in the graph.
+	require.NoError(t, h.Insert(3, []float32{0.8, 0.8}))
+	got, err = h.Search([]float32{0.9, 0.9}, 1, 10)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{3}, got)
+	require.Equal(t, 3, h.Len())
+}
+
+func TestDimensionMismatch(t *testing.T) {
+	h := New()
+	got, err := h.Search([]float32{1}, 1, 1)
+	require.NoError(t, err)
+	require.Empty(t, got)
+
+	require.NoError(t, h.Insert(1, []float32{1, 2}))
+	require.Error(t, h.Insert(2, []float32{1, 2, 3}))
+	require.Error(t, h.Insert(2, nil))
+	_, err = h.Search([]float32{1}, 1, 1)
+	require.Error(t, err)
+}
+
+func TestReplaceEntryPoint(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	h := New()
+	vecs := make(map[uint64][]float32)
+	for uid := uint64(1); uid <= 500; uid++ {
+		vecs[uid] = randomVector(r, 8)
+		require.NoError(t, h.Insert(uid, vecs[uid]))
+	}
+
+	// Move the entry point a few times, the search must still go through the whole graph.
+	for i := 0; i < 3; i++ {
+		entry := h.entry
+		vecs[entry] = randomVector(r, 8)
+		require.NoError(t, h.Insert(entry, vecs[entry]))
+		require.Equal(t, entry, h.entry)
+		for _, friends := range h.nodes[entry].friends[:1] {
+			require.NotEmpty(t, friends)
+		}
+	}
+	require.Equal(t, 500, h.Len())
+
+	const k = 10
+	var found int
+	for i := 0; i < 20; i++ {
+		q := randomVector(r, 8)
+		got, err := h.Search(q, k, 64)
+		require.NoError(t, err)
+		require.Len(t, got, k)
+
+		want := make(map[uint64]bool)
+		for _, uid := range bruteForce(vecs, q, k) {
+			want[uid] = true
+		}
+		for _, uid := range got {
+			if want[uid] {
+				found++
+			}
+		}
+	}
+	require.True(t, found >= 20*k*9/10, "recall too low: %d/%d", found, 20*k)
+}
+
+func TestDelete(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	h := New()
+	vecs := make(map[uint64][]float32)
+	for uid := uint64(1); uid <= 500; uid++ {
+		vecs[uid] = randomVector(r, 8)
+		require.NoError(t, h.Insert(uid, vecs[uid]))
+	}
+
+	// Delete the nearest neighbours of the query, the search must find the next ones.
+	q := randomVector(r, 8)
+	for _, uid := range bruteForce(vecs, q, 100) {
+		h.Delete(uid)
+		delete(vecs, uid)
+	}
+	h.Delete(1000)
+	require.Equal(t, 400, h.Len())
+
+	got, err := h.Search(q, 10, 10)
+	require.NoError(t, err)
+	require.Len(t, got, 10)
+	for _, uid := range got {
+		require.Contains(t, vecs, uid)
+	}
+	for i := 1; i < len(got); i++ {
+		require.True(t, Distance(q, vecs[got[i-1]]) <= Distance(q, vecs[got[i]]))
+	}
+
+	// Setting the vector again brings the node back.
+	h.Delete(got[0])
+	require.Equal(t, 399, h.Len())
+	require.NoError(t, h.Insert(got[0], q))
+	require.Equal(t, 400, h.Len())
+	res, err := h.Search(q, 1, 10)
+	require.NoError(t, err)
+	require.Equal(t, got[:1], res)
+}
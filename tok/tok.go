@@ -52,6 +52,7 @@ const (
 	IdentBool      = 0x9
 	IdentTrigram   = 0xA
 	IdentHash      = 0xB
+	IdentHNSW      = 0xC
 	IdentCustom    = 0x80
 	IdentDelimiter = 0x1f // ASCII 31 - Unit seperator
 )
@@ -99,6 +100,7 @@ func init() {
 	registerTokenizer(HashTokenizer{})
 	registerTokenizer(TermTokenizer{})
 	registerTokenizer(FullTextTokenizer{})
+	registerTokenizer(HNSWTokenizer{})
 	setupBleve()
 }
 
@@ -444,6 +446,23 @@ func (t HashTokenizer) IsSortable() bool { return false }
 // query operations using the hash index.
 func (t HashTokenizer) IsLossy() bool { return false }
 
+// HNSWTokenizer marks float32vector predicates whose values are kept in an HNSW graph
+// (see package hnsw) to answer nearest neighbour queries. The graph is held in memory by
+// the posting package, so no index keys are generated.
+type HNSWTokenizer struct{}
+
+func (t HNSWTokenizer) Name() string { return "hnsw" }
+func (t HNSWTokenizer) Type() string { return "float32vector" }
+func (t HNSWTokenizer) Tokens(v interface{}) ([]string, error) {
+	if _, ok := v.([]float32); !ok {
+		return nil, errors.Errorf("HNSW index only supported for float32vector types")
+	}
+	return nil, nil
+}
+func (t HNSWTokenizer) Identifier() byte { return IdentHNSW }
+func (t HNSWTokenizer) IsSortable() bool { return false }
+func (t HNSWTokenizer) IsLossy() bool    { return true }
+
 // PluginTokenizer is implemented by external plugins loaded dynamically via
 // *.so files. It follows the implementation semantics of the Tokenizer
 // interface.
@@ -91,6 +91,12 @@ func Convert(from Val, toID TypeID) (Val, error) {
 				*res = w
 			case PasswordID:
 				*res = string(data)
+			case VFloatID:
+				vec, err := bytesToVFloat(data)
+				if err != nil {
+					return to, err
+				}
+				*res = vec
 			default:
 				return to, cantConvert(fromID, toID)
 			}
@@ -144,6 +150,12 @@ func Convert(from Val, toID TypeID) (Val, error) {
 					return to, err
 				}
 				*res = p
+			case VFloatID:
+				vec, err := ParseVFloat(vc)
+				if err != nil {
+					return to, err
+				}
+				*res = vec
 			default:
 				return to, cantConvert(fromID, toID)
 			}
@@ -304,6 +316,23 @@ func Convert(from Val, toID TypeID) (Val, error) {
 				return to, cantConvert(fromID, toID)
 			}
 		}
+	case VFloatID:
+		{
+			vc, err := bytesToVFloat(data)
+			if err != nil {
+				return to, err
+			}
+			switch toID {
+			case VFloatID:
+				*res = vc
+			case BinaryID:
+				*res = data
+			case StringID, DefaultID:
+				*res = FormatVFloat(vc)
+			default:
+				return to, cantConvert(fromID, toID)
+			}
+		}
 	default:
 		return to, cantConvert(fromID, toID)
 	}
@@ -435,6 +464,19 @@ func Marshal(from Val, to *Val) error {
 		default:
 			return cantConvert(fromID, toID)
 		}
+	case VFloatID:
+		vc, ok := val.([]float32)
+		if !ok {
+			return errors.Errorf("Expected a float32vector type")
+		}
+		switch toID {
+		case BinaryID:
+			*res = vfloatToBytes(vc)
+		case StringID, DefaultID:
+			*res = FormatVFloat(vc)
+		default:
+			return cantConvert(fromID, toID)
+		}
 	default:
 		return cantConvert(fromID, toID)
 	}
@@ -503,6 +545,14 @@ func ObjectValue(id TypeID, value interface{}) (*api.Value, error) {
 			return def, errors.Errorf("Expected value of type password. Got : %v", value)
 		}
 		return &api.Value{Val: &api.Value_PasswordVal{PasswordVal: v}}, nil
+	case VFloatID:
+		// There is no vector value in the api, so it is sent in its text form and
+		// converted back using the schema.
+		var v []float32
+		if v, ok = value.([]float32); !ok {
+			return def, errors.Errorf("Expected value of type float32vector. Got : %v", value)
+		}
+		return &api.Value{Val: &api.Value_DefaultVal{DefaultVal: FormatVFloat(v)}}, nil
 	default:
 		return def, errors.Errorf("ObjectValue not available for: %v", id)
 	}
@@ -537,6 +587,8 @@ func (v Val) MarshalJSON() ([]byte, error) {
 		return json.Marshal(v.Safe().(string))
 	case PasswordID:
 		return json.Marshal(v.Value.(string))
+	case VFloatID:
+		return json.Marshal(v.Value.([]float32))
 	}
 	return nil, errors.Errorf("Invalid type for MarshalJSON: %v", v.Tid)
 }
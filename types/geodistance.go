@@ -0,0 +1,97 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+import (
+	"math"
+
+	"github.com/golang/geo/s1"
+	"github.com/golang/geo/s2"
+	"github.com/pkg/errors"
+	geom "github.com/twpayne/go-geom"
+)
+
+// GeoDistance returns the distance in meters on earth from the geometry to the point. The
+// distance to a polygon is zero if the point is in the polygon, otherwise it is the distance to
+// the closest edge of the outer ring.
+func GeoDistance(g geom.T, pt *geom.Point) (float64, error) {
+	if g.Stride() != 2 || pt.Stride() != 2 {
+		return 0, errors.Errorf("Distance only available for 2D co-ordinates.")
+	}
+	p := pointFromPoint(pt)
+	switch v := g.(type) {
+	case *geom.Point:
+		return float64(EarthDistance(pointFromPoint(v).Angle(p.Vector))), nil
+	case *geom.Polygon:
+		l, err := loopFromPolygon(v)
+		if err != nil {
+			return 0, err
+		}
+		return float64(EarthDistance(loopDistance(l, p))), nil
+	case *geom.MultiPolygon:
+		dist := s1.Angle(math.Inf(1))
+		for i := 0; i < v.NumPolygons(); i++ {
+			l, err := loopFromPolygon(v.Polygon(i))
+			if err != nil {
+				return 0, err
+			}
+			if d := loopDistance(l, p); d < dist {
+				dist = d
+			}
+		}
+		if dist == s1.Angle(math.Inf(1)) {
+			return 0, errors.Errorf("Cannot compute distance to an empty multipolygon")
+		}
+		return float64(EarthDistance(dist)), nil
+	default:
+		return 0, errors.Errorf("Cannot compute distance to geometry of type %T", v)
+	}
+}
+
+// loopDistance returns the angle between the point and the closest point of the loop.
+func loopDistance(l *s2.Loop, p s2.Point) s1.Angle {
+	if l.ContainsPoint(p) {
+		return 0
+	}
+	dist := s1.Angle(math.Inf(1))
+	pts := l.Vertices()
+	for i := range pts {
+		if d := edgeDistance(p, pts[i], pts[(i+1)%len(pts)]); d < dist {
+			dist = d
+		}
+	}
+	return dist
+}
+
+// edgeDistance returns the angle between the point p and the closest point of the edge from a
+// to b, which is the shortest arc of great circle between them.
+func edgeDistance(p, a, b s2.Point) s1.Angle {
+	n := a.Cross(b.Vector)
+	if n.Norm2() > 0 {
+		n = n.Normalize()
+		// The projection of p on the great circle is on the edge if it is on the same side as b
+		// of the plane through a and n, and on the same side as a of the plane through b and n.
+		if a.Cross(p.Vector).Dot(n) >= 0 && p.Cross(b.Vector).Dot(n) >= 0 {
+			return s1.Angle(math.Asin(math.Min(1, math.Abs(p.Dot(n)))))
+		}
+	}
+	da, db := p.Angle(a.Vector), p.Angle(b.Vector)
+	if da < db {
+		return da
+	}
+	return db
+}
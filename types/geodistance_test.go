@@ -0,0 +1,66 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	geom "github.com/twpayne/go-geom"
+)
+
+func TestGeoDistance(t *testing.T) {
+	origin := geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{0, 0})
+
+	// One degree along the equator.
+	p := geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{1, 0})
+	dist, err := GeoDistance(p, origin)
+	require.NoError(t, err)
+	require.InDelta(t, 111195, dist, 1)
+
+	// The origin is in the first square and one degree west of the second one.
+	square := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+		{{-1, -1}, {1, -1}, {1, 1}, {-1, 1}, {-1, -1}},
+	})
+	dist, err = GeoDistance(square, origin)
+	require.NoError(t, err)
+	require.Zero(t, dist)
+
+	east := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+		{{1, -1}, {2, -1}, {2, 1}, {1, 1}, {1, -1}},
+	})
+	dist, err = GeoDistance(east, origin)
+	require.NoError(t, err)
+	require.InDelta(t, 111195, dist, 1)
+
+	// The closest point of the third square is its corner.
+	corner := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+		{{3, 4}, {5, 4}, {5, 5}, {3, 5}, {3, 4}},
+	})
+	dist, err = GeoDistance(corner, origin)
+	require.NoError(t, err)
+	cornerDist, err := GeoDistance(geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{3, 4}), origin)
+	require.NoError(t, err)
+	require.InDelta(t, cornerDist, dist, 1e-6)
+
+	mp := geom.NewMultiPolygon(geom.XY).MustSetCoords([][][]geom.Coord{
+		corner.Coords(), east.Coords(),
+	})
+	dist, err = GeoDistance(mp, origin)
+	require.NoError(t, err)
+	require.InDelta(t, 111195, dist, 1)
+}
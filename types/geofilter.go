@@ -18,6 +18,7 @@ package types
 
 import (
 	"bytes"
+	"encoding/json"
 	"strconv"
 	"strings"
 
@@ -54,7 +55,7 @@ type GeoQueryData struct {
 // IsGeoFunc returns if a function is of geo type.
 func IsGeoFunc(str string) bool {
 	switch str {
-	case "near", "contains", "within", "intersects":
+	case "near", "contains", "within", "intersects", "bbox":
 		return true
 	}
 
@@ -114,11 +115,65 @@ func GetGeoTokens(srcFunc *pb.SrcFunction) ([]string, *GeoQueryData, error) {
 			return nil, nil, err
 		}
 		return queryTokensGeo(QueryTypeIntersects, g, 0.0)
+	case "bbox":
+		if len(srcFunc.Args) != 1 {
+			return nil, nil, errors.Errorf("bbox function requires 1 arguments, but got %d",
+				len(srcFunc.Args))
+		}
+		g, err := bboxPolygon(srcFunc.Args[0])
+		if err != nil {
+			return nil, nil, err
+		}
+		// A bounding box query finds all the entities in the box or crossing its edges.
+		return queryTokensGeo(QueryTypeIntersects, g, 0.0)
 	default:
 		return nil, nil, errors.Errorf("Invalid geo function")
 	}
 }
 
+// bboxEdgePoints is the number of points on the edges of a bounding box along the parallels.
+// The edges of the s2 loops are geodesics, so the points keep the edges close to the parallels.
+const bboxEdgePoints = 16
+
+// bboxPolygon returns the polygon of a bounding box given as [minLon, minLat, maxLon, maxLat].
+// The box must span less than 180 degrees of longitude, so that it fits in a hemisphere.
+func bboxPolygon(arg string) (*geom.Polygon, error) {
+	var box []float64
+	if err := json.Unmarshal([]byte(arg), &box); err != nil {
+		return nil, errors.Wrapf(err, "Error while parsing bounding box %s", arg)
+	}
+	if len(box) != 4 {
+		return nil, errors.Errorf("Bounding box must be [minLon, minLat, maxLon, maxLat], got %s",
+			arg)
+	}
+	minLon, minLat, maxLon, maxLat := box[0], box[1], box[2], box[3]
+	switch {
+	case minLon < -180 || maxLon > 180:
+		return nil, errors.Errorf("Longitudes of bounding box must be in [-180, 180], got %s", arg)
+	case minLat <= -90 || maxLat >= 90:
+		return nil, errors.Errorf("Latitudes of bounding box must be in (-90, 90), got %s", arg)
+	case minLon >= maxLon || minLat >= maxLat:
+		return nil, errors.Errorf("Minimum of bounding box must be less than maximum, got %s",
+			arg)
+	case maxLon-minLon >= 180:
+		return nil, errors.Errorf("Bounding box must span less than 180 degrees of longitude,"+
+			" got %s", arg)
+	}
+
+	// The ring goes counter-clockwise: east along the southern edge, then west along the
+	// northern edge.
+	ring := make([]geom.Coord, 0, 2*bboxEdgePoints+1)
+	step := (maxLon - minLon) / float64(bboxEdgePoints-1)
+	for i := 0; i < bboxEdgePoints; i++ {
+		ring = append(ring, geom.Coord{minLon + float64(i)*step, minLat})
+	}
+	for i := bboxEdgePoints - 1; i >= 0; i-- {
+		ring = append(ring, geom.Coord{minLon + float64(i)*step, maxLat})
+	}
+	ring = append(ring, ring[0])
+	return geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{ring}), nil
+}
+
 // queryTokensGeo returns the tokens to be used to look up the geo index for a given filter.
 // qt is the type of Geo query - near/intersects/contains/within
 // g is the geom.T representation of the input. It could be a point/polygon/multipolygon.
@@ -22,6 +22,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 	"github.com/twpayne/go-geom"
@@ -410,6 +411,45 @@ func TestMatchesFilterNearPoint(t *testing.T) {
 	require.True(t, qd.MatchesFilter(poly))
 }
 
+func TestQueryTokensBbox(t *testing.T) {
+	src := &pb.SrcFunction{Name: "bbox", Args: []string{"[-122.1, 37.4, -122.0, 37.5]"}}
+	toks, qd, err := GetGeoTokens(src)
+	require.NoError(t, err)
+	require.NotZero(t, len(toks))
+	require.Equal(t, QueryTypeIntersects, qd.qtype)
+	require.Equal(t, 1, len(qd.loops))
+
+	// Inside the box.
+	p := geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{-122.082506, 37.4249518})
+	require.True(t, qd.MatchesFilter(p))
+	// Outside the box, on each side.
+	for _, c := range []geom.Coord{{-122.2, 37.45}, {-121.9, 37.45}, {-122.05, 37.3},
+		{-122.05, 37.6}} {
+		p = geom.NewPoint(geom.XY).MustSetCoords(c)
+		require.False(t, qd.MatchesFilter(p), "%v", c)
+	}
+	// A polygon crossing the edge of the box.
+	poly := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+		{{-122.2, 37.45}, {-122.05, 37.45}, {-122.05, 37.46}, {-122.2, 37.46}, {-122.2, 37.45}},
+	})
+	require.True(t, qd.MatchesFilter(poly))
+}
+
+func TestQueryTokensBboxError(t *testing.T) {
+	for _, arg := range []string{
+		"[-122.1, 37.4, -122.0]",
+		"[-122.0, 37.4, -122.1, 37.5]",
+		"[-122.1, 37.5, -122.0, 37.4]",
+		"[-190, 37.4, -122.0, 37.5]",
+		"[-122.1, -90, -122.0, 37.5]",
+		"[-100, 0, 100, 10]",
+		"[a, b, c, d]",
+	} {
+		_, _, err := GetGeoTokens(&pb.SrcFunction{Name: "bbox", Args: []string{arg}})
+		require.Error(t, err, arg)
+	}
+}
+
 func BenchmarkMatchesFilterContainsPoint(b *testing.B) {
 	us, _ := loadPolygon("testdata/us.json")
 	b.ResetTimer()
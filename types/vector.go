@@ -0,0 +1,87 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+import (
+	"encoding/binary"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ParseVFloat parses a vector written as a list of numbers, e.g. "[0.1, 0.2, 0.3]".
+func ParseVFloat(s string) ([]float32, error) {
+	s = strings.TrimSpace(s)
+	if len(s) < 2 || s[0] != '[' || s[len(s)-1] != ']' {
+		return nil, errors.Errorf("Invalid vector %q, expected a list like [0.1, 0.2]", s)
+	}
+	s = strings.TrimSpace(s[1 : len(s)-1])
+	if len(s) == 0 {
+		return nil, errors.Errorf("Vector can't be empty")
+	}
+
+	fields := strings.Split(s, ",")
+	vec := make([]float32, 0, len(fields))
+	for _, f := range fields {
+		v, err := strconv.ParseFloat(strings.TrimSpace(f), 32)
+		if err != nil {
+			return nil, errors.Wrapf(err, "while parsing vector %q", s)
+		}
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, errors.Errorf("Got invalid value %v in vector", v)
+		}
+		vec = append(vec, float32(v))
+	}
+	return vec, nil
+}
+
+// FormatVFloat returns the text form of the vector, as accepted by ParseVFloat.
+func FormatVFloat(vec []float32) string {
+	var sb strings.Builder
+	sb.WriteByte('[')
+	for i, v := range vec {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(strconv.FormatFloat(float64(v), 'f', -1, 32))
+	}
+	sb.WriteByte(']')
+	return sb.String()
+}
+
+// vfloatToBytes encodes the vector as little endian float32 values.
+func vfloatToBytes(vec []float32) []byte {
+	b := make([]byte, 4*len(vec))
+	for i, v := range vec {
+		binary.LittleEndian.PutUint32(b[4*i:], math.Float32bits(v))
+	}
+	return b
+}
+
+// bytesToVFloat decodes a vector encoded by vfloatToBytes.
+func bytesToVFloat(b []byte) ([]float32, error) {
+	if len(b)%4 != 0 {
+		return nil, errors.Errorf("Invalid data for float32vector of length %d", len(b))
+	}
+	vec := make([]float32, len(b)/4)
+	for i := range vec {
+		vec[i] = math.Float32frombits(binary.LittleEndian.Uint32(b[4*i:]))
+	}
+	return vec, nil
+}
@@ -0,0 +1,64 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVFloat(t *testing.T) {
+	vec, err := ParseVFloat(" [0.5, -1,2.25 ] ")
+	require.NoError(t, err)
+	require.Equal(t, []float32{0.5, -1, 2.25}, vec)
+	require.Equal(t, "[0.5, -1, 2.25]", FormatVFloat(vec))
+
+	for _, s := range []string{"", "[]", "0.5, 1", "[0.5, abc]", "[NaN]", "[0.5,]"} {
+		_, err := ParseVFloat(s)
+		require.Error(t, err, s)
+	}
+}
+
+func TestConvertVFloat(t *testing.T) {
+	src := Val{Tid: DefaultID, Value: []byte("[1, 0.25, -3]")}
+	vec, err := Convert(src, VFloatID)
+	require.NoError(t, err)
+	require.Equal(t, []float32{1, 0.25, -3}, vec.Value)
+
+	bin := ValueForType(BinaryID)
+	require.NoError(t, Marshal(vec, &bin))
+	require.Len(t, bin.Value, 12)
+
+	stored := Val{Tid: VFloatID, Value: bin.Value}
+	back, err := Convert(stored, VFloatID)
+	require.NoError(t, err)
+	require.Equal(t, vec.Value, back.Value)
+
+	str, err := Convert(stored, StringID)
+	require.NoError(t, err)
+	require.Equal(t, "[1, 0.25, -3]", str.Value)
+
+	_, err = Convert(stored, IntID)
+	require.Error(t, err)
+	_, err = Convert(Val{Tid: VFloatID, Value: []byte{1, 2, 3}}, VFloatID)
+	require.Error(t, err)
+
+	b, err := vec.MarshalJSON()
+	require.NoError(t, err)
+	require.Equal(t, "[1,0.25,-3]", string(b))
+}
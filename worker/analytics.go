@@ -0,0 +1,246 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"bytes"
+	"context"
+	"math"
+
+	"github.com/dgraph-io/badger/v2"
+	bpb "github.com/dgraph-io/badger/v2/pb"
+	"github.com/pkg/errors"
+
+	"github.com/dgraph-io/dgraph/codec"
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// The analytics tasks read the edges of a predicate from the posting lists of the group serving
+// it, and write their results back as posting lists of new predicates served by the same group.
+// The posting lists are proposed through Raft as key-values, like the ones of a predicate being
+// moved, so that every Alpha of the group writes them with a posting.TxnWriter.
+
+// maxAnalyticsProposalSize is the size of the key-values proposed at once.
+const maxAnalyticsProposalSize = 32 << 20
+
+// ServingGroup returns the group serving the predicate attr, in the namespace of ctx, asking
+// Zero if this Alpha doesn't know it. It returns 0 if no group serves the predicate.
+func ServingGroup(ctx context.Context, attr string) (uint32, error) {
+	return groups().BelongsToReadOnly(namespacedAttr(ctx, attr), 0)
+}
+
+// checkServedAt returns an error if this Alpha's group doesn't serve attr at readTs.
+func checkServedAt(ctx context.Context, attr string, readTs uint64) error {
+	gid, err := groups().BelongsToReadOnly(attr, readTs)
+	switch {
+	case err != nil:
+		return err
+	case gid != groups().groupId():
+		return errors.Errorf("predicate %s is served by group %d, not by group %d",
+			x.ParseAttr(attr), gid, groups().groupId())
+	}
+	return posting.Oracle().WaitForTs(ctx, readTs)
+}
+
+// ReadEdges returns the edges of the uid predicate attr as they were at readTs, by the uid of
+// their source. The predicate must be served by this Alpha's group.
+func ReadEdges(ctx context.Context, attr string, readTs uint64) (map[uint64][]uint64, error) {
+	attr = namespacedAttr(ctx, attr)
+	if err := checkServedAt(ctx, attr, readTs); err != nil {
+		return nil, err
+	}
+	return readEdges(attr, readTs)
+}
+
+// WriteValues writes the values as the predicate attr of the nodes with the given uids, at
+// commitTs, and deletes the values of the other nodes that had one at readTs. The predicate must
+// be served by this Alpha's group, with the type of the values and no index, the indexes aren't
+// updated by the key-values. The key-values are proposed in batches, so they aren't all read by
+// the queries at once.
+func WriteValues(ctx context.Context, attr string, uids []uint64, vals []types.Val,
+	readTs, commitTs uint64) error {
+
+	attr = namespacedAttr(ctx, attr)
+	if err := checkServedAt(ctx, attr, readTs); err != nil {
+		return err
+	}
+	kvs, err := valueKVs(ctx, attr, uids, vals, readTs, commitTs)
+	if err != nil {
+		return err
+	}
+
+	n := groups().Node
+	proposal := &pb.Proposal{}
+	size := 0
+	for _, kv := range kvs {
+		proposal.Kv = append(proposal.Kv, kv)
+		size += len(kv.Key) + len(kv.Value)
+		if size >= maxAnalyticsProposalSize {
+			if err := n.proposeAndWait(ctx, proposal); err != nil {
+				return err
+			}
+			proposal = &pb.Proposal{}
+			size = 0
+		}
+	}
+	if len(proposal.Kv) > 0 {
+		return n.proposeAndWait(ctx, proposal)
+	}
+	return nil
+}
+
+// iterateData calls f with the posting list of every node that has a value or edges for attr
+// at readTs.
+func iterateData(attr string, readTs uint64, f func(uid uint64, l *posting.List) error) error {
+	txn := pstore.NewTransactionAt(readTs, false)
+	defer txn.Discard()
+
+	itOpt := badger.DefaultIteratorOptions
+	itOpt.PrefetchValues = false
+	itOpt.AllVersions = true
+	itOpt.Prefix = x.PredicatePrefix(attr)
+	it := txn.NewIterator(itOpt)
+	defer it.Close()
+
+	var prevKey []byte
+	for it.Rewind(); it.Valid(); {
+		item := it.Item()
+		if bytes.Equal(item.Key(), prevKey) {
+			it.Next()
+			continue
+		}
+		prevKey = append(prevKey[:0], item.Key()...)
+
+		// Parse the key upfront, otherwise ReadPostingList would advance the iterator.
+		pk, err := x.Parse(item.Key())
+		if err != nil {
+			return err
+		}
+		if !pk.IsData() || pk.HasStartUid {
+			continue
+		}
+		// We do need to copy over the key for ReadPostingList.
+		l, err := posting.ReadPostingList(item.KeyCopy(nil), it)
+		if err != nil {
+			return err
+		}
+		if err := f(pk.Uid, l); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readEdges returns the edges of the uid predicate attr at readTs, by the uid of their source.
+func readEdges(attr string, readTs uint64) (map[uint64][]uint64, error) {
+	if su, ok := schema.State().Get(context.Background(), attr); !ok {
+		return nil, errors.Errorf("predicate %s not found", x.ParseAttr(attr))
+	} else if su.ValueType != pb.Posting_UID {
+		return nil, errors.Errorf("predicate %s isn't a uid predicate", x.ParseAttr(attr))
+	}
+
+	edges := make(map[uint64][]uint64)
+	err := iterateData(attr, readTs, func(uid uint64, l *posting.List) error {
+		list, err := l.Uids(posting.ListOptions{ReadTs: readTs})
+		if err != nil {
+			return err
+		}
+		if len(list.Uids) > 0 {
+			edges[uid] = list.Uids
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return edges, nil
+}
+
+// valueKVs returns the key-values setting the values as the predicate attr of the nodes with
+// the given uids at commitTs, and deleting the values of the other nodes that had one at readTs.
+func valueKVs(ctx context.Context, attr string, uids []uint64, vals []types.Val,
+	readTs, commitTs uint64) ([]*bpb.KV, error) {
+
+	su, ok := schema.State().Get(ctx, attr)
+	switch {
+	case !ok:
+		return nil, errors.Errorf("predicate %s not found", x.ParseAttr(attr))
+	case su.List || len(vals) > 0 && su.ValueType != pb.Posting_ValType(vals[0].Tid):
+		return nil, errors.Errorf("predicate %s already exists with another type",
+			x.ParseAttr(attr))
+	case len(su.Tokenizer) > 0 || su.Count || su.Directive != pb.SchemaUpdate_NONE:
+		return nil, errors.Errorf("predicate %s has an index, drop it first and add it back "+
+			"once the values are written", x.ParseAttr(attr))
+	}
+
+	set := make(map[uint64]struct{}, len(uids))
+	for _, uid := range uids {
+		set[uid] = struct{}{}
+	}
+	var kvs []*bpb.KV
+	err := iterateData(attr, readTs, func(uid uint64, l *posting.List) error {
+		if _, ok := set[uid]; ok || l.Length(readTs, 0) == 0 {
+			return nil
+		}
+		kvs = append(kvs, &bpb.KV{
+			Key:      x.DataKey(attr, uid),
+			UserMeta: []byte{posting.BitEmptyPosting},
+			Version:  commitTs,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i, uid := range uids {
+		b, err := valuePostingList(vals[i])
+		if err != nil {
+			return nil, err
+		}
+		kvs = append(kvs, &bpb.KV{
+			Key:      x.DataKey(attr, uid),
+			Value:    b,
+			UserMeta: []byte{posting.BitCompletePosting},
+			Version:  commitTs,
+		})
+	}
+	return kvs, nil
+}
+
+// valuePostingList returns the posting list holding the single value val.
+func valuePostingList(val types.Val) ([]byte, error) {
+	out := types.ValueForType(types.BinaryID)
+	if err := types.Marshal(val, &out); err != nil {
+		return nil, err
+	}
+	pl := &pb.PostingList{
+		Pack: codec.Encode([]uint64{math.MaxUint64}, 256),
+		Postings: []*pb.Posting{{
+			Uid:         math.MaxUint64,
+			Value:       out.Value.([]byte),
+			ValType:     pb.Posting_ValType(val.Tid),
+			PostingType: pb.Posting_VALUE,
+			Op:          posting.Set,
+		}},
+	}
+	return pl.Marshal()
+}
@@ -0,0 +1,129 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"context"
+	"testing"
+
+	bpb "github.com/dgraph-io/badger/v2/pb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/codec"
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+func writeAnalyticsEdges(t *testing.T, attr string, edges map[uint64][]uint64, ts uint64) {
+	w := posting.NewTxnWriter(pstore)
+	for uid, uids := range edges {
+		pl := &pb.PostingList{Pack: codec.Encode(uids, 256)}
+		b, err := pl.Marshal()
+		require.NoError(t, err)
+		require.NoError(t, w.SetAt(x.DataKey(attr, uid), b, posting.BitCompletePosting, ts))
+	}
+	require.NoError(t, w.Flush())
+}
+
+// readAnalyticsValues returns the int values of the predicate attr at readTs.
+func readAnalyticsValues(t *testing.T, attr string, readTs uint64) map[uint64]int64 {
+	vals := make(map[uint64]int64)
+	err := iterateData(attr, readTs, func(uid uint64, l *posting.List) error {
+		val, err := l.Value(readTs)
+		if err == posting.ErrNoValue {
+			return nil
+		}
+		require.NoError(t, err)
+		val, err = types.Convert(val, types.IntID)
+		require.NoError(t, err)
+		vals[uid] = val.Value.(int64)
+		return nil
+	})
+	require.NoError(t, err)
+	return vals
+}
+
+func writeAnalyticsValues(t *testing.T, attr string, vals map[uint64]int64,
+	readTs, commitTs uint64) {
+	var uids []uint64
+	var tvals []types.Val
+	for uid, v := range vals {
+		uids = append(uids, uid)
+		tvals = append(tvals, types.Val{Tid: types.IntID, Value: v})
+	}
+	kvs, err := valueKVs(context.Background(), attr, uids, tvals, readTs, commitTs)
+	require.NoError(t, err)
+	// The key-values are written as populateKeyValues does when their proposal is applied.
+	w := posting.NewTxnWriter(pstore)
+	require.NoError(t, w.Write(&bpb.KVList{Kv: kvs}))
+	require.NoError(t, w.Flush())
+}
+
+func TestAnalyticsReadAndWrite(t *testing.T) {
+	require.NoError(t, schema.ParseBytes([]byte(`
+		analytics.follows: [uid] .
+		analytics.follows.component: int .
+	`), 1))
+	edgeAttr, resultAttr := "analytics.follows", "analytics.follows.component"
+	// The other tests read the whole store.
+	defer func() {
+		require.NoError(t, pstore.DropPrefix(x.PredicatePrefix(edgeAttr),
+			x.PredicatePrefix(resultAttr)))
+	}()
+
+	writeAnalyticsEdges(t, edgeAttr, map[uint64][]uint64{1: {2}, 2: {1}, 3: {4}}, 10)
+	edges, err := readEdges(edgeAttr, 10)
+	require.NoError(t, err)
+	require.Equal(t, map[uint64][]uint64{1: {2}, 2: {1}, 3: {4}}, edges)
+	edges, err = readEdges(edgeAttr, 9)
+	require.NoError(t, err)
+	require.Empty(t, edges)
+
+	writeAnalyticsValues(t, resultAttr, map[uint64]int64{1: 1, 2: 1, 3: 3, 4: 3}, 10, 11)
+	require.Equal(t, map[uint64]int64{1: 1, 2: 1, 3: 3, 4: 3},
+		readAnalyticsValues(t, resultAttr, 11))
+
+	// The values of the nodes that aren't in the results anymore are deleted, the older
+	// values are still read at their timestamp.
+	writeAnalyticsValues(t, resultAttr, map[uint64]int64{1: 1, 2: 2}, 12, 13)
+	require.Equal(t, map[uint64]int64{1: 1, 2: 2}, readAnalyticsValues(t, resultAttr, 13))
+	require.Equal(t, map[uint64]int64{1: 1, 2: 1, 3: 3, 4: 3},
+		readAnalyticsValues(t, resultAttr, 12))
+}
+
+func TestAnalyticsErrors(t *testing.T) {
+	require.NoError(t, schema.ParseBytes([]byte(`
+		analytics.name: string .
+		analytics.rank: int @index(int) .
+	`), 1))
+	nameAttr, indexedAttr := "analytics.name", "analytics.rank"
+
+	_, err := readEdges("analytics.missing", 10)
+	require.EqualError(t, err, "predicate analytics.missing not found")
+	_, err = readEdges(nameAttr, 10)
+	require.EqualError(t, err, "predicate analytics.name isn't a uid predicate")
+
+	vals := []types.Val{{Tid: types.IntID, Value: int64(1)}}
+	_, err = valueKVs(context.Background(), nameAttr, []uint64{1}, vals, 10, 11)
+	require.EqualError(t, err, "predicate analytics.name already exists with another type")
+	_, err = valueKVs(context.Background(), indexedAttr, []uint64{1}, vals, 10, 11)
+	require.EqualError(t, err, "predicate analytics.rank has an index, drop it first and "+
+		"add it back once the values are written")
+}
@@ -93,7 +93,8 @@ type BackupRes struct {
 	err error
 }
 
-func ProcessBackupRequest(ctx context.Context, req *pb.BackupRequest, forceFull bool) error {
+// VerifyBackupRequest checks that a backup can be taken, before queuing it.
+func VerifyBackupRequest(req *pb.BackupRequest) error {
 	if !EnterpriseEnabled() {
 		return errors.New("you must enable enterprise features first. " +
 			"Supply the appropriate license file to Dgraph Zero using the HTTP endpoint.")
@@ -102,6 +103,13 @@ func ProcessBackupRequest(ctx context.Context, req *pb.BackupRequest, forceFull
 	if req.Destination == "" {
 		return errors.Errorf("you must specify a 'destination' value")
 	}
+	return nil
+}
+
+func ProcessBackupRequest(ctx context.Context, req *pb.BackupRequest, forceFull bool) error {
+	if err := VerifyBackupRequest(req); err != nil {
+		return err
+	}
 
 	if err := x.HealthCheck(); err != nil {
 		glog.Errorf("Backup canceled, not ready to accept requests: %s", err)
@@ -189,13 +197,14 @@ func ProcessBackupRequest(ctx context.Context, req *pb.BackupRequest, forceFull
 	}
 
 	var dropOperations []*pb.DropOperation
-	for range groups {
+	for i := range groups {
 		if backupRes := <-resCh; backupRes.err != nil {
 			glog.Errorf("Error received during backup: %v", backupRes.err)
 			return backupRes.err
 		} else {
 			dropOperations = append(dropOperations, backupRes.res.GetDropOperations()...)
 		}
+		reportProgress(ctx, i+1, len(groups))
 	}
 
 	m := Manifest{Since: req.ReadTs, Groups: predMap, DropOperations: dropOperations}
@@ -162,6 +162,12 @@ func (pr *BackupProcessor) WriteBackup(ctx context.Context) (*pb.BackupResponse,
 			return true
 		}
 
+		// The records of the tasks hold the status of the tasks running in this cluster, e.g.
+		// of this backup. Restoring them would run the unfinished tasks again.
+		if parsedKey.Attr == "dgraph.task" && !parsedKey.IsSchema() {
+			return false
+		}
+
 		// Only backup schema and data keys for the requested predicates.
 		_, ok := predMap[parsedKey.Attr]
 		return ok
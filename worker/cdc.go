@@ -0,0 +1,496 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/dgraph-io/ristretto/z"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+)
+
+const (
+	// CDCCheckpointFileName is the name of the file inside the postings directory that
+	// stores the commit timestamp of the last change event handed over to the sink.
+	CDCCheckpointFileName = "cdc_checkpoint"
+
+	cdcOpSet = "set"
+	cdcOpDel = "del"
+
+	// cdcMaxRetryDelay caps the delay between two attempts to send events to the sink.
+	cdcMaxRetryDelay = 10 * time.Second
+	// cdcDrainInterval is how often the queue is checked for events to send, in case this Alpha
+	// became the leader of its group with events left in it.
+	cdcDrainInterval = time.Second
+)
+
+// CDCEvent is a single change to a predicate that was part of a committed transaction.
+type CDCEvent struct {
+	CommitTs   uint64          `json:"commit_ts"`
+	StartTs    uint64          `json:"start_ts"`
+	Op         string          `json:"op"`
+	Namespace  uint64          `json:"namespace,omitempty"`
+	Predicate  string          `json:"predicate"`
+	Subject    string          `json:"subject"`
+	Object     json.RawMessage `json:"object"`
+	ObjectType string          `json:"object_type"`
+	Lang       string          `json:"lang,omitempty"`
+}
+
+// CDCSink receives the change events of committed transactions. The events passed to a
+// single call to Send belong to one transaction and calls are made in commit timestamp
+// order, from a single goroutine. Send must only return once the events are durably stored
+// by the sink, because the checkpoint is advanced past them right after. A call that fails is
+// retried with the same events until it succeeds.
+//
+// Events are delivered at least once. After a crash or a change of leader, the events of the
+// last transactions may be sent again. Their commit timestamp can be used to skip them.
+type CDCSink interface {
+	Send(events []*CDCEvent) error
+	Close() error
+}
+
+// fileSink is a CDCSink that appends events as JSON lines to a local file.
+type fileSink struct {
+	sync.Mutex
+	f *os.File
+}
+
+// NewFileSink returns a CDCSink that appends events to the file at the given path,
+// one JSON object per line.
+func NewFileSink(path string) (CDCSink, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while opening CDC file %s", path)
+	}
+	return &fileSink{f: f}, nil
+}
+
+func (s *fileSink) Send(events []*CDCEvent) error {
+	s.Lock()
+	defer s.Unlock()
+
+	var buf []byte
+	for _, e := range events {
+		b, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		buf = append(buf, b...)
+		buf = append(buf, '\n')
+	}
+	if _, err := s.f.Write(buf); err != nil {
+		return err
+	}
+	return s.f.Sync()
+}
+
+func (s *fileSink) Close() error {
+	s.Lock()
+	defer s.Unlock()
+	return s.f.Close()
+}
+
+// cdcBatch holds the events of a committed transaction, waiting to be sent to the sink.
+type cdcBatch struct {
+	startTs  uint64
+	commitTs uint64
+	events   []*CDCEvent
+}
+
+// cdc tracks the edges of in-flight transactions and queues them once the transaction
+// commits. Events of aborted transactions are dropped. The queue is drained to the sink by a
+// separate goroutine, so that a slow or unavailable sink never holds up the application of
+// the Raft log.
+//
+// Every Alpha of the group queues the events, but only the leader sends them. Once the sink
+// accepted them, the leader proposes the commit timestamp of the last transaction sent, and
+// every Alpha drops the events up to it and moves its checkpoint forward when applying the
+// proposal. So a new leader sends the events its predecessor didn't.
+type cdc struct {
+	sync.Mutex
+	sink    CDCSink
+	dir     string
+	pending map[uint64][]*CDCEvent
+	queue   []cdcBatch
+	// queuedTs is the commit timestamp of the last transaction queued. Transactions committed
+	// at or before it are skipped, which makes the replay of the Raft log after a restart not
+	// emit duplicate events.
+	queuedTs uint64
+	// sentTs is the commit timestamp of the last transaction the leader of the group handed
+	// over to the sink. The checkpoint only moves forward once the sink accepted the events.
+	sentTs uint64
+	// amLeader tells whether this Alpha is the leader of its group.
+	amLeader func() bool
+	// propose replicates the commit timestamp of the last transaction sent to the group.
+	propose func(sentTs uint64) error
+	notify  chan struct{}
+	closer  *z.Closer
+}
+
+var cdcState *cdc
+
+// InitCDC enables change data capture with the given sink. The checkpoint is kept in the
+// given directory, usually the postings directory.
+func InitCDC(sink CDCSink, dir string) error {
+	ts, err := readCDCCheckpoint(dir)
+	if err != nil {
+		return err
+	}
+	glog.Infof("Change data capture enabled. Resuming after commit ts: %d", ts)
+	cdcState = newCDC(sink, dir, ts)
+	cdcState.amLeader = func() bool {
+		n := groups().Node
+		return n != nil && n.AmLeader()
+	}
+	cdcState.propose = func(sentTs uint64) error {
+		return groups().Node.proposeCDCState(sentTs)
+	}
+	go cdcState.run()
+	return nil
+}
+
+// CloseCDC stops sending change events, and closes the sink used by change data capture, if
+// any. The events still queued aren't lost: neither the checkpoint nor the Raft snapshot moves
+// past them, so they're queued again while replaying the Raft log after a restart.
+func CloseCDC() {
+	if cdcState == nil {
+		return
+	}
+	cdcState.closer.SignalAndWait()
+	if err := cdcState.sink.Close(); err != nil {
+		glog.Errorf("Error while closing CDC sink: %v", err)
+	}
+}
+
+func newCDC(sink CDCSink, dir string, sentTs uint64) *cdc {
+	c := &cdc{
+		sink:     sink,
+		dir:      dir,
+		pending:  make(map[uint64][]*CDCEvent),
+		queuedTs: sentTs,
+		sentTs:   sentTs,
+		amLeader: func() bool { return true },
+		notify:   make(chan struct{}, 1),
+		closer:   z.NewCloser(1),
+	}
+	// Without a Raft group, the state is applied right away.
+	c.propose = func(sentTs uint64) error {
+		c.applyState(&pb.CDCState{SentTs: sentTs})
+		return nil
+	}
+	return c
+}
+
+func readCDCCheckpoint(dir string) (uint64, error) {
+	contents, err := ioutil.ReadFile(filepath.Join(dir, CDCCheckpointFileName))
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(strings.TrimSpace(string(contents)), 10, 64)
+}
+
+func (c *cdc) writeCheckpoint(ts uint64) error {
+	// Write to a temporary file first, so a crash never leaves a truncated checkpoint.
+	path := filepath.Join(c.dir, CDCCheckpointFileName)
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, []byte(strconv.FormatUint(ts, 10)+"\n"), 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// addEdges records the edges of the mutation until its transaction is committed or aborted.
+func (c *cdc) addEdges(m *pb.Mutations) {
+	if c == nil || m == nil {
+		return
+	}
+	events := make([]*CDCEvent, 0, len(m.Edges))
+	for _, edge := range m.Edges {
+		if e := toCDCEvent(m.StartTs, edge); e != nil {
+			events = append(events, e)
+		}
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	c.pending[m.StartTs] = append(c.pending[m.StartTs], events...)
+}
+
+// processDelta queues the events of the transactions committed in the delta and forgets
+// about the aborted ones. It's called while applying the Raft log, so it never waits for the
+// sink.
+func (c *cdc) processDelta(delta *pb.OracleDelta) {
+	if c == nil {
+		return
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	txns := make([]*pb.TxnStatus, len(delta.Txns))
+	copy(txns, delta.Txns)
+	sort.Slice(txns, func(i, j int) bool {
+		return txns[i].CommitTs < txns[j].CommitTs
+	})
+	queued := false
+	for _, status := range txns {
+		events := c.pending[status.StartTs]
+		delete(c.pending, status.StartTs)
+		if status.CommitTs == 0 || len(events) == 0 || status.CommitTs <= c.queuedTs {
+			continue
+		}
+		for _, e := range events {
+			e.CommitTs = status.CommitTs
+		}
+		c.queue = append(c.queue, cdcBatch{
+			startTs:  status.StartTs,
+			commitTs: status.CommitTs,
+			events:   events,
+		})
+		c.queuedTs = status.CommitTs
+		queued = true
+	}
+	if queued {
+		select {
+		case c.notify <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// applyState drops the queued events up to the commit timestamp sent by the leader, and moves
+// the checkpoint past them. It's called while applying the Raft log.
+func (c *cdc) applyState(state *pb.CDCState) {
+	if c == nil {
+		return
+	}
+
+	c.Lock()
+	if state.SentTs <= c.sentTs {
+		c.Unlock()
+		return
+	}
+	c.sentTs = state.SentTs
+	if c.queuedTs < state.SentTs {
+		c.queuedTs = state.SentTs
+	}
+	i := sort.Search(len(c.queue), func(i int) bool {
+		return c.queue[i].commitTs > state.SentTs
+	})
+	c.queue = c.queue[i:]
+	c.Unlock()
+
+	if err := c.writeCheckpoint(state.SentTs); err != nil {
+		glog.Errorf("Error while writing CDC checkpoint: %v", err)
+	}
+}
+
+// minStartTs returns the lowest start timestamp of the transactions whose events weren't sent
+// yet, or math.MaxUint64 if there are none. The Raft log must be kept from there on, so
+// that their events are queued again after a restart.
+func (c *cdc) minStartTs() uint64 {
+	min := uint64(math.MaxUint64)
+	if c == nil {
+		return min
+	}
+	c.Lock()
+	defer c.Unlock()
+	for _, batch := range c.queue {
+		if batch.startTs < min {
+			min = batch.startTs
+		}
+	}
+	return min
+}
+
+// run sends the queued events to the sink until the closer is signalled.
+func (c *cdc) run() {
+	defer c.closer.Done()
+
+	ticker := time.NewTicker(cdcDrainInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.closer.HasBeenClosed():
+			return
+		case <-c.notify:
+			c.drain()
+		case <-ticker.C:
+			c.drain()
+		}
+	}
+}
+
+// drain sends the queued events to the sink in commit timestamp order, if this Alpha is the
+// leader of its group. A batch is retried until the sink accepts it. The batches sent are
+// removed from the queue right away, but the checkpoint is only moved past them once the
+// proposal of the leader is applied. It returns early if the closer is signalled.
+func (c *cdc) drain() {
+	for {
+		c.Lock()
+		batches := c.queue
+		c.queue = nil
+		c.Unlock()
+		if len(batches) == 0 {
+			return
+		}
+		if !c.amLeader() {
+			c.requeue(batches)
+			return
+		}
+
+		sentTs := uint64(0)
+		for i, batch := range batches {
+			if !c.send(batch) {
+				c.requeue(batches[i:])
+				break
+			}
+			sentTs = batch.commitTs
+		}
+		if sentTs == 0 {
+			return
+		}
+		// The state is proposed once per round rather than once per transaction. If the
+		// proposal is lost, the events of the round are sent again by the next leader, or after
+		// a restart.
+		if err := c.propose(sentTs); err != nil {
+			glog.Errorf("Error while proposing CDC state: %v", err)
+		}
+		select {
+		case <-c.closer.HasBeenClosed():
+			return
+		default:
+		}
+	}
+}
+
+// requeue puts the batches not sent back in front of the queue, but the ones sent by another
+// leader in the meantime.
+func (c *cdc) requeue(batches []cdcBatch) {
+	c.Lock()
+	defer c.Unlock()
+	i := sort.Search(len(batches), func(i int) bool {
+		return batches[i].commitTs > c.sentTs
+	})
+	c.queue = append(batches[i:], c.queue...)
+}
+
+// send hands the batch over to the sink, retrying with a growing delay until it succeeds. It
+// returns false if the closer is signalled before that.
+func (c *cdc) send(batch cdcBatch) bool {
+	delay := 100 * time.Millisecond
+	for {
+		err := c.sink.Send(batch.events)
+		if err == nil {
+			return true
+		}
+		glog.Errorf("Unable to send CDC events for commit ts %d, retrying in %s: %v",
+			batch.commitTs, delay, err)
+		select {
+		case <-c.closer.HasBeenClosed():
+			return false
+		case <-time.After(delay):
+		}
+		if delay *= 2; delay > cdcMaxRetryDelay {
+			delay = cdcMaxRetryDelay
+		}
+	}
+}
+
+// reset drops the events of all in-flight transactions. Used when the oracle forgets
+// about them, e.g. on a drop all.
+func (c *cdc) reset() {
+	if c == nil {
+		return
+	}
+	c.Lock()
+	defer c.Unlock()
+	c.pending = make(map[uint64][]*CDCEvent)
+}
+
+// proposeCDCState lets the group know that the events of the transactions committed up to
+// sentTs were handed over to the sink. Like snapshots, it's proposed without waiting for it to
+// be applied.
+func (n *node) proposeCDCState(sentTs uint64) error {
+	proposal := &pb.Proposal{
+		CdcState: &pb.CDCState{SentTs: sentTs},
+	}
+	data := make([]byte, 8+proposal.Size())
+	sz, err := proposal.MarshalToSizedBuffer(data[8:])
+	if err != nil {
+		return err
+	}
+	return n.Raft().Propose(n.ctx, data[:8+sz])
+}
+
+func toCDCEvent(startTs uint64, edge *pb.DirectedEdge) *CDCEvent {
+	ns, attr := x.ParseNamespaceAttr(edge.Attr)
+	e := &CDCEvent{
+		StartTs:   startTs,
+		Op:        cdcOpSet,
+		Namespace: ns,
+		Predicate: attr,
+		Subject:   fmt.Sprintf("%#x", edge.Entity),
+		Lang:      edge.Lang,
+	}
+	if edge.Op == pb.DirectedEdge_DEL {
+		e.Op = cdcOpDel
+	}
+
+	tid := posting.TypeID(edge)
+	e.ObjectType = tid.Name()
+	switch {
+	case tid == types.UidID:
+		e.Object, _ = json.Marshal(fmt.Sprintf("%#x", edge.ValueId))
+	case bytes.Equal(edge.Value, []byte(x.Star)):
+		e.Object, _ = json.Marshal(x.Star)
+	case tid == types.PasswordID:
+		// Never leak hashed passwords to the outside world.
+		e.Object, _ = json.Marshal("****")
+	default:
+		val, err := types.Convert(types.Val{Tid: types.BinaryID, Value: edge.Value}, tid)
+		if err != nil {
+			glog.Errorf("Skipping CDC event for predicate %s: %v", edge.Attr, err)
+			return nil
+		}
+		if e.Object, err = val.MarshalJSON(); err != nil {
+			glog.Errorf("Skipping CDC event for predicate %s: %v", edge.Attr, err)
+			return nil
+		}
+	}
+	return e
+}
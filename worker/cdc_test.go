@@ -0,0 +1,219 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// memorySink stands in for a message queue in tests.
+type memorySink struct {
+	events []*CDCEvent
+}
+
+func (s *memorySink) Send(events []*CDCEvent) error {
+	s.events = append(s.events, events...)
+	return nil
+}
+
+func (s *memorySink) Close() error { return nil }
+
+// failingSink fails to send events until it's told to recover.
+type failingSink struct {
+	memorySink
+	failing int32
+}
+
+func (s *failingSink) Send(events []*CDCEvent) error {
+	if atomic.LoadInt32(&s.failing) == 1 {
+		return errors.New("sink unavailable")
+	}
+	return s.memorySink.Send(events)
+}
+
+func cdcMutation(startTs uint64, edges ...*pb.DirectedEdge) *pb.Mutations {
+	return &pb.Mutations{StartTs: startTs, Edges: edges}
+}
+
+func TestCDCCommitOrder(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cdc")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	sink := &memorySink{}
+	c := newCDC(sink, dir, 0)
+	c.addEdges(cdcMutation(1, &pb.DirectedEdge{
+		Entity: 1, Attr: "name", Value: []byte("alice"), ValueType: pb.Posting_STRING}))
+	c.addEdges(cdcMutation(2, &pb.DirectedEdge{
+		Entity: 2, Attr: "friend", ValueId: 1}))
+	c.addEdges(cdcMutation(3, &pb.DirectedEdge{
+		Entity: 3, Attr: "name", Value: []byte("bob"), ValueType: pb.Posting_STRING}))
+
+	c.processDelta(&pb.OracleDelta{Txns: []*pb.TxnStatus{
+		{StartTs: 2, CommitTs: 6},
+		{StartTs: 1, CommitTs: 5},
+		{StartTs: 3},
+	}})
+	c.drain()
+
+	require.Len(t, sink.events, 2)
+	require.Equal(t, uint64(5), sink.events[0].CommitTs)
+	require.Equal(t, "name", sink.events[0].Predicate)
+	require.Equal(t, "0x1", sink.events[0].Subject)
+	require.JSONEq(t, `"alice"`, string(sink.events[0].Object))
+	require.Equal(t, uint64(6), sink.events[1].CommitTs)
+	require.JSONEq(t, `"0x1"`, string(sink.events[1].Object))
+	require.Empty(t, c.pending)
+
+	ts, err := readCDCCheckpoint(dir)
+	require.NoError(t, err)
+	require.Equal(t, uint64(6), ts)
+}
+
+func TestCDCResumeFromCheckpoint(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cdc")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	sink, err := NewFileSink(filepath.Join(dir, "cdc.json"))
+	require.NoError(t, err)
+	c := newCDC(sink, dir, 10)
+
+	// Replayed txn committed before the checkpoint must not be sent again.
+	c.addEdges(cdcMutation(7, &pb.DirectedEdge{
+		Entity: 1, Attr: "name", Value: []byte("old"), ValueType: pb.Posting_STRING}))
+	c.addEdges(cdcMutation(11, &pb.DirectedEdge{
+		Entity: 1, Attr: "name", Value: []byte("new"), ValueType: pb.Posting_STRING,
+		Op: pb.DirectedEdge_DEL}))
+	c.processDelta(&pb.OracleDelta{Txns: []*pb.TxnStatus{
+		{StartTs: 7, CommitTs: 8},
+		{StartTs: 11, CommitTs: 12},
+	}})
+	c.drain()
+	require.NoError(t, sink.Close())
+
+	f, err := os.Open(filepath.Join(dir, "cdc.json"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	var events []CDCEvent
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e CDCEvent
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &e))
+		events = append(events, e)
+	}
+	require.NoError(t, scanner.Err())
+	require.Len(t, events, 1)
+	require.Equal(t, uint64(12), events[0].CommitTs)
+	require.Equal(t, cdcOpDel, events[0].Op)
+
+	ts, err := readCDCCheckpoint(dir)
+	require.NoError(t, err)
+	require.Equal(t, uint64(12), ts)
+}
+
+func TestCDCSinkUnavailable(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cdc")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	sink := &failingSink{failing: 1}
+	c := newCDC(sink, dir, 0)
+	go c.run()
+
+	c.addEdges(cdcMutation(1, &pb.DirectedEdge{
+		Entity: 1, Attr: "name", Value: []byte("alice"), ValueType: pb.Posting_STRING}))
+	c.addEdges(cdcMutation(2, &pb.DirectedEdge{
+		Entity: 2, Attr: "name", Value: []byte("bob"), ValueType: pb.Posting_STRING}))
+	// Applying the delta doesn't wait for the sink.
+	c.processDelta(&pb.OracleDelta{Txns: []*pb.TxnStatus{{StartTs: 1, CommitTs: 3}}})
+	c.processDelta(&pb.OracleDelta{Txns: []*pb.TxnStatus{{StartTs: 2, CommitTs: 4}}})
+
+	// The checkpoint doesn't move while the sink is unavailable.
+	time.Sleep(300 * time.Millisecond)
+	ts, err := readCDCCheckpoint(dir)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), ts)
+
+	atomic.StoreInt32(&sink.failing, 0)
+	require.Eventually(t, func() bool {
+		ts, err := readCDCCheckpoint(dir)
+		return err == nil && ts == 4
+	}, 5*time.Second, 50*time.Millisecond)
+
+	c.closer.SignalAndWait()
+	require.Len(t, sink.events, 2)
+	require.Equal(t, uint64(3), sink.events[0].CommitTs)
+	require.Equal(t, uint64(4), sink.events[1].CommitTs)
+}
+
+func TestCDCFollower(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cdc")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	sink := &memorySink{}
+	c := newCDC(sink, dir, 0)
+	leader := false
+	c.amLeader = func() bool { return leader }
+
+	c.addEdges(cdcMutation(1, &pb.DirectedEdge{
+		Entity: 1, Attr: "name", Value: []byte("alice"), ValueType: pb.Posting_STRING}))
+	c.addEdges(cdcMutation(2, &pb.DirectedEdge{
+		Entity: 2, Attr: "name", Value: []byte("bob"), ValueType: pb.Posting_STRING}))
+	c.processDelta(&pb.OracleDelta{Txns: []*pb.TxnStatus{
+		{StartTs: 1, CommitTs: 3},
+		{StartTs: 2, CommitTs: 4},
+	}})
+
+	// A follower doesn't send the events, and keeps them until the leader sent them.
+	c.drain()
+	require.Empty(t, sink.events)
+	require.Equal(t, uint64(1), c.minStartTs())
+	ts, err := readCDCCheckpoint(dir)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), ts)
+
+	c.applyState(&pb.CDCState{SentTs: 3})
+	require.Equal(t, uint64(2), c.minStartTs())
+	ts, err = readCDCCheckpoint(dir)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), ts)
+
+	// Once it becomes the leader, it sends the events the previous leader didn't.
+	leader = true
+	c.drain()
+	require.Len(t, sink.events, 1)
+	require.Equal(t, uint64(4), sink.events[0].CommitTs)
+	require.Equal(t, uint64(math.MaxUint64), c.minStartTs())
+	ts, err = readCDCCheckpoint(dir)
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), ts)
+}
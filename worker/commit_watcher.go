@@ -0,0 +1,106 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"sync"
+
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// CommitWatcher is notified of the changes to the data of this Alpha's group, e.g. to invalidate
+// the results computed from it.
+type CommitWatcher interface {
+	// Changed is called once the given predicates have changed. The predicates served by the
+	// other groups aren't known to this Alpha, so remote is true when they could have changed
+	// as well.
+	Changed(preds []string, remote bool)
+	// Reset is called when any predicate could have changed, e.g. after a drop all.
+	Reset()
+}
+
+var commitWatchers struct {
+	sync.RWMutex
+	watchers []CommitWatcher
+}
+
+// AddCommitWatcher registers a watcher to be notified of the changes to the data.
+func AddCommitWatcher(w CommitWatcher) {
+	commitWatchers.Lock()
+	defer commitWatchers.Unlock()
+	commitWatchers.watchers = append(commitWatchers.watchers, w)
+}
+
+func notifyChanged(preds []string, remote bool) {
+	commitWatchers.RLock()
+	defer commitWatchers.RUnlock()
+	for _, w := range commitWatchers.watchers {
+		w.Changed(preds, remote)
+	}
+}
+
+func notifyReset() {
+	commitWatchers.RLock()
+	defer commitWatchers.RUnlock()
+	for _, w := range commitWatchers.watchers {
+		w.Reset()
+	}
+}
+
+// notifyCommits notifies the watchers of the predicates written by the transactions committed
+// in the delta. It must be called before the delta is processed by the oracle, which forgets
+// about the transactions.
+func notifyCommits(delta *pb.OracleDelta) {
+	commitWatchers.RLock()
+	hasWatchers := len(commitWatchers.watchers) > 0
+	commitWatchers.RUnlock()
+	if !hasWatchers {
+		return
+	}
+
+	var preds []string
+	var committed bool
+	for _, status := range delta.Txns {
+		if status.CommitTs == 0 {
+			continue
+		}
+		committed = true
+		preds = append(preds, posting.Oracle().GetTxn(status.StartTs).Predicates()...)
+	}
+	switch {
+	case !committed:
+	case x.WorkerConfig.LudicrousMode:
+		// The edges are applied as they come in ludicrous mode, without keeping track of the
+		// predicates of the transactions.
+		notifyReset()
+	default:
+		notifyChanged(x.Unique(preds), len(groups().KnownGroups()) > 1)
+	}
+}
+
+// ServesPredicate returns whether this Alpha's group serves the predicate. Unlike
+// ServesTablet, it doesn't ask Zero about the predicates this Alpha doesn't know of yet, and
+// returns false for them.
+func ServesPredicate(attr string) bool {
+	g := groups()
+	g.RLock()
+	tablet := g.tablets[attr]
+	g.RUnlock()
+	return tablet != nil && tablet.GroupId == g.groupId()
+}
@@ -75,6 +75,10 @@ type node struct {
 	elog        trace.EventLog
 
 	ex *executor
+
+	// The statistics of the predicates last computed by the leader, see addStats.
+	stats   map[string]*predicateStats
+	statsAt time.Time
 }
 
 type op int
@@ -310,6 +314,8 @@ func (n *node) applyMutations(ctx context.Context, proposal *pb.Proposal) (rerr
 	if proposal.Mutations.DropOp == pb.Mutations_DATA {
 		// Ensures nothing get written to disk due to commit proposals.
 		posting.Oracle().ResetTxns()
+		cdcState.reset()
+		defer notifyReset()
 		if err := posting.DeleteData(); err != nil {
 			return err
 		}
@@ -322,6 +328,8 @@ func (n *node) applyMutations(ctx context.Context, proposal *pb.Proposal) (rerr
 	if proposal.Mutations.DropOp == pb.Mutations_ALL {
 		// Ensures nothing get written to disk due to commit proposals.
 		posting.Oracle().ResetTxns()
+		cdcState.reset()
+		defer notifyReset()
 		schema.State().DeleteAll()
 
 		if err := posting.DeleteAll(); err != nil {
@@ -427,6 +435,7 @@ func (n *node) applyMutations(ctx context.Context, proposal *pb.Proposal) (rerr
 				return err
 			}
 			span.Annotatef(nil, "Deleting predicate: %s", edge.Attr)
+			defer notifyChanged([]string{edge.Attr}, false)
 			return posting.DeletePredicate(ctx, edge.Attr)
 		}
 		// Don't derive schema when doing deletion.
@@ -491,6 +500,8 @@ func (n *node) applyMutations(ctx context.Context, proposal *pb.Proposal) (rerr
 	}
 	// Discard the posting lists from cache to release memory at the end.
 	defer txn.Update()
+	// Hold on to the edges, they are sent to the CDC sink once the txn commits.
+	cdcState.addEdges(m)
 
 	process := func(edges []*pb.DirectedEdge) error {
 		var retries int
@@ -583,6 +594,7 @@ func (n *node) applyCommitted(proposal *pb.Proposal, key uint64) error {
 				proposal.CleanPredicate, proposal.ExpectedChecksum)
 			return nil
 		}
+		defer notifyChanged([]string{proposal.CleanPredicate}, false)
 		return posting.DeletePredicate(ctx, proposal.CleanPredicate)
 
 	case proposal.Delta != nil:
@@ -615,8 +627,13 @@ func (n *node) applyCommitted(proposal *pb.Proposal, key uint64) error {
 			}
 			glog.Warningf("Error while calling CreateSnapshot: %v. Retrying...", err)
 		}
-		// We can now discard all invalid versions of keys below this ts.
-		pstore.SetDiscardTs(snap.ReadTs)
+		// We can now discard all invalid versions of keys below this ts, but the ones
+		// within the retention period.
+		posting.SetDiscardTs(snap.ReadTs)
+		return nil
+
+	case proposal.CdcState != nil:
+		cdcState.applyState(proposal.CdcState)
 		return nil
 
 	case proposal.Restore != nil:
@@ -635,6 +652,7 @@ func (n *node) applyCommitted(proposal *pb.Proposal, key uint64) error {
 		if err := handleRestoreProposal(ctx, proposal.Restore); err != nil {
 			return err
 		}
+		notifyReset()
 
 		// Call commitOrAbort to update the group checksums.
 		ts := proposal.Restore.RestoreTs
@@ -810,6 +828,10 @@ func (n *node) commitOrAbort(pkey uint64, delta *pb.OracleDelta) error {
 	}
 	posting.WaitForCache()
 
+	// The txns are now on disk, emit their change events.
+	cdcState.processDelta(delta)
+	notifyCommits(delta)
+
 	// Now advance Oracle(), so we can service waiting reads.
 	posting.Oracle().ProcessDelta(delta)
 	return nil
@@ -887,6 +909,7 @@ func (n *node) retrieveSnapshot(snap pb.Snapshot) error {
 	if err := n.populateSnapshot(snap, pool); err != nil {
 		return errors.Wrapf(err, "cannot retrieve snapshot from peer")
 	}
+	notifyReset()
 	// Populate shard stores the streamed data directly into db, so we need to refresh
 	// schema for current group id
 	if err := schema.LoadFromDb(); err != nil {
@@ -1323,7 +1346,8 @@ func listWrap(kv *bpb.KV) *bpb.KVList {
 	return &bpb.KVList{Kv: []*bpb.KV{kv}}
 }
 
-// calculateTabletSizes updates the tablet sizes for the keys.
+// calculateTabletSizes updates the tablet sizes for the keys, along with the statistics of their
+// predicates.
 func (n *node) calculateTabletSizes() {
 	if !n.AmLeader() {
 		// Only leader sends the tablet size updates to Zero. No one else does.
@@ -1374,6 +1398,8 @@ func (n *node) calculateTabletSizes() {
 		}
 	}
 
+	n.addStats(tablets, tableInfos)
+
 	if len(tablets) == 0 {
 		glog.V(2).Infof("No tablets found.")
 		return
@@ -1538,6 +1564,10 @@ func (n *node) calculateSnapshot(startIdx uint64, discardN int) (*pb.Snapshot, e
 	// snapshotIdx. In any case, we continue picking up txn updates, to generate
 	// a maxCommitTs, which would become the readTs for the snapshot.
 	minPendingStart := posting.Oracle().MinPendingStartTs()
+	// The Raft log must also be kept for the transactions whose change events weren't sent yet.
+	if ts := cdcState.minStartTs(); ts < minPendingStart {
+		minPendingStart = ts
+	}
 	maxCommitTs := snap.ReadTs
 	var snapshotIdx uint64
 
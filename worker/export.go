@@ -24,6 +24,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/url"
 	"os"
@@ -70,6 +71,13 @@ var exportFormats = map[string]exportFormat{
 		pre:  "",
 		post: "",
 	},
+	// The tabular formats write a file per type, see exportTabular.
+	"csv": {
+		ext: ".csv",
+	},
+	"parquet": {
+		ext: ".parquet",
+	},
 }
 
 type exporter struct {
@@ -355,10 +363,12 @@ func fieldToString(update *pb.SchemaUpdate) string {
 type fileWriter struct {
 	fd           *os.File
 	bw           *bufio.Writer
+	w            io.Writer
 	gw           *gzip.Writer
 	relativePath string
 }
 
+// open creates the file at fpath. The file is compressed with gzip if its name ends with .gz.
 func (writer *fileWriter) open(fpath string) error {
 	var err error
 	writer.fd, err = os.Create(fpath)
@@ -366,20 +376,27 @@ func (writer *fileWriter) open(fpath string) error {
 		return err
 	}
 	writer.bw = bufio.NewWriterSize(writer.fd, 1e6)
-	w, err := enc.GetWriter(x.WorkerConfig.EncryptionKey, writer.bw)
-	if err != nil {
+	writer.w, err = enc.GetWriter(x.WorkerConfig.EncryptionKey, writer.bw)
+	if err != nil || filepath.Ext(fpath) != ".gz" {
 		return err
 	}
-	writer.gw, err = gzip.NewWriterLevel(w, gzip.BestCompression)
+	writer.gw, err = gzip.NewWriterLevel(writer.w, gzip.BestCompression)
+	writer.w = writer.gw
 	return err
 }
 
+func (writer *fileWriter) Write(b []byte) (int, error) {
+	return writer.w.Write(b)
+}
+
 func (writer *fileWriter) Close() error {
-	if err := writer.gw.Flush(); err != nil {
-		return err
-	}
-	if err := writer.gw.Close(); err != nil {
-		return err
+	if writer.gw != nil {
+		if err := writer.gw.Flush(); err != nil {
+			return err
+		}
+		if err := writer.gw.Close(); err != nil {
+			return err
+		}
 	}
 	if err := writer.bw.Flush(); err != nil {
 		return err
@@ -508,8 +525,12 @@ func (r *remoteExportStorage) finishWriting(fs ...*fileWriter) (ExportedFiles, e
 		filePath := path.Join(r.les.destination, f)
 		// FIXME: tejas [06/2020] - We could probably stream these results, but it's easier to copy for now
 		glog.Infof("Uploading from %s to %s\n", filePath, d)
+		contentType := "application/gzip"
+		if filepath.Ext(f) != ".gz" {
+			contentType = "application/octet-stream"
+		}
 		_, err := r.mc.FPutObject(r.bucket, d, filePath, minio.PutObjectOptions{
-			ContentType: "application/gzip",
+			ContentType: contentType,
 		})
 		if err != nil {
 			return nil, err
@@ -553,6 +574,10 @@ func export(ctx context.Context, in *pb.ExportRequest) (ExportedFiles, error) {
 // when exporting a p directory directly from disk without a running cluster.
 func exportInternal(ctx context.Context, in *pb.ExportRequest, db *badger.DB,
 	skipZero bool) (ExportedFiles, error) {
+	if isTabularExport(in.Format) {
+		return nil, errors.Errorf("Export format %s can't be used to export a single group",
+			in.Format)
+	}
 	uts := time.Unix(in.UnixTs, 0)
 	exportStorage, err := newExportStorage(in,
 		fmt.Sprintf("dgraph.r%d.u%s", in.ReadTs, uts.UTC().Format("0102.1504")))
@@ -605,6 +630,12 @@ func exportInternal(ctx context.Context, in *pb.ExportRequest, db *badger.DB,
 			return false
 		}
 
+		// Only the requested namespace is exported. Its predicates and types are written
+		// without the namespace, so that the export can be loaded into any namespace.
+		if pk.Namespace() != in.Namespace {
+			return false
+		}
+
 		if !pk.IsType() && !skipZero {
 			if servesTablet, err := groups().ServesTablet(pk.Attr); err != nil || !servesTablet {
 				return false
@@ -623,11 +654,12 @@ func exportInternal(ctx context.Context, in *pb.ExportRequest, db *badger.DB,
 				hex.EncodeToString(item.Key()))
 			return nil, err
 		}
+		attr := pk.Predicate()
 		e := &exporter{
 			readTs: in.ReadTs,
 		}
 		e.uid = pk.Uid
-		e.attr = pk.Attr
+		e.attr = attr
 
 		// Schema and type keys should be handled first because schema keys are also
 		// considered data keys.
@@ -642,7 +674,7 @@ func exportInternal(ctx context.Context, in *pb.ExportRequest, db *badger.DB,
 				glog.Errorf("Unable to unmarshal schema: %+v. Err=%v\n", pk, err)
 				return nil, nil
 			}
-			return toSchema(pk.Attr, &update)
+			return toSchema(attr, &update)
 
 		case pk.IsType():
 			var update pb.TypeUpdate
@@ -654,23 +686,33 @@ func exportInternal(ctx context.Context, in *pb.ExportRequest, db *badger.DB,
 				glog.Errorf("Unable to unmarshal type: %+v. Err=%v\n", pk, err)
 				return nil, nil
 			}
-			return toType(pk.Attr, update)
+			return toType(attr, update)
 
-		case pk.Attr == "dgraph.graphql.xid":
+		case attr == "dgraph.graphql.xid":
+			// Ignore this predicate.
+		case attr == "dgraph.cors":
+			// Ignore this predicate.
+		case attr == "dgraph.drop.op":
+			// Ignore this predicate.
+		case attr == "dgraph.graphql.schema_created_at":
+			// Ignore this predicate.
+		case attr == "dgraph.graphql.schema_history":
+			// Ignore this predicate.
+		case attr == "dgraph.graphql.p_query":
 			// Ignore this predicate.
-		case pk.Attr == "dgraph.cors":
+		case attr == "dgraph.graphql.p_sha256hash":
 			// Ignore this predicate.
-		case pk.Attr == "dgraph.drop.op":
+		case attr == "dgraph.graphql.p_client":
 			// Ignore this predicate.
-		case pk.Attr == "dgraph.graphql.schema_created_at":
+		case attr == "dgraph.graphql.p_name":
 			// Ignore this predicate.
-		case pk.Attr == "dgraph.graphql.schema_history":
+		case attr == "dgraph.graphql.p_version":
 			// Ignore this predicate.
-		case pk.Attr == "dgraph.graphql.p_query":
+		case attr == "dgraph.graphql.p_usage":
 			// Ignore this predicate.
-		case pk.Attr == "dgraph.graphql.p_sha256hash":
+		case attr == "dgraph.task":
 			// Ignore this predicate.
-		case pk.IsData() && pk.Attr == "dgraph.graphql.schema":
+		case pk.IsData() && attr == "dgraph.graphql.schema":
 			// Export the graphql schema.
 			pl, err := posting.ReadPostingList(key, itr)
 			if err != nil {
@@ -708,7 +750,7 @@ func exportInternal(ctx context.Context, in *pb.ExportRequest, db *badger.DB,
 
 			// The GraphQL layer will create a node of type "dgraph.graphql". That entry
 			// should not be exported.
-			if pk.Attr == "dgraph.type" {
+			if attr == "dgraph.type" {
 				vals, err := e.pl.AllValues(in.ReadTs)
 				if err != nil {
 					return nil, errors.Wrapf(err, "cannot read value of dgraph.type entry")
@@ -778,7 +820,7 @@ func exportInternal(ctx context.Context, in *pb.ExportRequest, db *badger.DB,
 
 			if kv.Version == 1 { // only insert separator for data
 				if hasDataBefore {
-					if _, err := writer.gw.Write(separator); err != nil {
+					if _, err := writer.Write(separator); err != nil {
 						return err
 					}
 				}
@@ -787,19 +829,19 @@ func exportInternal(ctx context.Context, in *pb.ExportRequest, db *badger.DB,
 				hasDataBefore = true
 			}
 
-			_, err = writer.gw.Write(kv.Value)
+			_, err = writer.Write(kv.Value)
 			return err
 		})
 	}
 
 	// All prepwork done. Time to roll.
-	if _, err = dataWriter.gw.Write([]byte(xfmt.pre)); err != nil {
+	if _, err = dataWriter.Write([]byte(xfmt.pre)); err != nil {
 		return nil, err
 	}
 	if err := stream.Orchestrate(ctx); err != nil {
 		return nil, err
 	}
-	if _, err = dataWriter.gw.Write([]byte(xfmt.post)); err != nil {
+	if _, err = dataWriter.Write([]byte(xfmt.post)); err != nil {
 		return nil, err
 	}
 	glog.Infof("Export DONE for group %d at timestamp %d.", in.GroupId, in.ReadTs)
@@ -861,6 +903,29 @@ func ExportOverNetwork(ctx context.Context, input *pb.ExportRequest) (ExportedFi
 	readTs := ts.ReadOnly
 	glog.Infof("Got readonly ts from Zero: %d\n", readTs)
 
+	if isTabularExport(input.Format) {
+		// The types and predicates of the namespace are read through the context.
+		files, err := exportTabular(x.AttachNamespace(ctx, input.Namespace), &pb.ExportRequest{
+			ReadTs:    readTs,
+			UnixTs:    time.Now().Unix(),
+			Format:    input.Format,
+			Namespace: input.Namespace,
+
+			Destination:  input.Destination,
+			AccessKey:    input.AccessKey,
+			SecretKey:    input.SecretKey,
+			SessionToken: input.SessionToken,
+			Anonymous:    input.Anonymous,
+		})
+		if err != nil {
+			rerr := errors.Wrapf(err, "Export failed at readTs %d", readTs)
+			glog.Errorln(rerr)
+			return nil, rerr
+		}
+		glog.Infof("Export at readTs %d DONE", readTs)
+		return files, nil
+	}
+
 	// Let's first collect all groups.
 	gids := groups().KnownGroups()
 	glog.Infof("Requesting export for groups: %v\n", gids)
@@ -873,10 +938,11 @@ func ExportOverNetwork(ctx context.Context, input *pb.ExportRequest) (ExportedFi
 	for _, gid := range gids {
 		go func(group uint32) {
 			req := &pb.ExportRequest{
-				GroupId: group,
-				ReadTs:  readTs,
-				UnixTs:  time.Now().Unix(),
-				Format:  input.Format,
+				GroupId:   group,
+				ReadTs:    readTs,
+				UnixTs:    time.Now().Unix(),
+				Format:    input.Format,
+				Namespace: input.Namespace,
 
 				Destination:  input.Destination,
 				AccessKey:    input.AccessKey,
@@ -898,6 +964,7 @@ func ExportOverNetwork(ctx context.Context, input *pb.ExportRequest) (ExportedFi
 			return nil, rerr
 		}
 		allFiles = append(allFiles, pair.ExportedFiles...)
+		reportProgress(ctx, i+1, len(gids))
 	}
 
 	glog.Infof("Export at readTs %d DONE", readTs)
@@ -0,0 +1,333 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+
+	"github.com/dgraph-io/dgraph/chunker"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/types"
+)
+
+// Tabular exports write a file per type, with a row for each node of the type. The first
+// column holds the uid of the node, followed by a column for each predicate of the type.
+// Single scalar values are written as they are. Lists of values and edges are written as
+// JSON arrays, the uids of the edges in hex. Values with a language tag aren't exported.
+//
+// The predicates of a type are usually served by different groups, so a tabular export isn't
+// split by group like RDF and JSON exports, the alpha receiving the request reads the nodes
+// of each type from all the groups and writes all the files.
+
+// tabularExportBatch is the number of nodes whose values are read at once.
+const tabularExportBatch = 10000
+
+func isTabularExport(format string) bool {
+	return format == "csv" || format == "parquet"
+}
+
+type tabularColumn struct {
+	pred string
+	typ  types.TypeID
+	list bool
+}
+
+// cellType returns the type of the values of the column in the exported rows.
+func (c *tabularColumn) cellType() types.TypeID {
+	switch {
+	case c.list || c.typ == types.UidID:
+		return types.StringID
+	case c.typ == types.IntID, c.typ == types.FloatID, c.typ == types.BoolID,
+		c.typ == types.DateTimeID:
+		return c.typ
+	}
+	return types.StringID
+}
+
+// cell returns the value of the column for a node, given the result of the query of the
+// predicate for the node.
+func (c *tabularColumn) cell(uids *pb.List, vals *pb.ValueList) (interface{}, error) {
+	if c.typ == types.UidID {
+		if uids == nil || len(uids.Uids) == 0 {
+			return nil, nil
+		}
+		hex := make([]string, 0, len(uids.Uids))
+		for _, uid := range uids.Uids {
+			hex = append(hex, fmt.Sprintf("%#x", uid))
+		}
+		if !c.list {
+			return hex[0], nil
+		}
+		b, err := json.Marshal(hex)
+		return string(b), err
+	}
+
+	if vals == nil || len(vals.Values) == 0 {
+		return nil, nil
+	}
+	var cells []interface{}
+	for _, tv := range vals.Values {
+		if len(tv.Val) == 0 {
+			continue
+		}
+		src := types.Val{Tid: types.TypeID(tv.ValType), Value: tv.Val}
+		var v interface{}
+		if typ := c.typ; typ == types.IntID || typ == types.FloatID || typ == types.BoolID ||
+			typ == types.DateTimeID {
+			dst, err := types.Convert(src, typ)
+			if err != nil {
+				return nil, err
+			}
+			v = dst.Value
+		} else {
+			s, err := valToStr(src)
+			if err != nil {
+				return nil, err
+			}
+			v = s
+		}
+		cells = append(cells, v)
+	}
+	switch {
+	case len(cells) == 0:
+		return nil, nil
+	case !c.list:
+		return cells[0], nil
+	}
+	b, err := json.Marshal(cells)
+	return string(b), err
+}
+
+// tabularWriter writes the rows of an exported type.
+type tabularWriter interface {
+	Write(row []interface{}) error
+	Close() error
+}
+
+type csvExportWriter struct {
+	w *csv.Writer
+}
+
+func (cw *csvExportWriter) Write(row []interface{}) error {
+	record := make([]string, len(row))
+	for i, v := range row {
+		switch v := v.(type) {
+		case nil:
+		case string:
+			record[i] = v
+		case time.Time:
+			record[i] = v.Format(time.RFC3339Nano)
+		default:
+			record[i] = fmt.Sprint(v)
+		}
+	}
+	return cw.w.Write(record)
+}
+
+func (cw *csvExportWriter) Close() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+func newTabularWriter(format string, fw *fileWriter,
+	columns []*tabularColumn) (tabularWriter, error) {
+	switch format {
+	case "csv":
+		header := []string{"uid"}
+		for _, c := range columns {
+			header = append(header, c.pred)
+		}
+		cw := &csvExportWriter{w: csv.NewWriter(fw)}
+		if err := cw.w.Write(header); err != nil {
+			return nil, err
+		}
+		return cw, nil
+	case "parquet":
+		pcols := []chunker.ParquetColumn{{Name: "uid", Type: types.StringID}}
+		for _, c := range columns {
+			pcols = append(pcols, chunker.ParquetColumn{Name: c.pred, Type: c.cellType()})
+		}
+		return chunker.NewParquetWriter(fw, pcols)
+	}
+	return nil, errors.Errorf("Invalid tabular export format: %s", format)
+}
+
+// exportTabular exports the nodes of each type in the CSV or Parquet format.
+func exportTabular(ctx context.Context, in *pb.ExportRequest) (ExportedFiles, error) {
+	uts := time.Unix(in.UnixTs, 0)
+	exportStorage, err := newExportStorage(in,
+		fmt.Sprintf("dgraph.r%d.u%s", in.ReadTs, uts.UTC().Format("0102.1504")))
+	if err != nil {
+		return nil, err
+	}
+
+	typeUpdates, err := GetTypes(ctx, &pb.SchemaRequest{})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(typeUpdates, func(i, j int) bool {
+		return typeUpdates[i].TypeName < typeUpdates[j].TypeName
+	})
+
+	var userTypes []*pb.TypeUpdate
+	for _, tu := range typeUpdates {
+		// Internal types aren't exported.
+		if !strings.HasPrefix(tu.TypeName, "dgraph.") {
+			userTypes = append(userTypes, tu)
+		}
+	}
+
+	var files []*fileWriter
+	for i, tu := range userTypes {
+		fw, err := exportType(ctx, in, exportStorage, tu)
+		if err != nil {
+			return nil, errors.Wrapf(err, "while exporting type %s", tu.TypeName)
+		}
+		if fw != nil {
+			files = append(files, fw)
+		}
+		reportProgress(ctx, i+1, len(userTypes))
+	}
+	glog.Infof("Tabular export DONE at timestamp %d.", in.ReadTs)
+	return exportStorage.finishWriting(files...)
+}
+
+// exportType writes the nodes of a type to a new file, or returns nil if the type has no nodes.
+func exportType(ctx context.Context, in *pb.ExportRequest, storage exportStorage,
+	tu *pb.TypeUpdate) (*fileWriter, error) {
+	var preds []string
+	for _, field := range tu.Fields {
+		if !strings.HasPrefix(field.Predicate, "~") {
+			preds = append(preds, field.Predicate)
+		}
+	}
+	var columns []*tabularColumn
+	if len(preds) > 0 {
+		nodes, err := GetSchemaOverNetwork(ctx, &pb.SchemaRequest{
+			Predicates: preds, Fields: []string{"type", "list"}})
+		if err != nil {
+			return nil, err
+		}
+		byPred := make(map[string]*pb.SchemaNode, len(nodes))
+		for _, n := range nodes {
+			byPred[n.Predicate] = n
+		}
+		for _, pred := range preds {
+			n, ok := byPred[pred]
+			if !ok {
+				continue
+			}
+			typ, ok := types.TypeForName(n.Type)
+			if !ok || typ == types.PasswordID {
+				continue
+			}
+			columns = append(columns, &tabularColumn{pred: pred, typ: typ, list: n.List})
+		}
+	}
+
+	res, err := ProcessTaskOverNetwork(ctx, &pb.Query{
+		Attr:    "dgraph.type",
+		SrcFunc: &pb.SrcFunction{Name: "eq", Args: []string{tu.TypeName}},
+		ReadTs:  in.ReadTs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var uids []uint64
+	if len(res.UidMatrix) > 0 {
+		uids = res.UidMatrix[0].Uids
+	}
+	if len(uids) == 0 {
+		return nil, nil
+	}
+
+	ext := ".csv.gz"
+	if in.Format == "parquet" {
+		ext = ".parquet"
+	}
+	fw, err := storage.openFile(tu.TypeName + ext)
+	if err != nil {
+		return nil, err
+	}
+	tw, err := newTabularWriter(in.Format, fw, columns)
+	if err != nil {
+		return nil, err
+	}
+
+	for start := 0; start < len(uids); start += tabularExportBatch {
+		end := start + tabularExportBatch
+		if end > len(uids) {
+			end = len(uids)
+		}
+		batch := uids[start:end]
+		rows := make([][]interface{}, len(batch))
+		for i, uid := range batch {
+			rows[i] = make([]interface{}, len(columns)+1)
+			rows[i][0] = fmt.Sprintf("%#x", uid)
+		}
+
+		for c, col := range columns {
+			res, err := ProcessTaskOverNetwork(ctx, &pb.Query{
+				Attr:    col.pred,
+				UidList: &pb.List{Uids: batch},
+				ReadTs:  in.ReadTs,
+			})
+			switch {
+			case err == errNonExistentTablet:
+				// The predicate has no data.
+				continue
+			case err != nil:
+				return nil, errors.Wrapf(err, "while reading predicate %s", col.pred)
+			}
+			for i := range batch {
+				var uids *pb.List
+				var vals *pb.ValueList
+				if i < len(res.UidMatrix) {
+					uids = res.UidMatrix[i]
+				}
+				if i < len(res.ValueMatrix) {
+					vals = res.ValueMatrix[i]
+				}
+				cell, err := col.cell(uids, vals)
+				if err != nil {
+					glog.Errorf("Ignoring value of %s for uid %#x: %v", col.pred, batch[i], err)
+					continue
+				}
+				rows[i][c+1] = cell
+			}
+		}
+
+		for _, row := range rows {
+			if err := tw.Write(row); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	return fw, nil
+}
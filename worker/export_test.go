@@ -21,6 +21,7 @@ import (
 	"bytes"
 	"compress/gzip"
 	"context"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -379,6 +380,77 @@ func TestExportJson(t *testing.T) {
 	checkExportGqlSchema(t, gqlSchema)
 }
 
+func readExportFile(t *testing.T, file string) string {
+	f, err := os.Open(file)
+	require.NoError(t, err)
+	defer f.Close()
+	r, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	b, err := ioutil.ReadAll(r)
+	require.NoError(t, err)
+	return string(b)
+}
+
+func TestExportNamespace(t *testing.T) {
+	ns := uint64(2)
+	nameAttr, friendAttr := x.NamespaceAttr(ns, "ns.name"), x.NamespaceAttr(ns, "ns.friend")
+	typeName := x.NamespaceAttr(ns, "NsPerson")
+	// The other tests read the whole store.
+	defer func() {
+		require.NoError(t, pstore.DropPrefix(x.PredicatePrefix(nameAttr),
+			x.PredicatePrefix(friendAttr), x.PredicatePrefix("ns.name"),
+			x.SchemaKey(nameAttr), x.SchemaKey(friendAttr), x.TypeKey(typeName)))
+	}()
+
+	txn := pstore.NewTransactionAt(math.MaxUint64, true)
+	for attr, typ := range map[string]pb.Posting_ValType{
+		nameAttr: pb.Posting_STRING, friendAttr: pb.Posting_UID} {
+		val, err := (&pb.SchemaUpdate{Predicate: attr, ValueType: typ}).Marshal()
+		require.NoError(t, err)
+		require.NoError(t, txn.Set(x.SchemaKey(attr), val))
+	}
+	val, err := (&pb.TypeUpdate{TypeName: typeName, Fields: []*pb.SchemaUpdate{
+		{Predicate: "ns.name"}, {Predicate: "ns.friend"}}}).Marshal()
+	require.NoError(t, err)
+	require.NoError(t, txn.Set(x.TypeKey(typeName), val))
+	require.NoError(t, txn.CommitAt(1, nil))
+
+	// The galaxy has a predicate with the same name, which isn't exported with the namespace.
+	writeAnalyticsEdges(t, friendAttr, map[uint64][]uint64{1: {2}}, 10)
+	w := posting.NewTxnWriter(pstore)
+	for attr, name := range map[string]string{nameAttr: "alice", "ns.name": "galaxy"} {
+		b, err := valuePostingList(types.Val{Tid: types.StringID, Value: name})
+		require.NoError(t, err)
+		require.NoError(t, w.SetAt(x.DataKey(attr, 1), b, posting.BitCompletePosting, 10))
+	}
+	require.NoError(t, w.Flush())
+
+	bdir, err := ioutil.TempDir("", "export")
+	require.NoError(t, err)
+	defer os.RemoveAll(bdir)
+
+	files, err := exportInternal(context.Background(), &pb.ExportRequest{
+		ReadTs: 10, GroupId: 1, Format: "rdf", Destination: bdir, Namespace: ns}, pstore, true)
+	require.NoError(t, err)
+	fileList, schemaFileList, gqlSchema := getExportFileList(t, bdir)
+	require.Equal(t, len(files), len(fileList)+len(schemaFileList)+len(gqlSchema))
+
+	rdf := strings.Split(strings.TrimSpace(readExportFile(t, fileList[0])), "\n")
+	require.ElementsMatch(t, []string{
+		`<0x1> <ns.friend> <0x2> .`,
+		`<0x1> <ns.name> "alice"^^<xs:string> .`,
+	}, rdf)
+
+	result, err := schema.Parse(readExportFile(t, schemaFileList[0]))
+	require.NoError(t, err)
+	require.Equal(t, 2, len(result.Preds))
+	for _, pred := range result.Preds {
+		require.Contains(t, []string{"ns.name", "ns.friend"}, pred.Predicate)
+	}
+	require.Equal(t, 1, len(result.Types))
+	require.Equal(t, "NsPerson", result.Types[0].TypeName)
+}
+
 const exportRequest = `mutation export($format: String!) {
 	export(input: {format: $format}) {
 		exportedFiles
@@ -554,3 +626,54 @@ func TestToSchema(t *testing.T) {
 		require.Equal(t, testCase.expected, string(list.Kv[0].Value))
 	}
 }
+
+func TestTabularExportCell(t *testing.T) {
+	toBytes := func(v types.Val) []byte {
+		out := types.Val{Tid: types.BinaryID}
+		require.NoError(t, types.Marshal(v, &out))
+		return out.Value.([]byte)
+	}
+	taskVal := func(tid types.TypeID, v interface{}) *pb.TaskValue {
+		return &pb.TaskValue{ValType: tid.Enum(), Val: toBytes(types.Val{Tid: tid, Value: v})}
+	}
+
+	friends := &tabularColumn{pred: "friend", typ: types.UidID, list: true}
+	cell, err := friends.cell(&pb.List{Uids: []uint64{1, 0x1f}}, nil)
+	require.NoError(t, err)
+	require.Equal(t, `["0x1","0x1f"]`, cell)
+	require.Equal(t, types.StringID, friends.cellType())
+
+	boss := &tabularColumn{pred: "boss", typ: types.UidID}
+	cell, err = boss.cell(&pb.List{Uids: []uint64{2}}, nil)
+	require.NoError(t, err)
+	require.Equal(t, "0x2", cell)
+	cell, err = boss.cell(&pb.List{}, nil)
+	require.NoError(t, err)
+	require.Nil(t, cell)
+
+	age := &tabularColumn{pred: "age", typ: types.IntID}
+	cell, err = age.cell(nil,
+		&pb.ValueList{Values: []*pb.TaskValue{taskVal(types.IntID, int64(42))}})
+	require.NoError(t, err)
+	require.Equal(t, int64(42), cell)
+	require.Equal(t, types.IntID, age.cellType())
+
+	nicks := &tabularColumn{pred: "nick", typ: types.StringID, list: true}
+	cell, err = nicks.cell(nil, &pb.ValueList{Values: []*pb.TaskValue{
+		taskVal(types.StringID, "al"), taskVal(types.StringID, "\"A\"")}})
+	require.NoError(t, err)
+	require.Equal(t, `["al","\"A\""]`, cell)
+	cell, err = nicks.cell(nil, &pb.ValueList{})
+	require.NoError(t, err)
+	require.Nil(t, cell)
+}
+
+func TestCSVExportWriter(t *testing.T) {
+	var buf bytes.Buffer
+	cw := &csvExportWriter{w: csv.NewWriter(&buf)}
+	born := time.Date(1990, 5, 17, 10, 30, 0, 0, time.UTC)
+	require.NoError(t, cw.Write([]interface{}{"0x1", int64(42), 1.5, true, born, nil,
+		"Doe, Jane"}))
+	require.NoError(t, cw.Close())
+	require.Equal(t, "0x1,42,1.5,true,1990-05-17T10:30:00Z,,\"Doe, Jane\"\n", buf.String())
+}
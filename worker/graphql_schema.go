@@ -48,7 +48,7 @@ var (
 // UpdateGQLSchemaOverNetwork sends the request to the group one leader for execution.
 func UpdateGQLSchemaOverNetwork(ctx context.Context, req *pb.UpdateGraphQLSchemaRequest) (*pb.
 	UpdateGraphQLSchemaResponse, error) {
-	if isGroupOneLeader() {
+	if IsGroupOneLeader() {
 		return (&grpcWorker{}).UpdateGraphQLSchema(ctx, req)
 	}
 
@@ -66,7 +66,7 @@ func UpdateGQLSchemaOverNetwork(ctx context.Context, req *pb.UpdateGraphQLSchema
 // and then alters the dgraph schema. All this is done only on group one leader.
 func (w *grpcWorker) UpdateGraphQLSchema(ctx context.Context,
 	req *pb.UpdateGraphQLSchemaRequest) (*pb.UpdateGraphQLSchemaResponse, error) {
-	if !isGroupOneLeader() {
+	if !IsGroupOneLeader() {
 		return nil, errUpdatingGraphQLSchemaOnNonGroupOneLeader
 	}
 
@@ -195,8 +195,17 @@ func WaitForIndexingOrCtxError(ctx context.Context, shouldWait bool) error {
 	return nil
 }
 
-// isGroupOneLeader returns true if the current server is the leader of Group One,
+// IsGroupOneLeader returns true if the current server is the leader of Group One,
 // it returns false otherwise.
-func isGroupOneLeader() bool {
+func IsGroupOneLeader() bool {
 	return groups().ServesGroup(1) && groups().Node.AmLeader()
 }
+
+// LeadingGroup returns the group of the current server if it's the leader of the group, and 0
+// otherwise.
+func LeadingGroup() uint32 {
+	if groups().Node.AmLeader() {
+		return groups().groupId()
+	}
+	return 0
+}
@@ -0,0 +1,72 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"context"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// The query layer works with the names of predicates and types as the user wrote them. The
+// functions exported by the worker to serve queries and mutations qualify those names with
+// the namespace attached to the context (see x.AttachNamespace) before they are mapped to
+// groups and keys, and strip it from the schema they return.
+
+// namespacedAttr returns attr qualified with the namespace of the request. Attributes that
+// already carry a namespace are returned as is, so that requests forwarded from within the
+// worker aren't qualified twice.
+func namespacedAttr(ctx context.Context, attr string) string {
+	if x.IsNamespacedAttr(attr) {
+		return attr
+	}
+	return x.NamespaceAttr(x.ExtractNamespace(ctx), attr)
+}
+
+// namespaceMutations qualifies the predicates and types changed by m with the namespace of
+// the request. The fields of a type are kept as they are, because they are only ever read
+// back by the query layer.
+func namespaceMutations(ctx context.Context, m *pb.Mutations) {
+	for _, edge := range m.Edges {
+		edge.Attr = namespacedAttr(ctx, edge.Attr)
+	}
+	for _, su := range m.Schema {
+		su.Predicate = namespacedAttr(ctx, su.Predicate)
+	}
+	for _, tu := range m.Types {
+		tu.TypeName = namespacedAttr(ctx, tu.TypeName)
+	}
+	if m.DropOp == pb.Mutations_TYPE {
+		m.DropValue = namespacedAttr(ctx, m.DropValue)
+	}
+}
+
+// schemaInNamespace returns the nodes that belong to namespace ns with the namespace removed
+// from their predicate.
+func schemaInNamespace(ns uint64, nodes []*pb.SchemaNode) []*pb.SchemaNode {
+	out := nodes[:0]
+	for _, node := range nodes {
+		nodeNs, attr := x.ParseNamespaceAttr(node.Predicate)
+		if nodeNs != ns {
+			continue
+		}
+		node.Predicate = attr
+		out = append(out, node)
+	}
+	return out
+}
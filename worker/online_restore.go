@@ -26,6 +26,11 @@ import (
 	"github.com/golang/glog"
 )
 
+func VerifyRestoreRequest(ctx context.Context, req *pb.RestoreRequest) error {
+	glog.Warningf("Restore failed: %v", x.ErrNotSupported)
+	return x.ErrNotSupported
+}
+
 func ProcessRestoreRequest(ctx context.Context, req *pb.RestoreRequest) error {
 	glog.Warningf("Restore failed: %v", x.ErrNotSupported)
 	return x.ErrNotSupported
@@ -37,8 +37,9 @@ const (
 	errRestoreProposal = "cannot propose restore request"
 )
 
-// ProcessRestoreRequest verifies the backup data and sends a restore proposal to each group.
-func ProcessRestoreRequest(ctx context.Context, req *pb.RestoreRequest) error {
+// VerifyRestoreRequest verifies the backup data of a restore request, and fills the request
+// with the credentials to read it. It's cheap enough to be called before queuing the restore.
+func VerifyRestoreRequest(ctx context.Context, req *pb.RestoreRequest) error {
 	if req == nil {
 		return errors.Errorf("restore request cannot be nil")
 	}
@@ -46,12 +47,7 @@ func ProcessRestoreRequest(ctx context.Context, req *pb.RestoreRequest) error {
 	if err := UpdateMembershipState(ctx); err != nil {
 		return errors.Wrapf(err, "cannot update membership state before restore")
 	}
-	memState := GetMembershipState()
-
-	currentGroups := make([]uint32, 0)
-	for gid := range memState.GetGroups() {
-		currentGroups = append(currentGroups, gid)
-	}
+	currentGroups := restoreGroups()
 
 	creds := Credentials{
 		AccessKey:    req.AccessKey,
@@ -87,6 +83,22 @@ func ProcessRestoreRequest(ctx context.Context, req *pb.RestoreRequest) error {
 			"Please retry later.")
 	}
 
+	return nil
+}
+
+func restoreGroups() []uint32 {
+	currentGroups := make([]uint32, 0)
+	for gid := range GetMembershipState().GetGroups() {
+		currentGroups = append(currentGroups, gid)
+	}
+	return currentGroups
+}
+
+// ProcessRestoreRequest sends a restore proposal to each group and waits until all the groups
+// are restored. The request must have been verified by VerifyRestoreRequest.
+func ProcessRestoreRequest(ctx context.Context, req *pb.RestoreRequest) error {
+	currentGroups := restoreGroups()
+
 	req.RestoreTs = State.GetTimestamp(false)
 
 	// TODO: prevent partial restores when proposeRestoreOrSend only sends the restore
@@ -101,15 +113,17 @@ func ProcessRestoreRequest(ctx context.Context, req *pb.RestoreRequest) error {
 		}()
 	}
 
-	go func() {
-		for range currentGroups {
-			if err := <-errCh; err != nil {
-				glog.Errorf("Error while restoring %v", err)
+	var rerr error
+	for i := range currentGroups {
+		if err := <-errCh; err != nil {
+			glog.Errorf("Error while restoring %v", err)
+			if rerr == nil {
+				rerr = err
 			}
 		}
-	}()
-
-	return nil
+		reportProgress(ctx, i+1, len(currentGroups))
+	}
+	return rerr
 }
 
 func proposeRestoreOrSend(ctx context.Context, req *pb.RestoreRequest) error {
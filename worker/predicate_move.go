@@ -56,6 +56,11 @@ func populateKeyValues(ctx context.Context, kvs []*bpb.KV) error {
 	if err := writer.Flush(); err != nil {
 		return err
 	}
+	// The lists of the keys may be cached, when they are written again, e.g. by an analytics
+	// task run again.
+	for _, kv := range kvs {
+		posting.RemoveCacheFor(kv.Key)
+	}
 	pk, err := x.Parse(kvs[0].Key)
 	if err != nil {
 		return errors.Errorf("while parsing KV: %+v, got error: %v", kvs[0], err)
@@ -0,0 +1,40 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"context"
+)
+
+type progressKey struct{}
+
+// ProgressFunc receives the progress of an operation run over the network, as the number of
+// steps that are done out of the total number of steps. The steps are usually the groups, or
+// the types for the tabular exports.
+type ProgressFunc func(done, total int)
+
+// WithProgress returns a copy of ctx with which the exports, backups and restores report their
+// progress to f.
+func WithProgress(ctx context.Context, f ProgressFunc) context.Context {
+	return context.WithValue(ctx, progressKey{}, f)
+}
+
+func reportProgress(ctx context.Context, done, total int) {
+	if f, ok := ctx.Value(progressKey{}).(ProgressFunc); ok {
+		f(done, total)
+	}
+}
@@ -158,6 +158,10 @@ func loadFromBackup(db *badger.DB, r io.Reader, restoreTs uint64, preds predicat
 			if _, ok := preds[parsedKey.Attr]; !parsedKey.IsType() && !ok {
 				continue
 			}
+			// Older backups hold the records of the tasks, which must not be run again.
+			if parsedKey.Attr == "dgraph.task" && !parsedKey.IsSchema() {
+				continue
+			}
 
 			// Update the max id that has been seen while restoring this backup.
 			if parsedKey.Uid > maxUid {
@@ -26,6 +26,7 @@ import (
 	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/schema"
 	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/x"
 )
 
 var (
@@ -194,9 +195,15 @@ func GetSchemaOverNetwork(ctx context.Context, schema *pb.SchemaRequest) (
 		return nil, nil
 	}
 
+	ns := x.ExtractNamespace(ctx)
+	req := &pb.SchemaRequest{Fields: schema.Fields, Types: schema.Types}
+	for _, attr := range schema.Predicates {
+		req.Predicates = append(req.Predicates, namespacedAttr(ctx, attr))
+	}
+
 	// Map of groupd id => Predicates for that group.
 	schemaMap := make(map[uint32]*pb.SchemaRequest)
-	if err := addToSchemaMap(schemaMap, schema); err != nil {
+	if err := addToSchemaMap(schemaMap, req); err != nil {
 		return nil, err
 	}
 
@@ -221,7 +228,7 @@ func GetSchemaOverNetwork(ctx context.Context, schema *pb.SchemaRequest) (
 		}
 	}
 
-	return schemaNodes, nil
+	return schemaInNamespace(ns, schemaNodes), nil
 }
 
 // Schema is used to get schema information over the network on other instances.
@@ -245,10 +252,17 @@ func GetTypes(ctx context.Context, req *pb.SchemaRequest) ([]*pb.TypeUpdate, err
 	var typeNames []string
 	var out []*pb.TypeUpdate
 
+	ns := x.ExtractNamespace(ctx)
 	if len(req.Types) == 0 {
-		typeNames = schema.State().Types()
+		for _, name := range schema.State().Types() {
+			if x.ParseNamespace(name) == ns {
+				typeNames = append(typeNames, name)
+			}
+		}
 	} else {
-		typeNames = req.Types
+		for _, name := range req.Types {
+			typeNames = append(typeNames, x.NamespaceAttr(ns, name))
+		}
 	}
 
 	for _, name := range typeNames {
@@ -256,6 +270,7 @@ func GetTypes(ctx context.Context, req *pb.SchemaRequest) ([]*pb.TypeUpdate, err
 		if !found {
 			continue
 		}
+		typeUpdate.TypeName = x.ParseAttr(typeUpdate.TypeName)
 		out = append(out, &typeUpdate)
 	}
 
@@ -0,0 +1,127 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"context"
+	"sort"
+
+	otrace "go.opencensus.io/trace"
+
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/tok/hnsw"
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// minSimilarToEf is the minimum number of candidates fetched from the HNSW graph by
+// similar_to. The graph holds the latest vectors, which might not be visible at the read
+// timestamp of the query, so we fetch more candidates than asked for and check them against
+// the stored values.
+const minSimilarToEf = 64
+
+type similarTo struct {
+	uid  uint64
+	dist float32
+}
+
+// handleSimilarToFunction finds the k nodes whose vector for the attribute is nearest to
+// the vector of the function, e.g. similar_to(embedding, 10, "[0.1, 0.2, 0.3]"). The uids
+// are returned nearest first, not sorted by uid: the query sorts them before using them, and
+// keeps the order of similarity for the results.
+func (qs *queryState) handleSimilarToFunction(ctx context.Context, arg funcArgs) error {
+	span := otrace.FromContext(ctx)
+	stop := x.SpanTimer(span, "handleSimilarToFunction")
+	defer stop()
+
+	attr := arg.q.Attr
+	k := int(arg.srcFn.threshold[0])
+	vec := arg.srcFn.vector
+	index, err := posting.VectorIndex(attr)
+	if err != nil {
+		return err
+	}
+	ef := 4 * k
+	if ef < minSimilarToEf {
+		ef = minSimilarToEf
+	}
+
+	var res []similarTo
+	checked := make(map[uint64]struct{})
+	for {
+		cands, err := index.Search(vec, ef, ef)
+		if err != nil {
+			return err
+		}
+		if span != nil {
+			span.Annotatef(nil, "Candidates from HNSW index: %d", len(cands))
+		}
+		for _, uid := range cands {
+			if _, ok := checked[uid]; ok {
+				continue
+			}
+			checked[uid] = struct{}{}
+			r, ok, err := qs.similarTo(attr, uid, vec, arg.q.ReadTs)
+			if err != nil {
+				return err
+			}
+			if ok {
+				res = append(res, r)
+			}
+		}
+		// Fetch more candidates until k of them are visible at the read timestamp, or the
+		// graph has no more.
+		if len(res) >= k || len(cands) < ef {
+			break
+		}
+		ef *= 2
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].dist < res[j].dist })
+	if len(res) > k {
+		res = res[:k]
+	}
+
+	result := &pb.List{Uids: make([]uint64, 0, len(res))}
+	for _, r := range res {
+		result.Uids = append(result.Uids, r.uid)
+	}
+	arg.out.UidMatrix = append(arg.out.UidMatrix, result)
+	return nil
+}
+
+// similarTo returns the distance between vec and the vector of uid visible at readTs. It
+// returns false if uid has no vector of the same length.
+func (qs *queryState) similarTo(attr string, uid uint64, vec []float32,
+	readTs uint64) (similarTo, bool, error) {
+	vals, err := qs.getValsForUID(attr, "", uid, readTs)
+	switch {
+	case err == posting.ErrNoValue:
+		return similarTo{}, false, nil
+	case err != nil:
+		return similarTo{}, false, err
+	}
+	stored, err := types.Convert(vals[0], types.VFloatID)
+	if err != nil {
+		return similarTo{}, false, err
+	}
+	sv := stored.Value.([]float32)
+	if len(sv) != len(vec) {
+		return similarTo{}, false, nil
+	}
+	return similarTo{uid: uid, dist: hnsw.Distance(vec, sv)}, true, nil
+}
@@ -0,0 +1,393 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/dgryski/go-farm"
+	"github.com/golang/glog"
+
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/tok"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+const (
+	// numTopTokens is the number of most common tokens of an index reported to Zero.
+	numTopTokens = 16
+	// statsInterval is how often the leader of a group computes the statistics of its
+	// predicates. It reads a sample of their keys to do so, so it's done less often than
+	// sizing them.
+	statsInterval = 30 * time.Minute
+	// statsSampleKeys is the maximum number of data keys, and of index keys, read for each
+	// predicate. The statistics of the bigger predicates are extrapolated from the sample.
+	statsSampleKeys = 10000
+	// statsSampleRanges is the maximum number of key ranges of an index the sample of its
+	// tokens is spread across, so that it isn't made only of the smallest tokens.
+	statsSampleRanges = 16
+)
+
+type tokenCount struct {
+	hash  uint64
+	count uint64
+}
+
+// predicateStats are the cardinality statistics of a predicate, used to plan the queries.
+type predicateStats struct {
+	numUids      uint64
+	numTokens    uint64
+	numIndexUids uint64
+	// top are the fingerprints of the tokens of the index with the most uids.
+	top []tokenCount
+}
+
+func (s *predicateStats) addToken(token []byte, count uint64) {
+	s.numTokens++
+	s.numIndexUids += count
+	tc := tokenCount{hash: farm.Fingerprint64(token), count: count}
+	if len(s.top) < numTopTokens {
+		s.top = append(s.top, tc)
+		return
+	}
+	min := 0
+	for i := range s.top {
+		if s.top[i].count < s.top[min].count {
+			min = i
+		}
+	}
+	if count > s.top[min].count {
+		s.top[min] = tc
+	}
+}
+
+// fill sets the statistics in the tablet sent to Zero.
+func (s *predicateStats) fill(tablet *pb.Tablet) {
+	tablet.NumUids = s.numUids
+	tablet.NumTokens = s.numTokens
+	tablet.NumIndexUids = s.numIndexUids
+	sort.Slice(s.top, func(i, j int) bool {
+		return s.top[i].count > s.top[j].count
+	})
+	tablet.TopTokenHashes = tablet.TopTokenHashes[:0]
+	tablet.TopTokenCounts = tablet.TopTokenCounts[:0]
+	for _, tc := range s.top {
+		tablet.TopTokenHashes = append(tablet.TopTokenHashes, tc.hash)
+		tablet.TopTokenCounts = append(tablet.TopTokenCounts, tc.count)
+	}
+}
+
+// estimateKeys returns the number of keys with the prefix in the tables that only hold such
+// keys. It counts every version of the keys, so it's only used once a sample is exhausted.
+func estimateKeys(tables []badger.TableInfo, prefix []byte) uint64 {
+	var count uint64
+	for _, t := range tables {
+		if bytes.HasPrefix(t.Left, prefix) && bytes.HasPrefix(t.Right, prefix) {
+			count += uint64(t.KeyCount)
+		}
+	}
+	return count
+}
+
+// sampleKeys calls fn for each key having the prefix from start on, until limit keys were
+// read or the key end is reached. The iterator must iterate over all the versions. It returns
+// whether it stopped because of the limit.
+func sampleKeys(it *badger.Iterator, prefix, start, end []byte, limit int,
+	fn func(key []byte, item *badger.Item) error) (bool, error) {
+
+	var prevKey []byte
+	var numKeys int
+	for it.Seek(start); it.ValidForPrefix(prefix); {
+		item := it.Item()
+		if bytes.Equal(item.Key(), prevKey) {
+			it.Next()
+			continue
+		}
+		if len(end) > 0 && bytes.Compare(item.Key(), end) >= 0 {
+			return false, nil
+		}
+		if numKeys == limit {
+			return true, nil
+		}
+		numKeys++
+		prevKey = append(prevKey[:0], item.Key()...)
+		if item.UserMeta()&posting.BitEmptyPosting > 0 {
+			it.Next()
+			continue
+		}
+		// We do need to copy over the key, fn might give the iterator to ReadPostingList.
+		// The other versions of the key are skipped above, if fn didn't read them.
+		if err := fn(item.KeyCopy(nil), item); err != nil {
+			return false, err
+		}
+	}
+	return false, nil
+}
+
+// indexRanges returns the keys the sample of the index with the prefix starts from, using the
+// key ranges of the tables.
+func indexRanges(prefix []byte) [][]byte {
+	splits := pstore.KeySplits(prefix)
+	sort.Strings(splits)
+	starts := [][]byte{prefix}
+	step := 1
+	if len(splits) >= statsSampleRanges {
+		step = len(splits)/(statsSampleRanges-1) + 1
+	}
+	for i := 0; i < len(splits); i += step {
+		starts = append(starts, []byte(splits[i]))
+	}
+	return starts
+}
+
+// computePredicateStats reads a sample of the data and index keys of the predicate attr at
+// readTs, and returns its statistics.
+func computePredicateStats(txn *badger.Txn, attr string, readTs uint64,
+	tables []badger.TableInfo) (*predicateStats, error) {
+
+	itOpt := badger.DefaultIteratorOptions
+	itOpt.PrefetchValues = false
+	itOpt.AllVersions = true
+	it := txn.NewIterator(itOpt)
+	defer it.Close()
+
+	s := &predicateStats{}
+	dataPrefix := x.ParsedKey{Attr: attr}.DataPrefix()
+	truncated, err := sampleKeys(it, dataPrefix, dataPrefix, nil, statsSampleKeys,
+		func(key []byte, item *badger.Item) error {
+			s.numUids++
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	if n := estimateKeys(tables, dataPrefix); truncated && n > s.numUids {
+		s.numUids = n
+	}
+
+	indexPrefix := x.ParsedKey{Attr: attr}.IndexPrefix()
+	starts := indexRanges(indexPrefix)
+	truncated = false
+	for i, start := range starts {
+		var end []byte
+		if i+1 < len(starts) {
+			end = starts[i+1]
+		}
+		rangeTruncated, err := sampleKeys(it, indexPrefix, start, end,
+			statsSampleKeys/len(starts), func(key []byte, item *badger.Item) error {
+				pk, err := x.Parse(key)
+				if err != nil || pk.HasStartUid {
+					return nil
+				}
+				l, err := posting.ReadPostingList(key, it)
+				if err != nil {
+					return err
+				}
+				if n := l.Length(readTs, 0); n > 0 {
+					s.addToken([]byte(pk.Term), uint64(n))
+				}
+				return nil
+			})
+		if err != nil {
+			return nil, err
+		}
+		truncated = truncated || rangeTruncated
+	}
+	if n := estimateKeys(tables, indexPrefix); truncated && n > s.numTokens && s.numTokens > 0 {
+		// The tokens which weren't read have as many uids on average as the ones which were.
+		s.numIndexUids = s.numIndexUids * n / s.numTokens
+		s.numTokens = n
+	}
+	return s, nil
+}
+
+// computeStats returns the statistics of the predicates preds at readTs. The tables are used to
+// extrapolate the statistics of the predicates with more keys than the sample.
+func computeStats(ctx context.Context, preds []string, readTs uint64,
+	tables []badger.TableInfo) (map[string]*predicateStats, error) {
+
+	txn := pstore.NewTransactionAt(readTs, false)
+	defer txn.Discard()
+
+	stats := make(map[string]*predicateStats)
+	for _, pred := range preds {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+		s, err := computePredicateStats(txn, pred, readTs, tables)
+		if err != nil {
+			return nil, err
+		}
+		if s.numUids > 0 || s.numTokens > 0 {
+			stats[pred] = s
+		}
+	}
+	return stats, nil
+}
+
+// tokenEstimate estimates the number of uids of the index token of the tablet. The tokens which
+// aren't among its top tokens are assumed to share the rest of the uids evenly.
+func tokenEstimate(tablet *pb.Tablet, token string) uint64 {
+	numTop := len(tablet.TopTokenHashes)
+	if len(tablet.TopTokenCounts) < numTop {
+		numTop = len(tablet.TopTokenCounts)
+	}
+	hash := farm.Fingerprint64([]byte(token))
+	var topUids uint64
+	for i := 0; i < numTop; i++ {
+		if tablet.TopTokenHashes[i] == hash {
+			return tablet.TopTokenCounts[i]
+		}
+		topUids += tablet.TopTokenCounts[i]
+	}
+	if tablet.NumTokens <= uint64(numTop) || tablet.NumIndexUids <= topUids {
+		// All the tokens are among the top ones.
+		return 0
+	}
+	restUids := tablet.NumIndexUids - topUids
+	restTokens := tablet.NumTokens - uint64(numTop)
+	if restUids < restTokens {
+		return 1
+	}
+	return restUids / restTokens
+}
+
+// EstimateCardinality estimates the number of uids matched by the function fn on the predicate
+// attr, with the arguments args, using the statistics reported to Zero by the group serving
+// attr. It returns false if there are no statistics for attr, or if fn can't be estimated.
+func EstimateCardinality(ctx context.Context, attr, fn string, args []string,
+	lang string) (uint64, bool) {
+
+	attr = namespacedAttr(ctx, attr)
+	g := groups()
+	g.RLock()
+	tablet := g.tablets[attr]
+	g.RUnlock()
+	if tablet.GetNumUids() == 0 && tablet.GetNumTokens() == 0 {
+		return 0, false
+	}
+
+	var tokens []string
+	fnType, fn := parseFuncTypeHelper(fn)
+	switch fnType {
+	case hasFn:
+		return tablet.NumUids, true
+	case compareAttrFn:
+		if fn != "eq" {
+			return 0, false
+		}
+		tokenizer, err := pickTokenizer(ctx, attr, fn)
+		if err != nil {
+			return 0, false
+		}
+		tokenizer = tok.GetTokenizerForLang(tokenizer, lang)
+		for _, arg := range args {
+			val, err := convertValue(attr, arg)
+			if err != nil {
+				return 0, false
+			}
+			argTokens, err := tok.BuildTokens(val.Value, tokenizer)
+			if err != nil {
+				return 0, false
+			}
+			tokens = append(tokens, argTokens...)
+		}
+	case standardFn, fullTextSearchFn:
+		if fnType == standardFn && fn != "anyofterms" && fn != "allofterms" {
+			return 0, false
+		}
+		if _, found := verifyStringIndex(ctx, attr, fnType); !found {
+			return 0, false
+		}
+		var err error
+		if tokens, err = getStringTokens(args, lang, fnType); err != nil {
+			return 0, false
+		}
+	default:
+		return 0, false
+	}
+
+	if strings.HasPrefix(fn, "allof") {
+		// The uids must have all the tokens, so there are at most as many as for the rarest.
+		var est uint64
+		for i, token := range tokens {
+			if n := tokenEstimate(tablet, token); i == 0 || n < est {
+				est = n
+			}
+		}
+		return est, true
+	}
+	var est uint64
+	for _, token := range tokens {
+		est += tokenEstimate(tablet, token)
+	}
+	if est > tablet.NumUids && tablet.NumUids > 0 {
+		est = tablet.NumUids
+	}
+	return est, true
+}
+
+// addStats sets the statistics of the predicates served by this group in the tablets sent to
+// Zero. They're computed again once every statsInterval.
+func (n *node) addStats(tablets map[string]*pb.Tablet, tables []badger.TableInfo) {
+	g := groups()
+	if time.Since(n.statsAt) > statsInterval {
+		var preds []string
+		g.RLock()
+		for pred, tablet := range g.tablets {
+			if tablet.GetGroupId() == n.gid {
+				preds = append(preds, pred)
+			}
+		}
+		g.RUnlock()
+		stats, err := computeStats(n.ctx, preds, posting.Oracle().MaxAssigned(), tables)
+		if err != nil {
+			glog.Warningf("While computing the statistics of the predicates: %v", err)
+		} else {
+			n.stats, n.statsAt = stats, time.Now()
+		}
+	}
+
+	g.RLock()
+	defer g.RUnlock()
+	for pred, s := range n.stats {
+		known, ok := g.tablets[pred]
+		if !ok || known.GetGroupId() != n.gid {
+			continue
+		}
+		tablet, ok := tablets[pred]
+		if !ok {
+			// The size of the tablet couldn't be calculated, keep the one that Zero knows.
+			tablet = &pb.Tablet{
+				GroupId:           n.gid,
+				Predicate:         pred,
+				OnDiskBytes:       known.OnDiskBytes,
+				UncompressedBytes: known.UncompressedBytes,
+			}
+			tablets[pred] = tablet
+		}
+		s.fill(tablet)
+	}
+}
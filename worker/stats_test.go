@@ -0,0 +1,100 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/dgraph-io/dgraph/codec"
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/tok"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/dgryski/go-farm"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeStats(t *testing.T) {
+	writeList := func(w *posting.TxnWriter, key []byte, uids []uint64, ts uint64) {
+		pl := &pb.PostingList{Pack: codec.Encode(uids, 256)}
+		val, err := pl.Marshal()
+		require.NoError(t, err)
+		require.NoError(t, w.SetAt(key, val, posting.BitCompletePosting, ts))
+	}
+
+	ts := timestamp()
+	w := posting.NewTxnWriter(pstore)
+	for uid := uint64(1); uid <= 5; uid++ {
+		writeList(w, x.DataKey("stats_country", uid), nil, ts)
+	}
+	us, err := tok.GetTokens(tok.IdentExact, "US")
+	require.NoError(t, err)
+	fr, err := tok.GetTokens(tok.IdentExact, "FR")
+	require.NoError(t, err)
+	writeList(w, x.IndexKey("stats_country", us[0]), []uint64{1, 2, 3, 4}, ts)
+	writeList(w, x.IndexKey("stats_country", fr[0]), []uint64{5}, ts)
+	require.NoError(t, w.Flush())
+
+	stats, err := computeStats(context.Background(), []string{"stats_country"}, timestamp(),
+		pstore.Tables())
+	require.NoError(t, err)
+	s := stats["stats_country"]
+	require.NotNil(t, s)
+	require.Equal(t, uint64(5), s.numUids)
+	require.Equal(t, uint64(2), s.numTokens)
+	require.Equal(t, uint64(5), s.numIndexUids)
+
+	tablet := &pb.Tablet{}
+	s.fill(tablet)
+	// Only the fingerprints of the tokens are sent to Zero.
+	require.Equal(t, []uint64{farm.Fingerprint64([]byte(us[0])), farm.Fingerprint64([]byte(fr[0]))},
+		tablet.TopTokenHashes)
+	require.Equal(t, []uint64{4, 1}, tablet.TopTokenCounts)
+}
+
+func TestTokenEstimate(t *testing.T) {
+	s := &predicateStats{}
+	for i := 0; i < numTopTokens; i++ {
+		s.addToken([]byte{byte(i)}, 10)
+	}
+	s.addToken([]byte("big"), 1000)
+	s.addToken([]byte("small"), 2)
+	s.addToken([]byte("other"), 4)
+
+	tablet := &pb.Tablet{}
+	s.fill(tablet)
+	require.Len(t, tablet.TopTokenHashes, numTopTokens)
+	require.Equal(t, farm.Fingerprint64([]byte("big")), tablet.TopTokenHashes[0])
+	require.Equal(t, uint64(1000), tokenEstimate(tablet, "big"))
+	require.Equal(t, uint64(10), tokenEstimate(tablet, string([]byte{1})))
+	// The 10 uids of the token pushed out of the top ones, and the 6 of small and other, are
+	// shared by the 3 tokens that aren't among the top ones.
+	require.Equal(t, uint64(5), tokenEstimate(tablet, "unknown"))
+}
+
+func TestEstimateKeys(t *testing.T) {
+	prefix := x.ParsedKey{Attr: "stats_name"}.DataPrefix()
+	tables := []badger.TableInfo{
+		{Left: x.DataKey("stats_name", 1), Right: x.DataKey("stats_name", 100), KeyCount: 100},
+		{Left: x.DataKey("stats_name", 101), Right: x.DataKey("stats_name", 150), KeyCount: 50},
+		// The table also holds keys of another predicate, so it isn't counted.
+		{Left: x.DataKey("stats_name", 151), Right: x.DataKey("stats_other", 1), KeyCount: 80},
+	}
+	require.Equal(t, uint64(150), estimateKeys(tables, prefix))
+}
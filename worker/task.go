@@ -130,6 +130,7 @@ func processWithBackupRequest(
 // the instance which stores posting list corresponding to the predicate in the
 // query.
 func ProcessTaskOverNetwork(ctx context.Context, q *pb.Query) (*pb.Result, error) {
+	q.Attr = namespacedAttr(ctx, q.Attr)
 	attr := q.Attr
 	gid, err := groups().BelongsToReadOnly(attr, q.ReadTs)
 	switch {
@@ -166,6 +167,26 @@ func ProcessTaskOverNetwork(ctx context.Context, q *pb.Query) (*pb.Result, error
 	return reply, nil
 }
 
+// PredicateGroup returns the group serving the predicate attr, in the namespace of ctx, as known
+// by this Alpha. It returns 0 if the predicate isn't known to be served by any group yet.
+func PredicateGroup(ctx context.Context, attr string) uint32 {
+	g := groups()
+	g.RLock()
+	defer g.RUnlock()
+	return g.tablets[namespacedAttr(ctx, attr)].GetGroupId()
+}
+
+// UsesIndex tells if the function srcFunc is evaluated using the index of its predicate.
+// isFilter is set if the function filters a list of uids, instead of being a root function.
+func UsesIndex(srcFunc *pb.SrcFunction, isFilter bool) bool {
+	fnType, _ := parseFuncType(srcFunc)
+	var uidList *pb.List
+	if isFilter {
+		uidList = &pb.List{}
+	}
+	return needsIndex(fnType, uidList)
+}
+
 // convertValue converts the data to the schema.State() type of predicate.
 func convertValue(attr, data string) (types.Val, error) {
 	// Parse given value and get token. There should be only one token.
@@ -220,6 +241,7 @@ const (
 	uidInFn
 	customIndexFn
 	matchFn
+	similarToFn
 	standardFn = 100
 )
 
@@ -262,6 +284,8 @@ func parseFuncTypeHelper(name string) (FuncType, string) {
 		return customIndexFn, f
 	case "match":
 		return matchFn, f
+	case "similar_to":
+		return similarToFn, f
 	default:
 		if types.IsGeoFunc(f) {
 			return geoFn, f
@@ -314,7 +338,7 @@ func (srcFn *functionContext) needsValuePostings(typ types.TypeID) (bool, error)
 	case geoFn, regexFn, fullTextSearchFn, standardFn, hasFn, customIndexFn, matchFn:
 		// All of these require an index, hence would require fetching uid postings.
 		return false, nil
-	case uidInFn, compareScalarFn:
+	case uidInFn, compareScalarFn, similarToFn:
 		// Operate on uid postings
 		return false, nil
 	case notAFunction:
@@ -1015,6 +1039,13 @@ func (qs *queryState) helpProcessTask(ctx context.Context, q *pb.Query, gid uint
 		}
 	}
 
+	if srcFn.fnType == similarToFn {
+		span.Annotate(nil, "handleSimilarToFunction")
+		if err := qs.handleSimilarToFunction(ctx, args); err != nil {
+			return nil, err
+		}
+	}
+
 	if srcFn.fnType == compareScalarFn && srcFn.isFuncAtRoot {
 		span.Annotate(nil, "handleCompareScalarFunction")
 		if err := qs.handleCompareScalarFunction(ctx, args); err != nil {
@@ -1656,6 +1687,7 @@ type functionContext struct {
 	isFuncAtRoot   bool
 	isStringFn     bool
 	atype          types.TypeID
+	vector         []float32
 }
 
 const (
@@ -1930,6 +1962,26 @@ func parseSrcFn(ctx context.Context, q *pb.Query) (*functionContext, error) {
 		if fc.isFuncAtRoot {
 			return nil, errors.Errorf("uid_in function not allowed at root")
 		}
+	case similarToFn:
+		if err = ensureArgsCount(q.SrcFunc, 2); err != nil {
+			return nil, err
+		}
+		checkRoot(q, fc)
+		if !fc.isFuncAtRoot {
+			return nil, errors.Errorf("similar_to function is only allowed at root")
+		}
+		if !schema.State().HasTokenizer(ctx, tok.IdentHNSW, attr) {
+			return nil, errors.Errorf("Attribute %s is not indexed with type hnsw", attr)
+		}
+		k, err := strconv.ParseInt(q.SrcFunc.Args[0], 10, 32)
+		if err != nil || k <= 0 {
+			return nil, errors.Errorf("Number of neighbours must be a positive int, got %v",
+				q.SrcFunc.Args[0])
+		}
+		fc.threshold = []int64{k}
+		if fc.vector, err = types.ParseVFloat(q.SrcFunc.Args[1]); err != nil {
+			return nil, err
+		}
 	default:
 		return nil, errors.Errorf("FnType %d not handled in numFnAttrs.", fnType)
 	}
@@ -31,6 +31,12 @@ type Options struct {
 	// QueryEdgeLimit is the maximum number of edges that will be traversed during
 	// recurse and shortest-path queries.
 	QueryEdgeLimit uint64
+	// QueryTimeout is the maximum time a request of a client can run for, 0 means no limit. A
+	// request with a shorter deadline keeps it. The requests run by the Alpha itself have none.
+	QueryTimeout time.Duration
+	// QueryMemoryLimit is the maximum number of bytes the results of a query of a client can
+	// take, 0 means no limit. It's a soft limit, see query.MemoryUsage.
+	QueryMemoryLimit int64
 	// NormalizeNodeLimit is the maximum number of nodes allowed in a normalize query.
 	NormalizeNodeLimit int
 	// MutationsNQuadLimit is maximum number of nquads that can be present in a single
@@ -44,6 +50,22 @@ type Options struct {
 	GraphqlDebug bool
 	// GraphqlLambdaUrl stores the URL of lambda functions for custom GraphQL resolvers
 	GraphqlLambdaUrl string
+	// GraphqlTrustedDocuments restricts /graphql to the trusted documents registered through
+	// /admin
+	GraphqlTrustedDocuments bool
+	// GraphqlMaxDepth is the maximum depth of a GraphQL operation, 0 means no limit.
+	GraphqlMaxDepth int
+	// GraphqlMaxAliases is the maximum number of aliases in a GraphQL operation, 0 means no
+	// limit.
+	GraphqlMaxAliases int
+	// GraphqlMaxCost is the maximum cost of a GraphQL operation, 0 means no limit.
+	GraphqlMaxCost uint64
+	// GraphqlListSize is the number of items assumed for a list field without a first
+	// argument, when calculating the cost of a GraphQL operation.
+	GraphqlListSize uint64
+	// GraphqlResponseCacheMb is the size of the cache of the responses to the GraphQL queries
+	// with a @cacheControl directive, 0 disables the cache.
+	GraphqlResponseCacheMb int64
 }
 
 // Config stores the global instance of this package's options.
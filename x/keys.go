@@ -19,7 +19,9 @@ package x
 import (
 	"encoding/binary"
 	"encoding/hex"
+	"fmt"
 	"math"
+	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -50,8 +52,59 @@ const (
 	ByteSplit = byte(0x04)
 	// ByteUnused is a constant to specify keys which need to be discarded.
 	ByteUnused = byte(0xff)
+
+	// GalaxyNamespace is the default namespace. Predicates and types in the galaxy namespace
+	// are stored under their plain names, so data written before namespaces existed belongs
+	// to it.
+	GalaxyNamespace = uint64(0)
+	// nsSeparator starts the attribute of a predicate or type in a namespace other than the
+	// galaxy. It can't be part of a user defined predicate, so namespaced attributes can't
+	// collide with plain ones.
+	nsSeparator = byte(0x00)
+	// nsLen is the length of the hex encoded namespace that follows nsSeparator. The
+	// namespace is kept as text so that attributes are still valid UTF-8 strings in protos.
+	nsLen = 16
 )
 
+// NamespaceAttr returns the attribute under which the given predicate or type is stored in
+// namespace ns.
+func NamespaceAttr(ns uint64, attr string) string {
+	if ns == GalaxyNamespace {
+		return attr
+	}
+	return fmt.Sprintf("%c%016x%s", nsSeparator, ns, attr)
+}
+
+// IsNamespacedAttr returns true if attr belongs to a namespace other than the galaxy.
+func IsNamespacedAttr(attr string) bool {
+	return len(attr) > nsLen && attr[0] == nsSeparator
+}
+
+// ParseNamespaceAttr splits an attribute built by NamespaceAttr into the namespace and the
+// name of the predicate or type.
+func ParseNamespaceAttr(attr string) (uint64, string) {
+	if !IsNamespacedAttr(attr) {
+		return GalaxyNamespace, attr
+	}
+	ns, err := strconv.ParseUint(attr[1:1+nsLen], 16, 64)
+	if err != nil {
+		return GalaxyNamespace, attr
+	}
+	return ns, attr[1+nsLen:]
+}
+
+// ParseAttr returns the name of the predicate or type without its namespace.
+func ParseAttr(attr string) string {
+	_, name := ParseNamespaceAttr(attr)
+	return name
+}
+
+// ParseNamespace returns the namespace the attribute belongs to.
+func ParseNamespace(attr string) uint64 {
+	ns, _ := ParseNamespaceAttr(attr)
+	return ns
+}
+
 func writeAttr(buf []byte, attr string) []byte {
 	AssertTrue(len(attr) < math.MaxUint16)
 	binary.BigEndian.PutUint16(buf[:2], uint16(len(attr)))
@@ -103,7 +156,7 @@ func TypeKey(attr string) []byte {
 //
 // byte 0: key type prefix (set to DefaultPrefix or ByteSplit if part of a multi-part list)
 // byte 1-2: length of attr
-// next len(attr) bytes: value of attr
+// next len(attr) bytes: value of attr, prefixed with the namespace (see NamespaceAttr)
 // next byte: data type prefix (set to ByteData)
 // next eight bytes: value of uid
 // next eight bytes (optional): if the key corresponds to a split list, the startUid of
@@ -204,6 +257,16 @@ type ParsedKey struct {
 	bytePrefix  byte
 }
 
+// Namespace returns the namespace of the predicate the key belongs to.
+func (p ParsedKey) Namespace() uint64 {
+	return ParseNamespace(p.Attr)
+}
+
+// Predicate returns the name of the predicate the key belongs to, without its namespace.
+func (p ParsedKey) Predicate() string {
+	return ParseAttr(p.Attr)
+}
+
 // IsData returns whether the key is a data key.
 func (p ParsedKey) IsData() bool {
 	return (p.bytePrefix == DefaultPrefix || p.bytePrefix == ByteSplit) && p.ByteType == ByteData
@@ -565,6 +628,11 @@ var graphqlReservedPredicate = map[string]struct{}{
 	"dgraph.graphql.schema_created_at": {},
 	"dgraph.graphql.p_query":           {},
 	"dgraph.graphql.p_sha256hash":      {},
+	"dgraph.graphql.p_client":          {},
+	"dgraph.graphql.p_name":            {},
+	"dgraph.graphql.p_version":         {},
+	"dgraph.graphql.p_usage":           {},
+	"dgraph.task":                      {},
 }
 
 // internalPredicateMap stores a set of Dgraph's internal predicate. An internal
@@ -588,7 +656,7 @@ var preDefinedTypeMap = map[string]struct{}{
 // These are a subset of PreDefined predicates, so follow all their properties. In addition,
 // the value for these predicates is also not allowed to be mutated directly by the users.
 func IsGraphqlReservedPredicate(pred string) bool {
-	_, ok := graphqlReservedPredicate[pred]
+	_, ok := graphqlReservedPredicate[ParseAttr(pred)]
 	return ok
 }
 
@@ -624,14 +692,14 @@ func IsReservedPredicate(pred string) bool {
 //
 // Pre-defined predicates are subset of reserved predicates.
 func IsPreDefinedPredicate(pred string) bool {
-	_, ok := starAllPredicateMap[strings.ToLower(pred)]
+	_, ok := starAllPredicateMap[strings.ToLower(ParseAttr(pred))]
 	return ok || IsAclPredicate(pred) || IsGraphqlReservedPredicate(pred)
 }
 
 // IsAclPredicate returns true if the predicate is in the list of reserved
 // predicates for the ACL feature.
 func IsAclPredicate(pred string) bool {
-	_, ok := aclPredicateMap[strings.ToLower(pred)]
+	_, ok := aclPredicateMap[strings.ToLower(ParseAttr(pred))]
 	return ok
 }
 
@@ -656,7 +724,7 @@ func AllACLPredicates() []string {
 // IsInternalPredicate returns true if the predicate is in the internal predicate list.
 // Currently, `uid` is the only such candidate.
 func IsInternalPredicate(pred string) bool {
-	_, ok := internalPredicateMap[strings.ToLower(pred)]
+	_, ok := internalPredicateMap[strings.ToLower(ParseAttr(pred))]
 	return ok
 }
 
@@ -684,11 +752,11 @@ func IsReservedType(typ string) bool {
 //
 // Pre-defined types are subset of reserved types.
 func IsPreDefinedType(typ string) bool {
-	_, ok := preDefinedTypeMap[typ]
+	_, ok := preDefinedTypeMap[ParseAttr(typ)]
 	return ok
 }
 
 // isReservedName returns true if the given name is prefixed with `dgraph.`
 func isReservedName(name string) bool {
-	return strings.HasPrefix(strings.ToLower(name), "dgraph.")
+	return strings.HasPrefix(strings.ToLower(ParseAttr(name)), "dgraph.")
 }
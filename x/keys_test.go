@@ -17,6 +17,7 @@
 package x
 
 import (
+	"bytes"
 	"fmt"
 	"math"
 	"sort"
@@ -266,3 +267,33 @@ func TestBadKeys(t *testing.T) {
 	_, err = Parse(key)
 	require.Error(t, err)
 }
+
+func TestNamespaceAttr(t *testing.T) {
+	require.Equal(t, "name", NamespaceAttr(GalaxyNamespace, "name"))
+
+	attr := NamespaceAttr(0x2a, "name")
+	require.NotEqual(t, "name", attr)
+	require.True(t, IsNamespacedAttr(attr))
+	ns, name := ParseNamespaceAttr(attr)
+	require.Equal(t, uint64(0x2a), ns)
+	require.Equal(t, "name", name)
+
+	ns, name = ParseNamespaceAttr("name")
+	require.Equal(t, GalaxyNamespace, ns)
+	require.Equal(t, "name", name)
+
+	// Keys of different namespaces don't share a prefix.
+	key := DataKey(attr, 1)
+	require.False(t, bytes.HasPrefix(key, PredicatePrefix("name")))
+	pk, err := Parse(key)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0x2a), pk.Namespace())
+	require.Equal(t, "name", pk.Predicate())
+
+	// Reserved predicates and types are recognized in every namespace.
+	require.True(t, IsAclPredicate(NamespaceAttr(3, "dgraph.xid")))
+	require.True(t, IsPreDefinedPredicate(NamespaceAttr(3, "dgraph.type")))
+	require.True(t, IsReservedPredicate(NamespaceAttr(3, "dgraph.blah")))
+	require.True(t, IsPreDefinedType(NamespaceAttr(3, "dgraph.type.User")))
+	require.False(t, IsReservedPredicate(NamespaceAttr(3, "name")))
+}
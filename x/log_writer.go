@@ -0,0 +1,180 @@
+/*
+ * Copyright 2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package x
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultLogMaxSize = 100 << 20
+	logFileSuffix     = ".log"
+	logFileTimestamp  = "2006-01-02T15-04-05.000"
+)
+
+// LogWriter is an io.Writer that writes to size-limited files in a directory. A new file is
+// started on every open so that each encrypted file carries its own IV at the start. It writes
+// the audit logs, and the slow query log of the Alphas.
+type LogWriter struct {
+	sync.Mutex
+	dir      string
+	prefix   string
+	maxSize  int64
+	maxFiles int
+	wrap     func(w io.Writer) (io.Writer, error)
+
+	file *os.File
+	w    io.Writer
+	size int64
+}
+
+// NewLogWriter returns a LogWriter writing to files named after prefix in dir. The files are
+// rotated once they reach maxSize bytes, and only the latest maxFiles are retained, or all of
+// them if maxFiles is 0. If wrap is set, the data is written to the writer it returns for each
+// new file, e.g. to encrypt it.
+func NewLogWriter(dir, prefix string, maxSize int64, maxFiles int,
+	wrap func(w io.Writer) (io.Writer, error)) (*LogWriter, error) {
+	if maxSize <= 0 {
+		maxSize = defaultLogMaxSize
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	l := &LogWriter{
+		dir:      dir,
+		prefix:   prefix,
+		maxSize:  maxSize,
+		maxFiles: maxFiles,
+		wrap:     wrap,
+	}
+	if err := l.open(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Write writes p to the current file, after rotating it if p doesn't fit in it.
+func (l *LogWriter) Write(p []byte) (int, error) {
+	l.Lock()
+	defer l.Unlock()
+
+	if l.file == nil {
+		return 0, errors.New("log writer is closed")
+	}
+	if l.size+int64(len(p)) > l.maxSize {
+		if err := l.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := l.w.Write(p)
+	l.size += int64(n)
+	return n, err
+}
+
+// Close closes the current file. Nothing can be written after that.
+func (l *LogWriter) Close() error {
+	l.Lock()
+	defer l.Unlock()
+
+	if l.file == nil {
+		return nil
+	}
+	err := l.closeFile()
+	l.file = nil
+	return err
+}
+
+func (l *LogWriter) closeFile() error {
+	if err := l.file.Sync(); err != nil {
+		return err
+	}
+	return l.file.Close()
+}
+
+func (l *LogWriter) rotate() error {
+	if err := l.closeFile(); err != nil {
+		return errors.Wrapf(err, "while closing %s", l.file.Name())
+	}
+	if err := l.open(); err != nil {
+		return err
+	}
+	l.removeOldFiles()
+	return nil
+}
+
+// open starts a new file. Its name is unique, so that an encrypted file never gets a second IV
+// appended to it: a counter is added to the name of the files started in the same millisecond.
+func (l *LogWriter) open() error {
+	base := fmt.Sprintf("%s_%s", l.prefix, time.Now().UTC().Format(logFileTimestamp))
+	var f *os.File
+	for i := 0; f == nil; i++ {
+		name := base + logFileSuffix
+		if i > 0 {
+			name = fmt.Sprintf("%s_%d%s", base, i, logFileSuffix)
+		}
+		var err error
+		f, err = os.OpenFile(filepath.Join(l.dir, name), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err != nil && !os.IsExist(err) {
+			return err
+		}
+	}
+	var w io.Writer = f
+	if l.wrap != nil {
+		var err error
+		if w, err = l.wrap(f); err != nil {
+			_ = f.Close()
+			return err
+		}
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+	l.file, l.w, l.size = f, w, fi.Size()
+	return nil
+}
+
+// removeOldFiles deletes the oldest log files so that at most maxFiles are retained.
+func (l *LogWriter) removeOldFiles() {
+	if l.maxFiles <= 0 {
+		return
+	}
+	files, err := filepath.Glob(filepath.Join(l.dir, l.prefix+"_*"+logFileSuffix))
+	if err != nil {
+		glog.Errorf("Unable to list log files in %s: %v", l.dir, err)
+		return
+	}
+	// The timestamp in the name sorts the files from oldest to newest, the counter added to the
+	// files started in the same millisecond sorts after the first one.
+	sort.Strings(files)
+	for len(files) > l.maxFiles {
+		if err := os.Remove(files[0]); err != nil {
+			glog.Errorf("Unable to remove old log file %s: %v", files[0], err)
+		}
+		files = files[1:]
+	}
+}
@@ -127,6 +127,9 @@ const (
 		"Content-Type, Content-Length, Accept-Encoding, Cache-Control, " +
 		"X-CSRF-Token, X-Auth-Token, X-Requested-With"
 	DgraphCostHeader = "Dgraph-TouchedUids"
+	// DgraphPlanHeader is the gRPC header of the plans of the queries run with the explain or
+	// profile option. It's a binary header as the plans contain the arguments of the query.
+	DgraphPlanHeader = "Dgraph-Plan-Bin"
 )
 
 var (
@@ -424,6 +427,35 @@ func AttachRemoteIP(ctx context.Context, r *http.Request) context.Context {
 	return ctx
 }
 
+type namespaceKey struct{}
+
+// AttachNamespace returns a copy of ctx that carries the namespace the request operates in.
+func AttachNamespace(ctx context.Context, ns uint64) context.Context {
+	return context.WithValue(ctx, namespaceKey{}, ns)
+}
+
+// ExtractNamespace returns the namespace attached to the context. Requests without one
+// operate in the galaxy namespace.
+func ExtractNamespace(ctx context.Context) uint64 {
+	ns, _ := ctx.Value(namespaceKey{}).(uint64)
+	return ns
+}
+
+type graphqlOperationKey struct{}
+
+// WithGraphQLOperation returns a copy of ctx that carries the name of the GraphQL operation that
+// the request runs for.
+func WithGraphQLOperation(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, graphqlOperationKey{}, name)
+}
+
+// ExtractGraphQLOperation returns the name of the GraphQL operation attached to the context, or
+// "" if the request doesn't run for a named GraphQL operation.
+func ExtractGraphQLOperation(ctx context.Context) string {
+	name, _ := ctx.Value(graphqlOperationKey{}).(string)
+	return name
+}
+
 // isIpWhitelisted checks if the given ipString is within the whitelisted ip range
 func isIpWhitelisted(ipString string) bool {
 	ip := net.ParseIP(ipString)
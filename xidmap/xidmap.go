@@ -44,11 +44,17 @@ type XidMap struct {
 	maxUidSeen uint64
 
 	// Optionally, these can be set to persist the mappings.
+	db     *badger.DB
 	writer *badger.WriteBatch
 	wg     sync.WaitGroup
 
-	kvBuf  []kv
-	kvChan chan []kv
+	// syncLock is held for reading while queueing mappings to be written, and for writing by
+	// Sync. pending counts the queued batches of mappings that haven't been written yet.
+	syncLock sync.RWMutex
+	pending  sync.WaitGroup
+	kvLock   sync.Mutex
+	kvBuf    []kv
+	kvChan   chan []kv
 }
 
 type shard struct {
@@ -97,6 +103,7 @@ func New(zero *grpc.ClientConn, db *badger.DB, dir string) *XidMap {
 
 	if db != nil {
 		// If DB is provided, let's load up all the xid -> uid mappings in memory.
+		xm.db = db
 		xm.writer = db.NewWriteBatch()
 
 		for i := 0; i < 16; i++ {
@@ -185,6 +192,7 @@ func (m *XidMap) dbWriter() {
 		for _, kv := range buf {
 			x.Panic(m.writer.Set(kv.key, kv.value))
 		}
+		m.pending.Done()
 	}
 }
 
@@ -211,15 +219,20 @@ func (m *XidMap) AssignUid(xid string) (uint64, bool) {
 	newUid := sh.assign(m.newRanges)
 	sh.tree.Set(farm.Fingerprint64([]byte(xid)), newUid)
 
-	if m.writer != nil {
+	if m.db != nil {
 		var uidBuf [8]byte
 		binary.BigEndian.PutUint64(uidBuf[:], newUid)
+		m.syncLock.RLock()
+		m.kvLock.Lock()
 		m.kvBuf = append(m.kvBuf, kv{key: []byte(xid), value: uidBuf[:]})
 
 		if len(m.kvBuf) == 64 {
+			m.pending.Add(1)
 			m.kvChan <- m.kvBuf
 			m.kvBuf = make([]kv, 0, 64)
 		}
+		m.kvLock.Unlock()
+		m.syncLock.RUnlock()
 	}
 
 	return newUid, true
@@ -291,6 +304,7 @@ func (m *XidMap) Flush() error {
 	}()
 
 	if len(m.kvBuf) > 0 {
+		m.pending.Add(1)
 		m.kvChan <- m.kvBuf
 	}
 	close(m.kvChan)
@@ -298,3 +312,25 @@ func (m *XidMap) Flush() error {
 
 	return m.writer.Flush()
 }
+
+// Sync writes the mappings assigned so far to the DB, if one was provided. Unlike Flush, the
+// XidMap can still be used afterwards.
+func (m *XidMap) Sync() error {
+	if m.db == nil {
+		return nil
+	}
+	m.syncLock.Lock()
+	defer m.syncLock.Unlock()
+
+	if len(m.kvBuf) > 0 {
+		m.pending.Add(1)
+		m.kvChan <- m.kvBuf
+		m.kvBuf = make([]kv, 0, 64)
+	}
+	m.pending.Wait()
+	if err := m.writer.Flush(); err != nil {
+		return err
+	}
+	m.writer = m.db.NewWriteBatch()
+	return nil
+}
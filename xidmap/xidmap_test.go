@@ -1,6 +1,7 @@
 package xidmap
 
 import (
+	"encoding/binary"
 	"fmt"
 	"io/ioutil"
 	"math/rand"
@@ -73,6 +74,41 @@ func TestXidmap(t *testing.T) {
 	})
 }
 
+func TestXidmapSync(t *testing.T) {
+	conn, err := x.SetupConnection(testutil.SockAddrZero, nil, false)
+	require.NoError(t, err)
+	require.NotNil(t, conn)
+
+	withDB(t, func(db *badger.DB) {
+		xidmap := New(conn, db, "")
+		uida, _ := xidmap.AssignUid("a")
+		require.NoError(t, xidmap.Sync())
+
+		// The mapping is written without flushing the xidmap, which can still be used.
+		err := db.View(func(txn *badger.Txn) error {
+			item, err := txn.Get([]byte("a"))
+			if err != nil {
+				return err
+			}
+			return item.Value(func(val []byte) error {
+				require.Equal(t, uida, binary.BigEndian.Uint64(val))
+				return nil
+			})
+		})
+		require.NoError(t, err)
+
+		uidb, isNew := xidmap.AssignUid("b")
+		require.True(t, isNew)
+		require.NoError(t, xidmap.Flush())
+
+		xidmap2 := New(conn, db, "")
+		uidb2, isNew := xidmap2.AssignUid("b")
+		require.Equal(t, uidb, uidb2)
+		require.False(t, isNew)
+		require.NoError(t, xidmap2.Flush())
+	})
+}
+
 func TestXidmapMemory(t *testing.T) {
 	var loop uint32
 	bToMb := func(b uint64) uint64 {